@@ -18,27 +18,28 @@ import (
 
 // calendarEvent represents a calendar event for movies/series/albums/audiobooks.
 type calendarEvent struct {
-	ID         uint      `json:"id"`
-	Title      string    `json:"title"`
-	Type       string    `json:"type"` // "movie", "series", "album", or "audiobook"
-	Date       time.Time `json:"date"`
-	Year       int       `json:"year,omitempty"`
-	Season     string    `json:"season,omitempty"`
-	Episode    int       `json:"episode,omitempty"`
-	Status     string    `json:"status"`
-	Monitored  bool      `json:"monitored"`
-	Downloaded bool      `json:"downloaded"`
-	AirTime    string    `json:"airTime,omitempty"`
-	Network    string    `json:"network,omitempty"`
-	Overview   string    `json:"overview,omitempty"`
-	PosterURL  string    `json:"posterUrl,omitempty"`
-	IMDBRating float64   `json:"imdbRating,omitempty"`
-	Runtime    int       `json:"runtime,omitempty"`
-	IMDBID     string    `json:"imdbId,omitempty"`
-	TheTVDBID  int       `json:"thetvdbId,omitempty"`
-	MovieDBID  int       `json:"moviedbId,omitempty"`
-	TraktID    int       `json:"traktId,omitempty"`
-	Listname   string    `json:"listname,omitempty"`
+	ID           uint      `json:"id"`
+	Title        string    `json:"title"`
+	Type         string    `json:"type"` // "movie", "series", "album", or "audiobook"
+	Date         time.Time `json:"date"`
+	Year         int       `json:"year,omitempty"`
+	Season       string    `json:"season,omitempty"`
+	Episode      int       `json:"episode,omitempty"`
+	Status       string    `json:"status"`
+	Monitored    bool      `json:"monitored"`
+	Downloaded   bool      `json:"downloaded"`
+	AirTime      string    `json:"airTime,omitempty"`
+	Network      string    `json:"network,omitempty"`
+	Overview     string    `json:"overview,omitempty"`
+	PosterURL    string    `json:"posterUrl,omitempty"`
+	IMDBRating   float64   `json:"imdbRating,omitempty"`
+	Runtime      int       `json:"runtime,omitempty"`
+	IMDBID       string    `json:"imdbId,omitempty"`
+	TheTVDBID    int       `json:"thetvdbId,omitempty"`
+	MovieDBID    int       `json:"moviedbId,omitempty"`
+	TraktID      int       `json:"traktId,omitempty"`
+	Listname     string    `json:"listname,omitempty"`
+	Availability string    `json:"availability,omitempty"`
 }
 
 // CalendarPageHandler renders the calendar page.
@@ -228,19 +229,20 @@ func getCalendarEvents(startDate, endDate time.Time, eventType string) []calenda
 
 // MovieCalendarQuery represents the structure returned by movie calendar query.
 type MovieCalendarQuery struct {
-	ID          uint     `db:"id"`
-	Title       string   `db:"title"`
-	ReleaseDate string   `db:"release_date"`
-	Year        int      `db:"year"`
-	Overview    *string  `db:"overview"`
-	IMDBRating  *float64 `db:"imdb_rating"`
-	Runtime     *float64 `db:"runtime"`
-	Downloaded  bool     `db:"downloaded"`
-	Monitored   bool     `db:"monitored"`
-	IMDBID      *string  `db:"imdb_id"`
-	MovieDBID   *int     `db:"moviedb_id"`
-	TraktID     *int     `db:"trakt_id"`
-	Listname    string   `db:"listname"`
+	ID           uint     `db:"id"`
+	Title        string   `db:"title"`
+	ReleaseDate  string   `db:"release_date"`
+	Year         int      `db:"year"`
+	Overview     *string  `db:"overview"`
+	IMDBRating   *float64 `db:"imdb_rating"`
+	Runtime      *float64 `db:"runtime"`
+	Downloaded   bool     `db:"downloaded"`
+	Monitored    bool     `db:"monitored"`
+	IMDBID       *string  `db:"imdb_id"`
+	MovieDBID    *int     `db:"moviedb_id"`
+	TraktID      *int     `db:"trakt_id"`
+	Listname     string   `db:"listname"`
+	Availability string   `db:"availability"`
 }
 
 // getMovieCalendarEvents retrieves movie calendar events.
@@ -251,7 +253,7 @@ func getMovieCalendarEvents(startDate, endDate time.Time) []calendarEvent {
 		SELECT m.id, dm.title, dm.release_date, dm.year, dm.overview, dm.vote_average as imdb_rating, dm.runtime, dm.imdb_id, dm.moviedb_id, dm.trakt_id,
 			   CASE WHEN mf.id IS NOT NULL THEN 1 ELSE 0 END as downloaded,
 			   CASE WHEN m.dont_search = 0 THEN 1 ELSE 0 END as monitored,
-			   m.listname
+			   m.listname, `+database.MovieAvailabilityStatusExpr("dm")+` as availability
 		FROM movies m
 		INNER JOIN dbmovies dm ON dm.id = m.dbmovie_id
 		LEFT JOIN movie_files mf ON mf.movie_id = m.id
@@ -277,15 +279,16 @@ func getMovieCalendarEvents(startDate, endDate time.Time) []calendarEvent {
 		}
 
 		event := calendarEvent{
-			ID:         movie.ID,
-			Title:      movie.Title,
-			Type:       "movie",
-			Date:       releaseDate,
-			Year:       movie.Year,
-			Downloaded: movie.Downloaded,
-			Monitored:  movie.Monitored,
-			Status:     getMovieStatus(movie.Downloaded, movie.Monitored),
-			Listname:   movie.Listname,
+			ID:           movie.ID,
+			Title:        movie.Title,
+			Type:         "movie",
+			Date:         releaseDate,
+			Year:         movie.Year,
+			Downloaded:   movie.Downloaded,
+			Monitored:    movie.Monitored,
+			Status:       getMovieStatus(movie.Downloaded, movie.Monitored),
+			Listname:     movie.Listname,
+			Availability: movie.Availability,
 		}
 
 		if movie.Overview != nil {
@@ -323,6 +326,7 @@ type SeriesCalendarQuery struct {
 	ID           uint    `db:"id"`
 	SeriesName   string  `db:"seriename"`
 	FirstAired   *string `db:"first_aired"`
+	AirTime      *string `db:"air_time"`
 	Season       string  `db:"season"`
 	Episode      int     `db:"episode"`
 	EpisodeTitle *string `db:"episode_title"`
@@ -340,7 +344,7 @@ func getSeriesCalendarEvents(startDate, endDate time.Time) []calendarEvent {
 	var events []calendarEvent
 
 	seriesData := database.StructscanT[SeriesCalendarQuery](false, 0, `
-		SELECT se.id, ds.seriename, dse.first_aired,
+		SELECT se.id, ds.seriename, dse.first_aired, dse.air_time,
 			   CAST(dse.season AS INTEGER) as season, CAST(dse.episode AS INTEGER) as episode,
 			   dse.title as episode_title, dse.overview, ds.network, ds.thetvdb_id, ds.trakt_id,
 			   CASE WHEN sef.id IS NOT NULL THEN 1 ELSE 0 END as downloaded,
@@ -389,6 +393,19 @@ func getSeriesCalendarEvents(startDate, endDate time.Time) []calendarEvent {
 			Listname:   series.Listname,
 		}
 
+		// The air time is stored in UTC - show it in the local time zone
+		if series.AirTime != nil {
+			airTime, err := time.Parse(time.RFC3339, *series.AirTime)
+			if err != nil {
+				airTime, err = time.Parse(time.DateTime, *series.AirTime)
+			}
+
+			if err == nil {
+				event.Date = airTime.Local()
+				event.AirTime = event.Date.Format("15:04")
+			}
+		}
+
 		if series.EpisodeTitle != nil {
 			event.Title = fmt.Sprintf("%s - %s", event.Title, *series.EpisodeTitle)
 		}
@@ -566,6 +583,22 @@ func getMovieStatus(downloaded, monitored bool) string {
 	return "Unmonitored"
 }
 
+// availabilityLabel returns the display name of a movie availability level.
+func availabilityLabel(level string) string {
+	switch level {
+	case config.AvailabilityAnnounced:
+		return "Announced"
+	case config.AvailabilityInCinemas:
+		return "In Cinemas"
+	case config.AvailabilityDigital:
+		return "Digital Release"
+	case config.AvailabilityPhysical:
+		return "Physical Release"
+	default:
+		return ""
+	}
+}
+
 // getSeriesStatus returns the status string for a series episode.
 func getSeriesStatus(downloaded, monitored bool) string {
 	if downloaded {
@@ -596,7 +629,11 @@ func generateICalContent(events []calendarEvent) string {
 		ical.WriteString("BEGIN:VEVENT\r\n")
 		fmt.Fprintf(&ical, "UID:%s-%d@media-downloader\r\n", event.Type, event.ID)
 		fmt.Fprintf(&ical, "DTSTAMP:%s\r\n", time.Now().UTC().Format("20060102T150405Z"))
-		fmt.Fprintf(&ical, "DTSTART;VALUE=DATE:%s\r\n", event.Date.Format("20060102"))
+		if event.AirTime != "" {
+			fmt.Fprintf(&ical, "DTSTART:%s\r\n", event.Date.UTC().Format("20060102T150405Z"))
+		} else {
+			fmt.Fprintf(&ical, "DTSTART;VALUE=DATE:%s\r\n", event.Date.Format("20060102"))
+		}
 
 		title := event.Title
 		if event.Type == "series" && event.Season != "0" && event.Season != "" &&
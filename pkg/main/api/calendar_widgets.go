@@ -552,12 +552,12 @@ func renderWeekViewWithFilter(
 					return nil
 				}(),
 				func() gomponents.Node {
-					if event.Network != "" {
+					if airInfo := eventSubInfo(event); airInfo != "" {
 						return gomponents.Group([]gomponents.Node{
 							html.Br(),
 							html.Small(
 								html.Class("text-muted"),
-								gomponents.Text(event.Network),
+								gomponents.Text(airInfo),
 							),
 						})
 					}
@@ -698,10 +698,10 @@ func renderEventCards(events []calendarEvent) []gomponents.Node {
 							return nil
 						}(),
 						func() gomponents.Node {
-							if event.Network != "" {
+							if airInfo := eventSubInfo(event); airInfo != "" {
 								return html.Small(
 									html.Class("text-info"),
-									gomponents.Text(event.Network),
+									gomponents.Text(airInfo),
 								)
 							}
 
@@ -849,10 +849,10 @@ func CalendarDayEventsHandler(c *gin.Context) {
 					html.Div(
 						html.Class("d-flex justify-content-between align-items-center"),
 						func() gomponents.Node {
-							if event.Network != "" {
+							if airInfo := eventSubInfo(event); airInfo != "" {
 								return html.Small(
 									html.Class("text-info"),
-									gomponents.Text(event.Network),
+									gomponents.Text(airInfo),
 								)
 							}
 
@@ -931,6 +931,21 @@ func getEventTextColor(event calendarEvent) string {
 	return "#383d41"
 }
 
+// eventSubInfo returns the local air time and the network of an event, or
+// the reached availability of a movie, whichever of them are known.
+func eventSubInfo(event calendarEvent) string {
+	switch {
+	case event.Availability != "":
+		return availabilityLabel(event.Availability)
+	case event.AirTime != "" && event.Network != "":
+		return event.AirTime + " - " + event.Network
+	case event.AirTime != "":
+		return event.AirTime
+	default:
+		return event.Network
+	}
+}
+
 func padZero(num int) string {
 	if num < 10 {
 		return "0" + strconv.Itoa(num)
@@ -9,6 +9,7 @@ import (
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/structure"
 )
 
 // getPathsToScan returns all media paths that should be scanned for cleanup.
@@ -288,9 +289,9 @@ func performCleanupActions(
 		return results
 	}
 
-	// Remove orphaned files
+	// Remove orphaned files - recycled if the recycle bin of their path is enabled
 	for _, file := range orphanedFiles {
-		if err := os.Remove(file); err == nil {
+		if err := structure.RemoveMediaFile(file, database.RecycleReasonManualDelete); err == nil {
 			results.ActionsPerformed = append(
 				results.ActionsPerformed,
 				fmt.Sprintf("Removed orphaned file: %s", file),
@@ -301,7 +302,8 @@ func performCleanupActions(
 	// Remove duplicate files (keep the first one in each group)
 	for _, group := range duplicateGroups {
 		for i := 1; i < len(group); i++ {
-			if err := os.Remove(group[i]); err == nil {
+			err := structure.RemoveMediaFile(group[i], database.RecycleReasonManualDelete)
+			if err == nil {
 				results.ActionsPerformed = append(
 					results.ActionsPerformed,
 					fmt.Sprintf("Removed duplicate file: %s", group[i]),
@@ -9,6 +9,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/gin-gonic/gin"
@@ -412,7 +413,10 @@ func createDownloaderConfig(index string, c *gin.Context) config.DownloaderConfi
 		SetInt(&cfg.Priority, "Priority").
 		SetBool(&cfg.AddPaused, "AddPaused").
 		SetBool(&cfg.DelugeMoveAfter, "DelugeMoveAfter").
-		SetBool(&cfg.Enabled, "Enabled")
+		SetBool(&cfg.Enabled, "Enabled").
+		SetString(&cfg.ProxyURL, "ProxyURL").
+		SetString(&cfg.ProxyUsername, "ProxyUsername").
+		SetString(&cfg.ProxyPassword, "ProxyPassword")
 
 	return cfg
 }
@@ -461,6 +465,9 @@ func createListsConfig(index string, c *gin.Context) config.ListsConfig {
 		SetStringArray(&cfg.IRCAnnounceNicks, "IRCAnnounceNicks").
 		SetString(&cfg.IRCAnnounceRegex, "IRCAnnounceRegex").
 		SetInt(&cfg.IRCReadSeconds, "IRCReadSeconds").
+		SetString(&cfg.ProxyURL, "ProxyURL").
+		SetString(&cfg.ProxyUsername, "ProxyUsername").
+		SetString(&cfg.ProxyPassword, "ProxyPassword").
 		SetBool(&cfg.Enabled, "Enabled").
 		// Movie Scraper Configuration
 		SetString(&cfg.MovieScraperType, "MovieScraperType").
@@ -522,7 +529,6 @@ func createIndexersConfig(index string, c *gin.Context) config.IndexersConfig {
 		SetString(&cfg.MaxEntriesStr, "MaxEntriesStr").
 		SetUint8(&cfg.RssEntriesloop, "RssEntriesloop").
 		SetBool(&cfg.OutputAsJSON, "OutputAsJSON").
-		SetString(&cfg.Customapi, "Customapi").
 		SetString(&cfg.Customurl, "Customurl").
 		SetString(&cfg.Customrssurl, "Customrssurl").
 		SetString(&cfg.Customrsscategory, "Customrsscategory").
@@ -535,7 +541,15 @@ func createIndexersConfig(index string, c *gin.Context) config.IndexersConfig {
 		SetUint16(&cfg.TimeoutSeconds, "TimeoutSeconds").
 		SetBool(&cfg.TrustWithIMDBIDs, "TrustWithIMDBIDs").
 		SetBool(&cfg.TrustWithTVDBIDs, "TrustWithTVDBIDs").
-		SetBool(&cfg.CheckTitleOnIDSearch, "CheckTitleOnIDSearch")
+		SetBool(&cfg.CheckTitleOnIDSearch, "CheckTitleOnIDSearch").
+		SetUint16(&cfg.ResponseCacheMinutes, "ResponseCacheMinutes").
+		SetString(&cfg.ProxyURL, "ProxyURL").
+		SetString(&cfg.ProxyUsername, "ProxyUsername").
+		SetString(&cfg.ProxyPassword, "ProxyPassword").
+		SetString(&cfg.Definition, "Definition").
+		SetString(&cfg.Username, "Username").
+		SetString(&cfg.Password, "Password").
+		SetString(&cfg.Cookie, "Cookie")
 
 	return cfg
 }
@@ -568,11 +582,29 @@ func createPathsConfig(index string, c *gin.Context) config.PathsConfig {
 		SetInt(&cfg.MaxSize, "MaxSize").
 		SetInt(&cfg.MinSize, "MinSize").
 		SetInt(&cfg.MinVideoSize, "MinVideoSize").
+		SetBool(&cfg.OrganizeExtras, "OrganizeExtras").
+		SetInt(&cfg.ExtrasMaxRuntimePercent, "ExtrasMaxRuntimePercent").
+		SetString(&cfg.ExtrasTrailersFolder, "ExtrasTrailersFolder").
+		SetString(&cfg.ExtrasFeaturettesFolder, "ExtrasFeaturettesFolder").
+		SetString(&cfg.ExtrasDeletedScenesFolder, "ExtrasDeletedScenesFolder").
+		SetString(&cfg.ExtrasBehindTheScenesFolder, "ExtrasBehindTheScenesFolder").
+		SetString(&cfg.ExtrasInterviewsFolder, "ExtrasInterviewsFolder").
+		SetString(&cfg.ExtrasOtherFolder, "ExtrasOtherFolder").
+		SetString(&cfg.ImportMode, "ImportMode").
+		SetInt(&cfg.KeepSourceDays, "KeepSourceDays").
+		SetBool(&cfg.OrganizeSubtitles, "OrganizeSubtitles").
+		SetStringArray(&cfg.SubtitleLanguages, "SubtitleLanguages").
+		SetBool(&cfg.SubtitleKeepUnknown, "SubtitleKeepUnknown").
+		SetBool(&cfg.DownloadSubtitles, "DownloadSubtitles").
+		SetString(&cfg.SubtitleHearingImpaired, "SubtitleHearingImpaired").
 		SetInt(&cfg.CleanupsizeMB, "CleanupsizeMB").
 		SetInt(&cfg.UpgradeScanInterval, "UpgradeScanInterval").
 		SetInt(&cfg.MissingScanInterval, "MissingScanInterval").
 		SetInt(&cfg.MissingScanReleaseDatePre, "MissingScanReleaseDatePre").
+		SetInt(&cfg.MissingSearchBackoffMaxDays, "MissingSearchBackoffMaxDays").
+		SetInt(&cfg.MissingSearchRecentDays, "MissingSearchRecentDays").
 		SetInt(&cfg.MaxRuntimeDifference, "MaxRuntimeDifference").
+		SetInt(&cfg.RecycleRetentionDays, "RecycleRetentionDays").
 		SetString(&cfg.PresortFolderPath, "PresortFolderPath").
 		SetString(&cfg.MoveReplacedTargetPath, "MoveReplacedTargetPath").
 		SetString(&cfg.SetChmod, "SetChmod").
@@ -682,7 +714,14 @@ func saveConfig(configv any) error {
 
 	logger.Logtype("info", 1).Str("data", string(d)).Msg("log struct")
 
-	return config.UpdateCfgEntryAny(configv)
+	if err := config.UpdateCfgEntryAny(configv); err != nil {
+		return err
+	}
+
+	// Indexer and quality changes alter the searches - drop the cached responses
+	apiexternal.ClearResponseCache()
+
+	return nil
 }
 
 // createRegexConfig creates a RegexConfig from form data.
@@ -884,7 +923,15 @@ func createQualityConfig(index string, c *gin.Context) config.QualityConfig {
 		SetBool(&qualityConfig.PreferLossless, "PreferLossless").
 		SetInt(&qualityConfig.MinAudioBitrate, "MinAudioBitrate").
 		SetStringArray(&qualityConfig.WantedAudioFormats, "WantedAudioFormats").
-		SetInt(&qualityConfig.UseForPriorityMinDifference, "UseForPriorityMinDifference")
+		SetInt(&qualityConfig.UseForPriorityMinDifference, "UseForPriorityMinDifference").
+		SetString(&qualityConfig.ProperUpgrade, "ProperUpgrade").
+		SetStringArrayFromForm(&qualityConfig.PreferredGroups, "PreferredGroups").
+		SetInt(&qualityConfig.PreferredGroupsBonus, "PreferredGroupsBonus").
+		SetStringArrayFromForm(&qualityConfig.BlockedGroups, "BlockedGroups").
+		SetStringArrayFromForm(&qualityConfig.RejectRules, "RejectRules").
+		SetStringArrayFromForm(&qualityConfig.ScoreRules, "ScoreRules").
+		SetInt(&qualityConfig.SeasonPackWantedPercent, "SeasonPackWantedPercent").
+		SetString(&qualityConfig.MinimumAvailability, "MinimumAvailability")
 
 	// Parse nested configurations
 	qualityConfig.QualityReorder = createQualityReorderConfigs(index, c)
@@ -983,6 +1030,14 @@ func createSchedulerConfig(index string, c *gin.Context) config.SchedulerConfig
 		addConfig.IntervalScanDataimport = val
 	}
 
+	if val := getFormField(c, prefix, index, "IntervalArtwork"); val != "" {
+		addConfig.IntervalArtwork = val
+	}
+
+	if val := getFormField(c, prefix, index, "IntervalSearchSubtitles"); val != "" {
+		addConfig.IntervalSearchSubtitles = val
+	}
+
 	if val := getFormField(c, prefix, index, "IntervalDatabaseBackup"); val != "" {
 		addConfig.IntervalDatabaseBackup = val
 	}
@@ -1084,6 +1139,14 @@ func createSchedulerConfig(index string, c *gin.Context) config.SchedulerConfig
 		addConfig.CronScanDataimport = val
 	}
 
+	if val := getFormField(c, prefix, index, "CronArtwork"); val != "" {
+		addConfig.CronArtwork = val
+	}
+
+	if val := getFormField(c, prefix, index, "CronSearchSubtitles"); val != "" {
+		addConfig.CronSearchSubtitles = val
+	}
+
 	if val := getFormField(c, prefix, index, "CronDatabaseBackup"); val != "" {
 		addConfig.CronDatabaseBackup = val
 	}
@@ -1134,6 +1197,33 @@ func createSchedulerConfig(index string, c *gin.Context) config.SchedulerConfig
 		addConfig.CronCacheRefresh = val
 	}
 
+	// Indexer caps scheduling
+	if val := getFormField(c, prefix, index, "IntervalIndexerCaps"); val != "" {
+		addConfig.IntervalIndexerCaps = val
+	}
+
+	if val := getFormField(c, prefix, index, "CronIndexerCaps"); val != "" {
+		addConfig.CronIndexerCaps = val
+	}
+
+	// Recycle bin retention scheduling
+	if val := getFormField(c, prefix, index, "IntervalRecycleBin"); val != "" {
+		addConfig.IntervalRecycleBin = val
+	}
+
+	if val := getFormField(c, prefix, index, "CronRecycleBin"); val != "" {
+		addConfig.CronRecycleBin = val
+	}
+
+	// Imported sources cleanup scheduling
+	if val := getFormField(c, prefix, index, "IntervalImportedSources"); val != "" {
+		addConfig.IntervalImportedSources = val
+	}
+
+	if val := getFormField(c, prefix, index, "CronImportedSources"); val != "" {
+		addConfig.CronImportedSources = val
+	}
+
 	return addConfig
 }
 
@@ -3,12 +3,14 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/parser"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
@@ -84,6 +86,8 @@ func HandleGeneralConfigUpdate(c *gin.Context) {
 		return
 	}
 
+	apiexternal.ApplyDefaultProxy()
+
 	c.String(http.StatusOK, renderAlert("Update successful", "success"))
 }
 
@@ -524,6 +528,7 @@ func HandleTestParse(c *gin.Context) {
 	filename := c.PostForm("testparse_Filename")
 	configKey := c.PostForm("testparse_ConfigKey")
 	qualityKey := c.PostForm("testparse_QualityKey")
+	indexer := c.PostForm("testparse_Indexer")
 	usePath, _ := strconv.ParseBool(c.PostForm("testparse_UsePath"))
 	useFolder, _ := strconv.ParseBool(c.PostForm("testparse_UseFolder"))
 
@@ -553,8 +558,23 @@ func HandleTestParse(c *gin.Context) {
 		return
 	}
 
-	// Parse the file
-	m := parser_v2.ParseFile(filename, usePath, useFolder, cfgp, -1)
+	// Show the rewrite rules applied to the parsed name
+	name := filename
+	if usePath {
+		name = filepath.Base(filename)
+	}
+
+	_, rewrites := parser_v2.RewriteName(name, cfgp, -1, indexer)
+
+	// Parse the file - a release title of an indexer also gets its rewrite rules
+	var m *database.ParseInfo
+	if indexer != "" {
+		m = database.PLParseInfo.Get()
+		parser_v2.ParseReleaseP(filename, indexer, cfgp, -1, m)
+	} else {
+		m = parser_v2.ParseFile(filename, usePath, useFolder, cfgp, -1)
+	}
+
 	if m == nil {
 		c.String(http.StatusOK, renderAlert("ParseFile returned nil - parsing failed", "danger"))
 		return
@@ -565,7 +585,7 @@ func HandleTestParse(c *gin.Context) {
 	parser.GetPriorityMapQual(m, cfgp, quality, false, true)
 
 	// Render results
-	c.String(http.StatusOK, renderParseResults(m, filename, configKey, qualityKey))
+	c.String(http.StatusOK, renderParseResults(m, filename, configKey, qualityKey, rewrites))
 }
 
 // HandleTraktAuth handles Trakt authentication requests.
@@ -69,6 +69,9 @@ func createDownloaderParser() *ConfigParser[config.DownloaderConfig] {
 				AddPaused:       builder.getBool("AddPaused"),
 				DelugeMoveAfter: builder.getBool("DelugeMoveAfter"),
 				Enabled:         builder.getBool("Enabled"),
+				ProxyURL:        builder.getString("ProxyURL"),
+				ProxyUsername:   builder.getString("ProxyUsername"),
+				ProxyPassword:   builder.getString("ProxyPassword"),
 			}
 		},
 		Validate: func(configs []config.DownloaderConfig) error {
@@ -104,7 +107,6 @@ func createIndexerParser() *ConfigParser[config.IndexersConfig] {
 					builder.getInt("RssEntriesloop", 0),
 				),
 				OutputAsJSON:      builder.getBool("OutputAsJSON"),
-				Customapi:         builder.getString("Customapi"),
 				Customurl:         builder.getString("Customurl"),
 				Customrssurl:      builder.getString("Customrssurl"),
 				Customrsscategory: builder.getString("Customrsscategory"),
@@ -124,6 +126,16 @@ func createIndexerParser() *ConfigParser[config.IndexersConfig] {
 				TrustWithIMDBIDs:     builder.getBool("TrustWithIMDBIDs"),
 				TrustWithTVDBIDs:     builder.getBool("TrustWithTVDBIDs"),
 				CheckTitleOnIDSearch: builder.getBool("CheckTitleOnIDSearch"),
+				ResponseCacheMinutes: uint16(
+					builder.getInt("ResponseCacheMinutes", 0),
+				),
+				ProxyURL:      builder.getString("ProxyURL"),
+				ProxyUsername: builder.getString("ProxyUsername"),
+				ProxyPassword: builder.getString("ProxyPassword"),
+				Definition:    builder.getString("Definition"),
+				Username:      builder.getString("Username"),
+				Password:      builder.getString("Password"),
+				Cookie:        builder.getString("Cookie"),
 			}
 		},
 		Validate: func(configs []config.IndexersConfig) error {
@@ -153,29 +165,47 @@ func createPathsParser() *ConfigParser[config.PathsConfig] {
 				AllowedOtherExtensionsNoRename: builder.getStringArray(
 					"AllowedOtherExtensionsNoRename",
 				),
-				Blocked:                   builder.getStringArray("Blocked"),
-				Disallowed:                builder.getStringArray("Disallowed"),
-				AllowedLanguages:          builder.getStringArray("AllowedLanguages"),
-				MaxSize:                   builder.getInt("MaxSize", 0),
-				MinSize:                   builder.getInt("MinSize", 0),
-				MinVideoSize:              builder.getInt("MinVideoSize", 0),
-				CleanupsizeMB:             builder.getInt("CleanupsizeMB", 0),
-				UpgradeScanInterval:       builder.getInt("UpgradeScanInterval", 0),
-				MissingScanInterval:       builder.getInt("MissingScanInterval", 0),
-				MissingScanReleaseDatePre: builder.getInt("MissingScanReleaseDatePre", 0),
-				MaxRuntimeDifference:      builder.getInt("MaxRuntimeDifference", 0),
-				PresortFolderPath:         builder.getString("PresortFolderPath"),
-				MoveReplacedTargetPath:    builder.getString("MoveReplacedTargetPath"),
-				SetChmod:                  builder.getString("SetChmod"),
-				SetChmodFolder:            builder.getString("SetChmodFolder"),
-				Upgrade:                   builder.getBool("Upgrade"),
-				Replacelower:              builder.getBool("Replacelower"),
-				Usepresort:                builder.getBool("Usepresort"),
-				DeleteWrongLanguage:       builder.getBool("DeleteWrongLanguage"),
-				DeleteDisallowed:          builder.getBool("DeleteDisallowed"),
-				CheckRuntime:              builder.getBool("CheckRuntime"),
-				DeleteWrongRuntime:        builder.getBool("DeleteWrongRuntime"),
-				MoveReplaced:              builder.getBool("MoveReplaced"),
+				Blocked:                     builder.getStringArray("Blocked"),
+				Disallowed:                  builder.getStringArray("Disallowed"),
+				AllowedLanguages:            builder.getStringArray("AllowedLanguages"),
+				MaxSize:                     builder.getInt("MaxSize", 0),
+				MinSize:                     builder.getInt("MinSize", 0),
+				MinVideoSize:                builder.getInt("MinVideoSize", 0),
+				ExtrasMaxRuntimePercent:     builder.getInt("ExtrasMaxRuntimePercent", 0),
+				ExtrasTrailersFolder:        builder.getString("ExtrasTrailersFolder"),
+				ExtrasFeaturettesFolder:     builder.getString("ExtrasFeaturettesFolder"),
+				ExtrasDeletedScenesFolder:   builder.getString("ExtrasDeletedScenesFolder"),
+				ExtrasBehindTheScenesFolder: builder.getString("ExtrasBehindTheScenesFolder"),
+				ExtrasInterviewsFolder:      builder.getString("ExtrasInterviewsFolder"),
+				ExtrasOtherFolder:           builder.getString("ExtrasOtherFolder"),
+				ImportMode:                  builder.getString("ImportMode"),
+				KeepSourceDays:              builder.getInt("KeepSourceDays", 0),
+				SubtitleLanguages:           builder.getStringArray("SubtitleLanguages"),
+				SubtitleHearingImpaired:     builder.getString("SubtitleHearingImpaired"),
+				CleanupsizeMB:               builder.getInt("CleanupsizeMB", 0),
+				UpgradeScanInterval:         builder.getInt("UpgradeScanInterval", 0),
+				MissingScanInterval:         builder.getInt("MissingScanInterval", 0),
+				MissingScanReleaseDatePre:   builder.getInt("MissingScanReleaseDatePre", 0),
+				MissingSearchBackoffMaxDays: builder.getInt("MissingSearchBackoffMaxDays", 0),
+				MissingSearchRecentDays:     builder.getInt("MissingSearchRecentDays", 0),
+				MaxRuntimeDifference:        builder.getInt("MaxRuntimeDifference", 0),
+				PresortFolderPath:           builder.getString("PresortFolderPath"),
+				MoveReplacedTargetPath:      builder.getString("MoveReplacedTargetPath"),
+				RecycleRetentionDays:        builder.getInt("RecycleRetentionDays", 0),
+				SetChmod:                    builder.getString("SetChmod"),
+				SetChmodFolder:              builder.getString("SetChmodFolder"),
+				Upgrade:                     builder.getBool("Upgrade"),
+				Replacelower:                builder.getBool("Replacelower"),
+				Usepresort:                  builder.getBool("Usepresort"),
+				DeleteWrongLanguage:         builder.getBool("DeleteWrongLanguage"),
+				DeleteDisallowed:            builder.getBool("DeleteDisallowed"),
+				CheckRuntime:                builder.getBool("CheckRuntime"),
+				DeleteWrongRuntime:          builder.getBool("DeleteWrongRuntime"),
+				MoveReplaced:                builder.getBool("MoveReplaced"),
+				OrganizeExtras:              builder.getBool("OrganizeExtras"),
+				OrganizeSubtitles:           builder.getBool("OrganizeSubtitles"),
+				SubtitleKeepUnknown:         builder.getBool("SubtitleKeepUnknown"),
+				DownloadSubtitles:           builder.getBool("DownloadSubtitles"),
 			}
 		},
 		Validate: func(configs []config.PathsConfig) error {
@@ -273,6 +303,11 @@ func createListsParser() *ConfigParser[config.ListsConfig] {
 				ChartDefaultArtist:   builder.getString("ChartDefaultArtist"),
 				ChartDateURLPattern:  builder.getString("ChartDateURLPattern"),
 				ChartDateFormat:      builder.getString("ChartDateFormat"),
+
+				// Proxy
+				ProxyURL:      builder.getString("ProxyURL"),
+				ProxyUsername: builder.getString("ProxyUsername"),
+				ProxyPassword: builder.getString("ProxyPassword"),
 			}
 		},
 		Validate: func(configs []config.ListsConfig) error {
@@ -455,6 +490,8 @@ func parseMediaTypeConfig[T any](
 		SetBool(&cfg.Structure, "Structure").
 		SetUint16(&cfg.SearchmissingIncremental, "SearchmissingIncremental").
 		SetUint16(&cfg.SearchupgradeIncremental, "SearchupgradeIncremental").
+		SetInt(&cfg.SearchDelay, "SearchDelay").
+		SetString(&cfg.ReleaseRegion, "ReleaseRegion").
 		SetString(&cfg.AudibleRegion, "AudibleRegion")
 
 	return cfg
@@ -499,6 +536,34 @@ func parseMediaDataConfigs(c *gin.Context, mediaType, index string) []config.Med
 			cfg.WriteRenameLog, _ = strconv.ParseBool(val)
 		}
 
+		if val := c.PostForm(fmt.Sprintf("%s_%s_WriteNfo", prefix, subIndex)); val != "" {
+			cfg.WriteNfo, _ = strconv.ParseBool(val)
+		}
+
+		if val := c.PostForm(fmt.Sprintf("%s_%s_DownloadArtwork", prefix, subIndex)); val != "" {
+			cfg.DownloadArtwork, _ = strconv.ParseBool(val)
+		}
+
+		if val := c.PostFormArray(
+			fmt.Sprintf("%s_%s_ArtworkTypes", prefix, subIndex),
+		); len(
+			val,
+		) != 0 {
+			cfg.ArtworkTypes = val
+		}
+
+		if val := c.PostForm(fmt.Sprintf("%s_%s_ArtworkPosterSize", prefix, subIndex)); val != "" {
+			cfg.ArtworkPosterSize = val
+		}
+
+		if val := c.PostForm(fmt.Sprintf("%s_%s_ArtworkFanartSize", prefix, subIndex)); val != "" {
+			cfg.ArtworkFanartSize = val
+		}
+
+		if val := c.PostForm(fmt.Sprintf("%s_%s_ArtworkOverwrite", prefix, subIndex)); val != "" {
+			cfg.ArtworkOverwrite, _ = strconv.ParseBool(val)
+		}
+
 		if val := c.PostForm(fmt.Sprintf("%s_%s_EmbedArt", prefix, subIndex)); val != "" {
 			cfg.EmbedArt, _ = strconv.ParseBool(val)
 		}
@@ -804,6 +869,10 @@ func parseMediaListsConfigs(c *gin.Context, mediaType, index string) []config.Me
 			cfg.Addfound, _ = strconv.ParseBool(val)
 		}
 
+		cfg.MinimumAvailability = c.PostForm(
+			fmt.Sprintf("%s_%s_MinimumAvailability", prefix, subIndex),
+		)
+
 		configs = append(configs, cfg)
 	}
 
@@ -912,6 +981,8 @@ func parseGeneralConfig(c *gin.Context) config.GeneralConfig {
 		SetString(&updatedConfig.WebPort, "WebPort").
 		// SetBool(&updatedConfig.DisableVariableCleanup, "DisableVariableCleanup").
 		SetBool(&updatedConfig.DisableParserStringMatch, "DisableParserStringMatch").
+		SetBool(&updatedConfig.ParserShadowMode, "ParserShadowMode").
+		SetStringArrayFromForm(&updatedConfig.ParserRewriteRules, "ParserRewriteRules").
 		SetBool(&updatedConfig.UseMediaCache, "UseMediaCache").
 		SetInt(&updatedConfig.CacheDuration, "CacheDuration").
 		// SetBool(&updatedConfig.DisableSwagger, "DisableSwagger").
@@ -936,6 +1007,7 @@ func parseGeneralConfig(c *gin.Context) config.GeneralConfig {
 		SetBool(&updatedConfig.DatabaseBackupStopTasks, "DatabaseBackupStopTasks").
 		SetInt(&updatedConfig.MaxDatabaseBackups, "MaxDatabaseBackups").
 		SetInt(&updatedConfig.FailedIndexerBlockTime, "FailedIndexerBlockTime").
+		SetInt(&updatedConfig.RSSHistoryItems, "RSSHistoryItems").
 		SetBool(&updatedConfig.UseMediaFallback, "UseMediaFallback").
 		SetBool(&updatedConfig.UseMediainfo, "UseMediainfo").
 		SetString(&updatedConfig.MediainfoPath, "MediainfoPath").
@@ -965,11 +1037,18 @@ func parseGeneralConfig(c *gin.Context) config.GeneralConfig {
 		SetInt(&updatedConfig.JellyfinLimiterCalls, "JellyfinLimiterCalls").
 		SetUint16(&updatedConfig.JellyfinTimeoutSeconds, "JellyfinTimeoutSeconds").
 		SetBool(&updatedConfig.JellyfinDisableTLSVerify, "JellyfinDisableTLSVerify").
+		SetString(&updatedConfig.ProxyURL, "ProxyURL").
+		SetString(&updatedConfig.ProxyUsername, "ProxyUsername").
+		SetString(&updatedConfig.ProxyPassword, "ProxyPassword").
+		SetStringArray(&updatedConfig.ProxyBypass, "ProxyBypass").
+		SetString(&updatedConfig.MetadataProxyURL, "MetadataProxyURL").
 		SetBool(&updatedConfig.UseFileBufferCopy, "UseFileBufferCopy").
 		SetBool(&updatedConfig.UseCronInsteadOfInterval, "UseCronInsteadOfInterval").
 		SetBool(&updatedConfig.SchedulerDisabled, "SchedulerDisabled").
 		SetInt(&updatedConfig.MoveBufferSizeKB, "MoveBufferSizeKB").
 		SetBool(&updatedConfig.SerieMetaSourceTrakt, "SerieMetaSourceTrakt").
+		SetString(&updatedConfig.SceneMappingFile, "SceneMappingFile").
+		SetString(&updatedConfig.IndexerDefinitionsPath, "IndexerDefinitionsPath").
 		SetBool(&updatedConfig.SerieMetaSourceTmdb, "SerieMetaSourceTmdb").
 		SetStringArray(&updatedConfig.MovieParseMetaSourcePriority, "MovieParseMetaSourcePriority").
 		SetStringArray(&updatedConfig.MovieRSSMetaSourcePriority, "MovieRSSMetaSourcePriority").
@@ -1014,6 +1093,14 @@ func parseGeneralConfig(c *gin.Context) config.GeneralConfig {
 		SetInt(&updatedConfig.LastFMLimiterCalls, "LastFMLimiterCalls").
 		SetUint8(&updatedConfig.DeezerLimiterSeconds, "DeezerLimiterSeconds").
 		SetInt(&updatedConfig.DeezerLimiterCalls, "DeezerLimiterCalls").
+		// Subtitle Providers
+		SetString(&updatedConfig.OpenSubtitlesAPIKey, "OpenSubtitlesAPIKey").
+		SetString(&updatedConfig.OpenSubtitlesUsername, "OpenSubtitlesUsername").
+		SetString(&updatedConfig.OpenSubtitlesPassword, "OpenSubtitlesPassword").
+		SetString(&updatedConfig.OpenSubtitlesURL, "OpenSubtitlesURL").
+		SetUint8(&updatedConfig.OpenSubtitlesLimiterSeconds, "OpenSubtitlesLimiterSeconds").
+		SetInt(&updatedConfig.OpenSubtitlesLimiterCalls, "OpenSubtitlesLimiterCalls").
+		SetUint16(&updatedConfig.OpenSubtitlesTimeoutSeconds, "OpenSubtitlesTimeoutSeconds").
 		SetUint8(&updatedConfig.ITunesLimiterSeconds, "ITunesLimiterSeconds").
 		SetInt(&updatedConfig.ITunesLimiterCalls, "ITunesLimiterCalls").
 		SetUint8(&updatedConfig.TheAudioDBLimiterSeconds, "TheAudioDBLimiterSeconds").
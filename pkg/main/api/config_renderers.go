@@ -11,6 +11,7 @@ import (
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
 	"maragu.dev/gomponents"
 	hx "maragu.dev/gomponents-htmx"
 	"maragu.dev/gomponents/html"
@@ -584,6 +585,12 @@ func renderGeneralConfigSections(
 					Type:  "checkbox",
 					Value: configv.SerieMetaSourceTrakt,
 				},
+				{Name: "SceneMappingFile", Type: "text", Value: configv.SceneMappingFile},
+				{
+					Name:  "IndexerDefinitionsPath",
+					Type:  "text",
+					Value: configv.IndexerDefinitionsPath,
+				},
 			}, group, comments, displayNames),
 
 		// Rate Limiting Section
@@ -625,6 +632,16 @@ func renderGeneralConfigSections(
 				},
 			}, group, comments, displayNames),
 
+		// Proxy
+		renderConfigGroup("Proxy", "proxy", false,
+			[]FormFieldDefinition{
+				{Name: "ProxyURL", Type: "text", Value: configv.ProxyURL},
+				{Name: "ProxyUsername", Type: "text", Value: configv.ProxyUsername},
+				{Name: "ProxyPassword", Type: "password", Value: configv.ProxyPassword},
+				{Name: "ProxyBypass", Type: "array", Value: configv.ProxyBypass},
+				{Name: "MetadataProxyURL", Type: "text", Value: configv.MetadataProxyURL},
+			}, group, comments, displayNames),
+
 		// External Tools
 		renderConfigGroup("External Tools", "external", false,
 			[]FormFieldDefinition{
@@ -650,6 +667,34 @@ func renderGeneralConfigSections(
 				{Name: "TheAudioDBAPIKey", Type: "text", Value: configv.TheAudioDBAPIKey},
 			}, group, comments, displayNames),
 
+		// Subtitle Providers
+		renderConfigGroup("Subtitle Providers", "subtitle_providers", false,
+			[]FormFieldDefinition{
+				{Name: "OpenSubtitlesAPIKey", Type: "text", Value: configv.OpenSubtitlesAPIKey},
+				{Name: "OpenSubtitlesUsername", Type: "text", Value: configv.OpenSubtitlesUsername},
+				{
+					Name:  "OpenSubtitlesPassword",
+					Type:  "password",
+					Value: configv.OpenSubtitlesPassword,
+				},
+				{Name: "OpenSubtitlesURL", Type: "text", Value: configv.OpenSubtitlesURL},
+				{
+					Name:  "OpenSubtitlesLimiterSeconds",
+					Type:  "number",
+					Value: configv.OpenSubtitlesLimiterSeconds,
+				},
+				{
+					Name:  "OpenSubtitlesLimiterCalls",
+					Type:  "number",
+					Value: configv.OpenSubtitlesLimiterCalls,
+				},
+				{
+					Name:  "OpenSubtitlesTimeoutSeconds",
+					Type:  "number",
+					Value: configv.OpenSubtitlesTimeoutSeconds,
+				},
+			}, group, comments, displayNames),
+
 		// Book/Audiobook/Music Provider Rate Limits
 		renderConfigGroup("Book/Music Provider Rate Limits", "provider_limits", false,
 			[]FormFieldDefinition{
@@ -761,6 +806,12 @@ func renderGeneralConfigSections(
 					Type:  "checkbox",
 					Value: configv.DisableParserStringMatch,
 				},
+				{Name: "ParserShadowMode", Type: "checkbox", Value: configv.ParserShadowMode},
+				{
+					Name:  "ParserRewriteRules",
+					Type:  "array",
+					Value: configv.ParserRewriteRules,
+				},
 				{
 					Name:  "UseCronInsteadOfInterval",
 					Type:  "checkbox",
@@ -798,6 +849,7 @@ func renderGeneralConfigSections(
 					Type:  "number",
 					Value: configv.FailedIndexerBlockTime,
 				},
+				{Name: "RSSHistoryItems", Type: "number", Value: configv.RSSHistoryItems},
 				{Name: "MaxDatabaseBackups", Type: "number", Value: configv.MaxDatabaseBackups},
 				{
 					Name:  "DatabaseBackupStopTasks",
@@ -1006,6 +1058,26 @@ func renderMediaDataForm(prefix string, i int, configv *config.MediaDataConfig)
 		{Name: "AddFoundList", Type: "text", Value: configv.AddFoundList, Options: nil},
 		{Name: "EnableUnpacking", Type: "checkbox", Value: configv.EnableUnpacking, Options: nil},
 		{Name: "WriteRenameLog", Type: "checkbox", Value: configv.WriteRenameLog, Options: nil},
+		{Name: "WriteNfo", Type: "checkbox", Value: configv.WriteNfo, Options: nil},
+		{Name: "DownloadArtwork", Type: "checkbox", Value: configv.DownloadArtwork, Options: nil},
+		{Name: "ArtworkTypes", Type: "array", Value: configv.ArtworkTypes, Options: nil},
+		{
+			Name:  "ArtworkPosterSize",
+			Type:  "select",
+			Value: configv.ArtworkPosterSize,
+			Options: convertMapToSelectOptions(map[string][]string{
+				"options": {"", "w92", "w154", "w185", "w342", "w500", "w780", "original"},
+			}),
+		},
+		{
+			Name:  "ArtworkFanartSize",
+			Type:  "select",
+			Value: configv.ArtworkFanartSize,
+			Options: convertMapToSelectOptions(map[string][]string{
+				"options": {"", "w300", "w780", "w1280", "original"},
+			}),
+		},
+		{Name: "ArtworkOverwrite", Type: "checkbox", Value: configv.ArtworkOverwrite, Options: nil},
 		{Name: "EmbedArt", Type: "checkbox", Value: configv.EmbedArt, Options: nil},
 		{Name: "EmbedLyrics", Type: "checkbox", Value: configv.EmbedLyrics, Options: nil},
 		{
@@ -1190,6 +1262,14 @@ func renderMediaListsForm(prefix string, i int, configv *config.MediaListsConfig
 		{Name: "ReplaceMapLists", Type: "array", Value: configv.ReplaceMapLists, Options: nil},
 		{Name: "Enabled", Type: "checkbox", Value: configv.Enabled, Options: nil},
 		{Name: "AddFound", Type: "checkbox", Value: configv.Addfound, Options: nil},
+		{
+			Name:  "MinimumAvailability",
+			Type:  "select",
+			Value: configv.MinimumAvailability,
+			Options: convertMapToSelectOptions(map[string][]string{
+				"options": {"", "announced", "in_cinemas", "digital", "physical"},
+			}),
+		},
 	}
 
 	return renderArrayItemFormWithIndex(prefix, i, "List", configv, fields)
@@ -1319,6 +1399,12 @@ func renderMediaConfigSections(
 					Options: nil,
 				},
 				{Name: "Structure", Type: "checkbox", Value: configv.Structure, Options: nil},
+				{
+					Name:    "ReleaseRegion",
+					Type:    "text",
+					Value:   configv.ReleaseRegion,
+					Options: nil,
+				},
 				{
 					Name:  "AudibleRegion",
 					Type:  "select",
@@ -1400,6 +1486,12 @@ func renderMediaConfigSections(
 					Value:   configv.SearchupgradeIncremental,
 					Options: nil,
 				},
+				{
+					Name:    "SearchDelay",
+					Type:    "number",
+					Value:   configv.SearchDelay,
+					Options: nil,
+				},
 			},
 			"media_main_"+typev+"_"+configv.Name,
 			comments,
@@ -1717,6 +1809,9 @@ func renderDownloaderConfigSections(
 				{Name: "Port", Type: "number", Value: configv.Port, Options: nil},
 				{Name: "Username", Type: "text", Value: configv.Username, Options: nil},
 				{Name: "Password", Type: "password", Value: configv.Password, Options: nil},
+				{Name: "ProxyURL", Type: "text", Value: configv.ProxyURL, Options: nil},
+				{Name: "ProxyUsername", Type: "text", Value: configv.ProxyUsername, Options: nil},
+				{Name: "ProxyPassword", Type: "password", Value: configv.ProxyPassword, Options: nil},
 			},
 			group,
 			comments,
@@ -2385,6 +2480,9 @@ func renderListsConfigSections(
 					Value:   configv.IRCReadSeconds,
 					Options: nil,
 				},
+				{Name: "ProxyURL", Type: "text", Value: configv.ProxyURL, Options: nil},
+				{Name: "ProxyUsername", Type: "text", Value: configv.ProxyUsername, Options: nil},
+				{Name: "ProxyPassword", Type: "password", Value: configv.ProxyPassword, Options: nil},
 			},
 			group,
 			comments,
@@ -2450,7 +2548,7 @@ func renderIndexersConfigSections(
 					Type:  "select",
 					Value: configv.IndexerType,
 					Options: convertMapToSelectOptions(map[string][]string{
-						"options": {"torznab", "newznab", "torrent", "torrentrss"},
+						"options": {"torznab", "newznab", "torrent", "torrentrss", "cardigann"},
 					}),
 				},
 				{Name: "Enabled", Type: "checkbox", Value: configv.Enabled, Options: nil},
@@ -2487,6 +2585,30 @@ func renderIndexersConfigSections(
 					Value:   configv.TimeoutSeconds,
 					Options: nil,
 				},
+				{Name: "ProxyURL", Type: "text", Value: configv.ProxyURL, Options: nil},
+				{Name: "ProxyUsername", Type: "text", Value: configv.ProxyUsername, Options: nil},
+				{Name: "ProxyPassword", Type: "password", Value: configv.ProxyPassword, Options: nil},
+			},
+			group,
+			comments,
+			displayNames,
+			accordionId,
+		),
+
+		// Site Definition Settings
+		renderConfigGroupWithParent(
+			"Site Definition Settings",
+			"definition-indexers-"+strings.ReplaceAll(
+				strings.ReplaceAll(configv.Name, " ", "-"),
+				"_",
+				"-",
+			),
+			false,
+			[]FormFieldDefinition{
+				{Name: "Definition", Type: "text", Value: configv.Definition, Options: nil},
+				{Name: "Username", Type: "text", Value: configv.Username, Options: nil},
+				{Name: "Password", Type: "password", Value: configv.Password, Options: nil},
+				{Name: "Cookie", Type: "password", Value: configv.Cookie, Options: nil},
 			},
 			group,
 			comments,
@@ -2561,6 +2683,12 @@ func renderIndexersConfigSections(
 					Value:   configv.CheckTitleOnIDSearch,
 					Options: nil,
 				},
+				{
+					Name:    "ResponseCacheMinutes",
+					Type:    "number",
+					Value:   configv.ResponseCacheMinutes,
+					Options: nil,
+				},
 			},
 			group,
 			comments,
@@ -2608,7 +2736,6 @@ func renderIndexersConfigSections(
 			),
 			false,
 			[]FormFieldDefinition{
-				{Name: "Customapi", Type: "text", Value: configv.Customapi, Options: nil},
 				{Name: "Customurl", Type: "text", Value: configv.Customurl, Options: nil},
 				{Name: "OutputAsJSON", Type: "checkbox", Value: configv.OutputAsJSON, Options: nil},
 			},
@@ -2753,6 +2880,64 @@ func renderPathsConfigSections(
 			accordionId,
 		),
 
+		// Extras
+		renderConfigGroupWithParent("Extras", "extras-paths-"+sanitizedName, false,
+			[]FormFieldDefinition{
+				{Name: "OrganizeExtras", Type: "checkbox", Value: configv.OrganizeExtras},
+				{
+					Name:  "ExtrasMaxRuntimePercent",
+					Type:  "number",
+					Value: configv.ExtrasMaxRuntimePercent,
+				},
+				{Name: "ExtrasTrailersFolder", Type: "text", Value: configv.ExtrasTrailersFolder},
+				{
+					Name:  "ExtrasFeaturettesFolder",
+					Type:  "text",
+					Value: configv.ExtrasFeaturettesFolder,
+				},
+				{
+					Name:  "ExtrasDeletedScenesFolder",
+					Type:  "text",
+					Value: configv.ExtrasDeletedScenesFolder,
+				},
+				{
+					Name:  "ExtrasBehindTheScenesFolder",
+					Type:  "text",
+					Value: configv.ExtrasBehindTheScenesFolder,
+				},
+				{
+					Name:  "ExtrasInterviewsFolder",
+					Type:  "text",
+					Value: configv.ExtrasInterviewsFolder,
+				},
+				{Name: "ExtrasOtherFolder", Type: "text", Value: configv.ExtrasOtherFolder},
+			}, group, comments, displayNames, accordionId),
+
+		// Subtitles
+		renderConfigGroupWithParent("Subtitles", "subtitles-paths-"+sanitizedName, false,
+			[]FormFieldDefinition{
+				{Name: "OrganizeSubtitles", Type: "checkbox", Value: configv.OrganizeSubtitles},
+				{Name: "SubtitleLanguages", Type: "array", Value: configv.SubtitleLanguages},
+				{
+					Name:  "SubtitleKeepUnknown",
+					Type:  "checkbox",
+					Value: configv.SubtitleKeepUnknown,
+				},
+				{Name: "DownloadSubtitles", Type: "checkbox", Value: configv.DownloadSubtitles},
+				{
+					Name:  "SubtitleHearingImpaired",
+					Type:  "select",
+					Value: configv.SubtitleHearingImpaired,
+					Options: convertMapToSelectOptions(map[string][]string{
+						"options": {
+							"",
+							config.SubtitleHearingImpairedPrefer,
+							config.SubtitleHearingImpairedExclude,
+						},
+					}),
+				},
+			}, group, comments, displayNames, accordionId),
+
 		// Scanning Settings
 		renderConfigGroupWithParent("Scanning Settings", "scanning-paths-"+sanitizedName, false,
 			[]FormFieldDefinition{
@@ -2774,6 +2959,18 @@ func renderPathsConfigSections(
 					Value:   configv.MissingScanReleaseDatePre,
 					Options: nil,
 				},
+				{
+					Name:    "MissingSearchBackoffMaxDays",
+					Type:    "number",
+					Value:   configv.MissingSearchBackoffMaxDays,
+					Options: nil,
+				},
+				{
+					Name:    "MissingSearchRecentDays",
+					Type:    "number",
+					Value:   configv.MissingSearchRecentDays,
+					Options: nil,
+				},
 			}, group, comments, displayNames, accordionId),
 
 		// Quality Control
@@ -2809,6 +3006,26 @@ func renderPathsConfigSections(
 		// File Organization
 		renderConfigGroupWithParent("File Organization", "organization-paths-"+sanitizedName, false,
 			[]FormFieldDefinition{
+				{
+					Name:  "ImportMode",
+					Type:  "select",
+					Value: configv.ImportMode,
+					Options: convertMapToSelectOptions(map[string][]string{
+						"options": {
+							config.ImportModeMove,
+							config.ImportModeCopy,
+							config.ImportModeHardlink,
+							config.ImportModeReflink,
+							config.ImportModeSymlink,
+						},
+					}),
+				},
+				{
+					Name:    "KeepSourceDays",
+					Type:    "number",
+					Value:   configv.KeepSourceDays,
+					Options: nil,
+				},
 				{Name: "Replacelower", Type: "checkbox", Value: configv.Replacelower, Options: nil},
 				{Name: "Usepresort", Type: "checkbox", Value: configv.Usepresort, Options: nil},
 				{
@@ -2824,6 +3041,12 @@ func renderPathsConfigSections(
 					Value:   configv.MoveReplacedTargetPath,
 					Options: nil,
 				},
+				{
+					Name:    "RecycleRetentionDays",
+					Type:    "number",
+					Value:   configv.RecycleRetentionDays,
+					Options: nil,
+				},
 			}, group, comments, displayNames, accordionId),
 
 		// File Permissions
@@ -3317,6 +3540,12 @@ func renderQualityConfigSections(
 					Value:   configv.TitleStripPrefixForSearch,
 					Options: nil,
 				},
+				{
+					Name:    "SeasonPackWantedPercent",
+					Type:    "number",
+					Value:   configv.SeasonPackWantedPercent,
+					Options: nil,
+				},
 			},
 			group,
 			comments,
@@ -3414,6 +3643,52 @@ func renderQualityConfigSections(
 					Value:   configv.UseForPriorityMinDifference,
 					Options: nil,
 				},
+				{
+					Name:  "ProperUpgrade",
+					Type:  "select",
+					Value: configv.ProperUpgrade,
+					Options: convertMapToSelectOptions(map[string][]string{
+						"options": {"", "same_group", "any_group"},
+					}),
+				},
+				{
+					Name:    "PreferredGroups",
+					Type:    "array",
+					Value:   configv.PreferredGroups,
+					Options: nil,
+				},
+				{
+					Name:    "PreferredGroupsBonus",
+					Type:    "number",
+					Value:   configv.PreferredGroupsBonus,
+					Options: nil,
+				},
+				{
+					Name:    "BlockedGroups",
+					Type:    "array",
+					Value:   configv.BlockedGroups,
+					Options: nil,
+				},
+				{
+					Name:    "RejectRules",
+					Type:    "array",
+					Value:   configv.RejectRules,
+					Options: nil,
+				},
+				{
+					Name:    "ScoreRules",
+					Type:    "array",
+					Value:   configv.ScoreRules,
+					Options: nil,
+				},
+				{
+					Name:  "MinimumAvailability",
+					Type:  "select",
+					Value: configv.MinimumAvailability,
+					Options: convertMapToSelectOptions(map[string][]string{
+						"options": {"", "announced", "in_cinemas", "digital", "physical"},
+					}),
+				},
 			},
 			group,
 			comments,
@@ -3805,6 +4080,14 @@ func renderSchedulerConfigSections(
 					Value: configv.IntervalScanDataimport,
 				},
 				{Name: "CronScanDataimport", Type: "text", Value: configv.CronScanDataimport},
+				{Name: "IntervalArtwork", Type: "text", Value: configv.IntervalArtwork},
+				{Name: "CronArtwork", Type: "text", Value: configv.CronArtwork},
+				{
+					Name:  "IntervalSearchSubtitles",
+					Type:  "text",
+					Value: configv.IntervalSearchSubtitles,
+				},
+				{Name: "CronSearchSubtitles", Type: "text", Value: configv.CronSearchSubtitles},
 				{
 					Name:  "IntervalDatabaseBackup",
 					Type:  "text",
@@ -3815,6 +4098,16 @@ func renderSchedulerConfigSections(
 				{Name: "CronDatabaseCheck", Type: "text", Value: configv.CronDatabaseCheck},
 				{Name: "IntervalCacheRefresh", Type: "text", Value: configv.IntervalCacheRefresh},
 				{Name: "CronCacheRefresh", Type: "text", Value: configv.CronCacheRefresh},
+				{Name: "IntervalIndexerCaps", Type: "text", Value: configv.IntervalIndexerCaps},
+				{Name: "CronIndexerCaps", Type: "text", Value: configv.CronIndexerCaps},
+				{Name: "IntervalRecycleBin", Type: "text", Value: configv.IntervalRecycleBin},
+				{Name: "CronRecycleBin", Type: "text", Value: configv.CronRecycleBin},
+				{
+					Name:  "IntervalImportedSources",
+					Type:  "text",
+					Value: configv.IntervalImportedSources,
+				},
+				{Name: "CronImportedSources", Type: "text", Value: configv.CronImportedSources},
 			},
 			group,
 			comments,
@@ -4604,6 +4897,12 @@ func renderTestParsePage(csrfToken string) gomponents.Node {
 				"options": qualities,
 			}),
 
+			renderFormGroup("testparse", map[string]string{
+				"Indexer": "Optional indexer the release was found on - applies the rewrite rules scoped to it",
+			}, map[string]string{
+				"Indexer": "Indexer",
+			}, "Indexer", "text", "", nil),
+
 			renderFormGroup("testparse", map[string]string{
 				"UsePath": "Parse as full path instead of just filename",
 			}, map[string]string{
@@ -4645,10 +4944,45 @@ func renderTestParsePage(csrfToken string) gomponents.Node {
 	)
 }
 
+// renderRewriteStepRows renders the name before and after each parser rewrite
+// rule which changed it.
+func renderRewriteStepRows(rewrites []parser_v2.RewriteStep) []gomponents.Node {
+	if len(rewrites) == 0 {
+		return []gomponents.Node{
+			html.Tr(
+				html.Td(html.Strong(gomponents.Text("Rewrite Rules:"))),
+				html.Td(gomponents.Text("None applied")),
+			),
+			html.Tr(html.Td(gomponents.Attr("colspan", "2"), html.Hr())),
+		}
+	}
+
+	rows := make([]gomponents.Node, 0, len(rewrites)*3+1)
+	for i := range rewrites {
+		rows = append(rows,
+			html.Tr(
+				html.Td(html.Strong(gomponents.Text(fmt.Sprintf("Rewrite Rule %d:", i+1)))),
+				html.Td(html.Code(gomponents.Text(rewrites[i].Rule))),
+			),
+			html.Tr(
+				html.Td(html.Strong(gomponents.Text("Before:"))),
+				html.Td(gomponents.Text(rewrites[i].Before)),
+			),
+			html.Tr(
+				html.Td(html.Strong(gomponents.Text("After:"))),
+				html.Td(gomponents.Text(rewrites[i].After)),
+			),
+		)
+	}
+
+	return append(rows, html.Tr(html.Td(gomponents.Attr("colspan", "2"), html.Hr())))
+}
+
 // renderParseResults renders the parsing results in a formatted table.
 func renderParseResults(
 	m *database.ParseInfo,
 	originalFilename, configKey, qualityKey string,
+	rewrites []parser_v2.RewriteStep,
 ) string {
 	resultRows := []gomponents.Node{
 		// Header information
@@ -4666,6 +5000,9 @@ func renderParseResults(
 		),
 		html.Tr(html.Td(gomponents.Attr("colspan", "2"), html.Hr())),
 
+		// Parser rewrite rules
+		gomponents.Group(renderRewriteStepRows(rewrites)),
+
 		// Basic media information
 		html.Tr(html.Td(html.Strong(gomponents.Text("Title:"))), html.Td(gomponents.Text(m.Title))),
 		html.Tr(html.Td(html.Strong(gomponents.Text("File:"))), html.Td(gomponents.Text(m.File))),
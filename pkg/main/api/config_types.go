@@ -60,6 +60,7 @@ var downloaderValidator = &ConfigValidator[config.DownloaderConfig]{
 			"priority",
 			func(c config.DownloaderConfig) int { return c.Priority },
 		),
+		validateProxy("proxy_url", func(c config.DownloaderConfig) string { return c.ProxyURL }),
 	},
 }
 
@@ -70,8 +71,18 @@ var indexerValidator = &ConfigValidator[config.IndexersConfig]{
 		requireNonEmptyString("name", func(c config.IndexersConfig) string { return c.Name }),
 		requireNonEmptyString("URL", func(c config.IndexersConfig) string { return c.URL }),
 		validateURL("URL", func(c config.IndexersConfig) string { return c.URL }),
-		validateInStringList("type", []string{"torznab", "newznab", "torrent", "torrentrss"},
-			func(c config.IndexersConfig) string { return c.IndexerType }),
+		validateInStringList(
+			"type",
+			[]string{"torznab", "newznab", "torrent", "torrentrss", "cardigann"},
+			func(c config.IndexersConfig) string { return c.IndexerType },
+		),
+		func(c config.IndexersConfig) error {
+			if c.IndexerType != "cardigann" {
+				return nil
+			}
+
+			return validateRequiredField(c.Definition, "definition")
+		},
 		validateNonNegativeInt(
 			"limitercalls",
 			func(c config.IndexersConfig) int { return c.Limitercalls },
@@ -80,6 +91,7 @@ var indexerValidator = &ConfigValidator[config.IndexersConfig]{
 			"limitercallsdaily",
 			func(c config.IndexersConfig) int { return c.LimitercallsDaily },
 		),
+		validateProxy("proxy_url", func(c config.IndexersConfig) string { return c.ProxyURL }),
 	},
 }
 
@@ -131,6 +143,7 @@ var listsValidator = &ConfigValidator[config.ListsConfig]{
 			"min_rating",
 			func(c config.ListsConfig) float32 { return c.MinRating },
 		),
+		validateProxy("proxy_url", func(c config.ListsConfig) string { return c.ProxyURL }),
 		// Example usage of validateNoForbiddenValues for validation demonstration
 		validateNoForbiddenValues(
 			"example_tags",
@@ -181,6 +194,47 @@ var pathsValidator = &ConfigValidator[config.PathsConfig]{
 			"min_video_size",
 			func(c config.PathsConfig) int { return c.MinVideoSize },
 		),
+		validateNonNegativeInt(
+			"recycle_retention_days",
+			func(c config.PathsConfig) int { return c.RecycleRetentionDays },
+		),
+		validateNonNegativeInt(
+			"keep_source_days",
+			func(c config.PathsConfig) int { return c.KeepSourceDays },
+		),
+		validateInStringList(
+			"import_mode",
+			[]string{
+				config.ImportModeMove,
+				config.ImportModeCopy,
+				config.ImportModeHardlink,
+				config.ImportModeReflink,
+				config.ImportModeSymlink,
+			},
+			func(c config.PathsConfig) string { return c.ImportMode },
+		),
+		validateInStringList(
+			"subtitle_hearing_impaired",
+			[]string{
+				config.SubtitleHearingImpairedPrefer,
+				config.SubtitleHearingImpairedExclude,
+			},
+			func(c config.PathsConfig) string { return c.SubtitleHearingImpaired },
+		),
+		func(c config.PathsConfig) error {
+			if c.ImportMode == config.ImportModeSymlink && c.KeepSourceDays > 0 {
+				return errors.New("keep source days cannot be used with the symlink import mode")
+			}
+
+			return nil
+		},
+		func(c config.PathsConfig) error {
+			if c.ExtrasMaxRuntimePercent < 0 || c.ExtrasMaxRuntimePercent > 100 {
+				return errors.New("extras max runtime percent must be between 0 and 100")
+			}
+
+			return nil
+		},
 		func(c config.PathsConfig) error {
 			if c.MinSize > 0 && c.MaxSize > 0 && c.MinSize > c.MaxSize {
 				return errors.New("minimum size cannot be greater than maximum size")
@@ -7,7 +7,9 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/expression"
 )
 
 // validateListIntersection validates that values don't intersect with forbidden lists.
@@ -240,6 +242,23 @@ func validateURL[T any](fieldName string, getValue func(T) string) func(T) error
 	}
 }
 
+// validateProxyURL checks that a proxy url is empty, "direct" or a http, https
+// or socks5 url.
+func validateProxyURL(proxyurl string, fieldName string) error {
+	if _, err := (base.ProxyConfig{URL: proxyurl}).ProxyFunc(); err != nil {
+		return fmt.Errorf("invalid %s: %w", fieldName, err)
+	}
+
+	return nil
+}
+
+// validateProxy creates a proxy url validator using validateProxyURL.
+func validateProxy[T any](fieldName string, getValue func(T) string) func(T) error {
+	return func(config T) error {
+		return validateProxyURL(getValue(config), fieldName)
+	}
+}
+
 // validateNoForbiddenValues creates a validator using validateListIntersection.
 func validateNoForbiddenValues[T any](
 	fieldName string,
@@ -288,6 +307,25 @@ func validateGeneralConfig(config *config.GeneralConfig) error {
 		return errors.New("web port cannot be empty")
 	}
 
+	if err := validateRewriteRules(config.ParserRewriteRules); err != nil {
+		return err
+	}
+
+	if err := validateProxyURL(config.ProxyURL, "proxy_url"); err != nil {
+		return err
+	}
+
+	return validateProxyURL(config.MetadataProxyURL, "metadata_proxy_url")
+}
+
+// validateRewriteRules reports the first parser rewrite rule which cannot be compiled.
+func validateRewriteRules(rules []string) error {
+	for i, rule := range rules {
+		if _, err := config.ParseRewriteRule(rule); err != nil {
+			return fmt.Errorf("parser rewrite rule %d (%s): %w", i+1, rule, err)
+		}
+	}
+
 	return nil
 }
 
@@ -359,11 +397,52 @@ func validateQualityConfig(configs []config.QualityConfig) error {
 		if config.UseForPriorityMinDifference < 0 {
 			return errors.New("priority minimum difference cannot be negative")
 		}
+
+		if config.SeasonPackWantedPercent < 0 || config.SeasonPackWantedPercent > 100 {
+			return errors.New("season pack wanted percent must be between 0 and 100")
+		}
+
+		if !slices.Contains([]string{"", "same_group", "any_group"}, config.ProperUpgrade) {
+			return errors.New("proper upgrade must be empty, same_group or any_group")
+		}
+
+		if config.PreferredGroupsBonus < 0 {
+			return errors.New("preferred groups bonus cannot be negative")
+		}
+
+		if !validMinimumAvailability(config.MinimumAvailability) {
+			return errors.New(
+				"minimum availability must be empty, announced, in_cinemas, digital or physical",
+			)
+		}
+
+		for i, rule := range config.RejectRules {
+			if _, err := expression.Compile(rule); err != nil {
+				return fmt.Errorf("quality %s reject rule %d (%s): %w", config.Name, i+1, rule, err)
+			}
+		}
+
+		for i, rule := range config.ScoreRules {
+			if _, err := expression.CompileScoreRule(rule); err != nil {
+				return fmt.Errorf("quality %s score rule %d (%s): %w", config.Name, i+1, rule, err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// validMinimumAvailability reports if the value is empty or a known movie availability level.
+func validMinimumAvailability(value string) bool {
+	return slices.Contains([]string{
+		"",
+		config.AvailabilityAnnounced,
+		config.AvailabilityInCinemas,
+		config.AvailabilityDigital,
+		config.AvailabilityPhysical,
+	}, value)
+}
+
 // validateSchedulerConfig validates scheduler configuration.
 func validateSchedulerConfig(configs []config.SchedulerConfig) error {
 	return validateBatch(schedulerValidator, configs)
@@ -88,6 +88,9 @@ func AddGeneralRoutes(routerapi *gin.RouterGroup) {
 		routerapi.GET("/statistics/movies", apiMovieStatistics)
 		routerapi.GET("/statistics/series", apiSeriesStatistics)
 		routerapi.GET("/statistics/workers", apiWorkerStatistics)
+
+		routerapi.GET("/recyclebin", apiRecycleBinList)
+		routerapi.POST("/recyclebin/restore/:id", apiRecycleBinRestore)
 	}
 }
 
@@ -163,6 +166,7 @@ func AddWebRoutes(routerapi *gin.RouterGroup) {
 	routerapi.GET("/admin/service-health", adminPageServiceHealth)
 	routerapi.POST("/admin/service-health", HandleServiceHealth)
 	routerapi.POST("/admin/service-health/quick", HandleServiceHealth)
+	routerapi.POST("/admin/service-health/proxy", HandleServiceHealthProxy)
 
 	routerapi.GET("/admin/api-testing", adminPageAPITesting)
 	routerapi.POST("/admin/api-testing/execute", HandleAPITesting)
@@ -203,6 +207,20 @@ func AddWebRoutes(routerapi *gin.RouterGroup) {
 	routerapi.GET("/admin/wanted", renderWantedPage)
 	routerapi.GET("/admin/wanted/partial", renderWantedPartial)
 	routerapi.GET("/admin/wanted/tab", renderWantedTab)
+	routerapi.POST("/admin/wanted/resetbackoff", renderWantedResetBackoff)
+	routerapi.GET("/admin/rssitems", renderRSSItemsPage)
+	routerapi.GET("/admin/rssitems/list", renderRSSItemsList)
+	routerapi.POST("/admin/rssitems/reevaluate", renderRSSItemsReevaluate)
+	routerapi.POST("/admin/rssitems/grab", renderRSSItemsGrab)
+	routerapi.GET("/admin/recyclebin", renderRecycleBinPage)
+	routerapi.GET("/admin/recyclebin/list", renderRecycleBinList)
+	routerapi.POST("/admin/recyclebin/restore", renderRecycleBinRestore)
+	routerapi.POST("/admin/recyclebin/delete", renderRecycleBinDelete)
+	routerapi.GET("/admin/subtitles", renderSubtitlesPage)
+	routerapi.GET("/admin/subtitles/list", renderSubtitlesList)
+	routerapi.GET("/admin/parserdivergences", renderParserDivergencesPage)
+	routerapi.GET("/admin/parserdivergences/list", renderParserDivergencesList)
+	routerapi.POST("/admin/parserdivergences/clear", renderParserDivergencesClear)
 
 	// Calendar routes
 	routerapi.GET("/admin/calendar", CalendarPageHandler)
@@ -918,12 +936,13 @@ func apiParseString(ctx *gin.Context) {
 	}
 
 	cfgp := config.GetSettingsMedia(cfgv)
+	_, rewrites := parser_v2.RewriteName(getcfg.Name, cfgp, -1, "")
 	parse := parser_v2.ParseFile(getcfg.Name, false, false, cfgp, -1)
 	// parse := parser.NewFileParser(getcfg.Name, cfgp, false, -1)
 	parser.GetPriorityMapQual(parse, cfgp, config.GetSettingsQuality(getcfg.Quality), true, true)
 
 	err := parser.GetDBIDs(parse, cfgp, true, false)
-	ctx.JSON(http.StatusOK, gin.H{"data": parse, "error": err})
+	ctx.JSON(http.StatusOK, gin.H{"data": parse, "rewrites": rewrites, "error": err})
 	parse.Close()
 }
 
@@ -49,7 +49,7 @@ func renderJobManagementPage(csrfToken string) gomponents.Node {
 		"checkmissing", "checkmissingflag", "checkupgradeflag", "checkreachedflag",
 		"clearhistory", "searchmissinginc", "searchmissingfull", "searchmissinginctitle",
 		"searchmissingfulltitle", "searchupgradeinc", "searchupgradefull",
-		"searchupgradeinctitle", "searchupgradefulltitle",
+		"searchupgradeinctitle", "searchupgradefulltitle", "artwork", "searchsubtitles",
 	}
 
 	return html.Div(
@@ -953,10 +953,11 @@ func renderDatabaseMaintenancePage(csrfToken string) gomponents.Node {
 	// Get available table names for clear operations
 	tableNames := []string{
 		"movies", "dbmovies", "dbmovie_titles",
-		"series", "dbseries", "dbserie_episodes", "dbserie_alternates",
+		"series", "dbseries", "dbserie_episodes", "dbserie_alternates", "dbserie_scene_mappings",
 		"movie_files", "movie_histories", "movie_file_unmatcheds",
 		"serie_episodes", "serie_episode_files", "serie_episode_histories", "serie_file_unmatcheds",
-		"qualities", "job_histories", "r_sshistories", "indexer_fails",
+		"qualities", "job_histories", "r_sshistories", "rss_items", "parser_divergences", "indexer_fails",
+		"imported_sources", "subtitles", "recycle_bin",
 	}
 
 	return html.Div(
@@ -3,11 +3,14 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/worker"
@@ -576,6 +579,26 @@ func renderExternalServiceHealthCheckPage(csrfToken string) gomponents.Node {
 							"SaveResults": "Save Historical Data",
 						},
 						"SaveResults", "checkbox", false, nil),
+
+					renderFormGroup("service",
+						map[string]string{
+							"ProxySource": "Proxy to use for the proxy connection test",
+						},
+						map[string]string{
+							"ProxySource": "Proxy",
+						},
+						"ProxySource", "select", "global", map[string][]string{
+							"options": serviceHealthProxySources(),
+						}),
+
+					renderFormGroup("service",
+						map[string]string{
+							"ProxyTestURL": "URL requested through the proxy for the proxy connection test",
+						},
+						map[string]string{
+							"ProxyTestURL": "Proxy Test URL",
+						},
+						"ProxyTestURL", "text", defaultProxyTestURL, nil),
 				),
 			),
 
@@ -598,6 +621,16 @@ func renderExternalServiceHealthCheckPage(csrfToken string) gomponents.Node {
 					hx.Headers("{\"X-CSRF-Token\": \""+csrfToken+"\"}"),
 					hx.Include("#serviceHealthForm"),
 				),
+				html.Button(
+					html.Class("btn btn-info ml-2"),
+					gomponents.Text("Test Connection Through Proxy"),
+					html.Type("button"),
+					hx.Target("#serviceResults"),
+					hx.Swap("innerHTML"),
+					hx.Post("/api/admin/service-health/proxy"),
+					hx.Headers("{\"X-CSRF-Token\": \""+csrfToken+"\"}"),
+					hx.Include("#serviceHealthForm"),
+				),
 				html.Button(
 					html.Type("button"),
 					html.Class("btn btn-secondary ml-2"),
@@ -3904,6 +3937,121 @@ func HandleServiceHealth(c *gin.Context) {
 	c.String(http.StatusOK, renderComponentToString(result))
 }
 
+// defaultProxyTestURL is requested by the proxy connection test if no url is given.
+const defaultProxyTestURL = "https://www.google.com/generate_204"
+
+// serviceHealthProxySources returns the selectable proxies of the proxy
+// connection test - the global proxy, the metadata proxy and every indexer,
+// download client and list.
+func serviceHealthProxySources() []string {
+	sources := []string{"global", "metadata"}
+	for _, cfg := range config.GetSettingsIndexerAll() {
+		sources = append(sources, "indexer:"+cfg.Name)
+	}
+
+	for _, cfg := range config.GetSettingsDownloaderAll() {
+		sources = append(sources, "downloader:"+cfg.Name)
+	}
+
+	for _, cfg := range config.GetSettingsListAll() {
+		sources = append(sources, "list:"+cfg.Name)
+	}
+
+	return sources
+}
+
+// serviceHealthProxy returns the proxy config of a proxy source of
+// serviceHealthProxySources.
+func serviceHealthProxy(source string) base.ProxyConfig {
+	kind, name, _ := strings.Cut(source, ":")
+	switch kind {
+	case "metadata":
+		return apiexternal.MetadataProxy()
+	case "indexer":
+		if cfg := config.GetSettingsIndexer(name); cfg != nil {
+			return apiexternal.ProxyConfig(cfg.ProxyURL, cfg.ProxyUsername, cfg.ProxyPassword)
+		}
+	case "downloader":
+		for _, cfg := range config.GetSettingsDownloaderAll() {
+			if cfg.Name == name {
+				return apiexternal.ProxyConfig(cfg.ProxyURL, cfg.ProxyUsername, cfg.ProxyPassword)
+			}
+		}
+	case "list":
+		if cfg := config.GetSettingsList(name); cfg != nil {
+			return apiexternal.ProxyConfig(cfg.ProxyURL, cfg.ProxyUsername, cfg.ProxyPassword)
+		}
+	}
+
+	return base.ProxyConfig{}
+}
+
+// HandleServiceHealthProxy requests the test url through the selected proxy
+// and reports the response time.
+func HandleServiceHealthProxy(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		c.String(http.StatusOK, renderAlert("Failed to parse form data: "+err.Error(), "danger"))
+		return
+	}
+
+	source := c.PostForm("service_ProxySource")
+
+	testURL := strings.TrimSpace(c.PostForm("service_ProxyTestURL"))
+	if testURL == "" {
+		testURL = defaultProxyTestURL
+	}
+
+	timeout, err := strconv.Atoi(c.PostForm("service_Timeout"))
+	if err != nil || timeout <= 0 {
+		timeout = 10
+	}
+
+	proxy := serviceHealthProxy(source)
+
+	proxyURL := proxy.Resolve().URL
+	if u, err := url.Parse(proxyURL); err == nil {
+		proxyURL = u.Redacted()
+	}
+
+	elapsed, statusCode, err := base.TestProxy(
+		c.Request.Context(),
+		proxy,
+		testURL,
+		time.Duration(timeout)*time.Second,
+	)
+	if err != nil {
+		c.String(
+			http.StatusOK,
+			renderAlert(
+				fmt.Sprintf("Proxy test of %s (%s) failed: %s", source, proxyURL, err.Error()),
+				"danger",
+			),
+		)
+
+		return
+	}
+
+	alertType := "success"
+	if statusCode >= http.StatusBadRequest {
+		alertType = "warning"
+	}
+
+	c.String(
+		http.StatusOK,
+		renderAlert(
+			fmt.Sprintf(
+				"Proxy test of %s (%s): %s answered with HTTP %d in %dms",
+				source,
+				proxyURL,
+				testURL,
+				statusCode,
+				elapsed.Milliseconds(),
+			),
+			alertType,
+		),
+	)
+}
+
 // contains checks if a string slice contains a specific string (case insensitive).
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
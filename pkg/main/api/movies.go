@@ -264,12 +264,12 @@ func movieDeleteList(ctx *gin.Context) {
 	handleDBError(ctx, err, StrOK)
 }
 
-const allowedjobsmoviesstr = "rss,data,datafull,checkmissing,checkmissingflag,checkreachedflag,structure,searchmissingfull,searchmissinginc,searchupgradefull,searchupgradeinc,searchmissingfulltitle,searchmissinginctitle,searchupgradefulltitle,searchupgradeinctitle,clearhistory,feeds,refresh,refreshinc"
+const allowedjobsmoviesstr = "rss,data,datafull,checkmissing,checkmissingflag,checkreachedflag,structure,searchmissingfull,searchmissinginc,searchupgradefull,searchupgradeinc,searchmissingfulltitle,searchmissinginctitle,searchupgradefulltitle,searchupgradeinctitle,clearhistory,feeds,refresh,refreshinc,artwork,searchsubtitles"
 
 // @Summary      Start Jobs (All Lists)
 // @Description  Starts a Job
 // @Tags         movie
-// @Param        job  path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc"
+// @Param        job  path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc, artwork, searchsubtitles"
 // @Param        apikey query     string    true  "apikey"
 // @Success      200  {object}  string "returns job name started"
 // @Failure      204  {object}  string "error message"
@@ -294,7 +294,7 @@ func apimoviesAllJobs(c *gin.Context) {
 		cfgpstr := media.NamePrefix
 
 		switch c.Param(StrJobLower) {
-		case "data", logger.StrDataFull, logger.StrStructure, logger.StrClearHistory:
+		case "data", logger.StrDataFull, logger.StrStructure, logger.StrArtwork, logger.StrSubtitles, logger.StrClearHistory:
 			worker.Dispatch(
 				c.Param(StrJobLower)+"_"+cfgpstr,
 				func(key uint32, ctx context.Context) error {
@@ -447,7 +447,7 @@ func apimoviesAllJobs(c *gin.Context) {
 // @Summary      Start Jobs
 // @Description  Starts a Job
 // @Tags         movie
-// @Param        job   path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc"
+// @Param        job   path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc, artwork, searchsubtitles"
 // @Param        name  path      string  true  "List Name: ex. list"
 // @Param        apikey query     string    true  "apikey"
 // @Success      200  {object}  string "returns job name started"
@@ -465,7 +465,7 @@ func apimoviesJobs(c *gin.Context) {
 	cfgpstr := "movie_" + c.Param("name")
 
 	switch c.Param(StrJobLower) {
-	case "data", logger.StrDataFull, logger.StrStructure, logger.StrClearHistory:
+	case "data", logger.StrDataFull, logger.StrStructure, logger.StrArtwork, logger.StrSubtitles, logger.StrClearHistory:
 		worker.Dispatch(
 			c.Param(StrJobLower)+"_movies_"+c.Param("name"),
 			func(key uint32, ctx context.Context) error {
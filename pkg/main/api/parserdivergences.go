@@ -0,0 +1,404 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser"
+	"github.com/gin-gonic/gin"
+	"maragu.dev/gomponents"
+	hx "maragu.dev/gomponents-htmx"
+	"maragu.dev/gomponents/html"
+)
+
+// parserDivergencesPageSize is how many divergences are shown per page.
+const parserDivergencesPageSize = 50
+
+// parserDivergenceFields are the fields compared in shadow mode.
+var parserDivergenceFields = []string{
+	"title", "imdb", "tvdb", "season", "episode", "identifier", "quality", "resolution",
+}
+
+// renderParserDivergencesPage serves the Parser Divergences page listing where
+// the legacy and the v2 parser disagree in shadow mode.
+func renderParserDivergencesPage(ctx *gin.Context) {
+	pageNode := page(
+		"Parser Divergences",
+		false,
+		false,
+		true,
+		renderParserDivergencesContent(getCSRFToken(ctx)),
+	)
+
+	var buf strings.Builder
+	pageNode.Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// renderParserDivergencesContent builds the page shell with the shadow mode
+// summary, the filter box and a results region that lazy-loads via HTMX.
+func renderParserDivergencesContent(csrfToken string) gomponents.Node {
+	general := config.GetSettingsGeneral()
+
+	subtitle := "Fields on which the legacy and the v2 parser disagree. The v2 parser is authoritative."
+	if !general.ParserShadowMode {
+		subtitle = "Parser shadow mode is disabled. Enable Parser Shadow Mode in the general configuration to record divergences."
+	}
+
+	filterAttrs := func() []gomponents.Node {
+		return []gomponents.Node{
+			hx.Get("/api/admin/parserdivergences/list"),
+			hx.Include("#parserdiv-filter"),
+			hx.Target("#parserdiv-results"),
+			hx.Swap("innerHTML"),
+			hx.Indicator("#parserdiv-ind"),
+		}
+	}
+
+	fieldOptions := []gomponents.Node{
+		html.Option(html.Value(""), gomponents.Text("All fields")),
+	}
+	for _, field := range parserDivergenceFields {
+		fieldOptions = append(fieldOptions, html.Option(html.Value(field), gomponents.Text(field)))
+	}
+
+	return html.Div(
+		html.Class("config-section-enhanced"),
+
+		// Page header.
+		html.Div(
+			html.Class("page-header-enhanced"),
+			html.Div(
+				html.Class("header-content"),
+				html.Div(
+					html.Class("header-icon-wrapper"),
+					html.I(
+						html.Class("fas fa-code-compare header-icon"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+				),
+				html.Div(
+					html.Class("header-text"),
+					html.H2(html.Class("header-title"), gomponents.Text("Parser Divergences")),
+					html.P(html.Class("header-subtitle"), gomponents.Text(subtitle)),
+				),
+			),
+		),
+
+		parserDivergencesSummary(csrfToken),
+
+		// Filter box — changing the view or field or typing reloads page 1.
+		html.Form(
+			html.ID("parserdiv-filter"),
+			html.Class("input-group input-group-sm mb-2"),
+			gomponents.Attr("onsubmit", "return false;"),
+			html.Select(
+				append([]gomponents.Node{
+					html.Class("form-select"),
+					html.Style("max-width: 12rem;"),
+					html.Name("view"),
+					gomponents.Attr("aria-label", "Group divergences"),
+					hx.Trigger("change"),
+					html.Option(html.Value("grouped"), gomponents.Text("Grouped by values")),
+					html.Option(html.Value("inputs"), gomponents.Text("All inputs")),
+				}, filterAttrs()...)...,
+			),
+			html.Select(
+				append(append([]gomponents.Node{
+					html.Class("form-select"),
+					html.Style("max-width: 10rem;"),
+					html.Name("field"),
+					gomponents.Attr("aria-label", "Filter by field"),
+					hx.Trigger("change"),
+				}, fieldOptions...), filterAttrs()...)...,
+			),
+			html.Input(
+				append([]gomponents.Node{
+					html.Type("search"),
+					html.Class("form-control"),
+					html.Name("q"),
+					html.Placeholder("Filter by input or value..."),
+					gomponents.Attr("aria-label", "Filter parser divergences"),
+					hx.Trigger("keyup changed delay:350ms, search"),
+				}, filterAttrs()...)...,
+			),
+			html.Span(
+				html.ID("parserdiv-ind"),
+				html.Class("input-group-text htmx-indicator"),
+				html.Div(html.Class("spinner-border spinner-border-sm")),
+			),
+		),
+
+		// Results region — lazy-loads the first page.
+		html.Div(
+			html.ID("parserdiv-results"),
+			hx.Get("/api/admin/parserdivergences/list?page=1"),
+			hx.Trigger("load"),
+			hx.Swap("innerHTML"),
+			html.Div(
+				html.Class("text-center text-muted p-4"),
+				html.Div(html.Class("spinner-border")),
+			),
+		),
+	)
+}
+
+// parserDivergencesSummary renders the divergence rate since the start and the
+// number of divergent inputs per field with a button to clear the table.
+func parserDivergencesSummary(csrfToken string) gomponents.Node {
+	parses, divergent := parser.ShadowStats()
+
+	rate := "n/a"
+	if parses > 0 {
+		rate = strconv.FormatFloat(float64(divergent)*100/float64(parses), 'f', 1, 64) + "%"
+	}
+
+	badges := []gomponents.Node{
+		html.Span(
+			html.Class("badge bg-secondary me-1"),
+			gomponents.Textf("%d compared", parses),
+		),
+		html.Span(
+			html.Class("badge bg-warning text-dark me-3"),
+			gomponents.Textf("%d divergent (%s)", divergent, rate),
+		),
+	}
+
+	for _, field := range database.GetParserDivergenceFields() {
+		badges = append(badges, html.Span(
+			html.Class("badge bg-light text-dark border me-1"),
+			gomponents.Textf("%s: %d", field.Field, field.Inputs),
+		))
+	}
+
+	return html.Div(
+		html.Class("d-flex justify-content-between align-items-center mb-3"),
+		html.Div(
+			html.Div(html.Class("small text-muted mb-1"), gomponents.Text("Since start / recorded inputs per field")),
+			html.Div(badges...),
+		),
+		html.Button(
+			html.Type("button"),
+			html.Class("btn btn-sm btn-outline-danger"),
+			hx.Post("/api/admin/parserdivergences/clear"),
+			hx.Headers(createHTMXHeaders(csrfToken)),
+			hx.Target("#parserdiv-results"),
+			hx.Swap("innerHTML"),
+			hx.Confirm("Delete all recorded parser divergences?"),
+			html.I(html.Class("fas fa-trash me-1"), gomponents.Attr("aria-hidden", "true")),
+			gomponents.Text("Clear"),
+		),
+	)
+}
+
+// renderParserDivergencesList serves the filtered, paginated results fragment.
+func renderParserDivergencesList(ctx *gin.Context) {
+	view := ctx.Query("view")
+	field := ctx.Query("field")
+	search := strings.TrimSpace(ctx.Query("q"))
+
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	var node gomponents.Node
+	if view == "inputs" {
+		total := database.CountParserDivergences(field, search)
+		rows := database.GetParserDivergences(
+			field,
+			search,
+			parserDivergencesPageSize,
+			(page-1)*parserDivergencesPageSize,
+		)
+
+		trs := make([]gomponents.Node, 0, len(rows))
+		for idx := range rows {
+			trs = append(trs, parserDivergenceRow(&rows[idx]))
+		}
+
+		node = parserDivergencesResults(
+			view, field, search, page, total, len(rows),
+			[]string{"Last Seen", "Input", "Field", "Legacy", "V2", "Hits"},
+			trs,
+		)
+	} else {
+		view = "grouped"
+
+		total := database.CountParserDivergenceGroups(field, search)
+		rows := database.GetParserDivergenceGroups(
+			field,
+			search,
+			parserDivergencesPageSize,
+			(page-1)*parserDivergencesPageSize,
+		)
+
+		trs := make([]gomponents.Node, 0, len(rows))
+		for idx := range rows {
+			trs = append(trs, parserDivergenceGroupRow(&rows[idx]))
+		}
+
+		node = parserDivergencesResults(
+			view, field, search, page, total, len(rows),
+			[]string{"Field", "Legacy", "V2", "Inputs", "Hits"},
+			trs,
+		)
+	}
+
+	var buf strings.Builder
+	node.Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// renderParserDivergencesClear deletes all recorded divergences.
+func renderParserDivergencesClear(ctx *gin.Context) {
+	database.ClearParserDivergences()
+
+	ctx.String(http.StatusOK, renderAlert("All parser divergences deleted", "success"))
+}
+
+// parserDivergencesResults renders the count summary, table and pagination of a page.
+func parserDivergencesResults(
+	view, field, search string,
+	page, total, count int,
+	columns []string,
+	trs []gomponents.Node,
+) gomponents.Node {
+	if total == 0 {
+		return html.Div(
+			html.Class("text-center text-muted p-5"),
+			html.I(
+				html.Class("fas fa-code-compare mb-3"),
+				html.Style("font-size: 3rem; color: #6c757d;"),
+			),
+			html.H5(html.Class("text-muted"), gomponents.Text("Nothing to show")),
+			html.P(
+				html.Class("text-muted mb-0"),
+				gomponents.Text("No parser divergences match the filter."),
+			),
+		)
+	}
+
+	totalPages := (total + parserDivergencesPageSize - 1) / parserDivergencesPageSize
+	if page > totalPages {
+		page = totalPages
+	}
+
+	start := (page-1)*parserDivergencesPageSize + 1
+	end := start + count - 1
+
+	ths := make([]gomponents.Node, 0, len(columns))
+	for _, col := range columns {
+		ths = append(ths, html.Th(gomponents.Attr("scope", "col"), gomponents.Text(col)))
+	}
+
+	return html.Div(
+		html.Div(
+			html.Class("d-flex justify-content-between align-items-center mb-2 small text-muted"),
+			gomponents.Textf("Showing %d–%d of %d", start, end, total),
+			gomponents.Textf("Page %d of %d", page, totalPages),
+		),
+		html.Div(
+			html.Class("table-responsive"),
+			html.Table(
+				html.Class("table table-sm table-hover align-middle"),
+				html.THead(html.Tr(ths...)),
+				html.TBody(trs...),
+			),
+		),
+		parserDivergencesPagination(view, field, search, page, totalPages),
+	)
+}
+
+// parserDivergenceRow renders a single divergent input.
+func parserDivergenceRow(d *database.ParserDivergence) gomponents.Node {
+	return html.Tr(
+		html.Td(
+			html.Class("text-nowrap small"),
+			gomponents.Text(d.UpdatedAt.Format("2006-01-02 15:04")),
+		),
+		html.Td(
+			html.Div(html.Class("text-break"), gomponents.Text(d.Input)),
+			gomponents.If(
+				d.MediaType != "",
+				html.Div(html.Class("small text-muted"), gomponents.Text(d.MediaType)),
+			),
+		),
+		html.Td(html.Span(html.Class("badge bg-secondary"), gomponents.Text(d.Field))),
+		html.Td(html.Class("text-break"), parserDivergenceValue(d.LegacyValue)),
+		html.Td(html.Class("text-break"), parserDivergenceValue(d.V2Value)),
+		html.Td(gomponents.Text(strconv.Itoa(d.Hits))),
+	)
+}
+
+// parserDivergenceGroupRow renders the divergences of a field with the same values.
+func parserDivergenceGroupRow(g *database.ParserDivergenceGroup) gomponents.Node {
+	return html.Tr(
+		html.Td(html.Span(html.Class("badge bg-secondary"), gomponents.Text(g.Field))),
+		html.Td(html.Class("text-break"), parserDivergenceValue(g.LegacyValue)),
+		html.Td(html.Class("text-break"), parserDivergenceValue(g.V2Value)),
+		html.Td(gomponents.Text(strconv.Itoa(g.Inputs))),
+		html.Td(gomponents.Text(strconv.Itoa(g.Hits))),
+	)
+}
+
+// parserDivergenceValue renders a parsed value, marking empty ones.
+func parserDivergenceValue(value string) gomponents.Node {
+	if value == "" || value == "0" {
+		return html.Span(html.Class("text-muted fst-italic"), gomponents.Text("empty"))
+	}
+
+	return gomponents.Text(value)
+}
+
+// parserDivergencesPagination renders Prev/Next paging controls that swap the results region.
+func parserDivergencesPagination(view, field, search string, page, totalPages int) gomponents.Node {
+	if totalPages <= 1 {
+		return gomponents.Text("")
+	}
+
+	base := "/api/admin/parserdivergences/list?view=" + url.QueryEscape(view) +
+		"&field=" + url.QueryEscape(field) +
+		"&q=" + url.QueryEscape(search) + "&page="
+
+	pageBtn := func(label string, target int, disabled bool) gomponents.Node {
+		liClass := "page-item"
+		if disabled {
+			liClass = "page-item disabled"
+		}
+
+		btn := []gomponents.Node{
+			html.Class("page-link"),
+			html.Type("button"),
+			gomponents.Text(label),
+		}
+		if !disabled {
+			btn = append(btn,
+				hx.Get(base+strconv.Itoa(target)),
+				hx.Target("#parserdiv-results"),
+				hx.Swap("innerHTML"),
+			)
+		}
+
+		return html.Li(html.Class(liClass), html.Button(btn...))
+	}
+
+	return html.Nav(
+		gomponents.Attr("aria-label", "Parser divergences pagination"),
+		html.Ul(
+			html.Class("pagination pagination-sm mb-0 justify-content-center"),
+			pageBtn("« First", 1, page <= 1),
+			pageBtn("‹ Prev", page-1, page <= 1),
+			html.Li(html.Class("page-item disabled"),
+				html.Span(html.Class("page-link"), gomponents.Textf("%d / %d", page, totalPages))),
+			pageBtn("Next ›", page+1, page >= totalPages),
+			pageBtn("Last »", totalPages, page >= totalPages),
+		),
+	)
+}
@@ -0,0 +1,454 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/structure"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/utils"
+	"github.com/gin-gonic/gin"
+	"maragu.dev/gomponents"
+	hx "maragu.dev/gomponents-htmx"
+	"maragu.dev/gomponents/html"
+)
+
+// recycleBinPageSize is how many recycled files are shown per page.
+const recycleBinPageSize = 50
+
+// renderRecycleBinPage serves the Recycle Bin page listing the replaced and
+// deleted files which can be restored.
+func renderRecycleBinPage(ctx *gin.Context) {
+	pageNode := page("Recycle Bin", false, false, true, renderRecycleBinContent())
+
+	var buf strings.Builder
+	pageNode.Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// renderRecycleBinContent builds the page shell with the filter box and a
+// results region that lazy-loads (and paginates) via HTMX.
+func renderRecycleBinContent() gomponents.Node {
+	filterAttrs := func() []gomponents.Node {
+		return []gomponents.Node{
+			hx.Get("/api/admin/recyclebin/list"),
+			hx.Include("#recyclebin-filter"),
+			hx.Target("#recyclebin-results"),
+			hx.Swap("innerHTML"),
+			hx.Indicator("#recyclebin-ind"),
+		}
+	}
+
+	return html.Div(
+		html.Class("config-section-enhanced"),
+
+		// Page header.
+		html.Div(
+			html.Class("page-header-enhanced"),
+			html.Div(
+				html.Class("header-content"),
+				html.Div(
+					html.Class("header-icon-wrapper"),
+					html.I(
+						html.Class("fas fa-trash-can-arrow-up header-icon"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+				),
+				html.Div(
+					html.Class("header-text"),
+					html.H2(html.Class("header-title"), gomponents.Text("Recycle Bin")),
+					html.P(
+						html.Class("header-subtitle"),
+						gomponents.Text(
+							"Replaced and deleted files of paths with Move Replaced Files enabled. Restore them to their original path or delete them permanently.",
+						),
+					),
+				),
+			),
+		),
+
+		// Filter box — changing the reason or typing reloads page 1.
+		html.Form(
+			html.ID("recyclebin-filter"),
+			html.Class("input-group input-group-sm mb-2"),
+			gomponents.Attr("onsubmit", "return false;"),
+			html.Select(
+				append([]gomponents.Node{
+					html.Class("form-select"),
+					html.Style("max-width: 12rem;"),
+					html.Name("reason"),
+					gomponents.Attr("aria-label", "Filter by reason"),
+					hx.Trigger("change"),
+					html.Option(html.Value(""), gomponents.Text("All reasons")),
+					html.Option(
+						html.Value(database.RecycleReasonUpgrade),
+						gomponents.Text("Upgrade"),
+					),
+					html.Option(
+						html.Value(database.RecycleReasonWrongLanguage),
+						gomponents.Text("Wrong language"),
+					),
+					html.Option(
+						html.Value(database.RecycleReasonWrongRuntime),
+						gomponents.Text("Wrong runtime"),
+					),
+					html.Option(
+						html.Value(database.RecycleReasonManualDelete),
+						gomponents.Text("Manual delete"),
+					),
+				}, filterAttrs()...)...,
+			),
+			html.Input(
+				append([]gomponents.Node{
+					html.Type("search"),
+					html.Class("form-control"),
+					html.Name("q"),
+					html.Placeholder("Filter by path, configuration or list..."),
+					gomponents.Attr("aria-label", "Filter recycled files"),
+					hx.Trigger("keyup changed delay:350ms, search"),
+				}, filterAttrs()...)...,
+			),
+			html.Span(
+				html.ID("recyclebin-ind"),
+				html.Class("input-group-text htmx-indicator"),
+				html.Div(html.Class("spinner-border spinner-border-sm")),
+			),
+		),
+
+		// Results region — lazy-loads the first page.
+		html.Div(
+			html.ID("recyclebin-results"),
+			hx.Get("/api/admin/recyclebin/list?page=1"),
+			hx.Trigger("load"),
+			hx.Swap("innerHTML"),
+			html.Div(
+				html.Class("text-center text-muted p-4"),
+				html.Div(html.Class("spinner-border")),
+			),
+		),
+	)
+}
+
+// renderRecycleBinList serves the filtered, paginated results fragment.
+func renderRecycleBinList(ctx *gin.Context) {
+	reason := ctx.Query("reason")
+	search := strings.TrimSpace(ctx.Query("q"))
+
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	total := database.CountRecycleItems(reason, search)
+	rows := database.GetRecycleItems(
+		reason,
+		search,
+		recycleBinPageSize,
+		(page-1)*recycleBinPageSize,
+	)
+
+	var buf strings.Builder
+	recycleBinResults(reason, search, page, total, rows, getCSRFToken(ctx)).Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// recycleBinResults renders the count summary, table and pagination of a page.
+func recycleBinResults(
+	reason, search string,
+	page, total int,
+	rows []database.RecycleItem,
+	csrfToken string,
+) gomponents.Node {
+	if total == 0 {
+		return html.Div(
+			html.Class("text-center text-muted p-5"),
+			html.I(
+				html.Class("fas fa-trash-can mb-3"),
+				html.Style("font-size: 3rem; color: #6c757d;"),
+			),
+			html.H5(html.Class("text-muted"), gomponents.Text("Nothing to show")),
+			html.P(
+				html.Class("text-muted mb-0"),
+				gomponents.Text("No recycled files match the filter."),
+			),
+		)
+	}
+
+	totalPages := (total + recycleBinPageSize - 1) / recycleBinPageSize
+	if page > totalPages {
+		page = totalPages
+	}
+
+	start := (page-1)*recycleBinPageSize + 1
+	end := start + len(rows) - 1
+
+	trs := make([]gomponents.Node, 0, len(rows))
+	for idx := range rows {
+		trs = append(trs, recycleBinRow(&rows[idx], csrfToken))
+	}
+
+	return html.Div(
+		html.Div(
+			html.Class("d-flex justify-content-between align-items-center mb-2 small text-muted"),
+			gomponents.Textf("Showing %d–%d of %d", start, end, total),
+			gomponents.Textf("Page %d of %d", page, totalPages),
+		),
+		html.Div(
+			html.Class("table-responsive"),
+			html.Table(
+				html.Class("table table-sm table-hover align-middle"),
+				html.THead(
+					html.Tr(
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Style("width: 9rem;"),
+							gomponents.Text("Recycled"),
+						),
+						html.Th(gomponents.Attr("scope", "col"), gomponents.Text("File")),
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Style("width: 9rem;"),
+							gomponents.Text("Reason"),
+						),
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Class("text-end"),
+							html.Style("width: 14rem;"),
+							gomponents.Text("Action"),
+						),
+					),
+				),
+				html.TBody(trs...),
+			),
+		),
+		recycleBinPagination(reason, search, page, totalPages),
+	)
+}
+
+// recycleBinRow renders a single recycled file with its restore and delete
+// actions.
+func recycleBinRow(item *database.RecycleItem, csrfToken string) gomponents.Node {
+	idStr := strconv.FormatUint(uint64(item.ID), 10)
+
+	details := make([]string, 0, 4)
+	for _, v := range []string{item.Config, item.Listname} {
+		if v != "" {
+			details = append(details, v)
+		}
+	}
+
+	if item.MediaID != 0 {
+		details = append(details, "media "+strconv.FormatUint(uint64(item.MediaID), 10))
+	}
+
+	if item.Size > 0 {
+		details = append(details, formatFileSize(item.Size))
+	}
+
+	badge := "bg-secondary"
+	switch item.Reason {
+	case database.RecycleReasonUpgrade:
+		badge = "bg-primary"
+	case database.RecycleReasonWrongLanguage, database.RecycleReasonWrongRuntime:
+		badge = "bg-warning text-dark"
+	case database.RecycleReasonManualDelete:
+		badge = "bg-danger"
+	}
+
+	return html.Tr(
+		html.Td(
+			html.Class("text-nowrap small"),
+			gomponents.Text(item.CreatedAt.Format("2006-01-02 15:04")),
+		),
+		html.Td(
+			html.Div(html.Class("text-break"), gomponents.Text(item.OriginalPath)),
+			html.Div(
+				html.Class("small text-muted"),
+				gomponents.Text(strings.Join(details, " · ")),
+			),
+			html.Div(
+				html.Class("small text-muted text-break"),
+				gomponents.Text("→ "+item.RecyclePath),
+			),
+		),
+		html.Td(html.Span(html.Class("badge "+badge), gomponents.Text(item.Reason))),
+		html.Td(
+			html.ID("recycleaction-"+idStr),
+			html.Class("text-end"),
+			html.Div(
+				html.Class("d-flex justify-content-end gap-1"),
+				html.Button(
+					html.Type("button"),
+					html.Class("btn btn-sm btn-outline-primary"),
+					gomponents.Attr("aria-label", "Restore "+item.OriginalPath),
+					hx.Post("/api/admin/recyclebin/restore?id="+idStr),
+					hx.Headers(createHTMXHeaders(csrfToken)),
+					hx.Target("#recycleaction-"+idStr),
+					hx.Swap("innerHTML"),
+					hx.Confirm("Restore this file to its original path?"),
+					html.I(
+						html.Class("fas fa-trash-can-arrow-up me-1"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+					gomponents.Text("Restore"),
+				),
+				html.Button(
+					html.Type("button"),
+					html.Class("btn btn-sm btn-outline-danger"),
+					gomponents.Attr("aria-label", "Delete "+item.OriginalPath),
+					hx.Post("/api/admin/recyclebin/delete?id="+idStr),
+					hx.Headers(createHTMXHeaders(csrfToken)),
+					hx.Target("#recycleaction-"+idStr),
+					hx.Swap("innerHTML"),
+					hx.Confirm("Delete this file permanently?"),
+					html.I(
+						html.Class("fas fa-trash me-1"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+					gomponents.Text("Delete"),
+				),
+			),
+		),
+	)
+}
+
+// renderRecycleBinRestore restores a recycled file and returns its new action
+// cell.
+func renderRecycleBinRestore(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Query("id"), 10, 0)
+	if err != nil || id == 0 {
+		ctx.String(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	if err := utils.RestoreRecycleItem(ctx.Request.Context(), uint(id)); err != nil {
+		ctx.String(http.StatusOK, renderAlert(err.Error(), "danger"))
+		return
+	}
+
+	ctx.String(http.StatusOK, renderAlert("Restored", "success"))
+}
+
+// renderRecycleBinDelete deletes a recycled file permanently and returns its
+// new action cell.
+func renderRecycleBinDelete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Query("id"), 10, 0)
+	if err != nil || id == 0 {
+		ctx.String(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	item, err := database.GetRecycleItem(uint(id))
+	if err == nil {
+		err = structure.DeleteRecycledFile(item)
+	}
+
+	if err != nil {
+		ctx.String(http.StatusOK, renderAlert(err.Error(), "danger"))
+		return
+	}
+
+	ctx.String(http.StatusOK, renderAlert("Deleted", "secondary"))
+}
+
+// recycleBinPagination renders Prev/Next paging controls that swap the results region.
+func recycleBinPagination(reason, search string, page, totalPages int) gomponents.Node {
+	if totalPages <= 1 {
+		return gomponents.Text("")
+	}
+
+	base := "/api/admin/recyclebin/list?reason=" + url.QueryEscape(reason) +
+		"&q=" + url.QueryEscape(search) + "&page="
+
+	pageBtn := func(label string, target int, disabled bool) gomponents.Node {
+		liClass := "page-item"
+		if disabled {
+			liClass = "page-item disabled"
+		}
+
+		btn := []gomponents.Node{
+			html.Class("page-link"),
+			html.Type("button"),
+			gomponents.Text(label),
+		}
+		if !disabled {
+			btn = append(btn,
+				hx.Get(base+strconv.Itoa(target)),
+				hx.Target("#recyclebin-results"),
+				hx.Swap("innerHTML"),
+			)
+		}
+
+		return html.Li(html.Class(liClass), html.Button(btn...))
+	}
+
+	return html.Nav(
+		gomponents.Attr("aria-label", "Recycle bin pagination"),
+		html.Ul(
+			html.Class("pagination pagination-sm mb-0 justify-content-center"),
+			pageBtn("« First", 1, page <= 1),
+			pageBtn("‹ Prev", page-1, page <= 1),
+			html.Li(html.Class("page-item disabled"),
+				html.Span(html.Class("page-link"), gomponents.Textf("%d / %d", page, totalPages))),
+			pageBtn("Next ›", page+1, page >= totalPages),
+			pageBtn("Last »", totalPages, page >= totalPages),
+		),
+	)
+}
+
+// @Summary      List Recycle Bin
+// @Description  Lists the replaced and deleted files of the recycle bin, newest first
+// @Tags         recyclebin
+// @Param        reason  query     string  false  "Reason (upgrade, wrong language, wrong runtime, manual delete)"
+// @Param        q       query     string  false  "Search text for the path, configuration or list"
+// @Param        limit   query     int     false  "Limit"
+// @Param        page    query     int     false  "Page"
+// @Param        apikey  query     string  true   "apikey"
+// @Success      200     {object}  Jsondata{data=[]database.RecycleItem}
+// @Failure      401     {object}  Jsonerror
+// @Router       /api/recyclebin [get].
+func apiRecycleBinList(ctx *gin.Context) {
+	reason := ctx.Query("reason")
+	search := ctx.Query("q")
+
+	limit := recycleBinPageSize
+	if l, err := strconv.Atoi(ctx.Query("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	sendJSONResponse(
+		ctx,
+		http.StatusOK,
+		database.GetRecycleItems(reason, search, limit, (page-1)*limit),
+		database.CountRecycleItems(reason, search),
+	)
+}
+
+// @Summary      Restore Recycled File
+// @Description  Moves a recycled file back to its original path and links it to its media again
+// @Tags         recyclebin
+// @Param        id      path      int     true  "Recycle bin ID"
+// @Param        apikey  query     string  true  "apikey"
+// @Success      200     {object}  string "returns ok"
+// @Failure      400     {object}  Jsonerror
+// @Failure      401     {object}  Jsonerror
+// @Failure      403     {object}  Jsonerror
+// @Router       /api/recyclebin/restore/{id} [post].
+func apiRecycleBinRestore(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param(StrID), 10, 0)
+	if err != nil || id == 0 {
+		sendBadRequest(ctx, "Invalid id")
+		return
+	}
+
+	handleDBError(ctx, utils.RestoreRecycleItem(ctx.Request.Context(), uint(id)), StrOK)
+}
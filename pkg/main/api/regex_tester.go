@@ -1,12 +1,19 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/expression"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/searcher"
 	"github.com/gin-gonic/gin"
 	"maragu.dev/gomponents"
 	"maragu.dev/gomponents/html"
@@ -261,6 +268,81 @@ func renderRegexTesterPage(csrfToken string) gomponents.Node {
 								),
 							),
 
+							// Quality Rules
+							html.Div(
+								html.Class("form-group mb-4"),
+								html.Div(
+									html.Class("form-check form-switch mb-2"),
+									html.Input(
+										html.Class("form-check-input"),
+										html.Style("margin-left: 35px;"),
+										html.Type("checkbox"),
+										html.ID("test_rules"),
+										html.Name("test_rules"),
+										html.Value("true"),
+										html.Checked(),
+									),
+									html.Label(
+										html.Class("form-check-label"),
+										html.For("test_rules"),
+										gomponents.Text("Quality Rules"),
+									),
+								),
+								html.Textarea(
+									html.Class("form-control"),
+									html.ID("test_expression"),
+									html.Name("test_expression"),
+									html.Rows("2"),
+									html.Placeholder(
+										"Optional expression, e.g. size > 8GB and resolution == '1080p'",
+									),
+									html.Style("font-family: 'Courier New', monospace;"),
+								),
+								html.Div(html.Class("row mt-2"),
+									html.Div(html.Class("col-md-3"),
+										html.Input(
+											html.Class("form-control form-control-sm"),
+											html.Type("number"),
+											html.Name("rule_size"),
+											html.Placeholder("Size (MB)"),
+										),
+									),
+									html.Div(html.Class("col-md-3"),
+										html.Input(
+											html.Class("form-control form-control-sm"),
+											html.Type("number"),
+											html.Step("any"),
+											html.Name("rule_age"),
+											html.Placeholder("Age (days)"),
+										),
+									),
+									html.Div(html.Class("col-md-3"),
+										html.Input(
+											html.Class("form-control form-control-sm"),
+											html.Type("number"),
+											html.Name("rule_seeders"),
+											html.Placeholder("Seeders (torrent)"),
+										),
+									),
+									html.Div(html.Class("col-md-3"),
+										html.Input(
+											html.Class("form-control form-control-sm"),
+											html.Type("text"),
+											html.Name("rule_indexer"),
+											html.Placeholder("Indexer"),
+										),
+									),
+								),
+								html.Small(
+									html.Class("form-text text-muted"),
+									gomponents.Text(
+										"Tests the reject and score rules of the selected quality profile and the optional expression. "+
+											"The test string is parsed as release name, size, age, seeders and indexer are taken from the fields. "+
+											"Variables: "+strings.Join(expression.Variables(), ", "),
+									),
+								),
+							),
+
 							// Test Button
 							html.Div(
 								html.Class("form-group text-center"),
@@ -548,7 +630,13 @@ func renderRegexTesterPage(csrfToken string) gomponents.Node {
 								html += '<span class="badge bg-danger px-3 py-2" style="border-radius: 15px;"><i class="fas fa-times me-1"></i>No Match</span>';
 							}
 							html += '</td>';
-							html += '<td style="padding: 1rem; vertical-align: middle; font-family: monospace; color: #28a745;">' + (result.match_string || '') + '</td>';
+							html += '<td style="padding: 1rem; vertical-align: middle; font-family: monospace; color: #28a745;">';
+							if (result.error) {
+								html += '<span class="text-danger">' + escapeHtml(result.error) + '</span>';
+							} else {
+								html += escapeHtml(result.match_string || '');
+							}
+							html += '</td>';
 							html += '</tr>';
 						});
 					} else {
@@ -567,6 +655,7 @@ func renderRegexTesterPage(csrfToken string) gomponents.Node {
 						case 'Config': return 'bg-primary';
 						case 'Quality': return 'bg-warning';
 						case 'Global': return 'bg-success';
+						case 'Rule': return 'bg-info';
 						default: return 'bg-secondary';
 					}
 				}
@@ -576,6 +665,7 @@ func renderRegexTesterPage(csrfToken string) gomponents.Node {
 						case 'Config': return 'fas fa-cogs';
 						case 'Quality': return 'fas fa-star';
 						case 'Global': return 'fas fa-globe';
+						case 'Rule': return 'fas fa-filter';
 						default: return 'fas fa-question';
 					}
 				}
@@ -774,5 +864,80 @@ func HandleRegexTesting(ctx *gin.Context) {
 		}
 	}
 
+	// Test Quality Rules
+	if ctx.PostForm("test_rules") == "on" || ctx.PostForm("test_rules") == "true" {
+		response.Results = append(
+			response.Results,
+			testQualityRules(ctx, testString, selectedQualityConfig)...)
+	}
+
 	ctx.JSON(http.StatusOK, response)
 }
+
+// testQualityRules evaluates the reject and score rules of the quality profiles
+// and the expression of the form against the test string parsed as release.
+func testQualityRules(ctx *gin.Context, testString, selectedQuality string) []RegexTestResult {
+	entry := apiexternal_v2.Nzbwithprio{NZB: apiexternal_v2.Nzb{Title: testString}}
+	parser_v2.ParseFileP(testString, false, false, nil, -1, &entry.Info)
+
+	if size, err := strconv.ParseFloat(ctx.PostForm("rule_size"), 64); err == nil {
+		entry.NZB.Size = int64(size * 1024 * 1024)
+	}
+
+	if age, err := strconv.ParseFloat(ctx.PostForm("rule_age"), 64); err == nil {
+		entry.NZB.PubDate = time.Now().Add(-time.Duration(age * float64(24*time.Hour)))
+	}
+
+	if seeders, err := strconv.Atoi(ctx.PostForm("rule_seeders")); err == nil {
+		entry.NZB.Seeders = seeders
+		entry.NZB.IsTorrent = true
+	}
+
+	if indexer := strings.TrimSpace(ctx.PostForm("rule_indexer")); indexer != "" {
+		entry.NZB.Indexer = &config.IndexersConfig{Name: indexer}
+	}
+
+	env := searcher.RuleEnv(&entry)
+
+	var results []RegexTestResult
+	if expr := strings.TrimSpace(ctx.PostForm("test_expression")); expr != "" {
+		result := RegexTestResult{Type: "Rule", Name: "Expression", Pattern: expr}
+		if prog, err := expression.Compile(expr); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Match = prog.Eval(&env)
+		}
+
+		results = append(results, result)
+	}
+
+	config.RangeSettingsQuality(func(name string, qualityConfig *config.QualityConfig) {
+		if selectedQuality != "" && name != selectedQuality {
+			return
+		}
+
+		for _, rule := range qualityConfig.RejectRules {
+			result := RegexTestResult{Type: "Rule", Name: name + " (Reject)", Pattern: rule}
+			if prog, err := expression.Compile(rule); err != nil {
+				result.Error = err.Error()
+			} else if result.Match = prog.Eval(&env); result.Match {
+				result.MatchString = "rejected"
+			}
+
+			results = append(results, result)
+		}
+
+		for _, rule := range qualityConfig.ScoreRules {
+			result := RegexTestResult{Type: "Rule", Name: name + " (Score)", Pattern: rule}
+			if scoreRule, err := expression.CompileScoreRule(rule); err != nil {
+				result.Error = err.Error()
+			} else if result.Match = scoreRule.Program.Eval(&env); result.Match {
+				result.MatchString = fmt.Sprintf("%+d priority", scoreRule.Points)
+			}
+
+			results = append(results, result)
+		}
+	})
+
+	return results
+}
@@ -0,0 +1,434 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/searcher"
+	"github.com/gin-gonic/gin"
+	"maragu.dev/gomponents"
+	hx "maragu.dev/gomponents-htmx"
+	"maragu.dev/gomponents/html"
+)
+
+// rssItemsPageSize is how many RSS items are shown per page.
+const rssItemsPageSize = 50
+
+// renderRSSItemsPage serves the RSS Items page listing the recent candidates
+// of RSS searches with their decision.
+func renderRSSItemsPage(ctx *gin.Context) {
+	pageNode := page("RSS Items", false, false, true, renderRSSItemsContent())
+
+	var buf strings.Builder
+	pageNode.Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// renderRSSItemsContent builds the page shell with the filter box and a
+// results region that lazy-loads (and paginates) via HTMX.
+func renderRSSItemsContent() gomponents.Node {
+	subtitle := "Recent releases of RSS searches with their decision. Re-evaluate them against the current configuration or grab them for a chosen item."
+	if config.GetSettingsGeneral().RSSHistoryItems <= 0 {
+		subtitle = "Storing RSS items is disabled. Set RSS History Items in the general configuration to enable it."
+	}
+
+	filterAttrs := func() []gomponents.Node {
+		return []gomponents.Node{
+			hx.Get("/api/admin/rssitems/list"),
+			hx.Include("#rssitems-filter"),
+			hx.Target("#rssitems-results"),
+			hx.Swap("innerHTML"),
+			hx.Indicator("#rssitems-ind"),
+		}
+	}
+
+	return html.Div(
+		html.Class("config-section-enhanced"),
+
+		// Page header.
+		html.Div(
+			html.Class("page-header-enhanced"),
+			html.Div(
+				html.Class("header-content"),
+				html.Div(
+					html.Class("header-icon-wrapper"),
+					html.I(
+						html.Class("fas fa-rss header-icon"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+				),
+				html.Div(
+					html.Class("header-text"),
+					html.H2(html.Class("header-title"), gomponents.Text("RSS Items")),
+					html.P(html.Class("header-subtitle"), gomponents.Text(subtitle)),
+				),
+			),
+		),
+
+		// Filter box — changing the decision or typing reloads page 1.
+		html.Form(
+			html.ID("rssitems-filter"),
+			html.Class("input-group input-group-sm mb-2"),
+			gomponents.Attr("onsubmit", "return false;"),
+			html.Select(
+				append([]gomponents.Node{
+					html.Class("form-select"),
+					html.Style("max-width: 10rem;"),
+					html.Name("decision"),
+					gomponents.Attr("aria-label", "Filter by decision"),
+					hx.Trigger("change"),
+					html.Option(html.Value(""), gomponents.Text("All decisions")),
+					html.Option(html.Value(database.RSSDecisionAccepted), gomponents.Text("Accepted")),
+					html.Option(html.Value(database.RSSDecisionDenied), gomponents.Text("Denied")),
+					html.Option(html.Value(database.RSSDecisionGrabbed), gomponents.Text("Grabbed")),
+				}, filterAttrs()...)...,
+			),
+			html.Input(
+				append([]gomponents.Node{
+					html.Type("search"),
+					html.Class("form-control"),
+					html.Name("q"),
+					html.Placeholder("Filter by title, indexer or reason..."),
+					gomponents.Attr("aria-label", "Filter RSS items"),
+					hx.Trigger("keyup changed delay:350ms, search"),
+				}, filterAttrs()...)...,
+			),
+			html.Span(
+				html.ID("rssitems-ind"),
+				html.Class("input-group-text htmx-indicator"),
+				html.Div(html.Class("spinner-border spinner-border-sm")),
+			),
+		),
+
+		// Results region — lazy-loads the first page.
+		html.Div(
+			html.ID("rssitems-results"),
+			hx.Get("/api/admin/rssitems/list?page=1"),
+			hx.Trigger("load"),
+			hx.Swap("innerHTML"),
+			html.Div(
+				html.Class("text-center text-muted p-4"),
+				html.Div(html.Class("spinner-border")),
+			),
+		),
+	)
+}
+
+// renderRSSItemsList serves the filtered, paginated results fragment.
+func renderRSSItemsList(ctx *gin.Context) {
+	decision := ctx.Query("decision")
+	search := strings.TrimSpace(ctx.Query("q"))
+
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	total := database.CountRSSItems(decision, search)
+
+	totalPages := (total + rssItemsPageSize - 1) / rssItemsPageSize
+	if page > totalPages {
+		page = max(totalPages, 1)
+	}
+
+	rows := database.GetRSSItems(decision, search, rssItemsPageSize, (page-1)*rssItemsPageSize)
+
+	var buf strings.Builder
+	rssItemsResults(decision, search, page, total, rows, getCSRFToken(ctx)).Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// rssItemsResults renders the count summary, table and pagination of a page.
+func rssItemsResults(
+	decision, search string,
+	page, total int,
+	rows []database.RSSItem,
+	csrfToken string,
+) gomponents.Node {
+	if total == 0 || len(rows) == 0 {
+		return html.Div(
+			html.Class("text-center text-muted p-5"),
+			html.I(
+				html.Class("fas fa-rss mb-3"),
+				html.Style("font-size: 3rem; color: #6c757d;"),
+			),
+			html.H5(html.Class("text-muted"), gomponents.Text("Nothing to show")),
+			html.P(
+				html.Class("text-muted mb-0"),
+				gomponents.Text("No RSS items match the filter."),
+			),
+		)
+	}
+
+	totalPages := (total + rssItemsPageSize - 1) / rssItemsPageSize
+	start := (page-1)*rssItemsPageSize + 1
+	end := start + len(rows) - 1
+
+	trs := make([]gomponents.Node, 0, len(rows))
+	for idx := range rows {
+		trs = append(trs, rssItemRow(&rows[idx], csrfToken))
+	}
+
+	return html.Div(
+		html.Div(
+			html.Class("d-flex justify-content-between align-items-center mb-2 small text-muted"),
+			gomponents.Textf("Showing %d–%d of %d", start, end, total),
+			gomponents.Textf("Page %d of %d", page, totalPages),
+		),
+		html.Div(
+			html.Class("table-responsive"),
+			html.Table(
+				html.Class("table table-sm table-hover align-middle"),
+				html.THead(
+					html.Tr(
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Style("width: 9rem;"),
+							gomponents.Text("Seen"),
+						),
+						html.Th(gomponents.Attr("scope", "col"), gomponents.Text("Release")),
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Style("width: 16rem;"),
+							gomponents.Text("Decision"),
+						),
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Class("text-end"),
+							html.Style("width: 17rem;"),
+							gomponents.Text("Action"),
+						),
+					),
+				),
+				html.TBody(trs...),
+			),
+		),
+		rssItemsPagination(decision, search, page, totalPages),
+	)
+}
+
+// rssItemRow renders a single RSS item with its re-evaluate and grab actions.
+func rssItemRow(item *database.RSSItem, csrfToken string) gomponents.Node {
+	idStr := strconv.FormatUint(uint64(item.ID), 10)
+
+	details := make([]string, 0, 8)
+	for _, v := range []string{
+		item.Indexer,
+		item.Config,
+		item.QualityProfile,
+		item.Resolution,
+		item.Quality,
+		item.Codec,
+		item.ReleaseGroup,
+		item.Identifier,
+	} {
+		if v != "" {
+			details = append(details, v)
+		}
+	}
+
+	if item.Size > 0 {
+		details = append(details, formatFileSize(item.Size))
+	}
+
+	mediaID := ""
+	if item.MediaID != 0 {
+		mediaID = strconv.FormatUint(uint64(item.MediaID), 10)
+	}
+
+	return html.Tr(
+		html.Td(
+			html.Class("text-nowrap small"),
+			gomponents.Text(item.CreatedAt.Format("2006-01-02 15:04")),
+		),
+		html.Td(
+			html.Div(html.Class("text-break"), gomponents.Text(item.Title)),
+			html.Div(
+				html.Class("small text-muted"),
+				gomponents.Text(strings.Join(details, " · ")),
+			),
+		),
+		html.Td(
+			html.ID("rssdecision-"+idStr),
+			rssItemDecisionCell(item),
+		),
+		html.Td(
+			html.Class("text-end"),
+			html.Form(
+				html.Class("d-flex justify-content-end gap-1"),
+				hx.Post("/api/admin/rssitems/grab?id="+idStr),
+				hx.Headers(createHTMXHeaders(csrfToken)),
+				hx.Target("#rssdecision-"+idStr),
+				hx.Swap("innerHTML"),
+				hx.Confirm("Grab this release for the given media ID?"),
+				html.Button(
+					html.Type("button"),
+					html.Class("btn btn-sm btn-outline-secondary"),
+					gomponents.Attr("title", "Re-evaluate against the current configuration"),
+					gomponents.Attr("aria-label", "Re-evaluate "+item.Title),
+					hx.Post("/api/admin/rssitems/reevaluate?id="+idStr),
+					hx.Headers(createHTMXHeaders(csrfToken)),
+					hx.Target("#rssdecision-"+idStr),
+					hx.Swap("innerHTML"),
+					html.I(
+						html.Class("fas fa-rotate"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+				),
+				html.Input(
+					html.Type("number"),
+					html.Class("form-control form-control-sm"),
+					html.Style("width: 6rem;"),
+					html.Name("media_id"),
+					html.Min("1"),
+					html.Value(mediaID),
+					html.Placeholder("Media ID"),
+					gomponents.Attr("title", "ID of the movie, episode, book, audiobook or album"),
+					gomponents.Attr("aria-label", "Media ID for "+item.Title),
+				),
+				html.Button(
+					html.Type("submit"),
+					html.Class("btn btn-sm btn-outline-primary"),
+					gomponents.Attr("aria-label", "Grab "+item.Title),
+					html.I(
+						html.Class("fas fa-download me-1"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+					gomponents.Text("Grab"),
+				),
+			),
+		),
+	)
+}
+
+// rssItemDecisionCell renders the decision badge and the reason of an item.
+func rssItemDecisionCell(item *database.RSSItem) gomponents.Node {
+	badge := "bg-secondary"
+	switch item.Decision {
+	case database.RSSDecisionAccepted:
+		badge = "bg-success"
+	case database.RSSDecisionDenied:
+		badge = "bg-danger"
+	case database.RSSDecisionGrabbed:
+		badge = "bg-primary"
+	}
+
+	return gomponents.Group([]gomponents.Node{
+		html.Span(html.Class("badge "+badge), gomponents.Text(item.Decision)),
+		gomponents.If(
+			item.Priority != 0,
+			html.Span(
+				html.Class("small text-muted ms-1"),
+				gomponents.Textf("prio %d", item.Priority),
+			),
+		),
+		gomponents.If(
+			item.Reason != "",
+			html.Div(html.Class("small text-muted text-break"), gomponents.Text(item.Reason)),
+		),
+	})
+}
+
+// renderRSSItemsReevaluate runs an RSS item through the searcher again and
+// returns its new decision cell.
+func renderRSSItemsReevaluate(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Query("id"), 10, 0)
+	if err != nil || id == 0 {
+		ctx.String(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	item, err := searcher.ReevaluateRSSItem(uint(id))
+	if err != nil {
+		ctx.String(http.StatusOK, renderAlert(err.Error(), "danger"))
+		return
+	}
+
+	var buf strings.Builder
+	rssItemDecisionCell(item).Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// renderRSSItemsGrab downloads an RSS item for the media ID of the form and
+// returns its new decision cell.
+func renderRSSItemsGrab(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Query("id"), 10, 0)
+	if err != nil || id == 0 {
+		ctx.String(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	mediaid, err := strconv.ParseUint(ctx.PostForm("media_id"), 10, 0)
+	if err != nil || mediaid == 0 {
+		ctx.String(http.StatusOK, renderAlert("Enter the ID of the media to grab the release for", "warning"))
+		return
+	}
+
+	if err := searcher.GrabRSSItem(uint(id), uint(mediaid)); err != nil {
+		ctx.String(http.StatusOK, renderAlert(err.Error(), "danger"))
+		return
+	}
+
+	item, err := database.GetRSSItem(uint(id))
+	if err != nil {
+		ctx.String(http.StatusOK, renderAlert(err.Error(), "danger"))
+		return
+	}
+
+	var buf strings.Builder
+	rssItemDecisionCell(item).Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// rssItemsPagination renders Prev/Next paging controls that swap the results region.
+func rssItemsPagination(decision, search string, page, totalPages int) gomponents.Node {
+	if totalPages <= 1 {
+		return gomponents.Text("")
+	}
+
+	base := "/api/admin/rssitems/list?decision=" + url.QueryEscape(decision) +
+		"&q=" + url.QueryEscape(search) + "&page="
+
+	pageBtn := func(label string, target int, disabled bool) gomponents.Node {
+		liClass := "page-item"
+		if disabled {
+			liClass = "page-item disabled"
+		}
+
+		btn := []gomponents.Node{
+			html.Class("page-link"),
+			html.Type("button"),
+			gomponents.Text(label),
+		}
+		if !disabled {
+			btn = append(btn,
+				hx.Get(base+strconv.Itoa(target)),
+				hx.Target("#rssitems-results"),
+				hx.Swap("innerHTML"),
+			)
+		}
+
+		return html.Li(html.Class(liClass), html.Button(btn...))
+	}
+
+	return html.Nav(
+		gomponents.Attr("aria-label", "RSS items pagination"),
+		html.Ul(
+			html.Class("pagination pagination-sm mb-0 justify-content-center"),
+			pageBtn("« First", 1, page <= 1),
+			pageBtn("‹ Prev", page-1, page <= 1),
+			html.Li(html.Class("page-item disabled"),
+				html.Span(html.Class("page-link"), gomponents.Textf("%d / %d", page, totalPages))),
+			pageBtn("Next ›", page+1, page >= totalPages),
+			pageBtn("Last »", totalPages, page >= totalPages),
+		),
+	)
+}
@@ -33,6 +33,9 @@ func AddSeriesRoutes(routerseries *gin.RouterGroup) {
 
 		routerseries.GET("/metadata/:tvdb", apiSeriesMetadataGet)
 
+		routerseries.POST("/scenemappings/import", apiSeriesSceneMappingsImport)
+		routerseries.GET("/scenemappings/:id", apiSeriesSceneMappingsGet)
+
 		routerseries.GET("/all/refresh", apirefreshSeriesInc)
 		routerseries.GET("/all/refreshall", apirefreshSeries)
 		routerseries.GET("/refresh/:id", apirefreshSerie)
@@ -121,6 +124,7 @@ func apiSeriesDelete(ctx *gin.Context) {
 	database.DeleteRow("serie_episode_histories", querybydbserieid, id)
 	database.DeleteRow("serie_episodes", querybydbserieid, id)
 	database.DeleteRow("dbserie_episodes", querybydbserieid, id)
+	database.DeleteRow("dbserie_scene_mappings", querybydbserieid, id)
 	database.DeleteRow(logger.StrSeries, querybydbserieid, id)
 
 	_, err := database.DeleteRow("dbseries", logger.FilterByID, id)
@@ -351,12 +355,12 @@ func apiSeriesEpisodesListDelete(ctx *gin.Context) {
 	handleDBError(ctx, err, StrOK)
 }
 
-const allowedjobsseriesstr = "rss,rssseasons,rssseasonsall,data,datafull,checkmissing,checkmissingflag,checkreachedflag,structure,searchmissingfull,searchmissinginc,searchupgradefull,searchupgradeinc,searchmissingfulltitle,searchmissinginctitle,searchupgradefulltitle,searchupgradeinctitle,clearhistory,feeds,refresh,refreshinc"
+const allowedjobsseriesstr = "rss,rssseasons,rssseasonsall,data,datafull,checkmissing,checkmissingflag,checkreachedflag,structure,searchmissingfull,searchmissinginc,searchupgradefull,searchupgradeinc,searchmissingfulltitle,searchmissinginctitle,searchupgradefulltitle,searchupgradeinctitle,clearhistory,feeds,refresh,refreshinc,artwork,searchsubtitles"
 
 // @Summary      Start Jobs (All Lists)
 // @Description  Starts a Job
 // @Tags         series
-// @Param        job  path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc"
+// @Param        job  path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc, artwork, searchsubtitles"
 // @Param        apikey query     string    true  "apikey"
 // @Success      200  {object}  string "returns job name started"
 // @Failure      204  {object}  string "error message"
@@ -384,7 +388,7 @@ func apiseriesAllJobs(c *gin.Context) {
 		cfgpstr := "serie_" + media.Name
 
 		switch c.Param(StrJobLower) {
-		case logger.StrData, logger.StrDataFull, logger.StrStructure, logger.StrClearHistory:
+		case logger.StrData, logger.StrDataFull, logger.StrStructure, logger.StrArtwork, logger.StrSubtitles, logger.StrClearHistory:
 			worker.Dispatch(
 				c.Param(StrJobLower)+"_series_"+media.Name,
 				func(key uint32, ctx context.Context) error {
@@ -508,7 +512,7 @@ func apiseriesAllJobs(c *gin.Context) {
 // @Summary      Start Jobs
 // @Description  Starts a Job
 // @Tags         series
-// @Param        job   path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc"
+// @Param        job   path      string  true  "Job Name one of: rss, data, datafull, checkmissing, checkmissingflag, structure, searchmissingfull, searchmissinginc, searchupgradefull, searchupgradeinc, searchmissingfulltitle, searchmissinginctitle, searchupgradefulltitle, searchupgradeinctitle, clearhistory, feeds, refresh, refreshinc, artwork, searchsubtitles"
 // @Param        name  path      string  true  "List Name: ex. list"
 // @Param        apikey query     string    true  "apikey"
 // @Success      200  {object}  string "returns job name started"
@@ -529,7 +533,7 @@ func apiseriesJobs(c *gin.Context) {
 
 		cfgpstr := "serie_" + c.Param("name")
 		switch c.Param(StrJobLower) {
-		case logger.StrData, logger.StrDataFull, logger.StrStructure, logger.StrClearHistory:
+		case logger.StrData, logger.StrDataFull, logger.StrStructure, logger.StrArtwork, logger.StrSubtitles, logger.StrClearHistory:
 			worker.Dispatch(
 				c.Param(StrJobLower)+"_series_"+c.Param("name"),
 				func(key uint32, ctx context.Context) error {
@@ -715,6 +719,8 @@ func updateDBSeries(c *gin.Context) {
 				"Poster",
 				"Fanart",
 				"Identifiedby",
+				"Moviedb_ID",
+				"Tvmaze_ID",
 			},
 			dbserie.Seriename,
 			dbserie.Season,
@@ -742,6 +748,8 @@ func updateDBSeries(c *gin.Context) {
 			dbserie.Poster,
 			dbserie.Fanart,
 			dbserie.Identifiedby,
+			dbserie.MoviedbID,
+			dbserie.TvmazeID,
 		)
 	} else {
 		inres, inerr = database.UpdateArray(
@@ -773,6 +781,8 @@ func updateDBSeries(c *gin.Context) {
 				"Poster",
 				"Fanart",
 				"Identifiedby",
+				"Moviedb_ID",
+				"Tvmaze_ID",
 			},
 			"id != 0 and id = ?",
 			dbserie.Seriename,
@@ -801,6 +811,8 @@ func updateDBSeries(c *gin.Context) {
 			dbserie.Poster,
 			dbserie.Fanart,
 			dbserie.Identifiedby,
+			dbserie.MoviedbID,
+			dbserie.TvmazeID,
 			dbserie.ID,
 		)
 	}
@@ -916,23 +928,25 @@ func updateSeries(c *gin.Context) {
 	if counter == 0 {
 		inres, inerr = database.InsertArray(
 			logger.StrSeries,
-			[]string{"dbserie_id", "listname", "rootpath", "dont_upgrade", "dont_search"},
+			[]string{"dbserie_id", "listname", "rootpath", "dont_upgrade", "dont_search", "search_delay"},
 			serie.DbserieID,
 			serie.Listname,
 			serie.Rootpath,
 			serie.DontUpgrade,
 			serie.DontSearch,
+			serie.SearchDelay,
 		)
 	} else {
 		inres, inerr = database.UpdateArray(
 			logger.StrSeries,
-			[]string{"dbserie_id", "listname", "rootpath", "dont_upgrade", "dont_search"},
+			[]string{"dbserie_id", "listname", "rootpath", "dont_upgrade", "dont_search", "search_delay"},
 			"id != 0 and id = ?",
 			serie.DbserieID,
 			serie.Listname,
 			serie.Rootpath,
 			serie.DontUpgrade,
 			serie.DontSearch,
+			serie.SearchDelay,
 			serie.ID,
 		)
 	}
@@ -1741,7 +1755,7 @@ func apiSeriesMetadataGet(ctx *gin.Context) {
 
 		if dbserie.ID != 0 {
 			database.ExecN(
-				"update dbseries SET Seriename = ?, Season = ?, Status = ?, Firstaired = ?, Network = ?, Runtime = ?, Language = ?, Genre = ?, Overview = ?, Rating = ?, Siterating = ?, Siterating_Count = ?, Slug = ?, Trakt_ID = ?, Imdb_ID = ?, Thetvdb_ID = ?, Freebase_M_ID = ?, Freebase_ID = ?, Tvrage_ID = ?, Facebook = ?, Instagram = ?, Twitter = ?, Banner = ?, Poster = ?, Fanart = ?, Identifiedby = ? where id = ?",
+				"update dbseries SET Seriename = ?, Season = ?, Status = ?, Firstaired = ?, Network = ?, Runtime = ?, Language = ?, Genre = ?, Overview = ?, Rating = ?, Siterating = ?, Siterating_Count = ?, Slug = ?, Trakt_ID = ?, Imdb_ID = ?, Thetvdb_ID = ?, Freebase_M_ID = ?, Freebase_ID = ?, Tvrage_ID = ?, Facebook = ?, Instagram = ?, Twitter = ?, Banner = ?, Poster = ?, Fanart = ?, Identifiedby = ?, Moviedb_ID = ?, Tvmaze_ID = ? where id = ?",
 				&dbserie.Seriename,
 				&dbserie.Season,
 				&dbserie.Status,
@@ -1768,6 +1782,8 @@ func apiSeriesMetadataGet(ctx *gin.Context) {
 				&dbserie.Poster,
 				&dbserie.Fanart,
 				&dbserie.Identifiedby,
+				&dbserie.MoviedbID,
+				&dbserie.TvmazeID,
 				&dbserie.ID,
 			)
 		}
@@ -1775,3 +1791,62 @@ func apiSeriesMetadataGet(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"data": dbserie})
 }
+
+// @Summary      Get Scene Mappings
+// @Description  Lists the scene to TVDB numbering mappings of a series
+// @Tags         series
+// @Param        id   path      int  true  "Series ID (dbseries)"
+// @Param        apikey query     string    true  "apikey"
+// @Success      200  {object}  Jsondata{data=[]database.DbserieSceneMapping}
+// @Failure      401  {object}  Jsonerror
+// @Router       /api/series/scenemappings/{id} [get].
+func apiSeriesSceneMappingsGet(ctx *gin.Context) {
+	id, ok := getParamID(ctx, StrID)
+	if !ok {
+		return
+	}
+
+	data := database.StructscanT[database.DbserieSceneMapping](
+		false,
+		0,
+		"select id, created_at, updated_at, dbserie_id, scene_season, scene_episode, scene_absolute, tvdb_season, tvdb_episode from dbserie_scene_mappings where dbserie_id = ? order by scene_season, scene_episode, scene_absolute",
+		id,
+	)
+
+	sendJSONResponse(ctx, http.StatusOK, data, len(data))
+}
+
+// @Summary      Import Scene Mappings
+// @Description  Imports scene to TVDB numbering mappings from the TOML body. Without a body the configured scene_mapping_file is imported.
+// @Tags         series
+// @Accept       plain
+// @Param        mappings body      string  false  "Scene mapping TOML"
+// @Param        apikey   query     string  true   "apikey"
+// @Success      200  {object}  Jsondata{data=int}
+// @Failure      400  {object}  Jsonerror
+// @Failure      401  {object}  Jsonerror
+// @Router       /api/series/scenemappings/import [post].
+func apiSeriesSceneMappingsImport(ctx *gin.Context) {
+	var (
+		count int
+		err   error
+	)
+
+	if ctx.Request.ContentLength > 0 {
+		count, err = database.ImportSceneMappingsFrom(ctx.Request.Body, "upload")
+	} else {
+		file := config.GetSettingsGeneral().SceneMappingFile
+		if file == "" {
+			sendBadRequest(ctx, "no scene mapping file configured")
+			return
+		}
+
+		count, err = database.ImportSceneMappings(file)
+	}
+	if err != nil {
+		sendBadRequest(ctx, err.Error())
+		return
+	}
+
+	sendJSONResponse(ctx, http.StatusOK, count)
+}
@@ -626,6 +626,7 @@ func renderSeriesConfigSections(
 					Options: nil,
 				},
 				{Name: "IgnoreRuntime", Type: "checkbox", Value: serie.IgnoreRuntime, Options: nil},
+				{Name: "SearchDelay", Type: "number", Value: serie.SearchDelay, Options: nil},
 			},
 			group,
 			comments,
@@ -894,6 +895,10 @@ func parseSerieConfigForm(ctx *gin.Context) config.ManualConfig {
 	serie.SearchSpecials = ctx.PostForm("serie_SearchSpecials") == "on"
 	serie.IgnoreRuntime = ctx.PostForm("serie_IgnoreRuntime") == "on"
 
+	if searchDelay := ctx.PostForm("serie_SearchDelay"); searchDelay != "" {
+		fmt.Sscanf(searchDelay, "%d", &serie.SearchDelay)
+	}
+
 	// Scraper settings
 	serie.ScraperType = ctx.PostForm("serie_ScraperType")
 	serie.StartURL = ctx.PostForm("serie_StartURL")
@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
@@ -102,6 +103,12 @@ type ClientStats struct {
 	SuccessCount        int64     `json:"success_count"`
 	FailureCount        int64     `json:"failure_count"`
 	CircuitBreakerState string    `json:"circuit_breaker_state"`
+	CacheHits           int64     `json:"cache_hits"`
+	CacheMisses         int64     `json:"cache_misses"`
+	APIRemaining        int       `json:"api_remaining"`
+	APIMax              int       `json:"api_max"`
+	GrabRemaining       int       `json:"grab_remaining"`
+	GrabMax             int       `json:"grab_max"`
 }
 
 // SystemStatistics contains system performance statistics.
@@ -581,7 +588,7 @@ func getHTTPStatistics() HTTPStatistics {
 	}
 
 	// Get all indexer clients
-	allIndexers := providers.GetAllIndexers()
+	allIndexers := providers.GetAllIndexerClients()
 
 	stats.TotalClients = len(allIndexers)
 
@@ -604,6 +611,14 @@ func getHTTPStatistics() HTTPStatistics {
 			CircuitBreakerState: clientStats.CircuitBreakerState,
 		}
 
+		cs.CacheHits, cs.CacheMisses = apiexternal.GetResponseCacheStats(name)
+
+		if indcfg := config.GetSettingsIndexer(name); indcfg != nil {
+			quota := apiexternal.GetIndexerQuota(indcfg)
+			cs.APIRemaining, cs.APIMax = quota.APIRemaining, quota.APIMax
+			cs.GrabRemaining, cs.GrabMax = quota.GrabRemaining, quota.GrabMax
+		}
+
 		stats.ClientStats[name] = cs
 
 		stats.TotalRequests += clientStats.RequestsTotal
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/worker"
@@ -416,6 +417,29 @@ func renderHTTPClientStats(stats HTTPStatistics) gomponents.Node {
 		lastRequest := formatTimestamp(client.LastRequestAt)
 		nextAvailable := formatTimestamp(client.NextAvailableAt)
 
+		cacheHits := "-"
+		if cacheTotal := client.CacheHits + client.CacheMisses; cacheTotal > 0 {
+			cacheHits = fmt.Sprintf(
+				"%d (%.0f%%)",
+				client.CacheHits,
+				float64(client.CacheHits)/float64(cacheTotal)*100,
+			)
+		}
+
+		quota := "-"
+		if client.APIMax > 0 || client.GrabMax > 0 {
+			var parts []string
+			if client.APIMax > 0 {
+				parts = append(parts, fmt.Sprintf("API %d/%d", client.APIRemaining, client.APIMax))
+			}
+
+			if client.GrabMax > 0 {
+				parts = append(parts, fmt.Sprintf("Grabs %d/%d", client.GrabRemaining, client.GrabMax))
+			}
+
+			quota = strings.Join(parts, ", ")
+		}
+
 		errorMsg := gomponents.Text("-")
 		if client.LastErrorMessage != "" {
 			errorMsg = html.Span(
@@ -442,6 +466,8 @@ func renderHTTPClientStats(stats HTTPStatistics) gomponents.Node {
 				html.Class("text-right text-dark"),
 				gomponents.Textf("%.0fms", client.AvgResponseTimeMs),
 			),
+			html.Td(html.Class("text-right text-dark"), gomponents.Text(cacheHits)),
+			html.Td(html.Class("text-right text-dark"), gomponents.Text(quota)),
 			html.Td(
 				html.Class("text-center"),
 				html.Span(
@@ -457,7 +483,7 @@ func renderHTTPClientStats(stats HTTPStatistics) gomponents.Node {
 
 	if !hasClients {
 		rows = append(rows, html.Tr(
-			html.Td(gomponents.Attr("colspan", "11"), html.Class("text-center text-muted"),
+			html.Td(gomponents.Attr("colspan", "13"), html.Class("text-center text-muted"),
 				gomponents.Text("No HTTP clients")),
 		))
 	}
@@ -483,6 +509,8 @@ func renderHTTPClientStats(stats HTTPStatistics) gomponents.Node {
 							html.Th(html.Class("text-right"), gomponents.Text("Success")),
 							html.Th(html.Class("text-right"), gomponents.Text("Failed")),
 							html.Th(html.Class("text-right"), gomponents.Text("Avg Time")),
+							html.Th(html.Class("text-right"), gomponents.Text("Cache Hits")),
+							html.Th(html.Class("text-right"), gomponents.Text("Quota Left")),
 							html.Th(html.Class("text-center"), gomponents.Text("Rate")),
 							html.Th(gomponents.Text("Last Request")),
 							html.Th(gomponents.Text("Next Available")),
@@ -0,0 +1,298 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/gin-gonic/gin"
+	"maragu.dev/gomponents"
+	hx "maragu.dev/gomponents-htmx"
+	"maragu.dev/gomponents/html"
+)
+
+// subtitlesPageSize is how many video files are shown per page.
+const subtitlesPageSize = 50
+
+// renderSubtitlesPage serves the Subtitles page listing the subtitle status
+// of every wanted language per video file.
+func renderSubtitlesPage(ctx *gin.Context) {
+	pageNode := page("Subtitles", false, false, true, renderSubtitlesContent())
+
+	var buf strings.Builder
+	pageNode.Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// renderSubtitlesContent builds the page shell with the filter box and a
+// results region that lazy-loads (and paginates) via HTMX.
+func renderSubtitlesContent() gomponents.Node {
+	filterAttrs := func() []gomponents.Node {
+		return []gomponents.Node{
+			hx.Get("/api/admin/subtitles/list"),
+			hx.Include("#subtitles-filter"),
+			hx.Target("#subtitles-results"),
+			hx.Swap("innerHTML"),
+			hx.Indicator("#subtitles-ind"),
+		}
+	}
+
+	return html.Div(
+		html.Class("config-section-enhanced"),
+
+		// Page header.
+		html.Div(
+			html.Class("page-header-enhanced"),
+			html.Div(
+				html.Class("header-content"),
+				html.Div(
+					html.Class("header-icon-wrapper"),
+					html.I(
+						html.Class("fas fa-closed-captioning header-icon"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+				),
+				html.Div(
+					html.Class("header-text"),
+					html.H2(html.Class("header-title"), gomponents.Text("Subtitles")),
+					html.P(
+						html.Class("header-subtitle"),
+						gomponents.Text(
+							"Subtitle status of the wanted languages per video file of paths with Download Subtitles enabled.",
+						),
+					),
+				),
+			),
+		),
+
+		// Filter box — changing the status or typing reloads page 1.
+		html.Form(
+			html.ID("subtitles-filter"),
+			html.Class("input-group input-group-sm mb-2"),
+			gomponents.Attr("onsubmit", "return false;"),
+			html.Select(
+				append([]gomponents.Node{
+					html.Class("form-select"),
+					html.Style("max-width: 12rem;"),
+					html.Name("status"),
+					gomponents.Attr("aria-label", "Filter by status"),
+					hx.Trigger("change"),
+					html.Option(html.Value(""), gomponents.Text("All files")),
+					html.Option(
+						html.Value(database.SubtitleStatusMissing),
+						gomponents.Text("With missing languages"),
+					),
+					html.Option(
+						html.Value(database.SubtitleStatusDownloaded),
+						gomponents.Text("With downloads"),
+					),
+					html.Option(
+						html.Value(database.SubtitleStatusExisting),
+						gomponents.Text("With existing subtitles"),
+					),
+				}, filterAttrs()...)...,
+			),
+			html.Input(
+				append([]gomponents.Node{
+					html.Type("search"),
+					html.Class("form-control"),
+					html.Name("q"),
+					html.Placeholder("Filter by path..."),
+					gomponents.Attr("aria-label", "Filter video files"),
+					hx.Trigger("keyup changed delay:350ms, search"),
+				}, filterAttrs()...)...,
+			),
+			html.Span(
+				html.ID("subtitles-ind"),
+				html.Class("input-group-text htmx-indicator"),
+				html.Div(html.Class("spinner-border spinner-border-sm")),
+			),
+		),
+
+		// Results region — lazy-loads the first page.
+		html.Div(
+			html.ID("subtitles-results"),
+			hx.Get("/api/admin/subtitles/list?page=1"),
+			hx.Trigger("load"),
+			hx.Swap("innerHTML"),
+			html.Div(
+				html.Class("text-center text-muted p-4"),
+				html.Div(html.Class("spinner-border")),
+			),
+		),
+	)
+}
+
+// renderSubtitlesList serves the filtered, paginated results fragment.
+func renderSubtitlesList(ctx *gin.Context) {
+	status := ctx.Query("status")
+	search := strings.TrimSpace(ctx.Query("q"))
+
+	page := 1
+	if p, err := strconv.Atoi(ctx.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	total := database.CountSubtitleFiles(status, search)
+
+	totalPages := (total + subtitlesPageSize - 1) / subtitlesPageSize
+	if page > totalPages {
+		page = max(totalPages, 1)
+	}
+
+	files := database.GetSubtitleFiles(
+		status,
+		search,
+		subtitlesPageSize,
+		(page-1)*subtitlesPageSize,
+	)
+
+	var buf strings.Builder
+	subtitlesResults(status, search, page, total, files).Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
+// subtitlesResults renders the count summary, table and pagination of a page.
+func subtitlesResults(status, search string, page, total int, files []string) gomponents.Node {
+	if total == 0 || len(files) == 0 {
+		return html.Div(
+			html.Class("text-center text-muted p-5"),
+			html.I(
+				html.Class("fas fa-closed-captioning mb-3"),
+				html.Style("font-size: 3rem; color: #6c757d;"),
+			),
+			html.H5(html.Class("text-muted"), gomponents.Text("Nothing to show")),
+			html.P(
+				html.Class("text-muted mb-0"),
+				gomponents.Text("No video files match the filter."),
+			),
+		)
+	}
+
+	totalPages := (total + subtitlesPageSize - 1) / subtitlesPageSize
+	start := (page-1)*subtitlesPageSize + 1
+	end := start + len(files) - 1
+
+	trs := make([]gomponents.Node, 0, len(files))
+	for _, location := range files {
+		trs = append(trs, subtitlesRow(location, database.GetSubtitles(location)))
+	}
+
+	return html.Div(
+		html.Div(
+			html.Class("d-flex justify-content-between align-items-center mb-2 small text-muted"),
+			gomponents.Textf("Showing %d–%d of %d", start, end, total),
+			gomponents.Textf("Page %d of %d", page, totalPages),
+		),
+		html.Div(
+			html.Class("table-responsive"),
+			html.Table(
+				html.Class("table table-sm table-hover align-middle"),
+				html.THead(
+					html.Tr(
+						html.Th(gomponents.Attr("scope", "col"), gomponents.Text("File")),
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Style("width: 40%;"),
+							gomponents.Text("Languages"),
+						),
+					),
+				),
+				html.TBody(trs...),
+			),
+		),
+		subtitlesPagination(status, search, page, totalPages),
+	)
+}
+
+// subtitlesRow renders a video file with the status of each of its subtitle
+// languages.
+func subtitlesRow(location string, subs []database.Subtitle) gomponents.Node {
+	langs := make([]gomponents.Node, 0, len(subs))
+	for idx := range subs {
+		sub := &subs[idx]
+
+		badge := "bg-secondary"
+		details := make([]string, 0, 4)
+		switch sub.Status {
+		case database.SubtitleStatusExisting:
+			badge = "bg-success"
+		case database.SubtitleStatusDownloaded:
+			badge = "bg-primary"
+			details = append(details, sub.Provider, "score "+strconv.Itoa(sub.Score))
+			if sub.ReleaseName != "" {
+				details = append(details, sub.ReleaseName)
+			}
+		case database.SubtitleStatusMissing:
+			badge = "bg-warning text-dark"
+		}
+
+		if sub.Path != "" && sub.Path != location {
+			details = append(details, sub.Path)
+		}
+
+		if sub.LastSearch.Valid {
+			details = append(details, "searched "+sub.LastSearch.Time.Format("2006-01-02 15:04"))
+		}
+
+		langs = append(langs, html.Span(
+			html.Class("badge "+badge+" me-1"),
+			html.Title(strings.Join(details, " · ")),
+			gomponents.Text(sub.Language+" "+sub.Status),
+		))
+	}
+
+	return html.Tr(
+		html.Td(html.Div(html.Class("text-break"), gomponents.Text(location))),
+		html.Td(langs...),
+	)
+}
+
+// subtitlesPagination renders Prev/Next paging controls that swap the results region.
+func subtitlesPagination(status, search string, page, totalPages int) gomponents.Node {
+	if totalPages <= 1 {
+		return gomponents.Text("")
+	}
+
+	base := "/api/admin/subtitles/list?status=" + url.QueryEscape(status) +
+		"&q=" + url.QueryEscape(search) + "&page="
+
+	pageBtn := func(label string, target int, disabled bool) gomponents.Node {
+		liClass := "page-item"
+		if disabled {
+			liClass = "page-item disabled"
+		}
+
+		btn := []gomponents.Node{
+			html.Class("page-link"),
+			html.Type("button"),
+			gomponents.Text(label),
+		}
+		if !disabled {
+			btn = append(btn,
+				hx.Get(base+strconv.Itoa(target)),
+				hx.Target("#subtitles-results"),
+				hx.Swap("innerHTML"),
+			)
+		}
+
+		return html.Li(html.Class(liClass), html.Button(btn...))
+	}
+
+	return html.Nav(
+		gomponents.Attr("aria-label", "Subtitles pagination"),
+		html.Ul(
+			html.Class("pagination pagination-sm mb-0 justify-content-center"),
+			pageBtn("« First", 1, page <= 1),
+			pageBtn("‹ Prev", page-1, page <= 1),
+			html.Li(html.Class("page-item disabled"),
+				html.Span(html.Class("page-link"), gomponents.Textf("%d / %d", page, totalPages))),
+			pageBtn("Next ›", page+1, page >= totalPages),
+			pageBtn("Last »", totalPages, page >= totalPages),
+		),
+	)
+}
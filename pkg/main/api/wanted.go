@@ -8,6 +8,9 @@ import (
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype/mtstrings"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/syncops"
 	"github.com/gin-gonic/gin"
 	"maragu.dev/gomponents"
 	hx "maragu.dev/gomponents-htmx"
@@ -23,7 +26,8 @@ type wantedSection struct {
 	Key        string // tab/media key used by the client-side search handler
 	Title      string
 	Icon       string
-	SelectExpr string // label expression + id, scanned positionally
+	IsType     uint   // media type of the section, used to reset the search backoff
+	SelectExpr string // label expression + next planned search + id, scanned positionally
 	From       string // FROM ... JOIN ...
 	Where      string // base filter (missing = 1)
 	SearchExpr string // expression matched with LIKE when filtering
@@ -36,7 +40,8 @@ var wantedSections = []wantedSection{
 		Key:        "movie",
 		Title:      "Movies",
 		Icon:       "fas fa-film",
-		SelectExpr: "dm.title, m.id",
+		IsType:     config.MediaTypeMovie,
+		SelectExpr: "dm.title, " + wantedNextSearchExpr("m") + ", m.id",
 		From:       "FROM movies m JOIN dbmovies dm ON dm.id = m.dbmovie_id",
 		Where:      "m.missing = 1",
 		SearchExpr: "dm.title",
@@ -46,7 +51,8 @@ var wantedSections = []wantedSection{
 		Key:        "episode",
 		Title:      "Episodes",
 		Icon:       "fas fa-tv",
-		SelectExpr: "ds.seriename || ' - ' || dse.identifier, se.id",
+		IsType:     config.MediaTypeSeries,
+		SelectExpr: "ds.seriename || ' - ' || dse.identifier, " + wantedNextSearchExpr("se") + ", se.id",
 		From: "FROM serie_episodes se " +
 			"JOIN dbserie_episodes dse ON dse.id = se.dbserie_episode_id " +
 			"JOIN series s ON s.id = se.serie_id " +
@@ -58,12 +64,14 @@ var wantedSections = []wantedSection{
 	{
 		// dbalbums has no artist column; the (primary) artist comes from the
 		// dbalbum_artists -> dbartists join, fetched per-row as a subquery.
-		Key:   "album",
-		Title: "Albums",
-		Icon:  "fas fa-compact-disc",
+		Key:    "album",
+		Title:  "Albums",
+		Icon:   "fas fa-compact-disc",
+		IsType: config.MediaTypeMusic,
 		SelectExpr: "COALESCE((SELECT ar.name FROM dbalbum_artists aa " +
 			"JOIN dbartists ar ON ar.id = aa.dbartist_id " +
-			"WHERE aa.dbalbum_id = da.id LIMIT 1) || ' - ', '') || da.title, a.id",
+			"WHERE aa.dbalbum_id = da.id LIMIT 1) || ' - ', '') || da.title, " +
+			wantedNextSearchExpr("a") + ", a.id",
 		From:       "FROM albums a JOIN dbalbums da ON da.id = a.dbalbum_id",
 		Where:      "a.missing = 1",
 		SearchExpr: "da.title",
@@ -72,11 +80,13 @@ var wantedSections = []wantedSection{
 	},
 	{
 		// dbbooks stores the author via dbauthor_id -> dbauthors, not a column.
-		Key:   "book",
-		Title: "Books",
-		Icon:  "fas fa-book",
+		Key:    "book",
+		Title:  "Books",
+		Icon:   "fas fa-book",
+		IsType: config.MediaTypeBook,
 		SelectExpr: "COALESCE((SELECT au.name FROM dbauthors au " +
-			"WHERE au.id = db.dbauthor_id) || ' - ', '') || db.title, b.id",
+			"WHERE au.id = db.dbauthor_id) || ' - ', '') || db.title, " +
+			wantedNextSearchExpr("b") + ", b.id",
 		From:       "FROM books b JOIN dbbooks db ON db.id = b.dbbook_id",
 		Where:      "b.missing = 1",
 		SearchExpr: "db.title",
@@ -85,14 +95,16 @@ var wantedSections = []wantedSection{
 	{
 		// Author comes from dbaudiobook_authors -> dbauthors. The title can be
 		// blank on shell records, so fall back to the linked print book's title.
-		Key:   "audiobook",
-		Title: "Audiobooks",
-		Icon:  "fas fa-headphones",
+		Key:    "audiobook",
+		Title:  "Audiobooks",
+		Icon:   "fas fa-headphones",
+		IsType: config.MediaTypeAudiobook,
 		SelectExpr: "COALESCE((SELECT au.name FROM dbaudiobook_authors aba " +
 			"JOIN dbauthors au ON au.id = aba.dbauthor_id " +
 			"WHERE aba.dbaudiobook_id = da.id LIMIT 1) || ' - ', '') || " +
 			"COALESCE(NULLIF(da.title, ''), " +
-			"(SELECT bk.title FROM dbbooks bk WHERE bk.id = da.dbbook_id), ''), ab.id",
+			"(SELECT bk.title FROM dbbooks bk WHERE bk.id = da.dbbook_id), ''), " +
+			wantedNextSearchExpr("ab") + ", ab.id",
 		From:       "FROM audiobooks ab JOIN dbaudiobooks da ON da.id = ab.dbaudiobook_id",
 		Where:      "ab.missing = 1",
 		SearchExpr: "da.title",
@@ -100,6 +112,12 @@ var wantedSections = []wantedSection{
 	},
 }
 
+// wantedNextSearchExpr selects the planned next missing search of the media
+// table alias as text, empty if the item is searched on the next cycle.
+func wantedNextSearchExpr(alias string) string {
+	return "COALESCE(CAST(" + alias + ".next_search AS TEXT), '')"
+}
+
 // findWantedSection looks up a section by its key.
 func findWantedSection(key string) (wantedSection, bool) {
 	for _, s := range wantedSections {
@@ -342,24 +360,60 @@ func renderWantedTab(ctx *gin.Context) {
 	total := int(database.Getdatarow[uint](false, cq, cargs...))
 
 	rq, rargs := sec.rowsQuery(search, page)
-	rows := database.GetrowsN[database.DbstaticOneStringOneUInt](
+	rows := database.GetrowsN[syncops.DbstaticTwoStringOneInt](
 		false,
 		uint(wantedPageSize),
 		rq,
 		rargs...)
 
+	var availability map[uint]string
+	if sec.IsType == config.MediaTypeMovie {
+		availability = wantedMovieAvailability(rows)
+	}
+
 	var buf strings.Builder
-	wantedResults(sec, search, page, total, rows).Render(&buf)
+	wantedResults(sec, search, page, total, rows, availability, getCSRFToken(ctx)).Render(&buf)
 	ctx.Header("Content-Type", "text/html; charset=utf-8")
 	ctx.String(http.StatusOK, buf.String())
 }
 
+// wantedMovieAvailability returns the reached availability level of the
+// movies of a page by their id.
+func wantedMovieAvailability(rows []syncops.DbstaticTwoStringOneInt) map[uint]string {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(rows))
+	for i := range rows {
+		args[i] = rows[i].Num
+	}
+
+	levels := database.GetrowsN[database.DbstaticOneStringOneUInt](
+		false,
+		uint(len(rows)),
+		"SELECT "+database.MovieAvailabilityStatusExpr("dm")+", m.id "+
+			"FROM movies m JOIN dbmovies dm ON dm.id = m.dbmovie_id "+
+			"WHERE m.id IN (?"+strings.Repeat(",?", len(rows)-1)+")",
+		args...)
+
+	availability := make(map[uint]string, len(levels))
+	for _, l := range levels {
+		availability[l.Num] = l.Str
+	}
+
+	return availability
+}
+
 // wantedResults renders the count summary, table and pagination for a tab page.
+// availability holds the reached availability per item id, nil if the media has none.
 func wantedResults(
 	sec wantedSection,
 	search string,
 	page, total int,
-	rows []database.DbstaticOneStringOneUInt,
+	rows []syncops.DbstaticTwoStringOneInt,
+	availability map[uint]string,
+	csrfToken string,
 ) gomponents.Node {
 	if total == 0 {
 		msg := "All " + strings.ToLower(sec.Title) + " are accounted for."
@@ -393,15 +447,43 @@ func wantedResults(
 		idStr := strconv.FormatUint(uint64(r.Num), 10)
 
 		trs = append(trs, html.Tr(
-			html.Td(gomponents.Text(r.Str)),
 			html.Td(
-				html.Class("text-end"),
+				gomponents.Text(r.Str1),
+				gomponents.If(
+					availability[r.Num] != "",
+					html.Span(
+						html.Class("badge bg-secondary ms-2"),
+						gomponents.Text(availabilityLabel(availability[r.Num])),
+					),
+				),
+			),
+			html.Td(
+				html.Class("text-nowrap small"),
+				html.ID("wnext-"+sec.Key+"-"+idStr),
+				wantedNextSearchCell(r.Str2),
+			),
+			html.Td(
+				html.Class("text-end text-nowrap"),
+				html.Button(
+					html.Type("button"),
+					html.Class("btn btn-sm btn-outline-secondary me-1"),
+					gomponents.Attr("title", "Reset the search backoff"),
+					gomponents.Attr("aria-label", "Reset search backoff for "+r.Str1),
+					hx.Post("/api/admin/wanted/resetbackoff?media="+sec.Key+"&id="+idStr),
+					hx.Headers(createHTMXHeaders(csrfToken)),
+					hx.Target("#wnext-"+sec.Key+"-"+idStr),
+					hx.Swap("innerHTML"),
+					html.I(
+						html.Class("fas fa-rotate-left"),
+						gomponents.Attr("aria-hidden", "true"),
+					),
+				),
 				html.Button(
 					html.Type("button"),
 					html.Class("btn btn-sm btn-outline-primary wanted-search-btn"),
 					html.Data("media", sec.Key),
 					html.Data("id", idStr),
-					gomponents.Attr("aria-label", "Search for "+r.Str),
+					gomponents.Attr("aria-label", "Search for "+r.Str1),
 					html.I(
 						html.Class("fas fa-magnifying-glass me-1"),
 						gomponents.Attr("aria-hidden", "true"),
@@ -425,10 +507,15 @@ func wantedResults(
 				html.THead(
 					html.Tr(
 						html.Th(gomponents.Attr("scope", "col"), gomponents.Text(sec.Title)),
+						html.Th(
+							gomponents.Attr("scope", "col"),
+							html.Style("width: 10rem;"),
+							gomponents.Text("Next Search"),
+						),
 						html.Th(
 							gomponents.Attr("scope", "col"),
 							html.Class("text-end"),
-							html.Style("width: 8rem;"),
+							html.Style("width: 11rem;"),
 							gomponents.Text("Action"),
 						),
 					),
@@ -440,6 +527,39 @@ func wantedResults(
 	)
 }
 
+// wantedNextSearchCell renders the planned next missing search of an item.
+func wantedNextSearchCell(nextsearch string) gomponents.Node {
+	if len(nextsearch) < 16 {
+		return html.Span(html.Class("text-muted"), gomponents.Text("Next cycle"))
+	}
+
+	return gomponents.Text(nextsearch[:16])
+}
+
+// renderWantedResetBackoff clears the search backoff of a wanted item so it
+// is searched on the next missing search cycle.
+func renderWantedResetBackoff(ctx *gin.Context) {
+	sec, ok := findWantedSection(ctx.Query("media"))
+	if !ok {
+		ctx.String(http.StatusBadRequest, "Unknown media type")
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Query("id"), 10, 0)
+	if err != nil || id == 0 {
+		ctx.String(http.StatusBadRequest, "Invalid id")
+		return
+	}
+
+	mediaid := uint(id)
+	database.ExecN(mtstrings.GetStringsMap(sec.IsType, logger.ResetMediaSearchBackoff), &mediaid)
+
+	var buf strings.Builder
+	wantedNextSearchCell("").Render(&buf)
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	ctx.String(http.StatusOK, buf.String())
+}
+
 // wantedPagination renders Prev/Next paging controls that swap the results region.
 func wantedPagination(key, search string, page, totalPages int) gomponents.Node {
 	if totalPages <= 1 {
@@ -746,6 +746,50 @@ func createNavbar(activeConfig bool, activeDatabase bool, activeManagement bool)
 								),
 							),
 						),
+						html.Li(html.Class("sidebar-item"),
+							html.A(
+								html.Class("sidebar-link"),
+								html.Href("/api/admin/rssitems"),
+								html.I(html.Class("align-middle fa-solid fa-rss")),
+								html.Span(
+									html.Class("align-middle"),
+									gomponents.Text("RSS Items"),
+								),
+							),
+						),
+						html.Li(html.Class("sidebar-item"),
+							html.A(
+								html.Class("sidebar-link"),
+								html.Href("/api/admin/recyclebin"),
+								html.I(html.Class("align-middle fa-solid fa-trash-can-arrow-up")),
+								html.Span(
+									html.Class("align-middle"),
+									gomponents.Text("Recycle Bin"),
+								),
+							),
+						),
+						html.Li(html.Class("sidebar-item"),
+							html.A(
+								html.Class("sidebar-link"),
+								html.Href("/api/admin/subtitles"),
+								html.I(html.Class("align-middle fa-solid fa-closed-captioning")),
+								html.Span(
+									html.Class("align-middle"),
+									gomponents.Text("Subtitles"),
+								),
+							),
+						),
+						html.Li(html.Class("sidebar-item"),
+							html.A(
+								html.Class("sidebar-link"),
+								html.Href("/api/admin/parserdivergences"),
+								html.I(html.Class("align-middle fa-solid fa-code-compare")),
+								html.Span(
+									html.Class("align-middle"),
+									gomponents.Text("Parser Divergences"),
+								),
+							),
+						),
 						html.Li(html.Class("sidebar-item"),
 							html.A(
 								html.Class("sidebar-link"),
@@ -251,6 +251,10 @@ func getFieldMapping(dbField string) FieldMapping {
 		return FieldMapping{"SearchSpecials", "Search Specials"}
 	case "ignore_runtime":
 		return FieldMapping{"IgnoreRuntime", "Ignore Runtime"}
+	case "search_delay":
+		return FieldMapping{"SearchDelay", "Search Delay"}
+	case "air_time":
+		return FieldMapping{"AirTime", "Air Time"}
 	case "dbmovie_id":
 		return FieldMapping{"DbmovieID", "Database Movie ID"}
 	case "dbserie_id":
@@ -315,6 +319,8 @@ func getFieldMapping(dbField string) FieldMapping {
 		return FieldMapping{"TwitterID", "Twitter ID"}
 	case "tvrage_id":
 		return FieldMapping{"TvrageID", "TVRage ID"}
+	case "tvmaze_id":
+		return FieldMapping{"TvmazeID", "TVmaze ID"}
 	case "siterating_count":
 		return FieldMapping{"SiteratingCount", "Site Rating Count"}
 	case "episode_title":
@@ -698,6 +704,7 @@ func getReferenceTable(fieldName string) string {
 		strings.EqualFold(fieldName, "freebase_m_id") ||
 		strings.EqualFold(fieldName, "freebase_id") ||
 		strings.EqualFold(fieldName, "tvrage_id") ||
+		strings.EqualFold(fieldName, "tvmaze_id") ||
 		strings.EqualFold(fieldName, "trakt_id") ||
 		strings.EqualFold(fieldName, "moviedb_id") ||
 		strings.EqualFold(fieldName, "facebook_id") ||
@@ -764,6 +771,8 @@ func buildCustomFilters(tableName string, ctx *gin.Context) (string, []any) {
 			idColumn = "dbmovie_titles.id"
 		case "dbserie_alternates":
 			idColumn = "dbserie_alternates.id"
+		case "dbserie_scene_mappings":
+			idColumn = "dbserie_scene_mappings.id"
 		case "dbserie_episodes":
 			idColumn = "dbserie_episodes.id"
 		case "movies":
@@ -1215,6 +1224,17 @@ func buildCustomFilters(tableName string, ctx *gin.Context) (string, []any) {
 			args = append(args, "%"+region+"%")
 		}
 
+	case "dbserie_scene_mappings":
+		if seriesName := getParamValue(ctx, "filter-series_name"); seriesName != "" {
+			conditions = append(conditions, "dbseries.seriename LIKE ?")
+			args = append(args, "%"+seriesName+"%")
+		}
+
+		if season := getParamValue(ctx, "filter-scene_season"); season != "" {
+			conditions = append(conditions, "dbserie_scene_mappings.scene_season = ?")
+			args = append(args, season)
+		}
+
 	case "dbserie_episodes":
 		if title := getParamValue(ctx, "filter-title"); title != "" {
 			conditions = append(conditions, "dbserie_episodes.title LIKE ?")
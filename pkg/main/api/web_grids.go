@@ -1468,6 +1468,26 @@ func renderCustomFilters(tableName string) gomponents.Node {
 				),
 			}
 
+		case "dbserie_scene_mappings":
+			filterFields = []gomponents.Node{
+				html.Div(
+					html.Label(html.Class("form-label"), gomponents.Text("Series Name")),
+					html.Input(
+						html.Class("form-control custom-filter"),
+						html.Type("text"),
+						html.ID(
+							"filter-series_name",
+						),
+						html.Placeholder("Filter by series name..."),
+					),
+				),
+				html.Div(
+					html.Label(html.Class("form-label"), gomponents.Text("Scene Season")),
+					html.Input(html.Class("form-control custom-filter"), html.Type("number"),
+						html.ID("filter-scene_season"), html.Placeholder("Season...")),
+				),
+			}
+
 		case "dbserie_episodes":
 			filterFields = []gomponents.Node{
 				html.Div(
@@ -995,6 +995,8 @@ func apiAdminTableDataJson(ctx *gin.Context) {
 		countTable = "dbmovie_titles"
 	case "dbserie_alternates":
 		countTable = "dbserie_alternates"
+	case "dbserie_scene_mappings":
+		countTable = "dbserie_scene_mappings"
 	case "dbserie_episodes":
 		countTable = "dbserie_episodes"
 	case "movies":
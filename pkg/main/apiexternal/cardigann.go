@@ -0,0 +1,233 @@
+package apiexternal
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/cardigann"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/providers"
+)
+
+// IsCardigann reports whether the indexer searches a torrent site using a
+// YAML definition instead of a newznab api.
+func IsCardigann(ind *config.IndexersConfig) bool {
+	return strings.EqualFold(ind.IndexerType, "cardigann")
+}
+
+// GetIndexerClient returns the search client of a newznab or cardigann
+// indexer, nil if it could not be created.
+func GetIndexerClient(ind *config.IndexersConfig) *base.BaseClient {
+	if IsCardigann(ind) {
+		if c := Getcardigannclient(ind); c != nil {
+			return c.BaseClient
+		}
+
+		return nil
+	}
+
+	if c := Getnewznabclient(ind); c != nil {
+		return c.BaseClient
+	}
+
+	return nil
+}
+
+// Getcardigannclient returns the provider of a cardigann indexer, creating it
+// from its definition if needed. Returns nil if the definition can not be loaded.
+func Getcardigannclient(indcfg *config.IndexersConfig) *cardigann.Provider {
+	provider := providers.GetCardigann(indcfg.Name)
+	if provider == nil {
+		newCardigann(indcfg)
+
+		provider = providers.GetCardigann(indcfg.Name)
+	}
+
+	return provider
+}
+
+// cardigannDefinitionPath returns the definition file of the indexer. Plain
+// names are looked up as <name>.yml in indexer_definitions_path.
+func cardigannDefinitionPath(indcfg *config.IndexersConfig) string {
+	def := indcfg.Definition
+	if strings.ContainsAny(def, `/\`) || strings.HasSuffix(def, ".yml") ||
+		strings.HasSuffix(def, ".yaml") {
+		return def
+	}
+
+	dir := config.GetSettingsGeneral().IndexerDefinitionsPath
+	if dir == "" {
+		dir = filepath.Join(config.GetConfigDir(), "indexers")
+	}
+
+	return filepath.Join(dir, def+".yml")
+}
+
+// newCardigann loads the definition of the indexer and registers its provider.
+func newCardigann(idxCfg *config.IndexersConfig) {
+	path := cardigannDefinitionPath(idxCfg)
+
+	def, err := cardigann.LoadDefinition(path)
+	if err != nil {
+		logger.Logtype("error", 0).
+			Str(logger.StrIndexer, idxCfg.Name).
+			Str(logger.StrFile, path).
+			Err(err).
+			Msg("Indexer definition could not be loaded")
+
+		return
+	}
+
+	provider, err := cardigann.NewProvider(cardigann.ProviderConfig{
+		IndexerName:       idxCfg.Name,
+		BaseURL:           idxCfg.URL,
+		Definition:        def,
+		Username:          idxCfg.Username,
+		Password:          idxCfg.Password,
+		Cookie:            idxCfg.Cookie,
+		TimeoutSeconds:    idxCfg.TimeoutSeconds,
+		MaxEntries:        idxCfg.MaxEntries,
+		LimiterCalls:      idxCfg.Limitercalls,
+		LimiterSeconds:    idxCfg.Limiterseconds,
+		LimiterCallsDaily: idxCfg.LimitercallsDaily,
+		DisableTLSVerify:  idxCfg.DisableTLSVerify,
+		Proxy:             ProxyConfig(idxCfg.ProxyURL, idxCfg.ProxyUsername, idxCfg.ProxyPassword),
+	})
+	if err != nil {
+		logger.Logtype("error", 0).
+			Str(logger.StrIndexer, idxCfg.Name).
+			Err(err).
+			Msg("Indexer definition could not be used")
+
+		return
+	}
+
+	providers.SetCardigann(idxCfg.Name, provider)
+
+	// The caps of the definition decide between id and title searches
+	if err := storeIndexerCaps(idxCfg.Name, def.Capabilities()); err != nil {
+		logger.Logtype("error", 0).
+			Str(logger.StrIndexer, idxCfg.Name).
+			Err(err).
+			Msg("Indexer caps could not be stored")
+	}
+}
+
+// querycardigann runs a search of a cardigann indexer through the response
+// cache. The categories of the quality are mapped to site categories by the
+// definition.
+func querycardigann(
+	ind *config.IndexersConfig,
+	qual *config.QualityConfig,
+	indexerid int,
+	query cardigann.Query,
+	results *NzbSlice,
+) (bool, string, error) {
+	c := Getcardigannclient(ind)
+	if c == nil {
+		return false, "", errNoClientReturned
+	}
+
+	query.Categories = cardigannCategories(qual, indexerid)
+
+	return searchcached(ind, qual, query.Key(), results, func(fetched *NzbSlice) (bool, string, error) {
+		arr, err := c.Search(context.Background(), query, ind, qual)
+		countIndexerQuery(ind, nil)
+
+		if err != nil {
+			return false, "", err
+		}
+
+		broke, firstid := addcardigannresults(arr, "", fetched)
+
+		return broke, firstid, nil
+	})
+}
+
+// querycardigannRSS lists the latest releases of a cardigann indexer - a
+// search without keywords. Adding stops after the release tillid.
+func querycardigannRSS(
+	ind *config.IndexersConfig,
+	qual *config.QualityConfig,
+	indexerid int,
+	tillid string,
+	results *NzbSlice,
+) (bool, string, error) {
+	c := Getcardigannclient(ind)
+	if c == nil {
+		return false, "", errNoClientReturned
+	}
+
+	arr, err := c.Search(context.Background(), cardigann.Query{
+		Mode:       "search",
+		Categories: cardigannCategories(qual, indexerid),
+	}, ind, qual)
+	countIndexerQuery(ind, nil)
+
+	if err != nil {
+		return false, "", err
+	}
+
+	broke, firstid := addcardigannresults(arr, tillid, results)
+
+	return broke, firstid, nil
+}
+
+// cardigannIDQuery builds an id search of the mode for the newznab id param.
+func cardigannIDQuery(mode, param, id string) cardigann.Query {
+	query := cardigann.Query{Mode: mode}
+
+	switch param {
+	case "imdbid":
+		query.IMDBID = id
+	case "tmdbid":
+		query.TMDBID = id
+	case "tvdbid":
+		query.TVDBID = id
+	case "rid":
+		query.TVRageID = id
+	case "tvmazeid":
+		query.TVMazeID = id
+	case "traktid":
+		query.TraktID = id
+	}
+
+	return query
+}
+
+// cardigannCategories returns the newznab categories of the indexer in the quality.
+func cardigannCategories(qual *config.QualityConfig, indexerid int) []string {
+	if indexerid == -1 || qual.Indexer[indexerid].CategoriesIndexer == "" {
+		return nil
+	}
+
+	return strings.Split(qual.Indexer[indexerid].CategoriesIndexer, ",")
+}
+
+// addcardigannresults adds the releases to results until the release tillid.
+// Returns whether tillid was reached and the id of the first release.
+func addcardigannresults(
+	arr []apiexternal_v2.Nzbwithprio,
+	tillid string,
+	results *NzbSlice,
+) (bool, string) {
+	var firstid string
+
+	for idx := range arr {
+		if firstid == "" {
+			firstid = arr[idx].NZB.ID
+		}
+
+		results.Add(&arr[idx])
+
+		if tillid != "" && tillid == arr[idx].NZB.ID {
+			return true, firstid
+		}
+	}
+
+	return false, firstid
+}
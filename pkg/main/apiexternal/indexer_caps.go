@@ -0,0 +1,197 @@
+package apiexternal
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+// ErrNoIDSearch is returned by ID searches if the capabilities of the indexer
+// support none of the IDs known for the media - a title search is needed.
+var ErrNoIDSearch = errors.New("indexer supports no id search for media")
+
+// RefreshIndexerCaps fetches the capabilities of the indexer and stores them
+// in the database.
+func RefreshIndexerCaps(cfgind *config.IndexersConfig) error {
+	if IsCardigann(cfgind) {
+		c := Getcardigannclient(cfgind)
+		if c == nil {
+			return errNoClientReturned
+		}
+
+		return storeIndexerCaps(cfgind.Name, c.Definition().Capabilities())
+	}
+
+	c := Getnewznabclient(cfgind)
+	if c == nil {
+		return errNoClientReturned
+	}
+
+	caps, err := c.GetCapabilities(context.Background())
+	if err != nil {
+		return err
+	}
+
+	c.Capabilities = caps
+
+	return storeIndexerCaps(cfgind.Name, caps)
+}
+
+// RefreshAllIndexerCaps refreshes the stored capabilities of all enabled
+// newznab and cardigann indexers.
+func RefreshAllIndexerCaps() {
+	config.RangeSettingsIndexer(func(_ string, cfgind *config.IndexersConfig) {
+		if !cfgind.Enabled ||
+			(!strings.EqualFold(cfgind.IndexerType, "newznab") && !IsCardigann(cfgind)) {
+			return
+		}
+
+		if err := RefreshIndexerCaps(cfgind); err != nil {
+			logger.Logtype("error", 0).
+				Str(logger.StrIndexer, cfgind.Name).
+				Err(err).
+				Msg("Indexer caps refresh failed")
+		}
+	})
+}
+
+// storeIndexerCaps converts caps reported by an indexer to their database form
+// and saves them.
+func storeIndexerCaps(indexer string, caps *apiexternal_v2.IndexerCapabilities) error {
+	if caps == nil {
+		return nil
+	}
+
+	categories := make([]string, 0, len(caps.Categories)*3)
+
+	var collect func(cats []apiexternal_v2.IndexerCategory)
+
+	collect = func(cats []apiexternal_v2.IndexerCategory) {
+		for idx := range cats {
+			categories = append(categories, cats[idx].ID)
+			collect(cats[idx].Subcategories)
+		}
+	}
+	collect(caps.Categories)
+
+	return database.SaveIndexerCaps(&database.IndexerCaps{
+		Indexer:           indexer,
+		ServerTitle:       caps.ServerTitle,
+		SearchModes:       logger.JoinStringsSep(caps.SearchModes, ","),
+		SearchParams:      logger.JoinStringsSep(caps.SearchParams["search"], ","),
+		TvSearchParams:    logger.JoinStringsSep(caps.SearchParams["tvsearch"], ","),
+		MovieSearchParams: logger.JoinStringsSep(caps.SearchParams["movie"], ","),
+		Categories:        logger.JoinStringsSep(categories, ","),
+		MaxResults:        caps.Limits.MaxResults,
+		DefaultLimit:      caps.Limits.DefaultLimit,
+	})
+}
+
+// QueryNewznabMovieID queries the Newznab indexer for movies by an ID
+// parameter other than imdbid (tmdbid, traktid) supported by the indexer, and
+// stores the results in the given slice.
+func QueryNewznabMovieID(
+	cfgind *config.IndexersConfig,
+	qual *config.QualityConfig,
+	param, id string,
+	indexerid int,
+	results *NzbSlice,
+) (bool, string, error) {
+	if id == "" || id == "0" {
+		return false, "", logger.ErrNoID
+	}
+
+	if indexerid == -1 {
+		return false, "", errQualityConfig
+	}
+
+	if IsCardigann(cfgind) {
+		return querycardigann(cfgind, qual, indexerid,
+			cardigannIDQuery("movie", param, id), results)
+	}
+
+	b := logger.PlAddBuffer.Get()
+	defer logger.PlAddBuffer.Put(b)
+
+	b.WriteString("&t=movie&")
+	b.WriteString(param)
+	b.WriteString("=")
+	b.WriteString(id)
+
+	if cfgind.MaxEntries != 0 {
+		b.WriteString(bqlimit)
+		b.WriteString(cfgind.MaxEntriesStr)
+	}
+
+	return processurlcached(
+		cfgind,
+		qual,
+		buildURLNew(false, indexerid, qual, cfgind, b.Bytes()),
+		results,
+		true,
+	)
+}
+
+// QueryNewznabTvID queries the Newznab indexer for TV episodes by an ID
+// parameter other than tvdbid (imdbid, tmdbid, tvmazeid, traktid, rid)
+// supported by the indexer, and stores the results in the given slice.
+func QueryNewznabTvID(
+	cfgind *config.IndexersConfig,
+	qual *config.QualityConfig,
+	param, id string,
+	indexerid int,
+	season, episode string,
+	results *NzbSlice,
+) (bool, string, error) {
+	if id == "" || id == "0" {
+		return false, "", logger.ErrNoID
+	}
+
+	if indexerid == -1 {
+		return false, "", errQualityConfig
+	}
+
+	if IsCardigann(cfgind) {
+		query := cardigannIDQuery("tvsearch", param, id)
+		query.Season = season
+		query.Ep = episode
+
+		return querycardigann(cfgind, qual, indexerid, query, results)
+	}
+
+	b := logger.PlAddBuffer.Get()
+	defer logger.PlAddBuffer.Put(b)
+
+	b.WriteString("&t=tvsearch&")
+	b.WriteString(param)
+	b.WriteString("=")
+	b.WriteString(id)
+
+	if cfgind.MaxEntries != 0 {
+		b.WriteString(bqlimit)
+		b.WriteUInt16(cfgind.MaxEntries)
+	}
+
+	if season != "" {
+		b.WriteString("&season=")
+		b.WriteString(season)
+	}
+
+	if episode != "" {
+		b.WriteString("&ep=")
+		b.WriteString(episode)
+	}
+
+	return processurlcached(
+		cfgind,
+		qual,
+		buildURLNew(false, indexerid, qual, cfgind, b.Bytes()),
+		results,
+		true,
+	)
+}
@@ -0,0 +1,216 @@
+package apiexternal
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+// indexerLimitWindow is the window of counted requests and grabs if the
+// indexer does not report its reset time.
+const indexerLimitWindow = 24 * time.Hour
+
+// indexerLimitsFlushDelay is the time counted usage is collected before it is
+// written to the database.
+const indexerLimitsFlushDelay = 30 * time.Second
+
+// indexerLimits holds the usage of the indexers in memory. Changed indexers
+// are saved together by FlushIndexerLimits, outside of the lock.
+var indexerLimits = struct {
+	entries map[string]*database.IndexerLimits
+	dirty   map[string]struct{}
+	flush   *time.Timer
+	mu      sync.Mutex
+}{
+	entries: make(map[string]*database.IndexerLimits, 10),
+	dirty:   make(map[string]struct{}, 10),
+}
+
+// IndexerQuota is the remaining usage of an indexer in the current window.
+// A max of 0 means no limit is known.
+type IndexerQuota struct {
+	APIReset      time.Time
+	GrabReset     time.Time
+	APIRemaining  int
+	APIMax        int
+	GrabRemaining int
+	GrabMax       int
+}
+
+// GetIndexerQuota returns the remaining api requests and grabs of the
+// indexer. Without a limit reported by the indexer limiter_calls_daily is used
+// for api requests.
+func GetIndexerQuota(ind *config.IndexersConfig) IndexerQuota {
+	indexerLimits.mu.Lock()
+	limits := *loadIndexerLimits(ind.Name)
+	indexerLimits.mu.Unlock()
+
+	now := time.Now()
+
+	quota := IndexerQuota{
+		APIMax:  limits.APIMax,
+		GrabMax: limits.GrabMax,
+	}
+
+	if quota.APIMax == 0 {
+		quota.APIMax = ind.LimitercallsDaily
+	}
+
+	quota.APIRemaining, quota.APIReset = remainingInWindow(
+		quota.APIMax, limits.APICurrent, limits.APIReset, now)
+	quota.GrabRemaining, quota.GrabReset = remainingInWindow(
+		quota.GrabMax, limits.GrabCurrent, limits.GrabReset, now)
+
+	return quota
+}
+
+// IndexerAPIQuotaReached reports whether the next request to the indexer
+// would exceed its api limit.
+func IndexerAPIQuotaReached(ind *config.IndexersConfig) bool {
+	quota := GetIndexerQuota(ind)
+
+	return quota.APIMax > 0 && quota.APIRemaining <= 0
+}
+
+// IndexerGrabQuotaReached reports whether the next grab from the indexer
+// would exceed its grab limit.
+func IndexerGrabQuotaReached(ind *config.IndexersConfig) bool {
+	if ind == nil {
+		return false
+	}
+
+	quota := GetIndexerQuota(ind)
+
+	return quota.GrabMax > 0 && quota.GrabRemaining <= 0
+}
+
+// CountIndexerGrab counts a release sent to a downloader against the grab
+// limit of its indexer.
+func CountIndexerGrab(ind *config.IndexersConfig) {
+	if ind == nil {
+		return
+	}
+
+	updateIndexerLimits(ind.Name, func(limits *database.IndexerLimits, now time.Time) {
+		limits.GrabCurrent, limits.GrabReset = countInWindow(
+			limits.GrabCurrent, limits.GrabReset, now)
+	})
+}
+
+// countIndexerQuery counts an api request of the indexer. Usage reported with
+// the response replaces the counted one.
+func countIndexerQuery(ind *config.IndexersConfig, reported *apiexternal_v2.IndexerQuota) {
+	updateIndexerLimits(ind.Name, func(limits *database.IndexerLimits, now time.Time) {
+		limits.APICurrent, limits.APIReset = countInWindow(
+			limits.APICurrent, limits.APIReset, now)
+
+		if reported == nil {
+			return
+		}
+
+		if reported.APIMax != 0 {
+			limits.APICurrent = reported.APICurrent
+			limits.APIMax = reported.APIMax
+
+			if !reported.APIReset.IsZero() {
+				limits.APIReset = sql.NullTime{Time: reported.APIReset, Valid: true}
+			}
+		}
+
+		if reported.GrabMax != 0 {
+			limits.GrabCurrent = reported.GrabCurrent
+			limits.GrabMax = reported.GrabMax
+
+			if !reported.GrabReset.IsZero() {
+				limits.GrabReset = sql.NullTime{Time: reported.GrabReset, Valid: true}
+			} else if !limits.GrabReset.Valid || now.After(limits.GrabReset.Time) {
+				limits.GrabReset = sql.NullTime{Time: now.Add(indexerLimitWindow), Valid: true}
+			}
+		}
+	})
+}
+
+// updateIndexerLimits applies fn to the usage of the indexer and schedules
+// saving it.
+func updateIndexerLimits(indexer string, fn func(*database.IndexerLimits, time.Time)) {
+	indexerLimits.mu.Lock()
+	defer indexerLimits.mu.Unlock()
+
+	fn(loadIndexerLimits(indexer), time.Now())
+
+	indexerLimits.dirty[strings.ToLower(indexer)] = struct{}{}
+	if indexerLimits.flush == nil {
+		indexerLimits.flush = time.AfterFunc(indexerLimitsFlushDelay, FlushIndexerLimits)
+	}
+}
+
+// loadIndexerLimits returns the usage of the indexer kept in memory, reading
+// it from the database on first use. indexerLimits.mu must be held.
+func loadIndexerLimits(indexer string) *database.IndexerLimits {
+	key := strings.ToLower(indexer)
+	if limits, ok := indexerLimits.entries[key]; ok {
+		return limits
+	}
+
+	limits := database.GetIndexerLimits(indexer)
+	indexerLimits.entries[key] = &limits
+
+	return &limits
+}
+
+// FlushIndexerLimits saves the usage of all indexers changed since the last
+// flush.
+func FlushIndexerLimits() {
+	indexerLimits.mu.Lock()
+
+	if indexerLimits.flush != nil {
+		indexerLimits.flush.Stop()
+		indexerLimits.flush = nil
+	}
+
+	changed := make([]database.IndexerLimits, 0, len(indexerLimits.dirty))
+	for key := range indexerLimits.dirty {
+		changed = append(changed, *indexerLimits.entries[key])
+		delete(indexerLimits.dirty, key)
+	}
+
+	indexerLimits.mu.Unlock()
+
+	for idx := range changed {
+		if err := database.SaveIndexerLimits(&changed[idx]); err != nil {
+			logger.Logtype("error", 1).
+				Str(logger.StrIndexer, changed[idx].Indexer).
+				Err(err).
+				Msg("Indexer limits could not be saved")
+		}
+	}
+}
+
+// countInWindow adds one to current, starting a new window if the reset passed.
+func countInWindow(current int, reset sql.NullTime, now time.Time) (int, sql.NullTime) {
+	if !reset.Valid || now.After(reset.Time) {
+		return 1, sql.NullTime{Time: now.Add(indexerLimitWindow), Valid: true}
+	}
+
+	return current + 1, reset
+}
+
+// remainingInWindow returns the remaining usage of a limit and the reset of
+// the window. A passed reset frees the whole limit.
+func remainingInWindow(
+	limit, current int,
+	reset sql.NullTime,
+	now time.Time,
+) (int, time.Time) {
+	if !reset.Valid || now.After(reset.Time) {
+		return limit, time.Time{}
+	}
+
+	return max(limit-current, 0), reset.Time
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/cardigann"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/newznab"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
@@ -71,6 +73,15 @@ const (
 
 var errQualityConfig = errors.New("error getting quality config")
 
+// writeMaxEntries appends the limit parameter with the max entries of the
+// indexer to the query, nothing if the indexer has no limit configured.
+func writeMaxEntries(b *logger.AddBuffer, cfgind *config.IndexersConfig) {
+	if cfgind.MaxEntries != 0 {
+		b.WriteString(bqlimit)
+		b.WriteString(cfgind.MaxEntriesStr)
+	}
+}
+
 // buildURLNew constructs the API URL to query the Newznab indexer based
 // on the given parameters. It handles building the base URL, API key,
 // custom URLs, categories, quality settings, output format, etc.
@@ -89,20 +100,6 @@ func buildURLNew(
 		bld.WriteString(row.Customrssurl)
 	case !rss && row.Customurl != "":
 		bld.WriteString(row.Customurl)
-	case row.Customapi != "":
-		bld.WriteString(row.URL)
-
-		if rss {
-			bld.WriteString(brss)
-		} else {
-			bld.WriteString(bapi)
-		}
-
-		bld.WriteByte('?')
-		bld.WriteString(row.Customapi)
-		bld.WriteByte('=')
-		bld.WriteString(row.Apikey)
-
 	default:
 		bld.WriteString(row.URL)
 
@@ -180,6 +177,13 @@ func processurl(
 	}
 
 	result, err := c.ExecuteRequest(context.Background(), urlv, tillid, ind, qual)
+
+	if quota, ok := c.TakeQuota(); ok {
+		countIndexerQuery(ind, &quota)
+	} else {
+		countIndexerQuery(ind, nil)
+	}
+
 	if err != nil && !errors.Is(err, newznab.ErrBroke) {
 		return false, "", err
 	}
@@ -510,7 +514,7 @@ func processurl(
 // Uses non-blocking check to avoid waiting during rate limit grace periods.
 // Returns true if under limit, false if over limit.
 func NewznabCheckLimiter(cfgindexer *config.IndexersConfig) bool {
-	c := Getnewznabclient(cfgindexer)
+	c := GetIndexerClient(cfgindexer)
 	if c == nil {
 		return true
 	}
@@ -533,6 +537,10 @@ func QueryNewznabMovieImdb(
 		return false, "", logger.ErrNoID
 	}
 
+	if IsCardigann(cfgind) {
+		return querycardigann(cfgind, qual, indexerid, cardigannIDQuery("movie", "imdbid", imdbid), results)
+	}
+
 	c := Getnewznabclient(cfgind)
 	if c == nil {
 		return false, "", errNoClientReturned
@@ -554,16 +562,12 @@ func QueryNewznabMovieImdb(
 	b.WriteString(bmovieimdb)
 	b.WriteString(imdbid)
 
-	if cfgind.MaxEntries != 0 {
-		b.WriteString(bqlimit)
-		b.WriteString(cfgind.MaxEntriesStr)
-	}
+	writeMaxEntries(b, cfgind)
 
-	return processurl(
+	return processurlcached(
 		cfgind,
 		qual,
 		buildURLNew(false, indexerid, qual, cfgind, b.Bytes()),
-		"",
 		results,
 		true,
 	)
@@ -572,7 +576,12 @@ func QueryNewznabMovieImdb(
 // Getnewznabclient returns a Client for the given IndexersConfig.
 // It checks if a client already exists for the given URL,
 // and returns it if found. Otherwise creates a new client and caches it.
+// Cardigann indexers have no newznab client and return nil.
 func Getnewznabclient(indcfg *config.IndexersConfig) *newznab.Provider {
+	if IsCardigann(indcfg) {
+		return nil
+	}
+
 	provider := providers.GetIndexer(indcfg.Name)
 	if provider == nil {
 		newNewznab(true, indcfg)
@@ -598,7 +607,21 @@ func DownloadNZB(
 		filename = filepath.Base(urlv)
 	}
 
-	return Getnewznabclient(idxcfg).Download(context.Background(), urlv, targetpath, filename)
+	if IsCardigann(idxcfg) {
+		c := Getcardigannclient(idxcfg)
+		if c == nil {
+			return errNoClientReturned
+		}
+
+		return c.Download(context.Background(), urlv, targetpath, filename)
+	}
+
+	c := Getnewznabclient(idxcfg)
+	if c == nil {
+		return errNoClientReturned
+	}
+
+	return c.Download(context.Background(), urlv, targetpath, filename)
 
 	// return ProcessHTTP(
 	// 	&Getnewznabclient(idxcfg).Client,
@@ -642,6 +665,19 @@ func QueryNewznabTvTvdb(
 		return false, "", errQualityConfig
 	}
 
+	if IsCardigann(cfgind) {
+		query := cardigannIDQuery("tvsearch", "tvdbid", strconv.Itoa(tvdbid))
+		if useseason {
+			query.Season = season
+		}
+
+		if useepisode {
+			query.Ep = episode
+		}
+
+		return querycardigann(cfgind, qual, indexerid, query, results)
+	}
+
 	b := logger.PlAddBuffer.Get()
 	defer logger.PlAddBuffer.Put(b)
 
@@ -651,9 +687,8 @@ func QueryNewznabTvTvdb(
 	if !useepisode || !useseason {
 		b.WriteString(bqlimit)
 		b.WriteString("100")
-	} else if cfgind.MaxEntries != 0 {
-		b.WriteString(bqlimit)
-		b.WriteUInt16(cfgind.MaxEntries)
+	} else {
+		writeMaxEntries(b, cfgind)
 	}
 
 	if useseason && season != "" {
@@ -666,11 +701,10 @@ func QueryNewznabTvTvdb(
 		b.WriteString(episode)
 	}
 
-	return processurl(
+	return processurlcached(
 		cfgind,
 		qual,
 		buildURLNew(false, indexerid, qual, cfgind, b.Bytes()),
-		"",
 		results,
 		true,
 	)
@@ -723,6 +757,18 @@ func QueryNewznabQuery(
 		return false, "", nil
 	}
 
+	if IsCardigann(cfgind) {
+		keywords := getaddstr(cfgp, title, e)
+		if cfgind.Addquotesfortitlequery {
+			keywords = "\"" + keywords + "\""
+		}
+
+		return querycardigann(cfgind, qual, indexerid, cardigann.Query{
+			Mode:     "search",
+			Keywords: keywords,
+		}, results)
+	}
+
 	b := logger.PlAddBuffer.Get()
 	defer logger.PlAddBuffer.Put(b)
 
@@ -740,16 +786,12 @@ func QueryNewznabQuery(
 		b.WriteString(bquotes)
 	}
 
-	if cfgind.MaxEntries != 0 {
-		b.WriteString(bqlimit)
-		b.WriteUInt16(cfgind.MaxEntries)
-	}
+	writeMaxEntries(b, cfgind)
 
-	return processurl(
+	return processurlcached(
 		cfgind,
 		qual,
 		buildURLNew(false, indexerid, qual, cfgind, b.Bytes()),
-		"",
 		results,
 		false,
 	)
@@ -765,6 +807,10 @@ func QueryNewznabRSS(
 	maxitems, indexerid int,
 	results *NzbSlice,
 ) (bool, string, error) {
+	if IsCardigann(ind) {
+		return querycardigannRSS(ind, qual, indexerid, "", results)
+	}
+
 	b := logger.PlAddBuffer.Get()
 	defer logger.PlAddBuffer.Put(b)
 
@@ -798,6 +844,11 @@ func QueryNewznabRSSLastCustom(
 		return "", errQualityConfig
 	}
 
+	if IsCardigann(ind) {
+		_, firstid, err := querycardigannRSS(ind, qual, indexerid, tillid, results)
+		return firstid, err
+	}
+
 	if ind.MaxEntries == 0 {
 		ind.MaxEntries = 100
 		ind.MaxEntriesStr = "100"
@@ -880,7 +931,6 @@ func newNewznab(_ bool, idxCfg *config.IndexersConfig) {
 		Categories:        []string{}, // Will be set per search from quality profiles
 		OutputAsJSON:      idxCfg.OutputAsJSON,
 		TimeoutSeconds:    idxCfg.TimeoutSeconds,
-		CustomAPI:         idxCfg.Customapi,
 		CustomURL:         idxCfg.Customurl,
 		CustomRSSURL:      idxCfg.Customrssurl,
 		CustomRSSCategory: idxCfg.Customrsscategory,
@@ -891,11 +941,20 @@ func newNewznab(_ bool, idxCfg *config.IndexersConfig) {
 		LimiterSeconds:    idxCfg.Limiterseconds,
 		LimiterCallsDaily: idxCfg.LimitercallsDaily,
 		Enabled:           idxCfg.Enabled,
+		Proxy:             ProxyConfig(idxCfg.ProxyURL, idxCfg.ProxyUsername, idxCfg.ProxyPassword),
 	})
 
 	// Store in direct providers registry
 	providers.SetIndexer(idxCfg.Name, provider)
 
+	// Persist the caps fetched on creation so the searcher can pick its strategy
+	if err := storeIndexerCaps(idxCfg.Name, provider.Capabilities); err != nil {
+		logger.Logtype("error", 0).
+			Str(logger.StrIndexer, idxCfg.Name).
+			Err(err).
+			Msg("Indexer caps could not be stored")
+	}
+
 	// var limiter *slidingwindow.Limiter
 	// if row.LimitercallsDaily != 0 {
 	// 	limiter = slidingwindow.NewLimiter(24*time.Hour, int64(row.LimitercallsDaily))
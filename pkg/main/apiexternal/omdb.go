@@ -85,6 +85,7 @@ func NewOmdbClient(
 		EnableStats:               true,
 		UserAgent:                 config.GetSettingsGeneral().UserAgent,
 		DisableTLSVerify:          disabletls,
+		Proxy:                     MetadataProxy(),
 	}
 	if provider := omdb.NewProviderWithConfig(omdbConfig); provider != nil {
 		providers.SetOMDB(provider)
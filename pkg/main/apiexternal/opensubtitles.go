@@ -0,0 +1,54 @@
+package apiexternal
+
+import (
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/opensubtitles"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/providers"
+)
+
+// NewOpenSubtitlesClient creates the OpenSubtitles subtitle provider and
+// registers it for the subtitle search. Without an API key the provider is
+// removed. It sets sane defaults for the rate limiting if 0 values are passed.
+func NewOpenSubtitlesClient(
+	apikey, username, password, baseurl string,
+	seconds uint8,
+	calls int,
+	timeoutseconds uint16,
+) {
+	if apikey == "" {
+		providers.SetSubtitleProvider("opensubtitles", nil)
+		return
+	}
+
+	if seconds == 0 {
+		seconds = 1
+	}
+
+	if calls == 0 {
+		calls = 5
+	}
+
+	if timeoutseconds == 0 {
+		timeoutseconds = 30
+	}
+
+	osConfig := base.ClientConfig{
+		BaseURL:                   baseurl,
+		Timeout:                   time.Duration(timeoutseconds) * time.Second,
+		APIKey:                    apikey,
+		RateLimitCalls:            calls,
+		RateLimitSeconds:          int(seconds),
+		CircuitBreakerThreshold:   5,
+		CircuitBreakerTimeout:     60 * time.Second,
+		CircuitBreakerHalfOpenMax: 2,
+		EnableStats:               true,
+		UserAgent:                 config.GetSettingsGeneral().UserAgent,
+		Proxy:                     MetadataProxy(),
+	}
+	if provider := opensubtitles.NewProviderWithConfig(osConfig, username, password); provider != nil {
+		providers.SetSubtitleProvider("opensubtitles", provider)
+	}
+}
@@ -0,0 +1,50 @@
+package apiexternal
+
+import (
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+// ApplyDefaultProxy sets the global proxy of the general settings as default
+// proxy of all clients. An invalid proxy url is logged and disables the
+// default proxy.
+func ApplyDefaultProxy() {
+	general := config.GetSettingsGeneral()
+	if err := base.SetDefaultProxy(base.ProxyConfig{
+		URL:      general.ProxyURL,
+		Username: general.ProxyUsername,
+		Password: general.ProxyPassword,
+		Bypass:   general.ProxyBypass,
+	}); err != nil {
+		logger.Logtype(logger.StatusError, 0).
+			Str(logger.StrURL, general.ProxyURL).
+			Err(err).
+			Msg("invalid global proxy")
+	}
+}
+
+// ProxyConfig returns the proxy config of an indexer, download client or list.
+// An empty url uses the global proxy.
+func ProxyConfig(proxyurl, username, password string) base.ProxyConfig {
+	return base.ProxyConfig{URL: proxyurl, Username: username, Password: password}
+}
+
+// MetadataProxy returns the proxy config of the metadata providers. An empty
+// metadata_proxy_url uses the global proxy, other urls use the credentials of
+// the global proxy.
+func MetadataProxy() base.ProxyConfig {
+	general := config.GetSettingsGeneral()
+	if general.MetadataProxyURL == "" ||
+		strings.EqualFold(general.MetadataProxyURL, base.ProxyDirect) {
+		return base.ProxyConfig{URL: general.MetadataProxyURL}
+	}
+
+	return base.ProxyConfig{
+		URL:      general.MetadataProxyURL,
+		Username: general.ProxyUsername,
+		Password: general.ProxyPassword,
+	}
+}
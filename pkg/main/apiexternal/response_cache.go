@@ -0,0 +1,192 @@
+package apiexternal
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+)
+
+// responseCacheEntry holds the results of one search request until expires.
+type responseCacheEntry struct {
+	expires time.Time
+	results []apiexternal_v2.Nzbwithprio
+	broke   bool
+}
+
+// responseCacheCounter counts cache hits and misses of an indexer.
+type responseCacheCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+var (
+	// responseCacheMu protects responseCache.
+	responseCacheMu sync.Mutex
+	// responseCache keeps search results per indexer, quality and normalized url.
+	responseCache = make(map[string]responseCacheEntry)
+	// responseCacheStats keeps the hit and miss counters per lowercased indexer name.
+	responseCacheStats sync.Map
+)
+
+// responseCacheKey builds the cache key of a search request. The url (or the
+// query key of definition indexers) already contains the query, the ids and
+// the categories of the search.
+func responseCacheKey(ind *config.IndexersConfig, qual *config.QualityConfig, urlv string) string {
+	var qualname string
+	if qual != nil {
+		qualname = qual.Name
+	}
+
+	return strings.ToLower(ind.Name) + "|" + strings.ToLower(qualname) + "|" +
+		normalizeCacheQuery(ind, urlv)
+}
+
+// normalizeCacheQuery removes the api key of the indexer from the search url
+// and sorts its parameters, so the cache key holds no credentials and does
+// not depend on the parameter order. Query keys which are no url are kept.
+func normalizeCacheQuery(ind *config.IndexersConfig, urlv string) string {
+	u, err := url.Parse(urlv)
+	if err != nil || u.RawQuery == "" {
+		return strings.ToLower(urlv)
+	}
+
+	query := u.Query()
+	for key, values := range query {
+		if ind.Apikey != "" && slices.Contains(values, ind.Apikey) {
+			query.Del(key)
+		}
+	}
+
+	u.RawQuery = query.Encode()
+
+	return strings.ToLower(u.String())
+}
+
+// responseCacheCounterFor returns the counters of the indexer, creating them if needed.
+func responseCacheCounterFor(indexer string) *responseCacheCounter {
+	v, _ := responseCacheStats.LoadOrStore(strings.ToLower(indexer), &responseCacheCounter{})
+	return v.(*responseCacheCounter)
+}
+
+// GetResponseCacheStats returns the number of search requests of the indexer
+// answered from the response cache and the number sent to the indexer.
+func GetResponseCacheStats(indexer string) (int64, int64) {
+	v, ok := responseCacheStats.Load(strings.ToLower(indexer))
+	if !ok {
+		return 0, 0
+	}
+
+	c := v.(*responseCacheCounter)
+
+	return c.hits.Load(), c.misses.Load()
+}
+
+// ClearResponseCache removes all cached search responses.
+func ClearResponseCache() {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+
+	clear(responseCache)
+}
+
+// getCachedResponse returns the cached results for key if they did not expire.
+func getCachedResponse(key string) (responseCacheEntry, bool) {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+
+	entry, ok := responseCache[key]
+	if !ok {
+		return entry, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(responseCache, key)
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// setCachedResponse stores the results for key and prunes expired entries.
+func setCachedResponse(key string, entry responseCacheEntry) {
+	responseCacheMu.Lock()
+	defer responseCacheMu.Unlock()
+
+	now := time.Now()
+	for k, v := range responseCache {
+		if now.After(v.expires) {
+			delete(responseCache, k)
+		}
+	}
+
+	responseCache[key] = entry
+}
+
+// processurlcached runs a search request through the response cache of the
+// indexer. Identical requests within response_cache_minutes are answered from
+// the cache instead of the indexer. Only search requests may use it - rss
+// requests need fresh results.
+func processurlcached(
+	ind *config.IndexersConfig,
+	qual *config.QualityConfig,
+	urlv string,
+	results *NzbSlice,
+	idsearched bool,
+) (bool, string, error) {
+	return searchcached(ind, qual, urlv, results, func(fetched *NzbSlice) (bool, string, error) {
+		return processurl(ind, qual, urlv, "", fetched, idsearched)
+	})
+}
+
+// searchcached answers a search identified by key from the response cache of
+// the indexer or runs fetch and caches its results.
+func searchcached(
+	ind *config.IndexersConfig,
+	qual *config.QualityConfig,
+	key string,
+	results *NzbSlice,
+	fetch func(*NzbSlice) (bool, string, error),
+) (bool, string, error) {
+	if ind.ResponseCacheMinutes == 0 {
+		return fetch(results)
+	}
+
+	key = responseCacheKey(ind, qual, key)
+	counter := responseCacheCounterFor(ind.Name)
+
+	if entry, ok := getCachedResponse(key); ok {
+		counter.hits.Add(1)
+		results.AddSlice(entry.results)
+
+		var firstid string
+		if len(entry.results) > 0 {
+			firstid = entry.results[0].NZB.ID
+		}
+
+		return entry.broke, firstid, nil
+	}
+
+	counter.misses.Add(1)
+
+	var fetched NzbSlice
+
+	broke, firstid, err := fetch(&fetched)
+	if err != nil {
+		return broke, firstid, err
+	}
+
+	setCachedResponse(key, responseCacheEntry{
+		expires: time.Now().Add(time.Duration(ind.ResponseCacheMinutes) * time.Minute),
+		results: slices.Clone(fetched.Arr),
+		broke:   broke,
+	})
+	results.AddSlice(fetched.Arr)
+
+	return broke, firstid, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/tmdb"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
@@ -149,6 +150,7 @@ func NewTmdbClient(
 		EnableStats:               true,
 		UserAgent:                 config.GetSettingsGeneral().UserAgent,
 		DisableTLSVerify:          disabletls,
+		Proxy:                     MetadataProxy(),
 	}
 	if provider := tmdb.NewProviderWithConfig(tmdbConfig, apikey); provider != nil {
 		providers.SetTMDB(provider)
@@ -579,6 +581,21 @@ func GetTmdbMovieTitles(id int) (*TheMovieDBMovieTitles, error) {
 	return nil, logger.ErrNotFound
 }
 
+// GetTmdbMovieReleaseDates retrieves the regional release dates for a TMDb movie by ID.
+// It returns the release dates of all countries,
+// or an error if the ID is invalid or the lookup fails.
+func GetTmdbMovieReleaseDates(id int) ([]apiexternal_v2.ReleaseDate, error) {
+	if id == 0 {
+		return nil, logger.ErrNotFound
+	}
+
+	if provider := providers.GetTMDB(); provider != nil {
+		return provider.GetMovieReleaseDates(context.Background(), id)
+	}
+
+	return nil, logger.ErrNotFound
+}
+
 // GetTmdbMovieExternal retrieves the external IDs for a TMDb movie by ID.
 // It returns a TheMovieDBTVExternal struct containing the external IDs,
 // or an error if the ID is invalid or the lookup fails.
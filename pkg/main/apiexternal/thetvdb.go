@@ -21,8 +21,11 @@ type CollectedEpisode struct {
 	AbsoluteNumber int
 	Title          string
 	FirstAired     time.Time
+	AirTime        time.Time // Exact broadcast time in UTC, zero if unknown
 	Overview       string
 	Poster         string
+	TvdbID         int    // TheTVDB episode ID, 0 if the episode is not from TheTVDB
+	ImdbID         string // IMDb episode ID, empty if unknown
 }
 
 type TheTVDBSeries struct {
@@ -103,6 +106,7 @@ func NewTvdbClient(seconds uint8, calls int, disabletls bool, timeoutseconds uin
 		EnableStats:               true,
 		UserAgent:                 config.GetSettingsGeneral().UserAgent,
 		DisableTLSVerify:          disabletls,
+		Proxy:                     MetadataProxy(),
 	}
 	// Note: apiKey, userKey, username would need to be provided from config
 	if provider := tvdb.NewProviderWithConfig(tvdbConfig, "", "", ""); provider != nil {
@@ -192,6 +196,8 @@ func CollectTvdbSeriesEpisodes(id int, _ string) map[string]*CollectedEpisode {
 				FirstAired:     ep.AirDate,
 				Overview:       ep.Overview,
 				Poster:         ep.StillPath,
+				TvdbID:         ep.ID,
+				ImdbID:         ep.ImdbID,
 			}
 		}
 	}
@@ -214,21 +220,35 @@ func WriteCollectedEpisodesToDB(episodes map[string]*CollectedEpisode, dbid *uin
 		dbid,
 	)
 
-	var epi, seas, ident string
+	var (
+		epi, seas, ident string
+		airtime          any
+	)
 	for _, ep := range episodes {
 		epi = strconv.Itoa(ep.Episode)
 		seas = strconv.Itoa(ep.Season)
 		ident = generateIdentifierStringFromInt(&ep.Season, &ep.Episode)
 
+		// Stored in the sqlite datetime format so it compares with datetime('now')
+		airtime = nil
+		if !ep.AirTime.IsZero() {
+			airtime = ep.AirTime.UTC().Format(time.DateTime)
+		}
+
 		if checkdbtwostrings(tbl, ep.Season, ep.Episode) {
 			// Episode exists - update it
 			database.ExecN(
-				"UPDATE dbserie_episodes SET title = ?, first_aired = ?, overview = ?, poster = ?, absolute_episode = ?, updated_at = CURRENT_TIMESTAMP WHERE dbserie_id = ? AND season = ? AND episode = ?",
+				"UPDATE dbserie_episodes SET title = ?, first_aired = ?, air_time = COALESCE(?, air_time), overview = ?, poster = ?, absolute_episode = ?, thetvdb_id = CASE WHEN ? != 0 THEN ? ELSE thetvdb_id END, imdb_id = CASE WHEN ? != '' THEN ? ELSE imdb_id END, updated_at = CURRENT_TIMESTAMP WHERE dbserie_id = ? AND season = ? AND episode = ?",
 				&ep.Title,
 				&ep.FirstAired,
+				airtime,
 				&ep.Overview,
 				&ep.Poster,
 				&ep.AbsoluteNumber,
+				&ep.TvdbID,
+				&ep.TvdbID,
+				&ep.ImdbID,
+				&ep.ImdbID,
 				dbid,
 				&seas,
 				&epi,
@@ -236,15 +256,18 @@ func WriteCollectedEpisodesToDB(episodes map[string]*CollectedEpisode, dbid *uin
 		} else {
 			// Episode doesn't exist - insert it
 			database.ExecN(
-				"INSERT INTO dbserie_episodes (episode, season, identifier, title, first_aired, overview, poster, absolute_episode, dbserie_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+				"INSERT INTO dbserie_episodes (episode, season, identifier, title, first_aired, air_time, overview, poster, absolute_episode, thetvdb_id, imdb_id, dbserie_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 				&epi,
 				&seas,
 				&ident,
 				&ep.Title,
 				&ep.FirstAired,
+				airtime,
 				&ep.Overview,
 				&ep.Poster,
 				&ep.AbsoluteNumber,
+				&ep.TvdbID,
+				&ep.ImdbID,
 				dbid,
 			)
 		}
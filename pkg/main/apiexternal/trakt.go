@@ -190,6 +190,7 @@ func NewTraktClient(
 		EnableStats:               true,
 		UserAgent:                 config.GetSettingsGeneral().UserAgent,
 		DisableTLSVerify:          disabletls,
+		Proxy:                     MetadataProxy(),
 	}
 	if provider := trakt.NewProviderWithConfig(
 		traktConfig,
@@ -2,14 +2,30 @@ package apiexternal
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/tvmaze"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/providers"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/syncops"
+)
+
+// tvmazeAirTimesCacheTime is the time the air times of a show are reused by
+// series refreshes before TVmaze is asked again.
+const tvmazeAirTimesCacheTime = 24 * time.Hour
+
+var (
+	// tvmazeAirTimes caches the air times of a TVmaze show per
+	// "season-episode", keyed by the TVmaze id.
+	tvmazeAirTimes = syncops.NewSyncMap[map[string]time.Time](10)
+
+	// tvmazeMissingShows holds the TVDB ids TVmaze has no show for.
+	tvmazeMissingShows = syncops.NewSyncMap[struct{}](10)
 )
 
 type TVmazeSearchResults []TVmazeShow
@@ -136,6 +152,7 @@ func NewTVmazeClient(_ uint8, _ int, disabletls bool, timeoutseconds uint16) {
 		EnableStats:               true,
 		UserAgent:                 config.GetSettingsGeneral().UserAgent,
 		DisableTLSVerify:          general.TvmazeDisableTLSVerify,
+		Proxy:                     MetadataProxy(),
 	}
 	if provider := tvmaze.NewProviderWithConfig(tvmazeConfig); provider != nil {
 		// Store in direct providers registry
@@ -216,3 +233,81 @@ func GetTVmazeSeasons(showID int) ([]*apiexternal_v2.Season, error) {
 
 	return providers.GetTVMaze().GetSeasons(context.Background(), showID)
 }
+
+// MergeTVmazeAirTimesIntoCollectedEpisodes sets the exact broadcast time of the
+// collected episodes from the TVmaze airstamp. TVmaze resolves the network time
+// zone, so the stored time is the real UTC air time of the episode.
+// A missing TVmaze id is looked up by the TVDB id and saved on the series.
+// Episodes unknown to TVmaze keep a zero air time.
+func MergeTVmazeAirTimesIntoCollectedEpisodes(
+	dbserie *database.Dbserie,
+	episodes map[string]*CollectedEpisode,
+) {
+	if len(episodes) == 0 || providers.GetTVMaze() == nil {
+		return
+	}
+
+	if dbserie.TvmazeID == 0 && dbserie.ThetvdbID != 0 {
+		tvdbkey := strconv.Itoa(dbserie.ThetvdbID)
+		if tvmazeMissingShows.Check(tvdbkey) && !tvmazeMissingShows.CheckExpires(tvdbkey, false, 0) {
+			return
+		}
+
+		show, err := GetTVmazeShowByTVDBID(dbserie.ThetvdbID)
+		if err != nil || show == nil || show.ID == 0 {
+			tvmazeMissingShows.Add(
+				tvdbkey, struct{}{}, time.Now().Add(tvmazeAirTimesCacheTime).UnixNano(), false, 0)
+
+			return
+		}
+
+		dbserie.TvmazeID = show.ID
+		database.ExecN(
+			"update dbseries set tvmaze_id = ? where id = ?",
+			&dbserie.TvmazeID,
+			&dbserie.ID,
+		)
+	}
+
+	if dbserie.TvmazeID == 0 {
+		return
+	}
+
+	airtimes := getTVmazeAirTimes(dbserie.TvmazeID)
+	for key, airtime := range airtimes {
+		if existing, ok := episodes[key]; ok {
+			existing.AirTime = airtime
+		}
+	}
+}
+
+// getTVmazeAirTimes returns the air times of the episodes of a TVmaze show per
+// "season-episode". The result is cached for tvmazeAirTimesCacheTime.
+func getTVmazeAirTimes(showID int) map[string]time.Time {
+	key := strconv.Itoa(showID)
+	if tvmazeAirTimes.Check(key) && !tvmazeAirTimes.CheckExpires(key, false, 0) {
+		return tvmazeAirTimes.GetVal(key)
+	}
+
+	tvmazeEpisodes, err := GetTVmazeEpisodes(showID)
+	if err != nil {
+		logger.Logtype("debug", 1).
+			Int(logger.StrID, showID).
+			Err(err).
+			Msg("TVmaze episodes not found")
+		return nil
+	}
+
+	airtimes := make(map[string]time.Time, len(tvmazeEpisodes))
+	for _, ep := range tvmazeEpisodes {
+		if ep == nil || ep.AirStamp.IsZero() {
+			continue
+		}
+
+		airtimes[strconv.Itoa(ep.SeasonNumber)+"-"+strconv.Itoa(ep.EpisodeNumber)] = ep.AirStamp
+	}
+
+	tvmazeAirTimes.Add(key, airtimes, time.Now().Add(tvmazeAirTimesCacheTime).UnixNano(), false, 0)
+
+	return airtimes
+}
@@ -80,6 +80,9 @@ type ClientConfig struct {
 	EnableCompression bool
 	UserAgent         string
 	DisableTLSVerify  bool // Disable TLS certificate verification (insecure)
+
+	// Proxy used for all requests, an empty URL uses the default proxy
+	Proxy ProxyConfig
 }
 
 // BaseClient provides all infrastructure features for API clients.
@@ -288,6 +291,13 @@ func NewBaseClient(cfg ClientConfig) *BaseClient {
 		}
 	}
 
+	if err := cfg.Proxy.ApplyProxy(transport); err != nil {
+		logger.Logtype(logger.StatusError, 0).
+			Str("client", cfg.Name).
+			Err(err).
+			Msg("Invalid proxy configuration - using direct connection")
+	}
+
 	httpClient := &http.Client{
 		Timeout:   cfg.Timeout,
 		Transport: transport,
@@ -592,6 +602,13 @@ func (bc *BaseClient) MakeRequestWithGracePeriod(
 	startTime := time.Now()
 
 	for attempt := 0; attempt <= bc.config.MaxRetries; attempt++ {
+		// The previous attempt consumed the body - send a fresh copy (form logins)
+		if attempt > 0 && req.GetBody != nil {
+			if req.Body, reqErr = req.GetBody(); reqErr != nil {
+				break
+			}
+		}
+
 		resp, reqErr = bc.httpClient.Do(req)
 		if reqErr == nil && resp.StatusCode < 500 {
 			break // Success or client error (4xx) - don't retry
@@ -601,6 +618,10 @@ func (bc *BaseClient) MakeRequestWithGracePeriod(
 			continue
 		}
 
+		if resp != nil {
+			resp.Body.Close()
+		}
+
 		backoff := bc.config.RetryBackoff * time.Duration(attempt+1)
 		logger.Logtype(logger.StatusDebug, 2).
 			Err(reqErr).
@@ -860,6 +881,13 @@ func (bc *BaseClient) MakeRequestWithHeaders(
 	startTime := time.Now()
 
 	for attempt := 0; attempt <= bc.config.MaxRetries; attempt++ {
+		// The previous attempt consumed the body - send a fresh copy (form logins)
+		if attempt > 0 && req.GetBody != nil {
+			if req.Body, reqErr = req.GetBody(); reqErr != nil {
+				break
+			}
+		}
+
 		resp, reqErr = bc.httpClient.Do(req)
 		if reqErr == nil && resp.StatusCode < 500 {
 			break // Success or client error (4xx) - don't retry
@@ -869,6 +897,10 @@ func (bc *BaseClient) MakeRequestWithHeaders(
 			continue
 		}
 
+		if resp != nil {
+			resp.Body.Close()
+		}
+
 		backoff := bc.config.RetryBackoff * time.Duration(attempt+1)
 		logger.Logtype(logger.StatusDebug, 2).
 			Err(reqErr).
@@ -0,0 +1,256 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"golang.org/x/net/proxy"
+)
+
+// ProxyDirect as proxy URL disables the proxy of a client, also the default one.
+const ProxyDirect = "direct"
+
+var (
+	errProxyScheme    = errors.New("unsupported proxy scheme - use http, https or socks5")
+	errProxyTransport = errors.New("client transport does not support proxies")
+	errProxyNotFound  = errors.New("no proxy configured")
+
+	// defaultProxy is used by all clients without an own proxy URL.
+	defaultProxy atomic.Pointer[compiledProxy]
+)
+
+// ProxyConfig describes a HTTP or SOCKS5 proxy.
+type ProxyConfig struct {
+	URL      string   // http://host:port, https://host:port or socks5://host:port, "direct" for none
+	Username string   // Optional proxy username, overrides the user of the URL
+	Password string   // Optional proxy password
+	Bypass   []string // Hosts, domains (.example.com) or CIDRs reached without proxy
+}
+
+// compiledProxy keeps a proxy config together with its selection function.
+type compiledProxy struct {
+	fn  func(*url.URL) (*url.URL, error)
+	cfg ProxyConfig
+}
+
+// SetDefaultProxy sets the proxy used by clients without an own proxy URL.
+// Clients pick up a changed default proxy with their next request.
+func SetDefaultProxy(cfg ProxyConfig) error {
+	fn, err := cfg.compile()
+	if err != nil {
+		defaultProxy.Store(nil)
+		return err
+	}
+
+	defaultProxy.Store(&compiledProxy{cfg: cfg, fn: fn})
+
+	return nil
+}
+
+// Resolve returns the proxy to use for the config. An empty URL falls back to
+// the default proxy, "direct" disables the proxy. The bypass list of the
+// default proxy applies if the config has none.
+func (p ProxyConfig) Resolve() ProxyConfig {
+	def := defaultProxy.Load()
+
+	if p.URL == "" {
+		if def == nil {
+			return ProxyConfig{}
+		}
+
+		p = def.cfg
+	}
+
+	if strings.EqualFold(p.URL, ProxyDirect) {
+		return ProxyConfig{}
+	}
+
+	if len(p.Bypass) == 0 && def != nil {
+		p.Bypass = def.cfg.Bypass
+	}
+
+	return p
+}
+
+// proxyURL parses the proxy URL and adds the credentials.
+func (p ProxyConfig) proxyURL() (*url.URL, error) {
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, errProxyScheme
+	}
+
+	if p.Username != "" {
+		u.User = url.UserPassword(p.Username, p.Password)
+	}
+
+	return u, nil
+}
+
+// compile builds the proxy selection function of an own (non default) proxy
+// config, nil if the config uses no proxy.
+func (p ProxyConfig) compile() (func(*url.URL) (*url.URL, error), error) {
+	if p.URL == "" || strings.EqualFold(p.URL, ProxyDirect) {
+		return nil, nil
+	}
+
+	u, err := p.proxyURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return (&httpproxy.Config{
+		HTTPProxy:  u.String(),
+		HTTPSProxy: u.String(),
+		NoProxy:    strings.Join(p.Bypass, ","),
+	}).ProxyFunc(), nil
+}
+
+// ProxyFunc returns the proxy selection function for a http.Transport. Configs
+// without an own URL follow the current default proxy.
+func (p ProxyConfig) ProxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if p.URL == "" {
+		return func(req *http.Request) (*url.URL, error) {
+			def := defaultProxy.Load()
+			if def == nil || def.fn == nil {
+				return nil, nil
+			}
+
+			return def.fn(req.URL)
+		}, nil
+	}
+
+	fn, err := p.Resolve().compile()
+	if err != nil || fn == nil {
+		return nil, err
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		return fn(req.URL)
+	}, nil
+}
+
+// ApplyProxy sets the proxy of the transport.
+func (p ProxyConfig) ApplyProxy(transport *http.Transport) error {
+	fn, err := p.ProxyFunc()
+	if err != nil {
+		return err
+	}
+
+	transport.Proxy = fn
+
+	return nil
+}
+
+// Transport returns a new http.Transport with the defaults of
+// http.DefaultTransport using the proxy of the config.
+func (p ProxyConfig) Transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if err := p.ApplyProxy(transport); err != nil {
+		return nil, err
+	}
+
+	return transport, nil
+}
+
+// DialContext connects to addr through the SOCKS5 proxy of the config or
+// directly if no proxy is used or addr is bypassed. HTTP proxies are not
+// supported for plain TCP connections - an own HTTP proxy fails, a default
+// HTTP proxy is skipped.
+func (p ProxyConfig) DialContext(
+	ctx context.Context,
+	forward *net.Dialer,
+	network, addr string,
+) (net.Conn, error) {
+	own := p.URL != ""
+
+	p = p.Resolve()
+	if p.URL == "" {
+		return forward.DialContext(ctx, network, addr)
+	}
+
+	u, err := p.proxyURL()
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(strings.ToLower(u.Scheme), "socks5") {
+		if own {
+			return nil, errProxyScheme
+		}
+
+		return forward.DialContext(ctx, network, addr)
+	}
+
+	dialer, err := proxy.FromURL(u, forward)
+	if err != nil {
+		return nil, err
+	}
+
+	perHost := proxy.NewPerHost(dialer, forward)
+	perHost.AddFromString(strings.Join(p.Bypass, ","))
+
+	return perHost.DialContext(ctx, network, addr)
+}
+
+// SetProxy replaces the proxy of the client's transport.
+func (bc *BaseClient) SetProxy(cfg ProxyConfig) error {
+	transport, ok := bc.httpClient.Transport.(*http.Transport)
+	if !ok {
+		return errProxyTransport
+	}
+
+	bc.config.Proxy = cfg
+
+	return cfg.ApplyProxy(transport)
+}
+
+// TestProxy requests testURL through the proxy and returns the response time.
+func TestProxy(
+	ctx context.Context,
+	cfg ProxyConfig,
+	testURL string,
+	timeout time.Duration,
+) (time.Duration, int, error) {
+	if cfg.Resolve().URL == "" {
+		return 0, 0, errProxyNotFound
+	}
+
+	transport := &http.Transport{}
+	if err := cfg.ApplyProxy(transport); err != nil {
+		return 0, 0, err
+	}
+	defer transport.CloseIdleConnections()
+
+	client := &http.Client{Timeout: timeout, Transport: transport}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return time.Since(start), 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return time.Since(start), resp.StatusCode, nil
+}
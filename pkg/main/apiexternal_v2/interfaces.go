@@ -1,6 +1,9 @@
 package apiexternal_v2
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 //
 // Metadata Provider Interface - Fully Typed, No any or any
@@ -111,6 +114,26 @@ type NotificationProvider interface {
 	TestConnection(ctx context.Context) error
 }
 
+// ErrSubtitleQuota is returned by DownloadSubtitle once the download quota of
+// the provider is used up.
+var ErrSubtitleQuota = errors.New("subtitle download quota reached")
+
+// SubtitleProvider defines the interface for subtitle search and download services.
+type SubtitleProvider interface {
+	GetProviderType() SubtitleProviderType
+	GetProviderName() string
+
+	// SearchSubtitles returns all subtitles matching the request.
+	SearchSubtitles(
+		ctx context.Context,
+		request SubtitleSearchRequest,
+	) ([]SubtitleResult, error)
+
+	// DownloadSubtitle returns the raw subtitle file content, or
+	// ErrSubtitleQuota if no downloads are left.
+	DownloadSubtitle(ctx context.Context, subtitle *SubtitleResult) ([]byte, error)
+}
+
 // IndexerProvider defines the interface for indexer services (Newznab/Torznab) with full type safety.
 // type IndexerProvider interface {
 // 	GetProviderType() IndexerProviderType
@@ -0,0 +1,339 @@
+package cardigann
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/goccy/go-yaml"
+)
+
+//
+// Cardigann Definitions - YAML descriptions of torrent sites
+// Describe login, search urls, category maps and row/field selectors of a site
+//
+
+var (
+	errNoSearchPath = errors.New("definition has no search path")
+	errNoRows       = errors.New("definition has no rows selector")
+	errNoTitleField = errors.New("definition has no title field")
+)
+
+// standardCategories maps the newznab category names usable in category
+// mappings to their newznab ids.
+var standardCategories = map[string]string{
+	"Movies":          "2000",
+	"Movies/Foreign":  "2010",
+	"Movies/Other":    "2020",
+	"Movies/SD":       "2030",
+	"Movies/HD":       "2040",
+	"Movies/UHD":      "2045",
+	"Movies/BluRay":   "2050",
+	"Movies/3D":       "2060",
+	"Movies/DVD":      "2070",
+	"Movies/WEB-DL":   "2080",
+	"Audio":           "3000",
+	"Audio/MP3":       "3010",
+	"Audio/Video":     "3020",
+	"Audio/Audiobook": "3030",
+	"Audio/Lossless":  "3040",
+	"Audio/Other":     "3050",
+	"Audio/Foreign":   "3060",
+	"PC":              "4000",
+	"TV":              "5000",
+	"TV/WEB-DL":       "5010",
+	"TV/Foreign":      "5020",
+	"TV/SD":           "5030",
+	"TV/HD":           "5040",
+	"TV/UHD":          "5045",
+	"TV/Other":        "5050",
+	"TV/Sport":        "5060",
+	"TV/Anime":        "5070",
+	"TV/Documentary":  "5080",
+	"XXX":             "6000",
+	"Books":           "7000",
+	"Books/Mags":      "7010",
+	"Books/EBook":     "7020",
+	"Books/Comics":    "7030",
+	"Books/Technical": "7040",
+	"Books/Other":     "7050",
+	"Books/Foreign":   "7060",
+	"Other":           "8000",
+}
+
+// searchModes maps the mode names of definitions to the newznab search modes.
+var searchModes = map[string]string{
+	"search":       "search",
+	"tv-search":    "tvsearch",
+	"movie-search": "movie",
+}
+
+// Definition describes a torrent site. Unknown keys of Cardigann definitions
+// are ignored.
+type Definition struct {
+	ID          string         `yaml:"id"`
+	Name        string         `yaml:"name"`
+	Description string         `yaml:"description"`
+	Links       []string       `yaml:"links"`
+	Caps        CapsBlock      `yaml:"caps"`
+	Login       *LoginBlock    `yaml:"login"`
+	Search      SearchBlock    `yaml:"search"`
+	Download    *DownloadBlock `yaml:"download"`
+}
+
+// CapsBlock holds the category mappings and search modes of a site.
+type CapsBlock struct {
+	CategoryMappings []CategoryMapping   `yaml:"categorymappings"`
+	Modes            map[string][]string `yaml:"modes"`
+}
+
+// CategoryMapping maps a site category to a newznab category. Cat is either a
+// newznab id (2040) or a standard name (Movies/HD).
+type CategoryMapping struct {
+	ID      string `yaml:"id"`
+	Cat     string `yaml:"cat"`
+	Desc    string `yaml:"desc"`
+	Default bool   `yaml:"default"`
+}
+
+// LoginBlock describes how to log in to a site.
+type LoginBlock struct {
+	Method string            `yaml:"method"` // form, post, get or cookie
+	Path   string            `yaml:"path"`
+	Inputs map[string]string `yaml:"inputs"`
+	Error  []SelectorBlock   `yaml:"error"`
+	Test   *PageTestBlock    `yaml:"test"`
+}
+
+// PageTestBlock checks a page for a selector to verify a login.
+type PageTestBlock struct {
+	Path     string `yaml:"path"`
+	Selector string `yaml:"selector"`
+}
+
+// SearchBlock describes the search requests of a site and how to read its
+// result rows.
+type SearchBlock struct {
+	Path    string            `yaml:"path"`
+	Paths   []SearchPath      `yaml:"paths"`
+	Inputs  map[string]string `yaml:"inputs"`
+	Rows    RowsBlock         `yaml:"rows"`
+	Fields  map[string]Field  `yaml:"fields"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+// SearchPath is one search request of a site.
+type SearchPath struct {
+	Path   string `yaml:"path"`
+	Method string `yaml:"method"` // get (default) or post
+}
+
+// RowsBlock selects the result rows of a search page.
+type RowsBlock struct {
+	Selector string `yaml:"selector"`
+	After    int    `yaml:"after"` // number of leading rows to skip
+}
+
+// DownloadBlock selects the torrent link on the details page if the search
+// results only link to the details page.
+type DownloadBlock struct {
+	Selector  string `yaml:"selector"`
+	Attribute string `yaml:"attribute"`
+}
+
+// SelectorBlock selects a value of a page.
+type SelectorBlock struct {
+	Selector  string `yaml:"selector"`
+	Attribute string `yaml:"attribute"`
+}
+
+// Field selects a value of a result row.
+type Field struct {
+	Selector  string   `yaml:"selector"`
+	Attribute string   `yaml:"attribute"`
+	Text      string   `yaml:"text"` // template, may use .Result of other fields
+	Optional  bool     `yaml:"optional"`
+	Filters   []Filter `yaml:"filters"`
+}
+
+// Filter transforms a field value.
+type Filter struct {
+	Name string `yaml:"name"`
+	Args any    `yaml:"args"`
+}
+
+// LoadDefinition reads a definition file.
+func LoadDefinition(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseDefinition(data)
+}
+
+// ParseDefinition parses and validates a YAML definition.
+func ParseDefinition(data []byte) (*Definition, error) {
+	var def Definition
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, err
+	}
+
+	if def.Search.Path != "" {
+		def.Search.Paths = append([]SearchPath{{Path: def.Search.Path}}, def.Search.Paths...)
+	}
+
+	if len(def.Search.Paths) == 0 {
+		return nil, errNoSearchPath
+	}
+
+	if def.Search.Rows.Selector == "" {
+		return nil, errNoRows
+	}
+
+	if _, ok := def.Search.Fields["title"]; !ok {
+		return nil, errNoTitleField
+	}
+
+	for idx := range def.Caps.CategoryMappings {
+		mapping := &def.Caps.CategoryMappings[idx]
+		if id, ok := standardCategories[mapping.Cat]; ok {
+			mapping.Cat = id
+		}
+	}
+
+	return &def, nil
+}
+
+// SiteCategories returns the site categories mapped to the newznab categories.
+// A parent category (2000) also selects its subcategories (2040). No newznab
+// categories return the default categories of the definition.
+func (d *Definition) SiteCategories(newznab []string) []string {
+	var out []string
+
+	for idx := range d.Caps.CategoryMappings {
+		mapping := &d.Caps.CategoryMappings[idx]
+
+		if len(newznab) == 0 {
+			if mapping.Default && !slices.Contains(out, mapping.ID) {
+				out = append(out, mapping.ID)
+			}
+
+			continue
+		}
+
+		for _, cat := range newznab {
+			cat = strings.TrimSpace(cat)
+			if cat == "" {
+				continue
+			}
+
+			if (mapping.Cat == cat || parentCategory(mapping.Cat) == cat) &&
+				!slices.Contains(out, mapping.ID) {
+				out = append(out, mapping.ID)
+			}
+		}
+	}
+
+	return out
+}
+
+// NewznabCategory returns the newznab category of a site category, "" if it
+// is not mapped.
+func (d *Definition) NewznabCategory(site string) string {
+	for idx := range d.Caps.CategoryMappings {
+		if d.Caps.CategoryMappings[idx].ID == site {
+			return d.Caps.CategoryMappings[idx].Cat
+		}
+	}
+
+	return ""
+}
+
+// Capabilities converts the caps of the definition to indexer capabilities.
+func (d *Definition) Capabilities() *apiexternal_v2.IndexerCapabilities {
+	caps := &apiexternal_v2.IndexerCapabilities{
+		ServerTitle:  d.Name,
+		SearchParams: make(map[string][]string, len(d.Caps.Modes)),
+		Provider:     "cardigann",
+	}
+
+	for mode, params := range d.Caps.Modes {
+		name, ok := searchModes[mode]
+		if !ok {
+			continue
+		}
+
+		caps.SearchModes = append(caps.SearchModes, name)
+
+		lowered := make([]string, 0, len(params))
+		for _, param := range params {
+			lowered = append(lowered, strings.ToLower(strings.TrimSpace(param)))
+		}
+
+		caps.SearchParams[name] = lowered
+	}
+
+	slices.Sort(caps.SearchModes)
+
+	parents := make(map[string]int)
+
+	for idx := range d.Caps.CategoryMappings {
+		cat := d.Caps.CategoryMappings[idx].Cat
+		if cat == "" {
+			continue
+		}
+
+		parent := parentCategory(cat)
+
+		pidx, ok := parents[parent]
+		if !ok {
+			pidx = len(caps.Categories)
+			parents[parent] = pidx
+
+			caps.Categories = append(caps.Categories, apiexternal_v2.IndexerCategory{
+				ID:   parent,
+				Name: categoryName(parent),
+			})
+		}
+
+		if cat == parent || slices.ContainsFunc(
+			caps.Categories[pidx].Subcategories,
+			func(c apiexternal_v2.IndexerCategory) bool { return c.ID == cat },
+		) {
+			continue
+		}
+
+		caps.Categories[pidx].Subcategories = append(
+			caps.Categories[pidx].Subcategories,
+			apiexternal_v2.IndexerCategory{ID: cat, Name: categoryName(cat)},
+		)
+	}
+
+	return caps
+}
+
+// parentCategory returns the parent of a newznab category (2040 -> 2000).
+func parentCategory(cat string) string {
+	id, err := strconv.Atoi(cat)
+	if err != nil {
+		return cat
+	}
+
+	return strconv.Itoa(id / 1000 * 1000)
+}
+
+// categoryName returns the standard name of a newznab category id.
+func categoryName(id string) string {
+	for name, cat := range standardCategories {
+		if cat == id {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("Category %s", id)
+}
@@ -0,0 +1,270 @@
+package cardigann
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errUnknownFilter = errors.New("unknown filter")
+
+// sizeUnits maps size suffixes of result rows to their byte factor.
+var sizeUnits = map[string]float64{
+	"B":   1,
+	"KB":  1000,
+	"KIB": 1024,
+	"MB":  1000 * 1000,
+	"MIB": 1024 * 1024,
+	"GB":  1000 * 1000 * 1000,
+	"GIB": 1024 * 1024 * 1024,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"TIB": 1024 * 1024 * 1024 * 1024,
+}
+
+// dateLayouts are tried for date fields without a dateparse filter.
+var dateLayouts = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"Jan 2 2006",
+	"Jan 2, 2006",
+}
+
+// filterArgs returns the arguments of a filter as strings - definitions use a
+// single value or a list.
+func (f *Filter) filterArgs() []string {
+	switch args := f.Args.(type) {
+	case nil:
+		return nil
+	case []any:
+		out := make([]string, len(args))
+		for idx := range args {
+			out[idx] = fmt.Sprint(args[idx])
+		}
+
+		return out
+	default:
+		return []string{fmt.Sprint(args)}
+	}
+}
+
+// applyFilters runs value through the filters of a field.
+func applyFilters(value string, filters []Filter) (string, error) {
+	for idx := range filters {
+		var err error
+
+		value, err = applyFilter(value, &filters[idx])
+		if err != nil {
+			return value, err
+		}
+	}
+
+	return value, nil
+}
+
+// applyFilter runs value through one filter.
+func applyFilter(value string, filter *Filter) (string, error) {
+	args := filter.filterArgs()
+	arg := func(idx int) string {
+		if idx < len(args) {
+			return args[idx]
+		}
+
+		return ""
+	}
+
+	switch strings.ToLower(filter.Name) {
+	case "replace":
+		return strings.ReplaceAll(value, arg(0), arg(1)), nil
+	case "re_replace":
+		re, err := regexp.Compile(arg(0))
+		if err != nil {
+			return value, err
+		}
+
+		return re.ReplaceAllString(value, arg(1)), nil
+	case "regexp":
+		re, err := regexp.Compile(arg(0))
+		if err != nil {
+			return value, err
+		}
+
+		match := re.FindStringSubmatch(value)
+		switch len(match) {
+		case 0:
+			return "", nil
+		case 1:
+			return match[0], nil
+		default:
+			return match[1], nil
+		}
+
+	case "split":
+		parts := strings.Split(value, arg(0))
+
+		pos, err := strconv.Atoi(arg(1))
+		if err != nil {
+			return value, err
+		}
+
+		if pos < 0 {
+			pos += len(parts)
+		}
+
+		if pos < 0 || pos >= len(parts) {
+			return "", nil
+		}
+
+		return parts[pos], nil
+	case "trim":
+		if arg(0) == "" {
+			return strings.TrimSpace(value), nil
+		}
+
+		return strings.Trim(value, arg(0)), nil
+	case "append":
+		return value + arg(0), nil
+	case "prepend":
+		return arg(0) + value, nil
+	case "tolower":
+		return strings.ToLower(value), nil
+	case "toupper":
+		return strings.ToUpper(value), nil
+	case "urldecode":
+		return url.QueryUnescape(value)
+	case "urlencode":
+		return url.QueryEscape(value), nil
+	case "querystring":
+		u, err := url.Parse(value)
+		if err != nil {
+			return value, err
+		}
+
+		return u.Query().Get(arg(0)), nil
+	case "dateparse":
+		t, err := time.Parse(arg(0), strings.TrimSpace(value))
+		if err != nil {
+			return value, err
+		}
+
+		return t.Format(time.RFC3339), nil
+	case "timeago":
+		t, ok := parseTimeAgo(value, time.Now())
+		if !ok {
+			return value, nil
+		}
+
+		return t.Format(time.RFC3339), nil
+	}
+
+	return value, fmt.Errorf("%w: %s", errUnknownFilter, filter.Name)
+}
+
+// parseSize converts a size like "1.5 GB" or "700MiB" to bytes. Plain numbers
+// are bytes.
+func parseSize(value string) int64 {
+	value = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), ",", "."))
+	if value == "" {
+		return 0
+	}
+
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end == -1 {
+		size, _ := strconv.ParseInt(value, 10, 64)
+		return size
+	}
+
+	number, err := strconv.ParseFloat(value[:end], 64)
+	if err != nil {
+		return 0
+	}
+
+	factor, ok := sizeUnits[strings.TrimSpace(value[end:])]
+	if !ok {
+		return int64(number)
+	}
+
+	return int64(number * factor)
+}
+
+// parseDate reads the value of a date field. Unknown formats return the zero
+// time.
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0)
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+
+	if t, ok := parseTimeAgo(value, time.Now()); ok {
+		return t
+	}
+
+	return time.Time{}
+}
+
+// parseTimeAgo parses relative dates like "3 hours ago" or "2 days, 4 hours ago".
+func parseTimeAgo(value string, now time.Time) (time.Time, bool) {
+	fields := strings.Fields(strings.ToLower(strings.NewReplacer(",", " ", "ago", " ").Replace(value)))
+	if len(fields) < 2 {
+		return time.Time{}, false
+	}
+
+	var (
+		total time.Duration
+		found bool
+	)
+
+	for idx := 0; idx+1 < len(fields); idx += 2 {
+		count, err := strconv.ParseFloat(fields[idx], 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		var unit time.Duration
+
+		switch strings.TrimSuffix(fields[idx+1], "s") {
+		case "sec", "second":
+			unit = time.Second
+		case "min", "minute":
+			unit = time.Minute
+		case "hour", "hr":
+			unit = time.Hour
+		case "day":
+			unit = 24 * time.Hour
+		case "week":
+			unit = 7 * 24 * time.Hour
+		case "month":
+			unit = 30 * 24 * time.Hour
+		case "year":
+			unit = 365 * 24 * time.Hour
+		default:
+			return time.Time{}, false
+		}
+
+		total += time.Duration(count * float64(unit))
+		found = true
+	}
+
+	return now.Add(-total), found
+}
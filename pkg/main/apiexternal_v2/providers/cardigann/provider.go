@@ -0,0 +1,739 @@
+package cardigann
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scrapers/htmlselect"
+	"golang.org/x/net/html"
+)
+
+//
+// Cardigann Provider - definition driven torrent site indexer
+// Logs in, searches and downloads as described by a YAML definition
+//
+
+var (
+	errNoBaseURL      = errors.New("definition has no link and indexer has no url")
+	errNoCookie       = errors.New("login method cookie needs a cookie")
+	errLoginFailed    = errors.New("login failed - test selector not found")
+	errNoDownloadLink = errors.New("no download link found on details page")
+)
+
+// templateFuncs are the functions usable in the templates of definitions.
+var templateFuncs = template.FuncMap{
+	"join": func(elems []string, sep string) string {
+		return strings.Join(elems, sep)
+	},
+	"replace": strings.ReplaceAll,
+	"re_replace": func(s, pattern, repl string) string {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return s
+		}
+
+		return re.ReplaceAllString(s, repl)
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ProviderConfig contains configuration for creating a Cardigann provider.
+type ProviderConfig struct {
+	IndexerName       string      // Name of the indexer (for logging and identification)
+	BaseURL           string      // Overrides the first link of the definition
+	Definition        *Definition // Parsed site definition
+	Username          string      // Username for form logins
+	Password          string      // Password for form logins
+	Cookie            string      // Cookie header ("uid=1; pass=abc") used instead of a login
+	TimeoutSeconds    uint16
+	MaxEntries        uint16 // Maximum number of entries per search
+	LimiterCalls      int    // Number of requests allowed within LimiterSeconds
+	LimiterSeconds    uint8  // Time window in seconds for rate limiting
+	LimiterCallsDaily int    // Maximum number of requests allowed per day
+	DisableTLSVerify  bool
+
+	// Proxy for searches and downloads, an empty URL uses the default proxy
+	Proxy base.ProxyConfig
+}
+
+// Provider searches a torrent site described by a definition.
+type Provider struct {
+	*base.BaseClient
+	DownloadClient *base.BaseClient // Separate client for tracking download statistics
+	definition     *Definition
+	baseURL        string
+	cookie         string
+	settings       map[string]string // .Config values of the templates
+	maxEntries     uint16
+	jar            http.CookieJar
+
+	loginMu  sync.Mutex
+	loggedIn bool
+}
+
+// Query describes a search. Empty keywords and ids list the latest releases.
+type Query struct {
+	Mode       string // search, tvsearch or movie
+	Keywords   string
+	IMDBID     string
+	TMDBID     string
+	TVDBID     string
+	TVRageID   string
+	TVMazeID   string
+	TraktID    string
+	Season     string
+	Ep         string
+	Categories []string // Newznab category ids
+}
+
+// IMDBIDShort returns the imdb id without the tt prefix.
+func (q *Query) IMDBIDShort() string {
+	return strings.TrimPrefix(q.IMDBID, "tt")
+}
+
+// Key identifies the query for the response cache.
+func (q *Query) Key() string {
+	return logger.JoinStringsSep([]string{
+		"cardigann", q.Mode, q.Keywords, q.IMDBID, q.TMDBID, q.TVDBID, q.TVRageID,
+		q.TVMazeID, q.TraktID, q.Season, q.Ep, logger.JoinStringsSep(q.Categories, ","),
+	}, "|")
+}
+
+// templateData is passed to the templates of definitions.
+type templateData struct {
+	Keywords   string
+	Query      *Query
+	Categories []string // Site categories of the query
+	Config     map[string]string
+	Result     map[string]string
+}
+
+// NewProvider creates a new Cardigann indexer provider.
+func NewProvider(cfg ProviderConfig) (*Provider, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" && len(cfg.Definition.Links) > 0 {
+		baseURL = cfg.Definition.Links[0]
+	}
+
+	if baseURL == "" {
+		return nil, errNoBaseURL
+	}
+
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	timeout := 60 * time.Second
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+
+	rateLimitCalls := cfg.LimiterCalls
+	if rateLimitCalls <= 0 {
+		rateLimitCalls = 5
+	}
+
+	rateLimitSeconds := int(cfg.LimiterSeconds)
+	if rateLimitSeconds == 0 {
+		rateLimitSeconds = 20
+	}
+
+	clientName := cfg.IndexerName
+	if clientName == "" {
+		clientName = "cardigann"
+	}
+
+	clientConfig := base.ClientConfig{
+		Name:                    clientName,
+		BaseURL:                 baseURL,
+		Timeout:                 timeout,
+		AuthType:                base.AuthNone, // Uses session cookies
+		RateLimitCalls:          rateLimitCalls,
+		RateLimitSeconds:        rateLimitSeconds,
+		RateLimitPer24h:         cfg.LimiterCallsDaily,
+		CircuitBreakerThreshold: 5,
+		CircuitBreakerTimeout:   60 * time.Second,
+		EnableStats:             true,
+		StatsDBTable:            "api_client_stats",
+		MaxRetries:              3,
+		RetryBackoff:            2 * time.Second,
+		DisableTLSVerify:        cfg.DisableTLSVerify,
+		Proxy:                   cfg.Proxy,
+	}
+
+	downloadClientConfig := clientConfig
+	downloadClientConfig.Name = clientName + "_download"
+
+	jar, err := cookiejar.New(&cookiejar.Options{})
+	if err != nil {
+		return nil, errors.New(logger.JoinStrings("failed to create cookie jar: ", err.Error()))
+	}
+
+	p := &Provider{
+		BaseClient:     base.NewBaseClient(clientConfig),
+		DownloadClient: base.NewBaseClient(downloadClientConfig),
+		definition:     cfg.Definition,
+		baseURL:        baseURL,
+		cookie:         cfg.Cookie,
+		maxEntries:     cfg.MaxEntries,
+		jar:            jar,
+		settings: map[string]string{
+			"username": cfg.Username,
+			"password": cfg.Password,
+			"cookie":   cfg.Cookie,
+			"sitelink": baseURL,
+		},
+	}
+
+	// Searches and downloads share the login session
+	p.GetHTTPClient().Jar = jar
+	p.DownloadClient.GetHTTPClient().Jar = jar
+
+	return p, nil
+}
+
+// GetProviderName returns the provider name.
+func (*Provider) GetProviderName() string {
+	return "cardigann"
+}
+
+// Definition returns the site definition of the provider.
+func (p *Provider) Definition() *Definition {
+	return p.definition
+}
+
+// Search runs the search paths of the definition and converts the result rows.
+// Releases are returned in site order, duplicates of earlier paths are skipped.
+func (p *Provider) Search(
+	ctx context.Context,
+	query Query,
+	ind *config.IndexersConfig,
+	qual *config.QualityConfig,
+) ([]apiexternal_v2.Nzbwithprio, error) {
+	if err := p.ensureLogin(ctx); err != nil {
+		return nil, err
+	}
+
+	data := &templateData{
+		Keywords:   query.Keywords,
+		Query:      &query,
+		Categories: p.definition.SiteCategories(query.Categories),
+		Config:     p.settings,
+	}
+
+	var ret []apiexternal_v2.Nzbwithprio
+
+	seen := make(map[string]struct{})
+
+	for idx := range p.definition.Search.Paths {
+		rows, err := p.searchRows(ctx, &p.definition.Search.Paths[idx], data)
+		if err != nil {
+			return ret, err
+		}
+
+		for _, row := range rows {
+			nzb, ok := p.rowToNzb(row, data, ind, qual)
+			if !ok {
+				continue
+			}
+
+			if _, dup := seen[nzb.ID]; dup {
+				continue
+			}
+
+			seen[nzb.ID] = struct{}{}
+
+			ret = append(ret, apiexternal_v2.Nzbwithprio{NZB: nzb})
+			if p.maxEntries > 0 && len(ret) >= int(p.maxEntries) {
+				return ret, nil
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// searchRows requests a search path and returns its result rows. A page
+// without rows that fails the login test triggers one new login.
+func (p *Provider) searchRows(
+	ctx context.Context,
+	path *SearchPath,
+	data *templateData,
+) ([]*html.Node, error) {
+	for attempt := 0; ; attempt++ {
+		doc, err := p.fetchPage(
+			ctx,
+			path.Method,
+			path.Path,
+			p.definition.Search.Inputs,
+			p.definition.Search.Headers,
+			data,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rows := htmlselect.Find(doc, p.definition.Search.Rows.Selector)
+		if after := p.definition.Search.Rows.After; after > 0 {
+			rows = rows[min(after, len(rows)):]
+		}
+
+		if len(rows) > 0 || attempt > 0 || !p.sessionExpired(doc) {
+			return rows, nil
+		}
+
+		p.loginMu.Lock()
+		p.loggedIn = false
+		p.loginMu.Unlock()
+
+		if err := p.ensureLogin(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rowToNzb reads the fields of a result row. Rows without title or link are
+// skipped.
+func (p *Provider) rowToNzb(
+	row *html.Node,
+	data *templateData,
+	ind *config.IndexersConfig,
+	qual *config.QualityConfig,
+) (apiexternal_v2.Nzb, bool) {
+	result := p.readFields(row, data)
+
+	nzb := apiexternal_v2.Nzb{
+		Title:          strings.TrimSpace(result["title"]),
+		SourceEndpoint: p.baseURL,
+		IsTorrent:      true,
+		Indexer:        ind,
+		Quality:        qual,
+	}
+
+	if nzb.Title == "" {
+		return nzb, false
+	}
+
+	details := p.resolve(result["details"])
+
+	nzb.DownloadURL = p.resolve(result["download"])
+	if nzb.DownloadURL == "" {
+		nzb.DownloadURL = result["magnet"]
+	}
+
+	if nzb.DownloadURL == "" && p.definition.Download != nil {
+		nzb.DownloadURL = details
+	}
+
+	if nzb.DownloadURL == "" {
+		return nzb, false
+	}
+
+	nzb.ID = details
+	if nzb.ID == "" {
+		nzb.ID = nzb.DownloadURL
+	}
+
+	nzb.Size = parseSize(result["size"])
+	nzb.PubDate = parseDate(result["date"])
+	nzb.Category = p.definition.NewznabCategory(result["category"])
+	nzb.IMDBID = result["imdb"]
+	nzb.Season = result["season"]
+	nzb.Episode = result["episode"]
+
+	if id, err := strconv.Atoi(result["tvdbid"]); err == nil {
+		nzb.TVDBID = id
+	}
+
+	if seeders, err := strconv.Atoi(strings.ReplaceAll(result["seeders"], ",", "")); err == nil {
+		nzb.Seeders = seeders
+	}
+
+	return nzb, true
+}
+
+// readFields evaluates the fields of the definition for a row. Selector fields
+// are read first so text templates can use them via .Result.
+func (p *Provider) readFields(row *html.Node, data *templateData) map[string]string {
+	names := make([]string, 0, len(p.definition.Search.Fields))
+	for name := range p.definition.Search.Fields {
+		names = append(names, name)
+	}
+
+	slices.SortStableFunc(names, func(a, b string) int {
+		at, bt := p.definition.Search.Fields[a].Text != "", p.definition.Search.Fields[b].Text != ""
+		switch {
+		case at == bt:
+			return strings.Compare(a, b)
+		case at:
+			return 1
+		default:
+			return -1
+		}
+	})
+
+	result := make(map[string]string, len(names))
+	rowdata := *data
+	rowdata.Result = result
+
+	for _, name := range names {
+		field := p.definition.Search.Fields[name]
+
+		var value string
+
+		if field.Text != "" {
+			rendered, err := render(field.Text, &rowdata)
+			if err != nil {
+				logger.Logtype(logger.StatusDebug, 2).
+					Str("provider", p.definition.ID).
+					Str("field", name).
+					Err(err).
+					Msg("cardigann field template failed")
+
+				continue
+			}
+
+			value = rendered
+		} else if field.Selector != "" {
+			value = htmlselect.ExtractText(row, field.Selector, field.Attribute)
+		} else {
+			value = htmlselect.Text(row, field.Attribute)
+		}
+
+		filtered, err := applyFilters(value, field.Filters)
+		if err != nil {
+			logger.Logtype(logger.StatusDebug, 2).
+				Str("provider", p.definition.ID).
+				Str("field", name).
+				Err(err).
+				Msg("cardigann field filter failed")
+		}
+
+		result[name] = filtered
+	}
+
+	return result
+}
+
+// Download saves the torrent file of a release. Definitions with a download
+// block read the torrent link from the details page first. Releases only
+// offering a magnet link are saved as .magnet file holding the link.
+func (p *Provider) Download(ctx context.Context,
+	requestURL string,
+	targetpath string,
+	filename string,
+) error {
+	if strings.HasPrefix(requestURL, "magnet:") {
+		return writeMagnet(requestURL, targetpath, filename)
+	}
+
+	if err := p.ensureLogin(ctx); err != nil {
+		return err
+	}
+
+	if p.definition.Download != nil && p.definition.Download.Selector != "" {
+		link, err := p.downloadLink(ctx, requestURL)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(link, "magnet:") {
+			return writeMagnet(link, targetpath, filename)
+		}
+
+		requestURL = link
+	}
+
+	return p.DownloadClient.MakeRequestWithGracePeriod(
+		ctx,
+		"GET",
+		requestURL,
+		nil,
+		nil,
+		func(resp *http.Response) error {
+			out, createErr := os.Create(filepath.Join(targetpath, filename))
+			if createErr != nil {
+				return createErr
+			}
+			defer out.Close()
+
+			if _, copyErr := io.Copy(out, resp.Body); copyErr != nil {
+				return copyErr
+			}
+
+			return out.Sync()
+		},
+		120*time.Second,
+	)
+}
+
+// downloadLink reads the torrent link of the details page.
+func (p *Provider) downloadLink(ctx context.Context, details string) (string, error) {
+	doc, err := p.fetchPage(ctx, "get", details, nil, nil, &templateData{Config: p.settings})
+	if err != nil {
+		return "", err
+	}
+
+	link := htmlselect.ExtractText(
+		doc,
+		p.definition.Download.Selector,
+		p.definition.Download.Attribute,
+	)
+	if link == "" {
+		return "", errNoDownloadLink
+	}
+
+	return p.resolve(link), nil
+}
+
+// writeMagnet saves the magnet link as .magnet file next to where the torrent
+// file would have been saved, for download clients watching the folder.
+func writeMagnet(magnet, targetpath, filename string) error {
+	filename = strings.TrimSuffix(filename, filepath.Ext(filename)) + ".magnet"
+
+	return os.WriteFile(filepath.Join(targetpath, filename), []byte(magnet), 0o644)
+}
+
+// ensureLogin logs in once per session.
+func (p *Provider) ensureLogin(ctx context.Context) error {
+	p.loginMu.Lock()
+	defer p.loginMu.Unlock()
+
+	if p.loggedIn {
+		return nil
+	}
+
+	if err := p.login(ctx); err != nil {
+		return err
+	}
+
+	p.loggedIn = true
+
+	return nil
+}
+
+// login runs the login block of the definition. A configured cookie replaces
+// the login.
+func (p *Provider) login(ctx context.Context) error {
+	login := p.definition.Login
+
+	if p.cookie != "" {
+		return p.setCookies(p.cookie)
+	}
+
+	if login == nil {
+		return nil
+	}
+
+	if strings.EqualFold(login.Method, "cookie") {
+		return errNoCookie
+	}
+
+	method := "post"
+	if strings.EqualFold(login.Method, "get") {
+		method = "get"
+	}
+
+	data := &templateData{Config: p.settings}
+
+	doc, err := p.fetchPage(ctx, method, login.Path, login.Inputs, nil, data)
+	if err != nil {
+		return err
+	}
+
+	if err := loginError(doc, login.Error); err != nil {
+		return err
+	}
+
+	if login.Test == nil || login.Test.Selector == "" {
+		return nil
+	}
+
+	doc, err = p.fetchPage(ctx, "get", login.Test.Path, nil, nil, data)
+	if err != nil {
+		return err
+	}
+
+	if htmlselect.FindOne(doc, login.Test.Selector) == nil {
+		return errLoginFailed
+	}
+
+	return nil
+}
+
+// sessionExpired reports whether the page fails the login test of the
+// definition.
+func (p *Provider) sessionExpired(doc *html.Node) bool {
+	login := p.definition.Login
+	if login == nil || login.Test == nil || login.Test.Selector == "" ||
+		strings.EqualFold(login.Method, "cookie") {
+		return false
+	}
+
+	return htmlselect.FindOne(doc, login.Test.Selector) == nil
+}
+
+// loginError returns the message of the first error selector found on the
+// login response.
+func loginError(doc *html.Node, selectors []SelectorBlock) error {
+	for idx := range selectors {
+		node := htmlselect.FindOne(doc, selectors[idx].Selector)
+		if node == nil {
+			continue
+		}
+
+		msg := htmlselect.Text(node, selectors[idx].Attribute)
+		if msg == "" {
+			msg = "error selector " + selectors[idx].Selector + " found"
+		}
+
+		return errors.New(logger.JoinStrings("login failed: ", msg))
+	}
+
+	return nil
+}
+
+// setCookies stores a cookie header ("uid=1; pass=abc") for the site.
+func (p *Provider) setCookies(header string) error {
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return err
+	}
+
+	cookies, err := http.ParseCookie(header)
+	if err != nil {
+		return err
+	}
+
+	p.jar.SetCookies(u, cookies)
+
+	return nil
+}
+
+// fetchPage renders path and inputs, requests the page and parses it. Inputs
+// are sent as query string for get and as form for post requests. The input
+// $raw is appended to the query string as is.
+func (p *Provider) fetchPage(
+	ctx context.Context,
+	method, path string,
+	inputs, headers map[string]string,
+	data *templateData,
+) (*html.Node, error) {
+	rendered, err := render(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(url.Values, len(inputs))
+
+	var raw string
+
+	for key, tmpl := range inputs {
+		value, err := render(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "$raw" {
+			raw = value
+			continue
+		}
+
+		values.Set(key, value)
+	}
+
+	requestURL := p.resolve(rendered)
+
+	var body io.Reader
+
+	// Sites answer with html, not json
+	reqheaders := map[string]string{"Accept": "text/html,application/xhtml+xml,*/*"}
+
+	if strings.EqualFold(method, "post") {
+		method = http.MethodPost
+		body = strings.NewReader(values.Encode())
+		reqheaders["Content-Type"] = "application/x-www-form-urlencoded"
+	} else {
+		method = http.MethodGet
+
+		query := values.Encode()
+		if raw = strings.TrimPrefix(raw, "&"); raw != "" {
+			if query != "" {
+				query += "&"
+			}
+
+			query += raw
+		}
+
+		if query != "" {
+			sep := "?"
+			if strings.Contains(requestURL, "?") {
+				sep = "&"
+			}
+
+			requestURL = logger.JoinStrings(requestURL, sep, query)
+		}
+	}
+
+	for key, value := range headers {
+		reqheaders[key] = value
+	}
+
+	var doc *html.Node
+
+	err = p.MakeRequestWithHeaders(ctx, method, requestURL, body, nil, func(resp *http.Response) error {
+		var parseErr error
+
+		doc, parseErr = html.Parse(resp.Body)
+
+		return parseErr
+	}, reqheaders)
+
+	return doc, err
+}
+
+// resolve returns ref as absolute url of the site.
+func (p *Provider) resolve(ref string) string {
+	if strings.HasPrefix(ref, "magnet:") {
+		return ref
+	}
+
+	return htmlselect.ResolveURL(p.baseURL, strings.TrimSpace(ref))
+}
+
+// render executes a template of the definition.
+func render(tmpl string, data *templateData) (string, error) {
+	if !strings.Contains(tmpl, "{{") {
+		return tmpl, nil
+	}
+
+	t, err := template.New("cardigann").Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,166 @@
+package cardigann
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scrapers/htmlselect"
+	"golang.org/x/net/html"
+)
+
+const testDefinition = `
+id: example
+name: Example Tracker
+links:
+  - https://tracker.example/
+caps:
+  categorymappings:
+    - {id: 1, cat: Movies/HD, default: true}
+    - {id: 2, cat: TV/HD}
+    - {id: 3, cat: "2045"}
+  modes:
+    search: [q]
+    movie-search: [q, imdbid]
+search:
+  path: browse.php
+  inputs:
+    search: "{{ .Keywords }}"
+    cat: "{{ join .Categories \",\" }}"
+  rows:
+    selector: table.torrents tr
+    after: 1
+  fields:
+    category:
+      selector: a[href*="cat="]
+      attribute: href
+      filters:
+        - name: querystring
+          args: cat
+    title:
+      selector: a.name
+    details:
+      selector: a.name
+      attribute: href
+    download:
+      selector: .//a[contains(@href, "download.php")]
+      attribute: href
+    size:
+      selector: td.size
+    imdb:
+      text: "{{ .Result.title }}"
+      filters:
+        - name: regexp
+          args: "(tt\\d+)"
+`
+
+const testPage = `<html><body><table class="torrents">
+<tr><th>Name</th></tr>
+<tr>
+ <td><a href="browse.php?cat=1">Movies</a></td>
+ <td><a class="name" href="details.php?id=10">Some.Movie.2024.1080p.WEB tt1234567</a></td>
+ <td><a href="download.php?id=10">DL</a></td>
+ <td class="size">1.5 GB</td>
+</tr>
+<tr>
+ <td><a href="browse.php?cat=2">TV</a></td>
+ <td><a class="name" href="details.php?id=11">Some.Show.S01E01.720p</a></td>
+ <td class="size">700 MiB</td>
+</tr>
+</table></body></html>`
+
+func TestParseDefinition(t *testing.T) {
+	def, err := ParseDefinition([]byte(testDefinition))
+	if err != nil {
+		t.Fatalf("parse definition: %v", err)
+	}
+
+	if got := def.SiteCategories([]string{"2000"}); !slices.Equal(got, []string{"1", "3"}) {
+		t.Errorf("SiteCategories(2000) = %v", got)
+	}
+
+	if got := def.SiteCategories(nil); !slices.Equal(got, []string{"1"}) {
+		t.Errorf("SiteCategories(nil) = %v", got)
+	}
+
+	if got := def.NewznabCategory("2"); got != "5040" {
+		t.Errorf("NewznabCategory(2) = %q", got)
+	}
+
+	caps := def.Capabilities()
+	if !slices.Equal(caps.SearchModes, []string{"movie", "search"}) {
+		t.Errorf("SearchModes = %v", caps.SearchModes)
+	}
+
+	if !slices.Equal(caps.SearchParams["movie"], []string{"q", "imdbid"}) {
+		t.Errorf("movie params = %v", caps.SearchParams["movie"])
+	}
+
+	if _, err := ParseDefinition([]byte("id: broken\nsearch:\n  path: x\n")); err == nil {
+		t.Error("definition without rows accepted")
+	}
+}
+
+func TestRowToNzb(t *testing.T) {
+	def, err := ParseDefinition([]byte(testDefinition))
+	if err != nil {
+		t.Fatalf("parse definition: %v", err)
+	}
+
+	doc, err := html.Parse(strings.NewReader(testPage))
+	if err != nil {
+		t.Fatalf("parse page: %v", err)
+	}
+
+	p := &Provider{definition: def, baseURL: "https://tracker.example/"}
+	data := &templateData{Query: &Query{}}
+
+	rows := htmlselect.Find(doc, def.Search.Rows.Selector)[def.Search.Rows.After:]
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+
+	nzb, ok := p.rowToNzb(rows[0], data, nil, nil)
+	if !ok {
+		t.Fatal("first row skipped")
+	}
+
+	if nzb.Title != "Some.Movie.2024.1080p.WEB tt1234567" ||
+		nzb.DownloadURL != "https://tracker.example/download.php?id=10" ||
+		nzb.ID != "https://tracker.example/details.php?id=10" ||
+		nzb.Category != "2040" ||
+		nzb.IMDBID != "tt1234567" ||
+		nzb.Size != 1500000000 ||
+		!nzb.IsTorrent {
+		t.Errorf("first row = %+v", nzb)
+	}
+
+	if _, ok := p.rowToNzb(rows[1], data, nil, nil); ok {
+		t.Error("row without download link accepted")
+	}
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		value   string
+		filters []Filter
+		want    string
+	}{
+		{"a-b-c", []Filter{{Name: "replace", Args: []any{"-", "."}}}, "a.b.c"},
+		{"Size: 12 GB", []Filter{{Name: "re_replace", Args: []any{`^Size:\s*`, ""}}}, "12 GB"},
+		{"a/b/c", []Filter{{Name: "split", Args: []any{"/", -1}}}, "c"},
+		{" Title ", []Filter{{Name: "trim"}, {Name: "toupper"}}, "TITLE"},
+		{"2024-05-01", []Filter{{Name: "dateparse", Args: "2006-01-02"}}, "2024-05-01T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		got, err := applyFilters(tt.value, tt.filters)
+		if err != nil || got != tt.want {
+			t.Errorf("applyFilters(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+
+	if got := parseSize("700 MiB"); got != 700*1024*1024 {
+		t.Errorf("parseSize(700 MiB) = %d", got)
+	}
+}
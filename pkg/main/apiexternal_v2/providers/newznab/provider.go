@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
@@ -34,7 +35,6 @@ type ProviderConfig struct {
 	Categories        []string
 	OutputAsJSON      bool
 	TimeoutSeconds    uint16
-	CustomAPI         string // Custom API parameter name (default: "apikey")
 	CustomURL         string // Custom API URL path (default: "/api")
 	CustomRSSURL      string // Custom RSS URL path (default: "/rss")
 	CustomRSSCategory string // Custom RSS category parameter (default: "&t=")
@@ -45,6 +45,9 @@ type ProviderConfig struct {
 	LimiterSeconds    uint8  // Time window in seconds for rate limiting (default: 3600)
 	LimiterCallsDaily int    // Maximum number of API calls allowed per day (default: 2000)
 	Enabled           bool   // Whether this indexer is active; skips capability fetch if false
+
+	// Proxy for searches and downloads, an empty URL uses the default proxy
+	Proxy base.ProxyConfig
 }
 
 // Provider implements the IndexerProvider interface for Newznab/Torznab.
@@ -56,7 +59,6 @@ type Provider struct {
 	categories        []string
 	isTorznab         bool
 	outputAsJSON      bool   // When true, request and parse JSON responses instead of XML
-	customAPI         string // Custom API parameter name (if not "apikey")
 	customURL         string // Custom API URL path (if not "/api")
 	customRSSURL      string // Custom RSS URL path (if not "/rss")
 	customRSSCategory string // Custom RSS category parameter (if not "&t=")
@@ -67,6 +69,15 @@ type Provider struct {
 	// SupportedCategories contains all category IDs (including subcategories) reported by the
 	// indexer's caps endpoint. Populated once during NewProvider and used for resolution inference.
 	SupportedCategories []string
+
+	// Capabilities holds the caps fetched during NewProvider, nil if the request failed
+	// or the indexer is disabled.
+	Capabilities *apiexternal_v2.IndexerCapabilities
+
+	// quota holds the api and grab usage reported since the last TakeQuota
+	quotaMu  sync.Mutex
+	quota    apiexternal_v2.IndexerQuota
+	quotaSet bool
 }
 
 var ErrBroke = errors.New("broke")
@@ -119,6 +130,7 @@ func NewProvider(config ProviderConfig) *Provider {
 		StatsDBTable:            "api_client_stats",
 		MaxRetries:              3,
 		RetryBackoff:            2 * time.Second,
+		Proxy:                   config.Proxy,
 	}
 
 	// Create separate client for download statistics tracking
@@ -138,6 +150,7 @@ func NewProvider(config ProviderConfig) *Provider {
 		StatsDBTable:            "api_client_stats",
 		MaxRetries:              3,
 		RetryBackoff:            2 * time.Second,
+		Proxy:                   config.Proxy,
 	}
 
 	p := &Provider{
@@ -147,7 +160,6 @@ func NewProvider(config ProviderConfig) *Provider {
 		apiKey:            config.APIKey,
 		categories:        config.Categories,
 		outputAsJSON:      config.OutputAsJSON,
-		customAPI:         config.CustomAPI,
 		customURL:         config.CustomURL,
 		customRSSURL:      config.CustomRSSURL,
 		customRSSCategory: config.CustomRSSCategory,
@@ -163,6 +175,7 @@ func NewProvider(config ProviderConfig) *Provider {
 	if config.Enabled {
 		if caps, err := p.GetCapabilities(context.Background()); err == nil {
 			p.SupportedCategories = flattenCategoryIDs(caps.Categories)
+			p.Capabilities = caps
 		}
 	}
 
@@ -231,12 +244,7 @@ func (p *Provider) Search(
 	}
 
 	// API key
-	apiKeyParam := "apikey"
-	if p.customAPI != "" {
-		apiKeyParam = p.customAPI
-	}
-
-	writeParam(apiKeyParam, p.apiKey)
+	writeParam("apikey", p.apiKey)
 
 	// Determine search type
 	searchType := "search"
@@ -515,12 +523,7 @@ func (p *Provider) GetRSSFeed(
 	}
 
 	// API key
-	apiKeyParam := "apikey"
-	if p.customAPI != "" {
-		apiKeyParam = p.customAPI
-	}
-
-	writeParam(apiKeyParam, p.apiKey)
+	writeParam("apikey", p.apiKey)
 
 	// RSS category parameter (default "t"); trim surrounding & and = if present
 	categoryParam := "t"
@@ -593,8 +596,8 @@ func (p *Provider) MakeSearchRequest(
 	// Build URL following the old buildurlnew logic with custom URL support
 	var requestURL string
 	if p.customURL != "" {
-		// Use custom URL path if configured
-		requestURL = logger.JoinStrings(p.baseURL, p.customURL, "?", params.Encode())
+		// Use custom URL path or absolute URL if configured
+		requestURL = customRequestURL(p.baseURL, p.customURL, params.Encode())
 	} else {
 		// Default to /api
 		requestURL = logger.JoinStrings(p.baseURL, "/api?", params.Encode())
@@ -633,14 +636,7 @@ func (p *Provider) makeRSSRequest(
 	if p.customRSSURL != "" {
 		// Use custom RSS URL path if configured
 		// Can be either a relative path or an absolute URL
-		if strings.HasPrefix(p.customRSSURL, "http://") ||
-			strings.HasPrefix(p.customRSSURL, "https://") {
-			// Absolute URL
-			requestURL = logger.JoinStrings(p.customRSSURL, "?", params.Encode())
-		} else {
-			// Relative path
-			requestURL = logger.JoinStrings(p.baseURL, p.customRSSURL, "?", params.Encode())
-		}
+		requestURL = customRequestURL(p.baseURL, p.customRSSURL, params.Encode())
 	} else {
 		// Default to /rss
 		requestURL = logger.JoinStrings(p.baseURL, "/rss?", params.Encode())
@@ -674,6 +670,9 @@ func (p *Provider) ExecuteRequest(
 	var ret []apiexternal_v2.Nzbwithprio
 
 	err := p.MakeRequest(ctx, "GET", requestURL, nil, nil, func(resp *http.Response) error {
+		quota := quotaFromHeaders(resp.Header, time.Now())
+		p.mergeQuota(&quota)
+
 		var err error
 		// If JSON output is enabled, try JSON parsing with format fallback
 		if p.outputAsJSON {
@@ -830,6 +829,10 @@ func (p *Provider) parseXMLResponse(
 					}
 				}
 
+			case "apilimits":
+				quota := quotaFromAPILimits(t.Attr)
+				p.mergeQuota(&quota)
+
 			case "attr":
 				// Newznab attribute element with name/value pairs
 				if !inItem {
@@ -884,6 +887,12 @@ func (p *Provider) parseXMLResponse(
 						currentNZB.Category = t.Attr[valueidx].Value
 					}
 
+				case "seeders":
+					seeders, err := strconv.Atoi(t.Attr[valueidx].Value)
+					if err == nil {
+						currentNZB.Seeders = seeders
+					}
+
 				case "size", "length":
 					if currentNZB.Size != 0 {
 						break
@@ -1089,6 +1098,11 @@ func (p *Provider) convertJSON1Items(
 				if nzb.Category == "" {
 					nzb.Category = items[i].Attributes[j].Attribute.Value
 				}
+
+			case "seeders":
+				if seeders, err := strconv.Atoi(items[i].Attributes[j].Attribute.Value); err == nil {
+					nzb.Seeders = seeders
+				}
 			}
 		}
 
@@ -1208,6 +1222,11 @@ func (*Provider) processAttributeForNzb(
 			nzb.Category = value
 		}
 
+	case "seeders":
+		if seeders, err := strconv.Atoi(value); err == nil {
+			nzb.Seeders = seeders
+		}
+
 	case "size":
 		if nzb.Size != 0 {
 			break
@@ -1245,7 +1264,8 @@ type newznabSearching struct {
 }
 
 type newznabSearch struct {
-	Available string `xml:"available,attr"`
+	Available       string `xml:"available,attr"`
+	SupportedParams string `xml:"supportedParams,attr"`
 }
 
 type newznabCategory struct {
@@ -1260,16 +1280,21 @@ type newznabCategory struct {
 
 func convertCapabilities(caps *newznabCaps) *apiexternal_v2.IndexerCapabilities {
 	searchModes := []string{}
+	searchParams := make(map[string][]string, 3)
+
 	if caps.Searching.Search.Available == "yes" {
 		searchModes = append(searchModes, "search")
+		searchParams["search"] = splitSupportedParams(caps.Searching.Search.SupportedParams)
 	}
 
 	if caps.Searching.TVSearch.Available == "yes" {
 		searchModes = append(searchModes, "tvsearch")
+		searchParams["tvsearch"] = splitSupportedParams(caps.Searching.TVSearch.SupportedParams)
 	}
 
 	if caps.Searching.MovieSearch.Available == "yes" {
 		searchModes = append(searchModes, "movie")
+		searchParams["movie"] = splitSupportedParams(caps.Searching.MovieSearch.SupportedParams)
 	}
 
 	categories := make([]apiexternal_v2.IndexerCategory, len(caps.Categories))
@@ -1281,6 +1306,7 @@ func convertCapabilities(caps *newznabCaps) *apiexternal_v2.IndexerCapabilities
 		ServerTitle:   caps.Server.Title,
 		ServerVersion: caps.Server.Version,
 		SearchModes:   searchModes,
+		SearchParams:  searchParams,
 		Categories:    categories,
 		Limits: apiexternal_v2.IndexerLimits{
 			MaxResults:   caps.Limits.Max,
@@ -1289,6 +1315,25 @@ func convertCapabilities(caps *newznabCaps) *apiexternal_v2.IndexerCapabilities
 	}
 }
 
+// splitSupportedParams splits the supportedParams attribute of a caps search
+// mode ("q,imdbid,tmdbid") into its lowercased parameter names.
+func splitSupportedParams(params string) []string {
+	if params == "" {
+		return nil
+	}
+
+	parts := strings.Split(strings.ToLower(params), ",")
+
+	out := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+
+	return out
+}
+
 func convertCategory(cat *newznabCategory) apiexternal_v2.IndexerCategory {
 	subcats := make([]apiexternal_v2.IndexerCategory, len(cat.Subcategories))
 	for i, sub := range cat.Subcategories {
@@ -1319,3 +1364,18 @@ func flattenCategoryIDs(cats []apiexternal_v2.IndexerCategory) []string {
 
 	return ids
 }
+
+// customRequestURL builds the request url from a custom url of the indexer,
+// which can be a path relative to the base url or an absolute url. Absolute
+// urls may already carry query parameters like the api key.
+func customRequestURL(baseURL, custom, query string) string {
+	if !strings.HasPrefix(custom, "http://") && !strings.HasPrefix(custom, "https://") {
+		return logger.JoinStrings(baseURL, custom, "?", query)
+	}
+
+	if strings.Contains(custom, "?") {
+		return logger.JoinStrings(custom, "&", query)
+	}
+
+	return logger.JoinStrings(custom, "?", query)
+}
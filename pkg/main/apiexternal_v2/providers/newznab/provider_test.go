@@ -0,0 +1,86 @@
+package newznab
+
+import (
+	"encoding/xml"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConvertCapabilities(t *testing.T) {
+	const capsXML = `<?xml version="1.0" encoding="UTF-8"?>
+<caps>
+  <server version="1.0" title="Example Indexer"/>
+  <limits max="100" default="50"/>
+  <searching>
+    <search available="yes" supportedParams="q"/>
+    <tv-search available="yes" supportedParams="q,rid,tvdbid,season,ep, imdbid"/>
+    <movie-search available="no" supportedParams="q,imdbid"/>
+  </searching>
+  <categories>
+    <category id="5000" name="TV">
+      <subcat id="5040" name="HD"/>
+    </category>
+  </categories>
+</caps>`
+
+	var caps newznabCaps
+	if err := xml.Unmarshal([]byte(capsXML), &caps); err != nil {
+		t.Fatalf("unmarshal caps: %v", err)
+	}
+
+	got := convertCapabilities(&caps)
+
+	if !slices.Equal(got.SearchModes, []string{"search", "tvsearch"}) {
+		t.Errorf("SearchModes = %v", got.SearchModes)
+	}
+
+	if !slices.Equal(
+		got.SearchParams["tvsearch"],
+		[]string{"q", "rid", "tvdbid", "season", "ep", "imdbid"},
+	) {
+		t.Errorf("tvsearch params = %v", got.SearchParams["tvsearch"])
+	}
+
+	if _, ok := got.SearchParams["movie"]; ok {
+		t.Errorf("movie params set although movie-search is unavailable")
+	}
+
+	if got.Limits.MaxResults != 100 || got.Limits.DefaultLimit != 50 {
+		t.Errorf("Limits = %+v", got.Limits)
+	}
+
+	if ids := flattenCategoryIDs(got.Categories); !slices.Equal(ids, []string{"5000", "5040"}) {
+		t.Errorf("categories = %v", ids)
+	}
+}
+
+func TestQuotaParsing(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "100")
+	header.Set("X-RateLimit-Remaining", "40")
+	header.Set("X-RateLimit-Reset", "3600")
+
+	quota := quotaFromHeaders(header, now)
+	if quota.APIMax != 100 || quota.APICurrent != 60 || !quota.APIReset.Equal(now.Add(time.Hour)) {
+		t.Errorf("quotaFromHeaders = %+v", quota)
+	}
+
+	var limits struct {
+		Attrs []xml.Attr `xml:",any,attr"`
+	}
+
+	const apilimits = `<apilimits apicurrent="12" apimax="100" grabcurrent="2" grabmax="10" graboldesttime="Wed, 01 May 2024 10:00:00 +0000"/>`
+	if err := xml.Unmarshal([]byte(apilimits), &limits); err != nil {
+		t.Fatalf("unmarshal apilimits: %v", err)
+	}
+
+	quota = quotaFromAPILimits(limits.Attrs)
+	if quota.APICurrent != 12 || quota.APIMax != 100 || quota.GrabCurrent != 2 || quota.GrabMax != 10 ||
+		!quota.GrabReset.Equal(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("quotaFromAPILimits = %+v", quota)
+	}
+}
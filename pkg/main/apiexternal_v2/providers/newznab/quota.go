@@ -0,0 +1,128 @@
+package newznab
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+)
+
+// quotaWindow is the period of the daily limits of newznab indexers.
+const quotaWindow = 24 * time.Hour
+
+// TakeQuota returns the api and grab usage reported by the latest responses
+// and clears it. ok is false if nothing was reported since the last call.
+func (p *Provider) TakeQuota() (apiexternal_v2.IndexerQuota, bool) {
+	p.quotaMu.Lock()
+	defer p.quotaMu.Unlock()
+
+	quota, ok := p.quota, p.quotaSet
+	p.quota = apiexternal_v2.IndexerQuota{}
+	p.quotaSet = false
+
+	return quota, ok
+}
+
+// mergeQuota records reported usage. Values missing in reported keep the
+// previously reported ones.
+func (p *Provider) mergeQuota(reported *apiexternal_v2.IndexerQuota) {
+	if reported.APIMax == 0 && reported.GrabMax == 0 {
+		return
+	}
+
+	p.quotaMu.Lock()
+	defer p.quotaMu.Unlock()
+
+	if reported.APIMax != 0 {
+		p.quota.APIMax = reported.APIMax
+		p.quota.APICurrent = reported.APICurrent
+		p.quota.APIReset = reported.APIReset
+	}
+
+	if reported.GrabMax != 0 {
+		p.quota.GrabMax = reported.GrabMax
+		p.quota.GrabCurrent = reported.GrabCurrent
+		p.quota.GrabReset = reported.GrabReset
+	}
+
+	p.quotaSet = true
+}
+
+// quotaFromHeaders reads the X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset headers of a response. The reset is either a unix
+// timestamp or the seconds until the reset.
+func quotaFromHeaders(header http.Header, now time.Time) apiexternal_v2.IndexerQuota {
+	var quota apiexternal_v2.IndexerQuota
+
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return quota
+	}
+
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return quota
+	}
+
+	quota.APIMax = limit
+	quota.APICurrent = max(limit-remaining, 0)
+
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil &&
+		reset > 0 {
+		if reset > 1_000_000_000 {
+			quota.APIReset = time.Unix(reset, 0)
+		} else {
+			quota.APIReset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	return quota
+}
+
+// quotaFromAPILimits reads the newznab:apilimits element of a response, e.g.
+// <newznab:apilimits apicurrent="12" apimax="100" grabcurrent="2" grabmax="10"
+// apioldesttime="..." graboldesttime="..."/>. The oldest request of the day
+// plus 24 hours is the time a slot becomes free again.
+func quotaFromAPILimits(attrs []xml.Attr) apiexternal_v2.IndexerQuota {
+	var quota apiexternal_v2.IndexerQuota
+
+	for idx := range attrs {
+		value := attrs[idx].Value
+
+		switch attrs[idx].Name.Local {
+		case "apicurrent":
+			quota.APICurrent, _ = strconv.Atoi(value)
+		case "apimax":
+			quota.APIMax, _ = strconv.Atoi(value)
+		case "grabcurrent":
+			quota.GrabCurrent, _ = strconv.Atoi(value)
+		case "grabmax":
+			quota.GrabMax, _ = strconv.Atoi(value)
+		case "apioldesttime":
+			if t, ok := parseQuotaTime(value); ok {
+				quota.APIReset = t.Add(quotaWindow)
+			}
+
+		case "graboldesttime":
+			if t, ok := parseQuotaTime(value); ok {
+				quota.GrabReset = t.Add(quotaWindow)
+			}
+		}
+	}
+
+	return quota
+}
+
+// parseQuotaTime parses the timestamps of apilimits - indexers use RFC1123Z
+// like pubDate or RFC3339.
+func parseQuotaTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123, time.RFC3339, time.DateTime} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,367 @@
+package opensubtitles
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+//
+// OpenSubtitles Provider - Subtitle search and download
+// API: https://opensubtitles.stoplight.io/docs/opensubtitles-api
+// Any service implementing the same REST API can be used by changing the base URL.
+//
+
+const (
+	defaultBaseURL = "https://api.opensubtitles.com/api/v1"
+
+	// hashChunkSize is the size of the head and tail blocks used by the file hash.
+	hashChunkSize = 64 * 1024
+
+	// maxSubtitleSize limits the size of a downloaded subtitle file.
+	maxSubtitleSize = 10 * 1024 * 1024
+)
+
+var (
+	errNoDownloadLink = errors.New("opensubtitles returned no download link")
+	errFileTooSmall   = errors.New("file too small to hash")
+)
+
+// Provider implements the subtitle provider for OpenSubtitles.
+type Provider struct {
+	*base.BaseClient
+	username string
+	password string
+
+	tokenMu sync.Mutex
+	token   string
+
+	// quotaReset is the time the used up download quota is reset
+	quotaMu    sync.Mutex
+	quotaReset time.Time
+}
+
+// NewProviderWithConfig creates a new OpenSubtitles provider with custom config.
+// The API key is sent in the Api-Key header. Username and password are optional,
+// a login raises the daily download quota.
+func NewProviderWithConfig(config base.ClientConfig, username, password string) *Provider {
+	config.Name = "opensubtitles"
+	if config.BaseURL == "" {
+		config.BaseURL = defaultBaseURL
+	}
+
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	config.AuthType = base.AuthAPIKeyHeader
+	config.APIKeyHeader = "Api-Key"
+
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	// OpenSubtitles rate limit: 5 requests per second
+	if config.RateLimitCalls == 0 {
+		config.RateLimitCalls = 5
+	}
+
+	if config.RateLimitSeconds == 0 {
+		config.RateLimitSeconds = 1
+	}
+
+	return &Provider{
+		BaseClient: base.NewBaseClient(config),
+		username:   username,
+		password:   password,
+	}
+}
+
+// GetProviderType returns the provider type.
+func (*Provider) GetProviderType() apiexternal_v2.SubtitleProviderType {
+	return apiexternal_v2.SubtitleProviderOpenSubtitles
+}
+
+// GetProviderName returns the provider name.
+func (*Provider) GetProviderName() string {
+	return "opensubtitles"
+}
+
+//
+// Search and Download Methods
+//
+
+// SearchSubtitles searches subtitles by file hash, IMDb ID and release name.
+// Parameters are written in alphabetical order as recommended by the API to avoid redirects.
+func (p *Provider) SearchSubtitles(
+	ctx context.Context,
+	request apiexternal_v2.SubtitleSearchRequest,
+) ([]apiexternal_v2.SubtitleResult, error) {
+	buf := logger.PlAddBuffer.Get()
+	buf.WriteString("/subtitles?")
+
+	if request.Episode > 0 {
+		buf.WriteString("episode_number=")
+		buf.WriteInt(request.Episode)
+		buf.WriteString("&")
+	}
+
+	if id := imdbNumber(request.ImdbID); id != "" {
+		buf.WriteString("imdb_id=")
+		buf.WriteString(id)
+		buf.WriteString("&")
+	}
+
+	if len(request.Languages) > 0 {
+		buf.WriteString("languages=")
+		buf.WriteURL(strings.ToLower(strings.Join(request.Languages, ",")))
+		buf.WriteString("&")
+	}
+
+	if request.MovieHash != "" {
+		buf.WriteString("moviehash=")
+		buf.WriteURL(request.MovieHash)
+		buf.WriteString("&")
+	}
+
+	if id := imdbNumber(request.ParentImdbID); id != "" {
+		buf.WriteString("parent_imdb_id=")
+		buf.WriteString(id)
+		buf.WriteString("&")
+	}
+
+	if request.Query != "" {
+		buf.WriteString("query=")
+		buf.WriteURL(strings.ToLower(request.Query))
+		buf.WriteString("&")
+	}
+
+	if request.Season > 0 {
+		buf.WriteString("season_number=")
+		buf.WriteInt(request.Season)
+		buf.WriteString("&")
+	}
+
+	endpoint := strings.TrimSuffix(buf.String(), "&")
+	logger.PlAddBuffer.Put(buf)
+
+	var response searchResponse
+	if err := p.MakeRequestWithHeaders(
+		ctx,
+		http.MethodGet,
+		endpoint,
+		nil,
+		&response,
+		nil,
+		p.authHeaders(ctx),
+	); err != nil {
+		return nil, err
+	}
+
+	return convertSearchResults(response.Data), nil
+}
+
+// DownloadSubtitle requests a download link for the subtitle and returns the file content.
+func (p *Provider) DownloadSubtitle(
+	ctx context.Context,
+	subtitle *apiexternal_v2.SubtitleResult,
+) ([]byte, error) {
+	if p.quotaReached() {
+		return nil, apiexternal_v2.ErrSubtitleQuota
+	}
+
+	fileID, err := strconv.Atoi(subtitle.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(downloadRequest{FileID: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	var response downloadResponse
+	if err := p.MakeRequestWithHeaders(
+		ctx,
+		http.MethodPost,
+		"/download",
+		bytes.NewReader(jsonData),
+		&response,
+		nil,
+		p.authHeaders(ctx),
+	); err != nil {
+		// The API answers 406 once the daily downloads are used up
+		if strings.Contains(err.Error(), "HTTP 406") {
+			p.setQuotaReset(time.Time{})
+			return nil, apiexternal_v2.ErrSubtitleQuota
+		}
+
+		return nil, err
+	}
+
+	// This download used the last one of the quota
+	if response.Requests > 0 && response.Remaining <= 0 {
+		p.setQuotaReset(response.ResetTimeUTC)
+	}
+
+	if response.Link == "" {
+		if response.Message != "" {
+			return nil, errors.New(
+				logger.JoinStrings("opensubtitles download failed: ", response.Message),
+			)
+		}
+
+		return nil, errNoDownloadLink
+	}
+
+	var content []byte
+	err = p.MakeRequest(
+		ctx,
+		http.MethodGet,
+		response.Link,
+		nil,
+		nil,
+		func(resp *http.Response) error {
+			var readErr error
+			content, readErr = io.ReadAll(io.LimitReader(resp.Body, maxSubtitleSize))
+			return readErr
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// TestConnection verifies the API key (and login if configured) with a minimal search.
+func (p *Provider) TestConnection(ctx context.Context) error {
+	_, err := p.SearchSubtitles(ctx, apiexternal_v2.SubtitleSearchRequest{
+		ImdbID:    "tt0133093",
+		Languages: []string{"en"},
+	})
+	return err
+}
+
+// authHeaders returns the Authorization header when a user login is configured.
+// The token is requested once and reused; a failed login falls back to anonymous requests.
+func (p *Provider) authHeaders(ctx context.Context) map[string]string {
+	if p.username == "" || p.password == "" {
+		return nil
+	}
+
+	p.tokenMu.Lock()
+	defer p.tokenMu.Unlock()
+
+	if p.token == "" {
+		jsonData, err := json.Marshal(loginRequest{Username: p.username, Password: p.password})
+		if err != nil {
+			return nil
+		}
+
+		var response loginResponse
+		err = p.MakeRequest(
+			ctx,
+			http.MethodPost,
+			"/login",
+			bytes.NewReader(jsonData),
+			&response,
+			nil,
+		)
+		if err != nil {
+			logger.Logtype(logger.StatusError, 0).
+				Str("provider", "opensubtitles").
+				Err(err).
+				Msg("login failed")
+
+			return nil
+		}
+
+		p.token = response.Token
+	}
+
+	if p.token == "" {
+		return nil
+	}
+
+	return map[string]string{"Authorization": "Bearer " + p.token}
+}
+
+// quotaReached reports if the download quota is used up and not reset yet.
+func (p *Provider) quotaReached() bool {
+	p.quotaMu.Lock()
+	defer p.quotaMu.Unlock()
+
+	return time.Now().Before(p.quotaReset)
+}
+
+// setQuotaReset marks the download quota as used up until the reset time.
+// Without a reset time the quota is used up until the next midnight UTC.
+func (p *Provider) setQuotaReset(reset time.Time) {
+	if reset.IsZero() {
+		reset = time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+
+	p.quotaMu.Lock()
+	p.quotaReset = reset
+	p.quotaMu.Unlock()
+}
+
+// imdbNumber converts an IMDb ID to the numeric form expected by the API.
+func imdbNumber(imdbID string) string {
+	id := strings.TrimLeft(strings.TrimPrefix(strings.ToLower(imdbID), "tt"), "0")
+	if id == "" {
+		return ""
+	}
+
+	if _, err := strconv.Atoi(id); err != nil {
+		return ""
+	}
+
+	return id
+}
+
+// Hash calculates the OpenSubtitles hash of a file: the file size plus the
+// 64 bit little endian sums of the first and the last 64 KB.
+func Hash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	if fi.Size() < hashChunkSize*2 {
+		return "", errFileTooSmall
+	}
+
+	hash := uint64(fi.Size())
+	buf := make([]byte, hashChunkSize)
+
+	for _, offset := range []int64{0, fi.Size() - hashChunkSize} {
+		if _, err := f.ReadAt(buf, offset); err != nil {
+			return "", err
+		}
+
+		for i := 0; i < hashChunkSize; i += 8 {
+			hash += binary.LittleEndian.Uint64(buf[i:])
+		}
+	}
+
+	return fmt.Sprintf("%016x", hash), nil
+}
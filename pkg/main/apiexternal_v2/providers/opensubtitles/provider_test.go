@@ -0,0 +1,202 @@
+package opensubtitles
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		words map[int]uint64 // little endian words written at the offsets
+		want  string
+	}{
+		{name: "Zeros add the size only", size: hashChunkSize * 2, want: "0000000000020000"},
+		{
+			name:  "Head and tail words are summed",
+			size:  hashChunkSize*3 + 8,
+			words: map[int]uint64{0: 1, 8: 2, hashChunkSize*3 + 8 - 8: 3},
+			want:  "000000000003000e",
+		},
+		{
+			name:  "Middle of the file is ignored",
+			size:  hashChunkSize * 3,
+			words: map[int]uint64{hashChunkSize + 8: 5},
+			want:  "0000000000030000",
+		},
+		{
+			name:  "Sum wraps around",
+			size:  hashChunkSize * 2,
+			words: map[int]uint64{0: ^uint64(0)},
+			want:  "000000000001ffff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := make([]byte, tt.size)
+			for offset, word := range tt.words {
+				binary.LittleEndian.PutUint64(data[offset:], word)
+			}
+
+			path := filepath.Join(t.TempDir(), "video.mkv")
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Hash(path)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Hash() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashSmallFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "small.mkv")
+	if err := os.WriteFile(path, make([]byte, hashChunkSize), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Hash(path); !errors.Is(err, errFileTooSmall) {
+		t.Errorf("Hash() error = %v, want %v", err, errFileTooSmall)
+	}
+}
+
+func TestImdbNumber(t *testing.T) {
+	tests := map[string]string{
+		"tt0133093": "133093",
+		"TT0133093": "133093",
+		"133093":    "133093",
+		"":          "",
+		"tt":        "",
+		"ttabc":     "",
+	}
+
+	for input, want := range tests {
+		if got := imdbNumber(input); got != want {
+			t.Errorf("imdbNumber(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+const testSearchResponse = `{
+  "total_pages": 1,
+  "total_count": 3,
+  "page": 1,
+  "data": [
+    {
+      "id": "1",
+      "type": "subtitle",
+      "attributes": {
+        "language": "en",
+        "download_count": 1200,
+        "hearing_impaired": true,
+        "foreign_parts_only": false,
+        "release": "Movie.2020.1080p.BluRay.x264-GROUP",
+        "format": "srt",
+        "upload_date": "2021-03-04T05:06:07Z",
+        "moviehash_match": true,
+        "files": [{"file_id": 42, "cd_number": 1, "file_name": "Movie.2020.en.srt"}]
+      }
+    },
+    {
+      "id": "2",
+      "type": "subtitle",
+      "attributes": {
+        "language": "pt-BR",
+        "download_count": 5,
+        "foreign_parts_only": true,
+        "release": "Movie.2020.WEB",
+        "files": [{"file_id": 43, "cd_number": 1, "file_name": "Movie.2020.pt.srt"}]
+      }
+    },
+    {
+      "id": "3",
+      "type": "subtitle",
+      "attributes": {
+        "language": "de",
+        "release": "Movie.2020.DVDRip.2CD",
+        "files": [
+          {"file_id": 44, "cd_number": 1, "file_name": "cd1.srt"},
+          {"file_id": 45, "cd_number": 2, "file_name": "cd2.srt"}
+        ]
+      }
+    }
+  ]
+}`
+
+func TestConvertSearchResults(t *testing.T) {
+	var response searchResponse
+	if err := json.Unmarshal([]byte(testSearchResponse), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	results := convertSearchResults(response.Data)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2 - multi-CD subtitles are skipped", len(results))
+	}
+
+	first := results[0]
+	if first.Provider != "opensubtitles" || first.FileID != "42" ||
+		first.FileName != "Movie.2020.en.srt" || first.Language != "en" {
+		t.Errorf("unexpected file of first result: %+v", first)
+	}
+
+	if first.ReleaseName != "Movie.2020.1080p.BluRay.x264-GROUP" || first.Format != "srt" ||
+		first.Downloads != 1200 {
+		t.Errorf("unexpected details of first result: %+v", first)
+	}
+
+	if !first.HearingImpaired || first.Forced || !first.HashMatch {
+		t.Errorf("unexpected flags of first result: %+v", first)
+	}
+
+	if first.UploadDate.Year() != 2021 {
+		t.Errorf("UploadDate = %v, want 2021", first.UploadDate)
+	}
+
+	second := results[1]
+	if second.FileID != "43" || second.Language != "pt-BR" || !second.Forced ||
+		second.HearingImpaired || second.HashMatch {
+		t.Errorf("unexpected second result: %+v", second)
+	}
+}
+
+func TestDownloadQuota(t *testing.T) {
+	p := &Provider{}
+	if p.quotaReached() {
+		t.Fatal("quotaReached() = true for a new provider")
+	}
+
+	p.setQuotaReset(time.Time{})
+
+	if !p.quotaReached() {
+		t.Error("quotaReached() = false until the next midnight UTC")
+	}
+
+	// No request is made once the quota is used up
+	_, err := p.DownloadSubtitle(context.Background(), &apiexternal_v2.SubtitleResult{FileID: "1"})
+	if !errors.Is(err, apiexternal_v2.ErrSubtitleQuota) {
+		t.Errorf("DownloadSubtitle() error = %v, want %v", err, apiexternal_v2.ErrSubtitleQuota)
+	}
+
+	p.setQuotaReset(time.Now().Add(-time.Minute))
+
+	if p.quotaReached() {
+		t.Error("quotaReached() = true after the reset time")
+	}
+}
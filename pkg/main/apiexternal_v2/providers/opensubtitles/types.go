@@ -0,0 +1,103 @@
+package opensubtitles
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+)
+
+//
+// OpenSubtitles Internal Types - Used for JSON unmarshaling
+//
+
+// loginRequest is the body of the /login call.
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// loginResponse is returned by /login.
+type loginResponse struct {
+	Token   string `json:"token"`
+	BaseURL string `json:"base_url"`
+	Status  int    `json:"status"`
+}
+
+// searchResponse is returned by /subtitles.
+type searchResponse struct {
+	TotalPages int              `json:"total_pages"`
+	TotalCount int              `json:"total_count"`
+	Page       int              `json:"page"`
+	Data       []searchSubtitle `json:"data"`
+}
+
+// searchSubtitle represents a single subtitle entry of a search.
+type searchSubtitle struct {
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	Attributes subtitleAttributes `json:"attributes"`
+}
+
+// subtitleAttributes holds the details of a subtitle entry.
+type subtitleAttributes struct {
+	Language         string         `json:"language"`
+	DownloadCount    int            `json:"download_count"`
+	HearingImpaired  bool           `json:"hearing_impaired"`
+	ForeignPartsOnly bool           `json:"foreign_parts_only"`
+	Release          string         `json:"release"`
+	Format           string         `json:"format"`
+	UploadDate       time.Time      `json:"upload_date"`
+	MoviehashMatch   bool           `json:"moviehash_match"`
+	Files            []subtitleFile `json:"files"`
+}
+
+// subtitleFile is a downloadable file of a subtitle entry.
+type subtitleFile struct {
+	FileID   int    `json:"file_id"`
+	CdNumber int    `json:"cd_number"`
+	FileName string `json:"file_name"`
+}
+
+// downloadRequest is the body of the /download call.
+type downloadRequest struct {
+	FileID int `json:"file_id"`
+}
+
+// downloadResponse is returned by /download.
+type downloadResponse struct {
+	ResetTimeUTC time.Time `json:"reset_time_utc"`
+	Link         string    `json:"link"`
+	FileName     string    `json:"file_name"`
+	Message      string    `json:"message"`
+	Requests     int       `json:"requests"`
+	Remaining    int       `json:"remaining"`
+}
+
+// convertSearchResults flattens the search entries into one result per file.
+// Multi-CD subtitles are skipped since they cannot be matched to a single video file.
+func convertSearchResults(data []searchSubtitle) []apiexternal_v2.SubtitleResult {
+	results := make([]apiexternal_v2.SubtitleResult, 0, len(data))
+	for idx := range data {
+		attr := &data[idx].Attributes
+		if len(attr.Files) != 1 {
+			continue
+		}
+
+		results = append(results, apiexternal_v2.SubtitleResult{
+			Provider:        "opensubtitles",
+			FileID:          strconv.Itoa(attr.Files[0].FileID),
+			FileName:        attr.Files[0].FileName,
+			Language:        attr.Language,
+			ReleaseName:     attr.Release,
+			Format:          attr.Format,
+			HearingImpaired: attr.HearingImpaired,
+			Forced:          attr.ForeignPartsOnly,
+			HashMatch:       attr.MoviehashMatch,
+			Downloads:       attr.DownloadCount,
+			UploadDate:      attr.UploadDate,
+		})
+	}
+
+	return results
+}
@@ -482,6 +482,25 @@ func (p *Provider) GetSeriesAlternativeTitles(
 	return convertAlternativeTitles(response.Results), nil
 }
 
+//
+// Release Dates
+//
+
+// GetMovieReleaseDates retrieves the regional release dates of a movie.
+func (p *Provider) GetMovieReleaseDates(
+	ctx context.Context,
+	id int,
+) ([]apiexternal_v2.ReleaseDate, error) {
+	url := fmt.Sprintf("%s/movie/%d/release_dates", p.baseURL, id)
+
+	var response tmdbReleaseDates
+	if err := p.makeRequest(ctx, url, &response); err != nil {
+		return nil, err
+	}
+
+	return convertReleaseDates(&response), nil
+}
+
 //
 // Credits
 //
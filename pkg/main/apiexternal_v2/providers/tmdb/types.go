@@ -129,6 +129,17 @@ type tmdbAlternativeTitle struct {
 	Type      string `json:"type"`
 }
 
+type tmdbReleaseDates struct {
+	Results []struct {
+		ISO3166_1    string `json:"iso_3166_1"`
+		ReleaseDates []struct {
+			Certification string `json:"certification"`
+			ReleaseDate   string `json:"release_date"`
+			Type          int    `json:"type"`
+		} `json:"release_dates"`
+	} `json:"results"`
+}
+
 type tmdbSeason struct {
 	ID           int    `json:"id"`
 	SeasonNumber int    `json:"season_number"`
@@ -437,6 +448,22 @@ func convertAlternativeTitles(tmdbTitles []tmdbAlternativeTitle) []apiexternal_v
 	return titles
 }
 
+func convertReleaseDates(tmdbDates *tmdbReleaseDates) []apiexternal_v2.ReleaseDate {
+	var dates []apiexternal_v2.ReleaseDate
+	for _, country := range tmdbDates.Results {
+		for _, d := range country.ReleaseDates {
+			dates = append(dates, apiexternal_v2.ReleaseDate{
+				ISO3166_1:     country.ISO3166_1,
+				Type:          d.Type,
+				Date:          parseDate(d.ReleaseDate),
+				Certification: d.Certification,
+			})
+		}
+	}
+
+	return dates
+}
+
 func convertSeasons(tmdbSeasons []tmdbSeason) []apiexternal_v2.Season {
 	seasons := make([]apiexternal_v2.Season, len(tmdbSeasons))
 	for i, s := range tmdbSeasons {
@@ -148,6 +148,7 @@ func convertV2EpisodeToDetails(episode *tvdbV2Episode) *apiexternal_v2.Episode {
 		AirDate:        parseTVDBDate(episode.FirstAired),
 		VoteAverage:    episode.SiteRating,
 		StillPath:      episode.Filename,
+		ImdbID:         episode.ImdbID,
 	}
 }
 
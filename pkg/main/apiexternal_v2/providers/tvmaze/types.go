@@ -262,6 +262,7 @@ func convertEpisodeToDetails(episode *tvmazeEpisode) *apiexternal_v2.Episode {
 		Name:          episode.Name,
 		Overview:      stripHTML(episode.Summary),
 		AirDate:       parseTVMazeDate(episode.Airdate),
+		AirStamp:      parseTVMazeDate(episode.Airstamp).UTC(),
 		Runtime:       episode.Runtime,
 		StillPath:     getImageURL(episode.Image),
 		VoteAverage:   episode.Rating.Average,
@@ -142,6 +142,15 @@ type AlternativeTitle struct {
 	Type      string `json:"type"`
 }
 
+// ReleaseDate represents a regional release of a movie.
+// Type follows TMDB: 1 premiere, 2 limited theatrical, 3 theatrical, 4 digital, 5 physical, 6 TV.
+type ReleaseDate struct {
+	ISO3166_1     string    `json:"iso_3166_1"` // Country code
+	Type          int       `json:"type"`
+	Date          time.Time `json:"release_date"`
+	Certification string    `json:"certification"`
+}
+
 // Season represents a TV series season.
 type Season struct {
 	ID           int       `json:"id"`
@@ -162,10 +171,12 @@ type Episode struct {
 	Name           string       `json:"name"`
 	Overview       string       `json:"overview"`
 	AirDate        time.Time    `json:"air_date"`
+	AirStamp       time.Time    `json:"air_stamp,omitempty"` // Exact broadcast time in UTC (zero if unknown)
 	Runtime        int          `json:"runtime"`
 	VoteAverage    float64      `json:"vote_average"`
 	VoteCount      int          `json:"vote_count"`
 	StillPath      string       `json:"still_path"`
+	ImdbID         string       `json:"imdb_id,omitempty"`
 	Crew           []CrewMember `json:"crew"`
 	GuestStars     []CastMember `json:"guest_stars"`
 }
@@ -350,6 +361,44 @@ const (
 	NotificationSendmail   NotificationProviderType = "sendmail"
 )
 
+//
+// Subtitle Types
+//
+
+// SubtitleProviderType represents the type of subtitle provider.
+type SubtitleProviderType string
+
+const (
+	SubtitleProviderOpenSubtitles SubtitleProviderType = "opensubtitles"
+)
+
+// SubtitleSearchRequest describes the video a subtitle is searched for.
+// Providers use whichever identifiers they support; empty fields are ignored.
+type SubtitleSearchRequest struct {
+	Languages    []string `json:"languages"`      // ISO 639-1 codes
+	MovieHash    string   `json:"moviehash"`      // OpenSubtitles file hash
+	ImdbID       string   `json:"imdb_id"`        // Movie or episode IMDb ID
+	ParentImdbID string   `json:"parent_imdb_id"` // Series IMDb ID for episodes
+	Query        string   `json:"query"`          // Release name
+	Season       int      `json:"season"`
+	Episode      int      `json:"episode"`
+}
+
+// SubtitleResult represents a single downloadable subtitle file.
+type SubtitleResult struct {
+	Provider        string    `json:"provider"`
+	FileID          string    `json:"file_id"`
+	FileName        string    `json:"file_name"`
+	Language        string    `json:"language"`
+	ReleaseName     string    `json:"release_name"`
+	Format          string    `json:"format"`
+	HearingImpaired bool      `json:"hearing_impaired"`
+	Forced          bool      `json:"forced"`
+	HashMatch       bool      `json:"hash_match"`
+	Downloads       int       `json:"downloads"`
+	UploadDate      time.Time `json:"upload_date"`
+}
+
 //
 // Download Client Types
 //
@@ -462,12 +511,15 @@ type IndexerSearchRequest struct {
 
 // IndexerCapabilities represents indexer capabilities.
 type IndexerCapabilities struct {
-	ServerTitle   string            `json:"server_title"`
-	ServerVersion string            `json:"server_version"`
-	SearchModes   []string          `json:"search_modes"` // movie, tvsearch, search
-	Categories    []IndexerCategory `json:"categories"`
-	Limits        IndexerLimits     `json:"limits"`
-	Provider      string            `json:"provider"`
+	ServerTitle   string   `json:"server_title"`
+	ServerVersion string   `json:"server_version"`
+	SearchModes   []string `json:"search_modes"` // movie, tvsearch, search
+	// SearchParams lists the supported parameters per search mode,
+	// e.g. "movie" -> [q imdbid tmdbid].
+	SearchParams map[string][]string `json:"search_params"`
+	Categories   []IndexerCategory   `json:"categories"`
+	Limits       IndexerLimits       `json:"limits"`
+	Provider     string              `json:"provider"`
 }
 
 // IndexerCategory represents a category supported by the indexer.
@@ -484,6 +536,18 @@ type IndexerLimits struct {
 	MaxAge       int `json:"max_age"` // Max age in days
 }
 
+// IndexerQuota holds the api and grab usage an indexer reported with a
+// response (apilimits element or X-RateLimit headers). A max of 0 means the
+// limit was not reported, a zero reset time that the reset is unknown.
+type IndexerQuota struct {
+	APIReset    time.Time `json:"api_reset"`
+	GrabReset   time.Time `json:"grab_reset"`
+	APICurrent  int       `json:"api_current"`
+	APIMax      int       `json:"api_max"`
+	GrabCurrent int       `json:"grab_current"`
+	GrabMax     int       `json:"grab_max"`
+}
+
 //
 // Searcher/Download Types - Bridge types for compatibility with searcher package
 //
@@ -501,6 +565,7 @@ type Nzb struct {
 	Size           int64     `json:"size,omitempty"`
 	TVDBID         int       `json:"tvdbid,omitempty"`
 	IsTorrent      bool      `json:"is_torrent,omitempty"`
+	Seeders        int       `json:"seeders,omitempty"`
 	PubDate        time.Time `json:"pub_date"`
 
 	// Category is the Newznab category ID from the indexer (e.g. "3010" for MP3, "3040" for Lossless).
@@ -537,6 +602,7 @@ type Nzbwithprio struct {
 	DontSearch          bool                              `json:"dont_search"`
 	DontUpgrade         bool                              `json:"dont_upgrade"`
 	IDSearched          bool                              `json:"id_searched"`
+	SeasonPack          bool                              `json:"season_pack"` // Full-season release covering every episode of Info.Season
 }
 
 //
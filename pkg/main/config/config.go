@@ -23,6 +23,32 @@ const (
 	MediaTypeCustom uint = 999
 )
 
+// Movie availability levels used by the minimum availability of missing
+// searches, ordered from the earliest to the latest release.
+const (
+	AvailabilityAnnounced = "announced"
+	AvailabilityInCinemas = "in_cinemas"
+	AvailabilityDigital   = "digital"
+	AvailabilityPhysical  = "physical"
+)
+
+// Import modes of the organizer. All modes except move keep the source files
+// of the download client.
+const (
+	ImportModeMove     = "move"
+	ImportModeCopy     = "copy"
+	ImportModeHardlink = "hardlink"
+	ImportModeReflink  = "reflink"
+	ImportModeSymlink  = "symlink"
+)
+
+// Hearing impaired preferences of the subtitle download. An empty value
+// accepts hearing impaired and regular subtitles without preference.
+const (
+	SubtitleHearingImpairedPrefer  = "prefer"
+	SubtitleHearingImpairedExclude = "exclude"
+)
+
 var (
 	Configfile       = "./config/config.toml"
 	RandomizerSource = rand.NewSource(time.Now().UnixNano())
@@ -154,6 +180,8 @@ func Getconfigtoml(reload bool, snapshot *ConfigSnapshot, tomlConfig *MainConfig
 		snapshot.General.MovieMetaSourcePriority = []string{"imdb", "tmdb", "omdb", "trakt"}
 	}
 
+	snapshot.General.CompileRewriteRules()
+
 	snapshot.Imdb = &snapshot.cachetoml.Imdbindexer
 
 	setupSimpleConfigMaps(snapshot)
@@ -440,6 +468,7 @@ func setupQualityConfigs(snapshot *ConfigSnapshot) {
 		snapshot.cachetoml.Quality[idx].WantedResolutionLen = len(
 			snapshot.cachetoml.Quality[idx].WantedResolution,
 		)
+		snapshot.cachetoml.Quality[idx].CompileRules()
 		snapshot.Quality[snapshot.cachetoml.Quality[idx].Name] = &snapshot.cachetoml.Quality[idx]
 	}
 }
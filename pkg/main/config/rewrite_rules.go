@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+// ParserRewriteRule is a compiled parser rewrite rule. The regular expression
+// is replaced in release titles and file names before they are parsed.
+type ParserRewriteRule struct {
+	// Rule is the rule as written in the config
+	Rule string
+	// Regex is the compiled pattern of the rule
+	Regex *regexp.Regexp
+	// Replacement replaces the matches of Regex - $1 or ${name} reference groups
+	Replacement string
+	// MediaTypes limits the rule to movie and/or series names
+	MediaTypes []string
+	// Indexers limits the rule to release titles of these indexers
+	Indexers []string
+	// Lists limits the rule to names parsed for these media lists
+	Lists []string
+}
+
+var (
+	errRewriteRuleSeparator = errors.New("rewrite rule needs a 'pattern => replacement'")
+	errRewriteRulePattern   = errors.New("rewrite rule pattern is empty")
+	errRewriteRuleScope     = errors.New("rewrite rule scope is not closed")
+	errRewriteRuleScopeKey  = errors.New("rewrite rule scope must be movie, series, indexer=name or list=name")
+)
+
+// ParseRewriteRule compiles a parser rewrite rule.
+// The rule is written as 'pattern => replacement' with an optional scope in
+// front of it, e.g. '{series, indexer=nzbgeek} (?i)\.(\d{1,2})x(\d{2})\. => .S${1}E${2}.'.
+// Values of the same scope kind are alternatives, different kinds must all
+// match. A kind which is not given matches everything.
+func ParseRewriteRule(rule string) (ParserRewriteRule, error) {
+	r := ParserRewriteRule{Rule: rule}
+
+	body := strings.TrimSpace(rule)
+	if strings.HasPrefix(body, "{") {
+		end := strings.IndexByte(body, '}')
+		if end == -1 {
+			return r, errRewriteRuleScope
+		}
+
+		for entry := range strings.SplitSeq(body[1:end], ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			key, value, found := strings.Cut(entry, "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			value = strings.TrimSpace(value)
+
+			switch {
+			case !found && (key == logger.StrMovie || key == logger.StrSeries):
+				r.MediaTypes = append(r.MediaTypes, key)
+			case found && key == "indexer" && value != "":
+				r.Indexers = append(r.Indexers, value)
+			case found && key == "list" && value != "":
+				r.Lists = append(r.Lists, value)
+			default:
+				return r, errRewriteRuleScopeKey
+			}
+		}
+
+		body = body[end+1:]
+	}
+
+	idx := strings.LastIndex(body, "=>")
+	if idx == -1 {
+		return r, errRewriteRuleSeparator
+	}
+
+	pattern := strings.TrimSpace(body[:idx])
+	if pattern == "" {
+		return r, errRewriteRulePattern
+	}
+
+	var err error
+
+	r.Regex, err = regexp.Compile(pattern)
+	if err != nil {
+		return r, err
+	}
+
+	r.Replacement = strings.TrimSpace(body[idx+2:])
+
+	return r, nil
+}
+
+// Matches reports if the rule applies to a name of the media type which was
+// found on the indexer for the list. Empty indexer or list values only match
+// rules without that scope.
+func (r *ParserRewriteRule) Matches(mediaType, indexer, list string) bool {
+	return rewriteScopeMatches(r.MediaTypes, mediaType) &&
+		rewriteScopeMatches(r.Indexers, indexer) &&
+		rewriteScopeMatches(r.Lists, list)
+}
+
+// rewriteScopeMatches reports if the value is one of the scope values or the
+// scope is not restricted.
+func rewriteScopeMatches(scope []string, value string) bool {
+	if len(scope) == 0 {
+		return true
+	}
+
+	for _, s := range scope {
+		if strings.EqualFold(s, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CompileRewriteRules compiles the ParserRewriteRules.
+// Invalid rules are logged and skipped - the config validation reports them
+// before they are saved.
+func (general *GeneralConfig) CompileRewriteRules() {
+	general.ParserRewriteRulesCompiled = make([]ParserRewriteRule, 0, len(general.ParserRewriteRules))
+	for _, rule := range general.ParserRewriteRules {
+		compiled, err := ParseRewriteRule(rule)
+		if err != nil {
+			logger.Logtype(logger.StatusError, 1).
+				Str("rule", rule).
+				Err(err).
+				Msg("Invalid parser rewrite rule skipped")
+
+			continue
+		}
+
+		general.ParserRewriteRulesCompiled = append(general.ParserRewriteRulesCompiled, compiled)
+	}
+}
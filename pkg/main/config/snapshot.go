@@ -282,6 +282,8 @@ func buildConfigSnapshot(tomlConfig *MainConfig, reload bool) (*ConfigSnapshot,
 		snapshot.General.MovieMetaSourcePriority = []string{"imdb", "tmdb", "omdb", "trakt"}
 	}
 
+	snapshot.General.CompileRewriteRules()
+
 	// Build configuration maps
 	for idx := range tomlConfig.Downloader {
 		cfg := &tomlConfig.Downloader[idx]
@@ -361,6 +363,7 @@ func buildConfigSnapshot(tomlConfig *MainConfig, reload bool) (*ConfigSnapshot,
 		cfg.WantedCodecLen = len(cfg.WantedCodec)
 		cfg.WantedQualityLen = len(cfg.WantedQuality)
 		cfg.WantedResolutionLen = len(cfg.WantedResolution)
+		cfg.CompileRules()
 		snapshot.Quality[cfg.Name] = cfg
 	}
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/expression"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 )
 
@@ -46,6 +47,10 @@ type ManualConfig struct {
 	// IgnoreRuntime indicates whether to ignore episode runtime checks
 	IgnoreRuntime bool `comment:"Set to true to skip runtime validation for this series.When false, downloaded episodes are checked for completeness" displayname:"Skip Runtime Validation" longcomment:"Set to true to skip runtime validation for this series.When false, downloaded episodes are checked against expected runtimeto ensure they're complete and not fake/incomplete files.Set to true for shows with highly variable episode lengthsor when runtime checking causes issues with legitimate files.Default: false (runtime checking enabled)" toml:"ignore_runtime"`
 
+	// SearchDelay overrides the media group search delay in minutes after airing - 0 uses the
+	// media group setting, -1 disables the delay
+	SearchDelay int `comment:"Minutes to wait after an episode aired before searching it.0 uses the media group setting, -1 disables the delay" displayname:"Search Delay After Airing" longcomment:"Minutes to wait after an episode aired before searching it.Overrides the search_delay of the media group for this series.Useful for shows whose releases appear late after the broadcast.Set to -1 to search this series as soon as an episode aired.Default: 0 (use the media group setting)" toml:"search_delay"`
+
 	// Source specifies the metadata source, e.g. none, tvdb, or scraper
 	Source string `comment:"Specify the metadata source for this series information.Available options:- 'tvdb' to use TheTVDB.com for episode data- 'scraper' to use external content scrapers- 'none' to disable metadata" displayname:"Metadata Source Provider" longcomment:"Specify the metadata source for this series information.Available options:- 'tvdb' to use TheTVDB.com for episode information and metadata- 'scraper' to use external content scrapers configured in the scrapers section- 'none' to disable automatic metadata fetchingWhen using 'scraper', the series name must match the serie_name configured in at least one scraper entry.Scrapers automatically fetch and create episodes from external APIs.Default: 'tvdb' (recommended for most series)" toml:"source"`
 
@@ -320,6 +325,11 @@ type GeneralConfig struct {
 	SerieMetaSourceTmdb bool `comment:"Enable The Movie Database (TMDb) as a metadata source for TV series.\nWhen true, series information" displayname:"Series TMDb Metadata" longcomment:"Enable The Movie Database (TMDb) as a metadata source for TV series.\nWhen true, series information will be fetched from TMDb API.\nRequires themoviedb_apikey to be configured.\nProvides high-quality series metadata with posters and episode information.\nDefault: false" toml:"serie_meta_source_tmdb"`
 	// SerieMetaSourceTrakt defines whether to scan Trakt for series metadata - default: false
 	SerieMetaSourceTrakt bool `comment:"Enable Trakt as a metadata source for TV series.\nWhen true, series information will be fetched" displayname:"Series Trakt Metadata" longcomment:"Enable Trakt as a metadata source for TV series.\nWhen true, series information will be fetched from Trakt API.\nRequires Trakt authentication (client ID/secret) to be configured.\nProvides user ratings, watch statistics, and social features for series.\nDefault: false" toml:"serie_meta_source_trakt"`
+	// SceneMappingFile is the path to a TOML file with scene to TVDB numbering mappings
+	// imported into the database on startup - default: empty (disabled)
+	SceneMappingFile string `comment:"Path to a TOML file mapping scene season/episode numbers to TVDB numbers.\nImported on startup" displayname:"Scene Mapping File" longcomment:"Path to a TOML file mapping scene season/episode numbers to TVDB numbers.\nImported into the database on startup and via POST /api/series/scenemappings/import.\nEach [[series]] entry sets tvdb_id and a list of [[series.mapping]] rows\nwith scene_season, scene_episode, scene_absolute, tvdb_season and tvdb_episode.\nMappings of a series in the file replace its existing mappings.\nExample: './config/scenemappings.toml'\nDefault: empty (disabled)" toml:"scene_mapping_file"`
+	// IndexerDefinitionsPath is the folder of the YAML definitions of cardigann indexers - default: ./config/indexers
+	IndexerDefinitionsPath string `comment:"Folder with the YAML definitions of cardigann indexers.\nDefault: ./config/indexers" displayname:"Indexer Definitions Folder" longcomment:"Folder with the YAML definitions of cardigann indexers.\nIndexers of type 'cardigann' load <definition>.yml from this folder.\nWrite your own definitions or share them - see config/indexerdefinition.example.yml.\nDefault: ./config/indexers" toml:"indexer_definitions_path"`
 	// MoveBufferSizeKB defines buffer size in KB to use if file buffer copy enabled - default: 1024
 	MoveBufferSizeKB int `comment:"File buffer size in kilobytes for file operations.\nLarger buffers can improve file copy/move performance but use more RAM" displayname:"File Buffer Size KB" longcomment:"File buffer size in kilobytes for file operations.\nLarger buffers can improve file copy/move performance but use more RAM.\nUseful when moving large files or working with network storage.\nRecommended range: 64-4096 KB depending on system and storage type.\nDefault: 1024" toml:"move_buffer_size_kb"`
 	// WebPort defines port for web interface and API - default: 9090
@@ -341,6 +351,12 @@ type GeneralConfig struct {
 
 	// DisableParserStringMatch defines whether to disable string matching in parsers - default: false
 	DisableParserStringMatch bool `comment:"Disable string-based parsing and use only regex for field matching.\nWhen true, only regex patterns are used for file identification" displayname:"Disable String Matching Parser" longcomment:"Disable string-based parsing and use only regex for field matching.\nWhen true, only regex patterns are used to identify release information.\nThis may decrease performance but can increase parsing accuracy.\nUseful when string matching produces too many false positives.\nWhen false, both string matching and regex are used (faster).\nDefault: false (use both string matching and regex)" toml:"disable_parser_string_match"`
+	// ParserShadowMode runs both file parsers and records where they disagree - default: false
+	ParserShadowMode bool `comment:"Run the legacy and the v2 parser side by side and record divergences.\nThe v2 parser result is used" displayname:"Parser Shadow Mode" longcomment:"Run the legacy and the v2 parser side by side and record divergences.\nEvery parsed release or file name goes through both parsers.\nThe result of the v2 parser is used, the legacy parser only runs in the shadow\non the same input after the parser rewrite rules.\nDifferences in title, IMDB/TVDB IDs, season, episode, identifier, quality and resolution\nare listed on the Parser Divergences page.\nOnly the 10000 most recently seen divergences are kept.\nDefault: false" toml:"parser_shadow_mode"`
+	// ParserRewriteRules are regex rewrites applied to names before they are parsed
+	ParserRewriteRules []string `comment:"Ordered regex rewrites applied to release titles and file names before parsing.\nExample: '{series} (?i)\\\\.(\\\\d{1,2})x(\\\\d{2})\\\\. => .S${1}E${2}.'" displayname:"Parser Rewrite Rules" longcomment:"Ordered regex rewrites applied to release titles and file names before parsing.\nUse them for naming schemes the parser cannot handle.\nEach rule is written as 'pattern => replacement', the pattern is a Go regular expression\nand the replacement may reference groups with $1 or ${name}.\nAn optional scope in front limits the rule: {movie}, {series}, {indexer=name}, {list=name}\nValues of the same kind are alternatives, different kinds must all match.\nRules run in the given order, each one on the result of the previous one.\nApplied rules are logged at debug level and shown on the parse test page.\nExample: [\"{series} (?i)\\\\.(\\\\d{1,2})x(\\\\d{2})\\\\. => .S${1}E${2}.\", \"^\\\\[[^\\\\]]+\\\\][ ._-]* => \"]" multiline:"true" toml:"parser_rewrite_rules"`
+	// ParserRewriteRulesCompiled are the compiled ParserRewriteRules
+	ParserRewriteRulesCompiled []ParserRewriteRule `toml:"-"`
 	// UseCronInsteadOfInterval defines whether to convert intervals to cron strings - default: false
 	UseCronInsteadOfInterval bool `comment:"Convert scheduler intervals to cron expressions for better performance.\nWhen true, simple intervals are internally converted to cron format" displayname:"Use Cron For Intervals" longcomment:"Convert scheduler intervals to cron expressions for better performance.\nWhen true, simple intervals are internally converted to cron formats.\nThis improves scheduler performance and provides more precise timing.\nWhen false, intervals are used as-is (simpler but less efficient).\nRecommended for systems with many scheduled tasks.\nDefault: false" toml:"use_cron_instead_of_interval"`
 	// UseFileBufferCopy defines whether to use buffered file copy - default: false
@@ -408,6 +424,8 @@ type GeneralConfig struct {
 
 	// FailedIndexerBlockTime specifies how long in minutes an indexer should be blocked after failures
 	FailedIndexerBlockTime int `comment:"Duration in minutes to temporarily block an indexer after consecutive failures.\nWhen an indexer fails repeatedly," displayname:"Failed Indexer Block Minutes" longcomment:"Duration in minutes to temporarily block an indexer after consecutive failures.\nWhen an indexer fails repeatedly, it's blocked for this time period.\nPrevents wasting resources on consistently failing indexers.\nAfter the block period, the indexer is retried automatically.\nLonger times reduce load on failing indexers, shorter times retry sooner.\nTypical range: 1-60 minutes\nDefault: 5" toml:"failed_indexer_block_time"`
+	// RSSHistoryItems defines how many recent RSS items are kept for review
+	RSSHistoryItems int `comment:"Number of recent RSS items kept with their decision for review.\nSet to 0 to disable" displayname:"RSS History Items" longcomment:"Number of recent RSS items kept with their decision for review.\nEvery accepted and denied release of an RSS search is stored with the reason of the decision.\nStored items can be re-evaluated against the current configuration or grabbed manually\nfrom the RSS Items page.\nOlder items beyond this limit are deleted after each RSS search.\nSet to 0 to disable storing RSS items.\nDefault: 0" toml:"rss_history_items"`
 
 	// MaxDatabaseBackups defines the maximum number of database backups to retain
 	MaxDatabaseBackups int `comment:"Maximum number of database backup files to keep before deleting old ones.\nAutomatic backups are created" displayname:"Maximum Database Backups" longcomment:"Maximum number of database backup files to keep before deleting old ones.\nAutomatic backups are created during maintenance and configuration changes.\nOlder backups beyond this limit are automatically deleted.\nSet to 0 to completely disable database backups (not recommended).\nHigher values preserve more backup history but use more disk space.\nRecommended range: 3-10 backups\nDefault: 0 (backups disabled)" toml:"max_database_backups"`
@@ -453,6 +471,17 @@ type GeneralConfig struct {
 	// JellyfinDisableTLSVerify specifies whether to disable TLS certificate verification for Jellyfin API calls - default: false
 	JellyfinDisableTLSVerify bool `comment:"Disable TLS certificate verification for Jellyfin API calls.\nWhen true, self-signed or invalid certificates are accepted" displayname:"Jellyfin Disable TLS Verification" longcomment:"Disable TLS certificate verification for Jellyfin API calls.\nWhen true, self-signed or invalid certificates are accepted.\nUseful for local Jellyfin servers with self-signed certificates.\nSecurity risk: enables man-in-the-middle attacks.\nOnly enable for trusted local networks or development environments.\nDefault: false (verify certificates)" toml:"jellyfin_disable_tls_verify"`
 
+	// ProxyURL is the global proxy used for all clients without an own proxy
+	ProxyURL string `comment:"Global proxy for indexers, download clients, lists and metadata providers.\nFormat: http://host:port or socks5://host:port" displayname:"Global Proxy URL" longcomment:"Global proxy for indexers, download clients, lists and metadata providers.\nFormat: http://host:port, https://host:port or socks5://host:port\nIndexers, download clients and lists can set an own proxy_url.\nEmpty disables the global proxy.\nDefault: empty (no proxy)" toml:"proxy_url"`
+	// ProxyUsername is the username of the global proxy
+	ProxyUsername string `comment:"Username for authentication with the global proxy.\nLeave empty if the proxy needs no authentication" displayname:"Global Proxy Username" longcomment:"Username for authentication with the global proxy.\nLeave empty if the proxy needs no authentication.\nDefault: empty" toml:"proxy_username"`
+	// ProxyPassword is the password of the global proxy
+	ProxyPassword string `comment:"Password for authentication with the global proxy.\nLeave empty if the proxy needs no authentication" displayname:"Global Proxy Password" longcomment:"Password for authentication with the global proxy.\nLeave empty if the proxy needs no authentication.\nDefault: empty" toml:"proxy_password"`
+	// ProxyBypass lists hosts which are reached without proxy
+	ProxyBypass []string `comment:"Hosts reached without proxy.\nExample: ['localhost', '192.168.0.0/16', '.local']" displayname:"Proxy Bypass List" longcomment:"Hosts reached without proxy.\nSupports host names, domains with leading dot (.example.com),\nIP addresses and CIDR ranges.\nApplies to all proxies without an own bypass list.\nExample: ['localhost', '127.0.0.1', '192.168.0.0/16', '.local']\nDefault: empty" toml:"proxy_bypass"`
+	// MetadataProxyURL is the proxy of the metadata providers (tmdb, trakt, omdb, tvdb, tvmaze, ...)
+	MetadataProxyURL string `comment:"Proxy for metadata providers like TMDB, Trakt, OMDB, TVDB and TVmaze.\nEmpty uses the global proxy, 'direct' connects without proxy" displayname:"Metadata Proxy URL" longcomment:"Proxy for metadata providers like TMDB, Trakt, OMDB, TVDB and TVmaze.\nFormat: http://host:port, https://host:port or socks5://host:port\nEmpty uses the global proxy.\n'direct' connects without proxy even if a global proxy is set.\nUses the credentials of the global proxy.\nDefault: empty (global proxy)" toml:"metadata_proxy_url"`
+
 	// Jobs To Run
 	Jobs map[string]func(uint32, context.Context) error `json:"-" toml:"-"`
 	// UseGoDir                           bool     `toml:"use_godir"`
@@ -575,6 +604,31 @@ type GeneralConfig struct {
 	// DeezerLimiterCalls defines calls limit for Deezer API in defined seconds - default: 50
 	DeezerLimiterCalls int `comment:"Maximum number of API calls allowed to Deezer within the defined time window.\nWorks with deezer_limiter_seconds" displayname:"Deezer Calls Per Window" longcomment:"Maximum number of API calls allowed to Deezer within the defined time window.\nWorks with deezer_limiter_seconds to enforce rate limiting.\nDeezer allows approximately 50 requests per 5 seconds (public API).\nDefault: 50 (fifty calls per time window)" toml:"deezer_limiter_calls"`
 
+	//
+	// Subtitle Providers
+	//
+
+	// OpenSubtitlesAPIKey is the API key for OpenSubtitles - get one at https://www.opensubtitles.com/consumers
+	OpenSubtitlesAPIKey string `comment:"API key for the OpenSubtitles REST API.\nRequired for subtitle search and download" displayname:"OpenSubtitles API Key" longcomment:"API key for the OpenSubtitles REST API.\nRequired for searching and downloading missing subtitles.\nCreate a consumer at: https://www.opensubtitles.com/consumers\nLeave empty to disable the OpenSubtitles provider." toml:"opensubtitles_apikey"`
+
+	// OpenSubtitlesUsername is the optional OpenSubtitles account used to raise the download quota
+	OpenSubtitlesUsername string `comment:"OpenSubtitles account username (optional)" displayname:"OpenSubtitles Username" longcomment:"OpenSubtitles account username.\nOptional - logged in users get a higher daily download quota.\nRequires opensubtitles_password." toml:"opensubtitles_username"`
+
+	// OpenSubtitlesPassword is the password of the OpenSubtitles account
+	OpenSubtitlesPassword string `comment:"OpenSubtitles account password (optional)" displayname:"OpenSubtitles Password" longcomment:"OpenSubtitles account password.\nOnly used together with opensubtitles_username." toml:"opensubtitles_password"`
+
+	// OpenSubtitlesURL overrides the API base URL for OpenSubtitles compatible services
+	OpenSubtitlesURL string `comment:"Base URL of the OpenSubtitles compatible API.\nEmpty uses https://api.opensubtitles.com/api/v1" displayname:"OpenSubtitles API URL" longcomment:"Base URL of the OpenSubtitles compatible REST API.\nChange only when using a mirror or a self hosted compatible service.\nDefault: https://api.opensubtitles.com/api/v1" toml:"opensubtitles_url"`
+
+	// OpenSubtitlesLimiterSeconds defines seconds limit for OpenSubtitles API calls - default: 1
+	OpenSubtitlesLimiterSeconds uint8 `comment:"Time window in seconds for OpenSubtitles API rate limiting" displayname:"OpenSubtitles Rate Limit Seconds" longcomment:"Time window in seconds for OpenSubtitles API rate limiting.\nWorks together with opensubtitles_limiter_calls.\nDefault: 1 (one second time window)" toml:"opensubtitles_limiter_seconds"`
+
+	// OpenSubtitlesLimiterCalls defines calls limit for OpenSubtitles API in defined seconds - default: 5
+	OpenSubtitlesLimiterCalls int `comment:"Maximum number of API calls allowed to OpenSubtitles within the defined time window" displayname:"OpenSubtitles Calls Per Window" longcomment:"Maximum number of API calls allowed to OpenSubtitles within the defined time window.\nOpenSubtitles allows 5 requests per second per IP.\nDownloads are additionally limited by the daily quota of the account.\nDefault: 5 (five calls per time window)" toml:"opensubtitles_limiter_calls"`
+
+	// OpenSubtitlesTimeoutSeconds defines the HTTP timeout in seconds for OpenSubtitles API calls - default: 30
+	OpenSubtitlesTimeoutSeconds uint16 `comment:"HTTP request timeout in seconds for OpenSubtitles API calls" displayname:"OpenSubtitles Request Timeout Seconds" longcomment:"HTTP request timeout in seconds for OpenSubtitles API calls.\nMaximum time to wait for search and download responses.\nDefault: 30" toml:"opensubtitles_timeout_seconds"`
+
 	// MusicMetaSourcePriority controls which music metadata providers are active and in what order.
 	// An empty list enables all providers in the default order: musicbrainz → acoustid → lastfm → discogs → deezer.
 	// To disable a provider, omit it from the list.
@@ -687,6 +741,9 @@ type MediaTypeConfig struct {
 	// SearchupgradeIncremental is the number of entries to process in incremental upgrade scans
 	SearchupgradeIncremental uint16 `comment:"Number of existing items to check for quality upgrades in each scan cycle.\nIncremental upgrade scans" displayname:"Upgrade Items Per Scan" longcomment:"Number of existing items to check for quality upgrades in each scan cycle.\nIncremental upgrade scans look for better quality versions of existing media.\nLower values reduce indexer load but slower upgrade discovery.\nHigher values find upgrades faster but consume more indexer API calls.\nUpgrade scans are typically less urgent than missing content searches.\nConsider setting lower than search_missing_incremental.\nTypical range: 5-30 items per scan\nExample: 15 (check 15 items for upgrades per scheduled scan)\nDefault: 20" toml:"search_upgrade_incremental"`
 
+	// SearchDelay is the number of minutes to wait after an episode aired before searching it
+	SearchDelay int `comment:"Minutes to wait after an episode has aired before searching for it.\nApplies to missing and RSS searches of series" displayname:"Search Delay After Airing" longcomment:"Minutes to wait after an episode has aired before searching for it.\nApplies to missing and RSS searches of series.\nThe air time is taken from TVmaze and respects the network time zone.\nEpisodes without a known air time are searched by their air date as before.\nA series can override this value with its own search_delay (-1 disables the delay).\nExample: 120 (search two hours after the broadcast)\nDefault: 0 (search as soon as the episode has aired)" toml:"search_delay"`
+
 	// Data contains the media data configs
 	Data    []MediaDataConfig        `comment:"Storage path configurations for this media group.\nDefines where media files will be stored and how" displayname:"Storage Path Configurations" longcomment:"Storage path configurations for this media group.\nDefines where media files will be stored and how they're organized.\nEach entry specifies:\n- Path template reference (from paths section)\n- Minimum and maximum file sizes\n- Upgrade behavior and file management rules\nMultiple data entries allow different storage tiers or locations.\nExample: Separate entries for different quality levels or storage devices.\nRequired: At least one data configuration must be defined." toml:"data"`
 	DataMap map[int]*MediaDataConfig `toml:"-"`
@@ -721,6 +778,9 @@ type MediaTypeConfig struct {
 	// Notification contains notification configs
 	Notification []MediaNotificationConfig `comment:"Notification settings for this specific media group.\nDefines how and when you'll be alerted about media" displayname:"Notification Configurations" longcomment:"Notification settings for this specific media group.\nDefines how and when you'll be alerted about media events.\nEach entry can reference:\n- Notification template configurations (from [notification] section)\n- Events to notify about (downloads, upgrades, failures)\n- Specific notification channels (Pushover, email, webhooks)\nAllows different notification preferences per media type.\nOptional: Only needed if you want notifications for this media group." toml:"notification"`
 
+	// ReleaseRegion is the country code used for the movie release dates
+	ReleaseRegion string `comment:"Country code (ISO 3166-1) of the movie release dates.\nUsed for the minimum availability of missing searches" displayname:"Release Date Region" longcomment:"Country code (ISO 3166-1) of the movie release dates.\nThe cinema, digital and physical release dates of TMDB are taken from this country.\nDates the country lacks are taken from the earliest date of all countries.\nExample: 'US', 'DE', 'GB'\nDefault: '' (earliest date of all countries)" toml:"release_region"`
+
 	// AudibleRegion specifies the Audible marketplace region for audiobook metadata
 	// Valid values: us, uk, ca, au, de, fr, it, es, in, jp
 	AudibleRegion string `comment:"Audible marketplace region for audiobook metadata lookups.\nDetermines which Audible catalog to search" displayname:"Audible Region" longcomment:"Audible marketplace region for audiobook metadata lookups.\nDetermines which Audible catalog to search for audiobook information.\nValid options:\n- 'us': United States (audible.com)\n- 'uk': United Kingdom (audible.co.uk)\n- 'ca': Canada (audible.ca)\n- 'au': Australia (audible.com.au)\n- 'de': Germany (audible.de)\n- 'fr': France (audible.fr)\n- 'it': Italy (audible.it)\n- 'es': Spain (audible.es)\n- 'in': India (audible.in)\n- 'jp': Japan (audible.co.jp)\nUsed for audiobook media types to match regional catalogs.\nDefault: 'us' (United States)" toml:"audible_region"`
@@ -749,6 +809,25 @@ type MediaDataConfig struct {
 	// WriteRenameLog enables writing a rename log file after media file organization
 	WriteRenameLog bool `comment:"Write a _rename_log.txt file into the target folder after organization.\nDocuments original and new filenames" displayname:"Write Rename Log" longcomment:"Write a _rename_log.txt file into the target folder after organization.\nDocuments original and new filenames for all moved files including the main media file and additional files (subtitles, NFOs, etc.).\nUseful for tracking what was renamed during organization.\nThe log includes a timestamp, media title, source and target paths, and a list of old to new filename mappings.\nDefault: false (no rename log)" toml:"write_rename_log"`
 
+	// WriteNfo enables writing Kodi NFO files for organized movies, series and episodes
+	WriteNfo bool `comment:"Write movie.nfo, tvshow.nfo and episode .nfo files in the Kodi schema after organization.\nThey are regenerated on metadata refresh" displayname:"Write NFO Files" longcomment:"Write NFO files in the Kodi XML schema after organization.\nMovies get a movie.nfo in their folder, or an .nfo with the name of the video\nif the folder is shared with other movies or is the root of the data path.\nSeries get a tvshow.nfo in the series folder and every episode video an .nfo\nwith the name of the video.\nThe files contain title, original title, year, plot, genres, rating, runtime,\npremiere date and the IMDB, TMDB and TVDB IDs from the database,\nso Kodi, Jellyfin and Emby match the media by ID instead of by folder name.\nThe files are regenerated when the metadata of the movie or series is refreshed.\nDefault: false" toml:"write_nfo"`
+
+	// DownloadArtwork enables downloading poster, fanart, banner, clearlogo and season posters
+	// into the folders of organized movies and series
+	DownloadArtwork bool `comment:"Download poster.jpg, fanart.jpg, banner.jpg, clearlogo.png and seasonNN-poster.jpg\ninto the media folders after organization and on metadata refresh" displayname:"Download Artwork" longcomment:"Download artwork into the media folders after organization.\nMovies get poster.jpg, fanart.jpg and clearlogo.png in their folder.\nSeries get poster.jpg, fanart.jpg, banner.jpg and clearlogo.png in the series folder\nand a seasonNN-poster.jpg for every season (season-specials-poster.jpg for specials).\nPosters, fanart and banners come from the database, logos and season posters from TMDB.\nThe artwork job and metadata refresh download missing artwork of existing media.\nDefault: false" toml:"download_artwork"`
+
+	// ArtworkTypes limits the downloaded artwork - empty downloads all types
+	ArtworkTypes []string `comment:"Artwork types to download: poster, fanart, banner, clearlogo, season.\nEmpty downloads all types" displayname:"Artwork Types" longcomment:"Artwork types to download when download_artwork is enabled.\nValid values:\n- poster: poster.jpg\n- fanart: fanart.jpg\n- banner: banner.jpg (series only)\n- clearlogo: clearlogo.png\n- season: seasonNN-poster.jpg (series only)\nEmpty downloads all types.\nExample: ['poster', 'fanart']" toml:"artwork_types"`
+
+	// ArtworkPosterSize is the TMDB size of posters, logos and season posters
+	ArtworkPosterSize string `comment:"TMDB image size for posters, logos and season posters.\nw92, w154, w185, w342, w500, w780 or original" displayname:"Artwork Poster Size" longcomment:"TMDB image size for posters, clearlogos and season posters.\nValid values: w92, w154, w185, w342, w500, w780, original.\nImages of TheTVDB are always downloaded in their original size.\nDefault: original" toml:"artwork_poster_size"`
+
+	// ArtworkFanartSize is the TMDB size of fanart
+	ArtworkFanartSize string `comment:"TMDB image size for fanart.\nw300, w780, w1280 or original" displayname:"Artwork Fanart Size" longcomment:"TMDB image size for fanart (backdrops).\nValid values: w300, w780, w1280, original.\nImages of TheTVDB are always downloaded in their original size.\nDefault: original" toml:"artwork_fanart_size"`
+
+	// ArtworkOverwrite replaces existing artwork files
+	ArtworkOverwrite bool `comment:"Replace existing artwork files. By default existing files are kept" displayname:"Overwrite Artwork" longcomment:"Replace existing artwork files when artwork is downloaded.\nBy default artwork files which already exist are skipped,\nso artwork placed by hand or by another tool is kept.\nDefault: false" toml:"artwork_overwrite"`
+
 	// EmbedArt enables downloading and embedding cover art into audio file tags during organization.
 	EmbedArt bool `comment:"Download and embed cover art into audio file tags during organization.\nFetches cover art from the database cover URL and embeds it into MP3, FLAC, and OGG files." displayname:"Embed Cover Art" longcomment:"Download and embed cover art into audio file metadata tags during organization.\nFetches cover art from the database cover URL (from Audible/Amazon CDN) and embeds it\ninto the audio files using ID3v2 APIC frames (MP3), FLAC picture blocks, or OGG METADATA_BLOCK_PICTURE.\nThe cover URL must already be stored in the database from the import step.\nDefault: false" toml:"embed_art"`
 
@@ -961,6 +1040,8 @@ type MediaListsConfig struct {
 	Enabled bool `comment:"Enable or disable this list configuration.\nWhen true, this list is actively processed and its entries" displayname:"Enable List Processing" longcomment:"Enable or disable this list configuration.\nWhen true, this list is actively processed and its entries are managed.\nWhen false, this list is ignored during all operations:\n- No synchronization with external sources\n- No processing of list entries\n- No searches or downloads triggered by this list\nUseful for temporarily disabling lists without deleting the configuration.\nAlso useful during testing or when lists are under maintenance.\nDefault: false (list disabled)" toml:"enabled"`
 	// Addfound indicates if entries not already watched should be added when found
 	Addfound bool `comment:"Automatically add discovered media to this list when found during file scans.\nWhen true, media files" displayname:"Auto Add Found Media" longcomment:"Automatically add discovered media to this list when found during file scans.\nWhen true, media files found during library scans are automatically added to this list.\nUseful for building lists from existing media collections:\n- Scan existing movie folders to populate a 'discovered-movies' list\n- Find TV series already on disk and add to 'existing-shows' list\n- Import media from shared storage or external drives\nWhen false, only manually added or externally synchronized entries are in the list.\nWorks in conjunction with the media group's data configuration settings.\nDefault: false (manual/external list management only)" toml:"add_found"`
+	// MinimumAvailability overrides the minimum availability of the quality profile for movies of this list
+	MinimumAvailability string `comment:"Minimum availability of movies of this list before missing searches start.\nEmpty uses the quality profile setting." displayname:"Minimum Availability" longcomment:"Minimum availability of movies before missing searches start.\nValid options:\n- 'announced': search as soon as the movie is on a list\n- 'in_cinemas': wait for the theatrical release\n- 'digital': wait for the digital release\n- 'physical': wait for the physical (DVD/Blu-ray) release\nMissing digital or physical dates are estimated 90 days after the cinema release.\nMovies without any release date are searched.\nThe release dates are taken from TMDB for the release_region of the media group.\nOverrides minimum_availability of the quality profile.\nDefault: '' (use the quality profile setting)" toml:"minimum_availability"`
 }
 
 // MediaNotificationConfig defines the configuration for notifications about media events.
@@ -1005,6 +1086,12 @@ type DownloaderConfig struct {
 	Priority int `comment:"Default priority level for downloads added to this client.\nHigher numbers typically mean higher priority (client-dependent).\nCommon" displayname:"Default Download Priority" longcomment:"Default priority level for downloads added to this client.\nHigher numbers typically mean higher priority (client-dependent).\nCommon values: -2 (very low), -1 (low), 0 (normal), 1 (high), 2 (very high)\nCheck your download client's documentation for valid ranges.\nExample: 0 for normal priority" toml:"priority"`
 	// Enabled specifies if this template is active
 	Enabled bool `comment:"Enable or disable this downloader configuration.\nWhen true, this downloader can be used by quality profiles.\nWhen" displayname:"Enable Downloader Configuration" longcomment:"Enable or disable this downloader configuration.\nWhen true, this downloader can be used by quality profiles.\nWhen false, this downloader is ignored and won't receive downloads.\nUseful for temporarily disabling a downloader without deleting the config.\nDefault: true" toml:"enabled"`
+	// ProxyURL is the proxy used for requests of this download client - empty uses the global proxy
+	ProxyURL string `comment:"Proxy for all requests of this download client.\nFormat: http://host:port or socks5://host:port" displayname:"Proxy URL" longcomment:"Proxy for all requests of this download client.\nFormat: http://host:port, https://host:port or socks5://host:port\nEmpty uses the global proxy of the general settings.\n'direct' connects without proxy even if a global proxy is set.\nDefault: empty (global proxy)" toml:"proxy_url"`
+	// ProxyUsername is the username of the proxy
+	ProxyUsername string `comment:"Username for proxy authentication.\nLeave empty if the proxy needs no authentication" displayname:"Proxy Username" longcomment:"Username for proxy authentication.\nLeave empty if the proxy needs no authentication.\nOnly used together with proxy_url.\nDefault: empty" toml:"proxy_username"`
+	// ProxyPassword is the password of the proxy
+	ProxyPassword string `comment:"Password for proxy authentication.\nLeave empty if the proxy needs no authentication" displayname:"Proxy Password" longcomment:"Password for proxy authentication.\nLeave empty if the proxy needs no authentication.\nOnly used together with proxy_url.\nDefault: empty" toml:"proxy_password"`
 }
 
 // ListsConfig defines the configuration for lists.
@@ -1232,6 +1319,15 @@ type ListsConfig struct {
 
 	// IRCReadSeconds is how long each scheduled run listens to the channels before returning.
 	IRCReadSeconds int `comment:"Seconds the first run waits for initial announcements (for type 'irc').\nDefault: 30 when unset." displayname:"IRC Initial Wait Seconds" longcomment:"The IRC connection is kept open in the background and announcements are buffered continuously.\nEach scheduled feed run drains whatever has been buffered since the previous run.\nThis value only applies to the very first run after a session starts: it is how long that run\nwaits for the first announcements to arrive before returning.\nDefault: 30 seconds when 0 or unset." toml:"irc_read_seconds"`
+
+	// ProxyURL is the proxy used for requests of this list - empty uses the global proxy
+	ProxyURL string `comment:"Proxy for all requests of this list (http lists, scrapers and irc).\nFormat: http://host:port or socks5://host:port" displayname:"Proxy URL" longcomment:"Proxy for all requests of this list (http lists, scrapers and irc).\nFormat: http://host:port, https://host:port or socks5://host:port\nIRC connections only support socks5 proxies.\nEmpty uses the global proxy of the general settings.\n'direct' connects without proxy even if a global proxy is set.\nDefault: empty (global proxy)" toml:"proxy_url"`
+
+	// ProxyUsername is the username of the proxy
+	ProxyUsername string `comment:"Username for proxy authentication.\nLeave empty if the proxy needs no authentication" displayname:"Proxy Username" longcomment:"Username for proxy authentication.\nLeave empty if the proxy needs no authentication.\nOnly used together with proxy_url.\nDefault: empty" toml:"proxy_username"`
+
+	// ProxyPassword is the password of the proxy
+	ProxyPassword string `comment:"Password for proxy authentication.\nLeave empty if the proxy needs no authentication" displayname:"Proxy Password" longcomment:"Password for proxy authentication.\nLeave empty if the proxy needs no authentication.\nOnly used together with proxy_url.\nDefault: empty" toml:"proxy_password"`
 }
 
 // IndexersConfig defines the configuration for indexers.
@@ -1239,8 +1335,8 @@ type IndexersConfig struct {
 	// Name is the name of the template
 	Name string `comment:"Unique name for this indexer configuration.\nUsed to identify this indexer in quality profiles and logs.\nChoose" displayname:"Indexer Configuration Name" longcomment:"Unique name for this indexer configuration.\nUsed to identify this indexer in quality profiles and logs.\nChoose a descriptive name that identifies the indexer site.\nExample: 'nzbgeek', 'drunkenslug', 'nzbfinder'" toml:"name"`
 
-	// IndexerType is the type of the indexer, currently has to be newznab
-	IndexerType string `comment:"Protocol type used by this indexer.\nCurrently only 'newznab' is supported.\nNewznab is the standard API used" displayname:"Indexer Protocol Type" longcomment:"Protocol type used by this indexer.\nCurrently only 'newznab' is supported.\nNewznab is the standard API used by most Usenet indexers.\nTorrent indexers using Newznab-compatible APIs also use 'newznab'.\nExample: 'newznab'" toml:"type"`
+	// IndexerType is the type of the indexer, newznab or cardigann
+	IndexerType string `comment:"Protocol type used by this indexer.\n'newznab' for Newznab/Torznab APIs, 'cardigann' for torrent sites described by a definition" displayname:"Indexer Protocol Type" longcomment:"Protocol type used by this indexer.\n'newznab' is the standard API used by most Usenet indexers.\nTorrent indexers using Newznab-compatible APIs (Jackett, Prowlarr) also use 'newznab'.\n'cardigann' searches a torrent site directly using a YAML definition\nfrom the indexer_definitions_path folder (see definition).\nExample: 'newznab'" toml:"type"`
 
 	// URL is the main url of the indexer
 	URL string `comment:"Base URL of the indexer website.\nThis should be the main domain without any API paths.\nDo" displayname:"Indexer Base URL" longcomment:"Base URL of the indexer website.\nThis should be the main domain without any API paths.\nDo not include '/api' or other paths - they're added automatically.\nMust include protocol (http:// or https://).\nExample: 'https://api.nzbgeek.info' or 'https://drunkenslug.com'" toml:"url"`
@@ -1270,9 +1366,6 @@ type IndexersConfig struct {
 	// Not recommended since the conversion is sometimes different
 	OutputAsJSON bool `comment:"Request JSON format instead of XML from the indexer.\nSome indexers support JSON responses which can" displayname:"Request JSON Format" longcomment:"Request JSON format instead of XML from the indexer.\nSome indexers support JSON responses which can be faster to parse.\nNot recommended as JSON conversion may lose data or format differently.\nOnly enable if you experience XML parsing issues with this indexer.\nDefault: false (use XML format)" toml:"output_as_json"`
 
-	// Customapi is used if the indexer needs a different value then 'apikey' for the key
-	Customapi string `comment:"Custom API parameter name if the indexer doesn't use 'apikey'.\nMost indexers use 'apikey' but some" displayname:"Custom API Parameter Name" longcomment:"Custom API parameter name if the indexer doesn't use 'apikey'.\nMost indexers use 'apikey' but some use different parameter names.\nCommon alternatives: 'api_key', 'token', 'key', 'apitoken'\nLeave empty if the indexer uses the standard 'apikey' parameter.\nCheck your indexer's API documentation for the correct parameter name.\nExample: 'api_key' or 'token'" toml:"custom_api"`
-
 	// Customurl is used if the indexer needs a different url then url/api/ or url/rss/
 	Customurl string `comment:"Custom API URL path if the indexer doesn't use standard paths.\nMost indexers use '/api' for API calls and '/rss' for RSS feeds" displayname:"Custom API Path" longcomment:"Custom API URL path if the indexer doesn't use standard paths.\nMost indexers use '/api' for API calls and '/rss' for RSS feeds.\nSome indexers use different paths like '/newznab/api' or '/api/v1'.\nLeave empty if the indexer uses standard '/api' and '/rss' paths.\nInclude the leading slash in your custom path.\nExample: '/newznab/api' or '/api/v2'" toml:"custom_url"`
 
@@ -1289,7 +1382,7 @@ type IndexersConfig struct {
 	Limiterseconds uint8 `comment:"Time window in seconds for the limiter_calls limit.\nDefines the period over which the call limit" displayname:"Rate Limit Window Seconds" longcomment:"Time window in seconds for the limiter_calls limit.\nDefines the period over which the call limit applies.\nTogether with limiter_calls, controls the rate limiting.\nExample: limiter_calls=5, limiter_seconds=10 = 5 calls per 10 seconds.\nMost indexers use per-second limits, so typically set to 1.\nDefault: 1" toml:"limiter_seconds"`
 
 	// LimitercallsDaily is the number of calls allowed daily, 0 is unlimited
-	LimitercallsDaily int `comment:"Maximum number of API calls allowed per day (24-hour period).\nHelps stay within daily API limits" displayname:"Daily API Call Limit" longcomment:"Maximum number of API calls allowed per day (24-hour period).\nHelps stay within daily API limits imposed by some indexers.\nSet to 0 for unlimited daily calls (only rate limiting applies).\nCheck your indexer account for daily API call limits.\nUseful for free accounts with daily restrictions.\nExample: 100 for limited accounts, 0 for unlimited\nDefault: 0 (unlimited)" toml:"limiter_calls_daily"`
+	LimitercallsDaily int `comment:"Maximum number of API calls allowed per day (24-hour period).\nHelps stay within daily API limits" displayname:"Daily API Call Limit" longcomment:"Maximum number of API calls allowed per day (24-hour period).\nHelps stay within daily API limits imposed by some indexers.\nSet to 0 for unlimited daily calls (only rate limiting applies).\nCheck your indexer account for daily API call limits.\nUseful for free accounts with daily restrictions.\nLimits reported by the indexer (apilimits, X-RateLimit headers) take precedence.\nSearches skip the indexer once the daily calls or grabs are used up.\nExample: 100 for limited accounts, 0 for unlimited\nDefault: 0 (unlimited)" toml:"limiter_calls_daily"`
 
 	// MaxAge is the maximum age of releases in days
 	MaxAge uint16 `comment:"Maximum age of releases to consider during searches (in days).\nReleases older than this age will" displayname:"Maximum Release Age Days" longcomment:"Maximum age of releases to consider during searches (in days).\nReleases older than this age will be ignored.\nHelps focus on recent releases and reduces processing time.\nSet to 0 to disable age filtering (search all releases).\nTypical values: 30-365 days depending on content preferences.\nExample: 90 for 3 months, 365 for 1 year\nDefault: 0 (no age limit)" toml:"max_age"`
@@ -1308,6 +1401,30 @@ type IndexersConfig struct {
 
 	// CheckTitleOnIDSearch is a bool indicating if the title of the release should be checked during an id based search? - default: false
 	CheckTitleOnIDSearch bool `comment:"Verify release titles even when searching by IMDB/TVDB ID.\nWhen true, both ID and title must" displayname:"Verify Title On ID Search" longcomment:"Verify release titles even when searching by IMDB/TVDB ID.\nWhen true, both ID and title must match for a release to be accepted.\nWhen false, only the ID needs to match (faster but less accurate).\nUseful when trust_with_imdb_ids or trust_with_tvdb_ids is enabled.\nHelps prevent incorrect matches from indexers with unreliable IDs.\nDefault: false (ID matching only)" toml:"check_title_on_id_search"`
+
+	// ResponseCacheMinutes is the time in minutes search responses of this indexer are cached
+	ResponseCacheMinutes uint16 `comment:"Minutes to reuse the results of an identical search query on this indexer.\nAvoids repeated API calls" displayname:"Response Cache Minutes" longcomment:"Minutes to reuse the results of an identical search query on this indexer.\nAvoids repeated API calls when scheduled, season and manual searches\nsend the same query (same search, ids and categories) within a short time.\nRSS feed requests are never cached.\nHelps to stay below daily API limits of indexers.\n0 disables the cache\nRecommended: 30 to 120 for indexers with tight daily limits\nDefault: 0 (disabled)" toml:"response_cache_minutes"`
+
+	// ProxyURL is the proxy used for requests of this indexer - empty uses the global proxy
+	ProxyURL string `comment:"Proxy for all requests of this indexer.\nFormat: http://host:port or socks5://host:port" displayname:"Proxy URL" longcomment:"Proxy for all requests of this indexer.\nFormat: http://host:port, https://host:port or socks5://host:port\nEmpty uses the global proxy of the general settings.\n'direct' connects without proxy even if a global proxy is set.\nDefault: empty (global proxy)" toml:"proxy_url"`
+
+	// ProxyUsername is the username of the proxy
+	ProxyUsername string `comment:"Username for proxy authentication.\nLeave empty if the proxy needs no authentication" displayname:"Proxy Username" longcomment:"Username for proxy authentication.\nLeave empty if the proxy needs no authentication.\nOnly used together with proxy_url.\nDefault: empty" toml:"proxy_username"`
+
+	// ProxyPassword is the password of the proxy
+	ProxyPassword string `comment:"Password for proxy authentication.\nLeave empty if the proxy needs no authentication" displayname:"Proxy Password" longcomment:"Password for proxy authentication.\nLeave empty if the proxy needs no authentication.\nOnly used together with proxy_url.\nDefault: empty" toml:"proxy_password"`
+
+	// Definition is the YAML definition of a cardigann indexer
+	Definition string `comment:"Definition file of a cardigann indexer.\nName in indexer_definitions_path or path to a .yml file" displayname:"Indexer Definition" longcomment:"Definition file of a cardigann indexer (type 'cardigann').\nA name is looked up as <name>.yml in the indexer_definitions_path folder,\na path is used as is.\nThe definition describes login, search urls, category mappings and\nthe CSS or XPath selectors of the result rows and fields.\nThe url of the indexer overrides the first link of the definition.\nExample: 'mytracker' or './config/indexers/mytracker.yml'" toml:"definition"`
+
+	// Username is the login username of a cardigann indexer
+	Username string `comment:"Username for the login of a cardigann indexer.\nAvailable as {{ .Config.username }} in the definition" displayname:"Site Username" longcomment:"Username for the login of a cardigann indexer.\nAvailable as {{ .Config.username }} in the login inputs of the definition.\nNot needed if cookie is set.\nDefault: empty" toml:"username"`
+
+	// Password is the login password of a cardigann indexer
+	Password string `comment:"Password for the login of a cardigann indexer.\nAvailable as {{ .Config.password }} in the definition" displayname:"Site Password" longcomment:"Password for the login of a cardigann indexer.\nAvailable as {{ .Config.password }} in the login inputs of the definition.\nNot needed if cookie is set.\nDefault: empty" toml:"password"`
+
+	// Cookie is the session cookie of a cardigann indexer, replaces the login
+	Cookie string `comment:"Session cookie of a cardigann indexer.\nReplaces the login of the definition" displayname:"Site Cookie" longcomment:"Session cookie of a cardigann indexer, copied from your browser.\nReplaces the login of the definition - needed for sites with captchas.\nFormat: 'name=value; name2=value2'\nDefault: empty (use the login of the definition)" toml:"cookie"`
 }
 
 type PathsConfig struct {
@@ -1365,6 +1482,36 @@ type PathsConfig struct {
 	MinVideoSize int `comment:"Minimum video file size in megabytes for file organization.\nVideo files smaller than this size will" displayname:"Minimum Video Size MB" longcomment:"Minimum video file size in megabytes for file organization.\nVideo files smaller than this size will not be organized/renamed.\nHelps exclude samples, trailers, and low-quality files from organization.\nSet to 0 to organize all video files regardless of size.\nTypical values: 50MB for TV episodes, 200MB for movies\nExample: 100 for 100MB minimum for organization" toml:"min_video_size"`
 	// MinVideoSizeByte is the minimum video size in bytes
 	MinVideoSizeByte int64 `toml:"-"`
+	// OrganizeExtras classifies samples and extras instead of organizing every video - default: false
+	OrganizeExtras bool `comment:"Classify samples, trailers, featurettes, deleted scenes, behind the scenes and interviews.\nExtras are moved into subfolders next to the main file, samples are deleted" displayname:"Organize Extras" longcomment:"Classify the videos next to the main file instead of treating each one as a main file.\nVideos are classified by their file name (sample, trailer, teaser, featurette,\ndeleted scenes, behind the scenes, making of, interview), by the folder they are in\n(e.g. Sample, Trailers, Featurettes, Extras) and, for movies, by their duration.\nFile names only count when the video is less than half the size of the largest one,\nso a movie called 'The Interview' is not taken for an extra.\nSamples are deleted, the other extras are moved into the extras subfolders\nnext to the organized main file. Extras are not subject to min_video_size.\nDefault: false" toml:"organize_extras"`
+	// ExtrasMaxRuntimePercent treats short movie videos as extras - default: 0
+	ExtrasMaxRuntimePercent int `comment:"Videos of a movie shorter than this percentage of its runtime are extras.\n0 disables the duration check" displayname:"Extras Max Runtime Percent" longcomment:"Treat other videos in the folder of a movie as extras when their duration\nis below this percentage of the movie runtime.\nCatches extras whose names do not tell what they are.\nThey are moved into the extras_other_folder.\nOnly used when organize_extras is enabled, series are not checked\nbecause the other videos of a series folder are episodes.\nExample: 25 for videos shorter than a quarter of the runtime\nDefault: 0 (disabled)" toml:"extras_max_runtime_percent"`
+	// ExtrasTrailersFolder is the extras subfolder for trailers and teasers - default: Trailers
+	ExtrasTrailersFolder string `comment:"Subfolder next to the main file for trailers and teasers.\nDefault: Trailers" displayname:"Trailers Folder" longcomment:"Name of the subfolder created next to the organized main file for trailers and teasers.\nThe default matches the extras folders recognized by Plex and Jellyfin.\nOnly used when organize_extras is enabled.\nDefault: Trailers" toml:"extras_trailers_folder"`
+	// ExtrasFeaturettesFolder is the extras subfolder for featurettes - default: Featurettes
+	ExtrasFeaturettesFolder string `comment:"Subfolder next to the main file for featurettes.\nDefault: Featurettes" displayname:"Featurettes Folder" longcomment:"Name of the subfolder created next to the organized main file for featurettes.\nThe default matches the extras folders recognized by Plex and Jellyfin.\nOnly used when organize_extras is enabled.\nDefault: Featurettes" toml:"extras_featurettes_folder"`
+	// ExtrasDeletedScenesFolder is the extras subfolder for deleted scenes - default: Deleted Scenes
+	ExtrasDeletedScenesFolder string `comment:"Subfolder next to the main file for deleted scenes.\nDefault: Deleted Scenes" displayname:"Deleted Scenes Folder" longcomment:"Name of the subfolder created next to the organized main file for deleted scenes.\nThe default matches the extras folders recognized by Plex and Jellyfin.\nOnly used when organize_extras is enabled.\nDefault: Deleted Scenes" toml:"extras_deleted_scenes_folder"`
+	// ExtrasBehindTheScenesFolder is the extras subfolder for behind the scenes and making of videos - default: Behind The Scenes
+	ExtrasBehindTheScenesFolder string `comment:"Subfolder next to the main file for behind the scenes and making of videos.\nDefault: Behind The Scenes" displayname:"Behind The Scenes Folder" longcomment:"Name of the subfolder created next to the organized main file for behind the scenes and making of videos.\nThe default matches the extras folders recognized by Plex and Jellyfin.\nOnly used when organize_extras is enabled.\nDefault: Behind The Scenes" toml:"extras_behind_the_scenes_folder"`
+	// ExtrasInterviewsFolder is the extras subfolder for interviews - default: Interviews
+	ExtrasInterviewsFolder string `comment:"Subfolder next to the main file for interviews.\nDefault: Interviews" displayname:"Interviews Folder" longcomment:"Name of the subfolder created next to the organized main file for interviews.\nThe default matches the extras folders recognized by Plex and Jellyfin.\nOnly used when organize_extras is enabled.\nDefault: Interviews" toml:"extras_interviews_folder"`
+	// ExtrasOtherFolder is the extras subfolder for other extras - default: Other
+	ExtrasOtherFolder string `comment:"Subfolder next to the main file for other extras.\nDefault: Other" displayname:"Other Folder" longcomment:"Name of the subfolder created next to the organized main file for other extras.\nThe default matches the extras folders recognized by Plex and Jellyfin.\nOnly used when organize_extras is enabled.\nDefault: Other" toml:"extras_other_folder"`
+	// ImportMode is how organized files are placed into the target - default: move
+	ImportMode string `comment:"How organized files are placed into the target: move, copy, hardlink, reflink or symlink.\nAll modes except move keep the source for seeding" displayname:"Import Mode" longcomment:"How organized files are placed into the target path.\n- 'move': move the files and clean up the source folder\n- 'copy': copy the files and keep the source\n- 'hardlink': hardlink the files and keep the source - falls back to a copy across filesystems\n- 'reflink': copy-on-write clone on btrfs or XFS and keep the source - falls back to a copy\n- 'symlink': link to the source files and keep the source\nAll modes except move keep the files and folders of the download client,\nso torrents keep seeding. Imported sources are remembered and not organized again.\nAudio files are only tagged with copy and reflink in these modes,\nbecause tagging hardlinks or symlinks would change the seeded files.\nDefault: move" toml:"import_mode"`
+	// KeepSourceDays is the number of days kept sources stay after their import, 0 keeps them
+	KeepSourceDays int `comment:"Number of days kept sources stay in the source path after their import.\n0 leaves them to the download client" displayname:"Keep Source Days" longcomment:"Number of days the sources of a copy, hardlink or reflink import stay in the source path.\nThe imported sources job removes sources imported longer ago from the source path,\nfor example once seeding should be done.\nSet to 0 to leave the sources until the download client removes them.\nOnly used when import_mode keeps the source. Symlink imports are never pruned,\nthe library file points at the source - the value must be 0 with symlink.\nExample: 14 to seed for two weeks\nDefault: 0" toml:"keep_source_days"`
+	// OrganizeSubtitles renames and moves external subtitles with the video - default: false
+	OrganizeSubtitles bool `comment:"Detect external subtitles (.srt, .ass, .ssa, .sub, .idx, .sup) next to the video and in Subs folders.\nThey are renamed to <video>.<lang>[.forced|.sdh].<ext> and moved with the video" displayname:"Organize Subtitles" longcomment:"Detect the external subtitles of the organized video and move them with it.\nSubtitles next to the video and in Subs or Subtitles folders below it are found.\nFor series only subtitles starting with the name of the episode video\nor in a subfolder named like it belong to the episode.\nThe language is detected from the file name (en, eng, English, ...),\nfrom the index of VobSub (.idx) files and from the text of .srt files.\nForced and SDH (sdh, cc, hi) subtitles are recognized.\nThe subtitles are renamed to <video>.<lang>[.forced|.sdh].<ext>.\nDefault: false" toml:"organize_subtitles"`
+	// SubtitleLanguages limits the organized subtitles to these languages - empty keeps all
+	SubtitleLanguages []string `comment:"Languages of the external subtitles to keep, e.g. ['en', 'de'].\nEmpty keeps all subtitles" displayname:"Subtitle Languages" longcomment:"Languages of the external subtitles which are moved with the video.\nLanguages can be given as ISO 639-1 (en), ISO 639-2 (eng, ger) or English name (English).\nSubtitles of other languages are not moved and removed with the source folder.\nEmpty keeps the subtitles of all languages.\nExample: ['en', 'de']" toml:"subtitle_languages"`
+	// SubtitleKeepUnknown keeps subtitles without a detected language when SubtitleLanguages is set - default: false
+	SubtitleKeepUnknown bool `comment:"Keep subtitles whose language could not be detected when subtitle_languages is set" displayname:"Keep Unknown Subtitles" longcomment:"Keep external subtitles whose language could not be detected\neven though subtitle_languages is set. They are renamed to <video>[.forced|.sdh].<ext>.\nWithout subtitle_languages all subtitles are kept anyway.\nDefault: false" toml:"subtitle_keep_unknown"`
+	// DownloadSubtitles searches providers for missing subtitle languages of the imported videos - default: false
+	DownloadSubtitles bool `comment:"Search the configured subtitle providers for missing subtitles of imported videos.\nThe wanted languages are taken from subtitle_languages" displayname:"Download Subtitles" longcomment:"Search the configured subtitle providers for imported videos\nwhich have no external subtitle for one of the languages in subtitle_languages.\nProviders are queried by file hash, IMDb ID and release name.\nThe best match is stored next to the video as <video>.<lang>[.forced|.sdh].srt.\nThe search runs with the searchsubtitles job.\nRequires a subtitle provider like OpenSubtitles in the general settings.\nDefault: false" toml:"download_subtitles"`
+	// SubtitleHearingImpaired defines how hearing impaired subtitles are handled by the download - default: empty (no preference)
+	SubtitleHearingImpaired string `comment:"Hearing impaired subtitles for downloads: '' (no preference), 'prefer' or 'exclude'" displayname:"Hearing Impaired Subtitles" longcomment:"How hearing impaired (SDH) subtitles are handled when downloading subtitles.\n- '': accept both without preference\n- 'prefer': prefer hearing impaired subtitles\n- 'exclude': never download hearing impaired subtitles\nDefault: ''" toml:"subtitle_hearing_impaired"`
 	// CleanupsizeMB is the minimum size in MB to keep a folder, 0 removes all
 	CleanupsizeMB int `comment:"Minimum total size in megabytes to keep a folder during cleanup.\nFolders with total content smaller" displayname:"Folder Cleanup Size MB" longcomment:"Minimum total size in megabytes to keep a folder during cleanup.\nFolders with total content smaller than this size will be deleted.\nHelps remove leftover folders with only samples, subtitles, or small files.\nSet to 0 to remove all folders regardless of size (aggressive cleanup).\nTypical values: 50-200MB depending on your minimum file requirements\nExample: 100 to keep folders with at least 100MB of content" toml:"cleanup_size_mb"`
 	// AllowedLanguages lists allowed languages for audio streams in videos
@@ -1383,6 +1530,10 @@ type PathsConfig struct {
 	MissingScanInterval int `comment:"Minimum days to wait between missing media searches for the same item.\nPrevents excessive searching by" displayname:"Missing Search Wait Days" longcomment:"Minimum days to wait between missing media searches for the same item.\nPrevents excessive searching by spacing out search attempts.\nSet to 0 to disable waiting (search for missing media every scan cycle).\nHigher values reduce indexer load but may delay finding new releases.\nTypical values: 1-7 days depending on how actively you want to search.\nExample: 3 for searches every 3 days" toml:"missing_scan_interval"`
 	// MissingScanReleaseDatePre is the minimum number of days to wait after media release before scanning, 0 means don't check
 	MissingScanReleaseDatePre int `comment:"Days to wait before the official release date before starting searches.\nAllows searching for media before" displayname:"Pre Release Search Days" longcomment:"Days to wait before the official release date before starting searches.\nAllows searching for media before its official release date (for pre-releases).\nPositive values search X days before release, negative values wait X days after.\nSet to 0 to disable release date checking (search immediately when added).\nExample: -7 to wait 7 days after release, 3 to search 3 days before release" toml:"missing_scan_release_date_pre"`
+	// MissingSearchBackoffMaxDays caps the exponential backoff of missing searches in days, 0 disables the backoff
+	MissingSearchBackoffMaxDays int `comment:"Maximum days between missing searches of an item that keeps returning nothing.\nEnables the search backoff" displayname:"Missing Search Backoff Max Days" longcomment:"Maximum days between missing searches of an item that keeps returning nothing.\nEvery missing search without an accepted release doubles the wait until the next\nsearch of that item, starting at missing_scan_interval days (at least 1), up to this cap.\nA successful search resets the backoff.\nSet to 0 to disable the backoff (search by missing_scan_interval only).\nExample: 30 to search long-missing items at most once a month" toml:"missing_search_backoff_max_days"`
+	// MissingSearchRecentDays is the number of days after release or adding in which items are searched without backoff
+	MissingSearchRecentDays int `comment:"Days after release or adding in which missing items are searched every cycle.\nOnly used with the search backoff" displayname:"Missing Search Recent Days" longcomment:"Days after release or adding in which missing items are searched every cycle.\nRecently released or recently added items skip the backoff and the\nmissing_scan_interval so new releases are picked up quickly.\nOnly used if missing_search_backoff_max_days is set.\nSet to 0 to treat all items the same.\nExample: 14 to search items released in the last two weeks every cycle" toml:"missing_search_recent_days"`
 	// Disallowed lists strings that will block processing if found
 	Disallowed []string `comment:"List of strings that prevent file organization when found in release names.\nFiles are downloaded but not organized if they contain these strings" displayname:"Disallowed File Patterns" longcomment:"List of strings that prevent file organization when found in release names.\nFiles are downloaded but not organized/renamed if they contain these strings.\nUseful for blocking specific release groups, qualities, or naming patterns.\nStrings are case-insensitive and can be partial matches.\nExample: ['CAM', 'TS', 'HDCAM', 'BadGroup'] to block low-quality releases" multiline:"true" toml:"disallowed"`
 	// DisallowedLen is the number of disallowed strings
@@ -1397,10 +1548,12 @@ type PathsConfig struct {
 	MaxRuntimeDifference int `comment:"Maximum allowed runtime difference in minutes for runtime verification.\nFiles with runtime differing more than this" displayname:"Max Runtime Difference Minutes" longcomment:"Maximum allowed runtime difference in minutes for runtime verification.\nFiles with runtime differing more than this amount are flagged or rejected.\nAccounts for encoding differences, credits, and metadata inaccuracies.\nSet to 0 to disable runtime checking entirely.\nTypical values: 5-15 minutes depending on content type and tolerance.\nExample: 10 to allow up to 10 minutes difference" toml:"max_runtime_difference"`
 	// DeleteWrongRuntime indicates if media with wrong runtime should be deleted, default false
 	DeleteWrongRuntime bool `comment:"Automatically delete files that fail runtime verification.\nWhen true, files with runtime outside max_runtime_difference are deleted.\nWhen" displayname:"Delete Wrong Runtime Files" longcomment:"Automatically delete files that fail runtime verification.\nWhen true, files with runtime outside max_runtime_difference are deleted.\nWhen false, files are kept but may not be organized (safer option).\nOnly works when check_runtime is enabled and max_runtime_difference is set.\nCaution: This permanently deletes files - use with care.\nDefault: false" toml:"delete_wrong_runtime"`
-	// MoveReplaced indicates if replaced media should be moved to old folder, default false
-	MoveReplaced bool `comment:"Move replaced files to a backup directory instead of deleting them.\nWhen true, old files are moved to move_replaced_target_path when upgraded" displayname:"Move Replaced Files" longcomment:"Move replaced files to a backup directory instead of deleting them.\nWhen true, old files are moved to move_replaced_target_path when upgraded.\nWhen false, old files are deleted during replacement (saves space).\nProvides a safety net for undoing upgrades if needed.\nRequires move_replaced_target_path to be configured.\nDefault: false" toml:"move_replaced"`
+	// MoveReplaced indicates if replaced and deleted media should be moved to the recycle bin, default false
+	MoveReplaced bool `comment:"Move replaced and deleted files to a recycle bin instead of deleting them.\nWhen true, old files are moved to move_replaced_target_path when upgraded" displayname:"Move Replaced Files" longcomment:"Move replaced and deleted files to a recycle bin instead of deleting them.\nWhen true, old files are moved to move_replaced_target_path when upgraded.\nFiles deleted for a wrong language or runtime and files removed by the cleanup tools are recycled too.\nRecycled files are stored in dated subfolders and can be restored from the Recycle Bin page.\nWhen false, old files are deleted during replacement (saves space).\nRequires move_replaced_target_path to be configured - files are only recycled when both are set,\notherwise replaced, rejected and cleaned up files are deleted permanently.\nDefault: false" toml:"move_replaced"`
 	// MoveReplacedTargetPath is the path to the folder for replaced media
-	MoveReplacedTargetPath string `comment:"Absolute path where replaced/upgraded files are moved for backup.\nUsed when move_replaced is enabled to store" displayname:"Replaced Files Directory" longcomment:"Absolute path where replaced/upgraded files are moved for backup.\nUsed when move_replaced is enabled to store old versions of files.\nMust be an absolute path accessible to the application.\nConsider storage space as this will accumulate replaced files over time.\nRequired when move_replaced is true, ignored otherwise.\nExample: '/backup/replaced-media', '/storage/old-files'" toml:"move_replaced_target_path"`
+	MoveReplacedTargetPath string `comment:"Absolute path where replaced/upgraded files are moved for backup.\nUsed when move_replaced is enabled to store" displayname:"Replaced Files Directory" longcomment:"Absolute path where replaced/upgraded files are moved for backup.\nUsed when move_replaced is enabled to store old versions of files.\nMust be an absolute path accessible to the application.\nConsider storage space as this will accumulate replaced files over time.\nRequired when move_replaced is true, ignored otherwise - without it nothing is recycled.\nExample: '/backup/replaced-media', '/storage/old-files'" toml:"move_replaced_target_path"`
+	// RecycleRetentionDays is the number of days recycled files are kept, 0 keeps them forever
+	RecycleRetentionDays int `comment:"Number of days recycled files are kept before the retention job purges them.\n0 keeps them forever" displayname:"Recycle Bin Retention Days" longcomment:"Number of days recycled files are kept before the retention job purges them.\nThe purge runs with the recycle bin retention scheduler job.\nSet to 0 to keep recycled files until they are deleted manually.\nOnly used when move_replaced is enabled.\nExample: 30 to keep replaced files for a month\nDefault: 0" toml:"recycle_retention_days"`
 	// SetChmod is the chmod for files in octal format, default 0777
 	SetChmod       string `comment:"File permissions to set on organized media files (Unix/Linux only).\nUse octal format (3-4 digits) to"        displayname:"File Permissions Octal"   longcomment:"File permissions to set on organized media files (Unix/Linux only).\nUse octal format (3-4 digits) to specify read/write/execute permissions.\nApplied to all organized files to ensure consistent access permissions.\nIgnored on Windows systems - only affects Unix-like systems.\nCommon values: '0644' (rw-r--r--), '0664' (rw-rw-r--), '0777' (rwxrwxrwx)\nDefault: '0777'"                                                                               toml:"set_chmod"`
 	SetChmodFolder string `comment:"Directory permissions to set on organized media folders (Unix/Linux only).\nUse octal format (3-4 digits) to" displayname:"Folder Permissions Octal" longcomment:"Directory permissions to set on organized media folders (Unix/Linux only).\nUse octal format (3-4 digits) to specify read/write/execute permissions.\nApplied to all created directories to ensure consistent access permissions.\nIgnored on Windows systems - only affects Unix-like systems.\nFolders typically need execute permission for access (x bit set).\nCommon values: '0755' (rwxr-xr-x), '0775' (rwxrwxr-x), '0777' (rwxrwxrwx)\nDefault: '0777'" toml:"set_chmod_folder"`
@@ -1543,6 +1696,28 @@ type QualityConfig struct {
 	WantedAudioFormatsLen int `toml:"-"`
 	// MinAudioBitrate is the minimum audio bitrate in kbps to accept (0 = no minimum)
 	MinAudioBitrate int `comment:"Minimum audio bitrate in kbps to accept (0 = no minimum).\nReleases below this bitrate will be rejected." displayname:"Minimum Audio Bitrate" longcomment:"Minimum audio bitrate in kbps to accept (0 = no minimum).\nReleases below this bitrate will be rejected.\nTypical values: 128, 192, 256, 320 for lossy; 0 for lossless (varies).\nDefault: 0 (no minimum)" toml:"min_audio_bitrate"`
+	// ProperUpgrade decides if a PROPER/REPACK of the existing file is grabbed even if its priority is not higher
+	// - "" (disabled), "same_group" or "any_group"
+	ProperUpgrade string `comment:"Grab PROPER/REPACK releases of an existing file automatically.\nEmpty disables it, same_group or any_group enables it." displayname:"Proper/Repack Upgrade" longcomment:"Grab PROPER/REPACK releases of an existing file automatically.\nA PROPER or REPACK fixes a broken release of the same resolution and quality,\nso it is grabbed even if its priority is not higher than the existing file.\nsame_group: only if it was released by the group of the existing file\nany_group: PROPER/REPACK releases of any group replace the existing file\nLeave empty to rely on the priority bonus of use_for_priority_other only.\nDefault: '' (disabled)" toml:"proper_upgrade"`
+	// PreferredGroups is a list of release groups which get PreferredGroupsBonus added to their priority
+	PreferredGroups []string `comment:"List of release groups to prefer.\nReleases of these groups get preferred_groups_bonus added to their priority." displayname:"Preferred Release Groups" longcomment:"List of release groups to prefer (case-insensitive).\nReleases of these groups get preferred_groups_bonus added to their priority,\nso they win over releases of other groups with the same quality.\nExisting movie and episode files keep the bonus of their stored group.\nMusic, audiobook and book files do not store their group - keep the bonus\nbelow use_for_priority_min_difference to avoid re-downloads of those.\nExample: ['NTb', 'FLUX']" multiline:"true" toml:"preferred_groups"`
+	// PreferredGroupsBonus is the priority added to releases of PreferredGroups
+	PreferredGroupsBonus int `comment:"Priority bonus for releases of preferred_groups.\n0 disables the bonus." displayname:"Preferred Groups Bonus" longcomment:"Priority bonus for releases of preferred_groups.\nCompare it with the priority steps of your qualities - a bonus above\nuse_for_priority_min_difference makes a preferred group an upgrade.\nDefault: 0 (disabled)" toml:"preferred_groups_bonus"`
+	// BlockedGroups is a list of release groups which are always rejected
+	BlockedGroups []string `comment:"List of release groups to reject.\nReleases of these groups are never downloaded." displayname:"Blocked Release Groups" longcomment:"List of release groups to reject (case-insensitive).\nReleases of these groups are never downloaded, whatever their quality.\nReplaces rejection regexes matching group names.\nExample: ['YIFY', 'RARBG']" multiline:"true" toml:"blocked_groups"`
+	// RejectRules are expressions - releases matching any of them are rejected
+	RejectRules []string `comment:"Expressions which reject a release when true.\nExample: size > 8GB and resolution == '1080p'" displayname:"Reject Rules" longcomment:"Expressions which reject a release when they are true.\nUse them for conditions the regex rejected strings cannot express.\nVariables: title, indexer, resolution, quality, codec, audio, group,\nlanguages, size (bytes), age (days), seeders, year, priority,\nproper, repack, extended, torrent\nOperators: and, or, not, == != < <= > >=, + - * /, in, not in,\ncontains, matches (regular expression)\nText is compared case-insensitively, sizes accept KB, MB, GB and TB.\nExample: [\"size > 8GB and resolution == '1080p'\", \"torrent and seeders < 5\"]" multiline:"true" toml:"reject_rules"`
+	// ScoreRules are expressions with points added to the priority of matching releases
+	ScoreRules []string `comment:"Expressions which add points to the priority of a release.\nFormat: expression => points" displayname:"Score Rules" longcomment:"Expressions which add points to the priority of a release when true.\nFormat: expression => points (points may be negative)\nThe points only change the order of the accepted releases of a search,\nthey do not make a release an upgrade of an existing file.\nSee reject_rules for the variables and operators.\nExample: [\"codec == 'x265' and age < 2 => 20\", \"indexer == 'slowindexer' => -10\"]" multiline:"true" toml:"score_rules"`
+	// RejectRulesCompiled are the compiled RejectRules
+	RejectRulesCompiled []*expression.Program `toml:"-"`
+	// ScoreRulesCompiled are the compiled ScoreRules
+	ScoreRulesCompiled []expression.ScoreRule `toml:"-"`
+	// MinimumAvailability is the release state a movie needs before missing searches start
+	// - "" or "announced", "in_cinemas", "digital" or "physical"
+	MinimumAvailability string `comment:"Minimum availability of movies before missing searches start.\nOne of announced, in_cinemas, digital or physical." displayname:"Minimum Availability" longcomment:"Minimum availability of movies before missing searches start.\nValid options:\n- 'announced': search as soon as the movie is on a list\n- 'in_cinemas': wait for the theatrical release\n- 'digital': wait for the digital release\n- 'physical': wait for the physical (DVD/Blu-ray) release\nMissing digital or physical dates are estimated 90 days after the cinema release.\nMovies without any release date are searched.\nThe release dates are taken from TMDB for the release_region of the media group.\nA list can override this value.\nDefault: '' (announced)" toml:"minimum_availability"`
+	// SeasonPackWantedPercent is the share of a season's episodes that must be missing before full-season releases are preferred (0 = disabled)
+	SeasonPackWantedPercent int `comment:"Prefer full-season releases once this percentage of a season's episodes is missing.\n0 disables season pack handling." displayname:"Season Pack Wanted Percent" longcomment:"Prefer full-season releases once this percentage of a season's episodes is missing.\nWhen reached, the missing search queries indexers for the whole season first\nand only falls back to episode-by-episode searches if no pack was grabbed.\nSeason searches also accept season packs when the threshold is met.\nThe season must have finished airing for a pack to be considered.\nSet to 0 to disable season pack handling.\nExample: 60 to prefer packs when 60% or more of a season is missing\nDefault: 0 (disabled)" toml:"season_pack_wanted_percent"`
 	// PreferLossless indicates if lossless audio formats should be preferred over lossy
 	PreferLossless bool `comment:"Prefer lossless audio formats (FLAC, ALAC) over lossy (MP3, AAC).\nLossless releases will get priority bonus." displayname:"Prefer Lossless Audio" longcomment:"Prefer lossless audio formats (FLAC, ALAC, WAV) over lossy (MP3, AAC, OGG).\nLossless releases will get a significant priority bonus.\nUseful for maintaining an audiophile-quality music library.\nDefault: false, Recommended: true for music" toml:"prefer_lossless"`
 }
@@ -1644,6 +1819,10 @@ type SchedulerConfig struct {
 	IntervalScanDataFlags string `comment:"Time interval between scans for media files marked with processing flags.\nControls how often files flagged" displayname:"Flagged File Scan Interval" longcomment:"Time interval between scans for media files marked with processing flags.\nControls how often files flagged for reprocessing, upgrading, or fixing are handled.\nFlags indicate files needing attention (corrupt, misnamed, quality issues).\nSupports Go duration format: '1h', '6h', '12h', '24h', '2d'\nAlso supports cron format for specific timing\nFlagged files often need prompt attention to resolve issues.\nShorter intervals resolve problems faster but increase processing load.\nRecommended: '6h' to '12h' for flagged file processing\nExample: '6h' for four-times-daily flagged file handling" toml:"interval_scan_data_flags"`
 	// IntervalScanDataimport is the interval for data import scans
 	IntervalScanDataimport string `comment:"Time interval between scans for new media files to import from configured import paths.\nControls how" displayname:"Import Directory Scan Interval" longcomment:"Time interval between scans for new media files to import from configured import paths.\nControls how often import directories are scanned for existing media to add to library.\nUseful for gradually importing large existing collections.\nSupports Go duration format: '1h', '6h', '12h', '24h', '2d'\nAlso supports cron format for specific timing\nImport scanning processes external media for library integration.\nFrequency depends on how often new files are added to import paths.\nRecommended: '12h' to '24h' for import directory monitoring\nExample: '12h' for twice-daily import scanning" toml:"interval_scan_data_import"`
+	// IntervalArtwork is the interval for artwork downloads
+	IntervalArtwork string `comment:"Time interval between downloads of missing artwork for existing movies and series.\nLeave empty to disable" displayname:"Artwork Download Interval" longcomment:"Time interval between downloads of missing artwork for existing movies and series.\nOnly data paths with download_artwork enabled are processed.\nExisting artwork files are skipped unless artwork_overwrite is enabled.\nSupports Go duration format: '12h', '24h', '7d'\nLeave empty to disable the scheduled artwork job.\nExample: '7d' for weekly artwork downloads" toml:"interval_artwork"`
+	// IntervalSearchSubtitles is the interval for subtitle searches
+	IntervalSearchSubtitles string `comment:"Time interval between searches for missing subtitles of existing movies and episodes.\nLeave empty to disable" displayname:"Subtitle Search Interval" longcomment:"Time interval between searches for missing subtitles of existing movies and episodes.\nOnly data paths with download_subtitles enabled are processed.\nLanguages without a match are searched again after 24 hours at the earliest.\nSupports Go duration format: '6h', '12h', '24h'\nLeave empty to disable the scheduled subtitle search.\nExample: '12h' for two searches per day" toml:"interval_search_subtitles"`
 	// IntervalDatabaseBackup is the interval for database backups
 	IntervalDatabaseBackup string `comment:"Time interval between automatic database backup operations.\nControls how often the application database is backed up" displayname:"Database Backup Interval" longcomment:"Time interval between automatic database backup operations.\nControls how often the application database is backed up for safety.\nBackups protect against data loss from corruption or system failures.\nSupports Go duration format: '24h', '168h' (1 week), '720h' (1 month), '8d'\nAlso supports cron format for specific timing (e.g., daily at 3 AM)\nDatabase backups temporarily lock the database during operation.\nBalance between data protection and system performance impact.\nRecommended: '24h' for daily backups, '168h' for weekly\nExample: '24h' for daily database backup at configured time" toml:"interval_database_backup"`
 	// IntervalDatabaseCheck is the interval for database checks
@@ -1712,6 +1891,10 @@ type SchedulerConfig struct {
 	CronScanDataFlags string `comment:"Cron schedule for flagged media file processing (alternative to interval).\nUse cron format for precise timing" displayname:"Flagged File Cron Schedule" longcomment:"Cron schedule for flagged media file processing (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nCommon examples:\n- '0 */4 * * *': Every 4 hours\n- '0 9,15,21 * * *': Three times daily at 9 AM, 3 PM, 9 PM\n- '*/45 * * * *': Every 45 minutes\nFlagged files often need prompt attention to resolve issues.\nSchedule frequently enough to handle problems quickly.\nExample: '0 */6 * * *' for every 6 hours flagged file processing" toml:"cron_scan_data_flags"`
 	// CronScanDataimport is the cron schedule for data import scans
 	CronScanDataimport string `comment:"Cron schedule for media import directory scanning (alternative to interval).\nUse cron format for precise timing" displayname:"Import Directory Cron Schedule" longcomment:"Cron schedule for media import directory scanning (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nCommon examples:\n- '0 */8 * * *': Every 8 hours\n- '0 10,22 * * *': Daily at 10 AM and 10 PM\n- '0 14 * * *': Daily at 2 PM\nImport scanning processes external media for library integration.\nFrequency depends on how often new files are added to import paths.\nExample: '0 */12 * * *' for every 12 hours import directory scanning" toml:"cron_scan_data_import"`
+	// CronArtwork is the cron schedule for artwork downloads
+	CronArtwork string `comment:"Cron schedule for downloads of missing artwork (alternative to interval).\nUse cron format for precise timing" displayname:"Artwork Download Cron Schedule" longcomment:"Cron schedule for downloads of missing artwork (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nOnly data paths with download_artwork enabled are processed.\nExample: '0 3 * * 0' for Sundays at 3 AM" toml:"cron_artwork"`
+	// CronSearchSubtitles is the cron schedule for subtitle searches
+	CronSearchSubtitles string `comment:"Cron schedule for searches of missing subtitles (alternative to interval).\nUse cron format for precise timing" displayname:"Subtitle Search Cron Schedule" longcomment:"Cron schedule for searches of missing subtitles (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nOnly data paths with download_subtitles enabled are processed.\nExample: '0 4 * * *' for daily at 4 AM" toml:"cron_search_subtitles"`
 	// CronDatabaseBackup is the cron schedule for database backups
 	CronDatabaseBackup string `comment:"Cron schedule for automatic database backup operations (alternative to interval).\nUse cron format for precise timing" displayname:"Database Backup Cron Schedule" longcomment:"Cron schedule for automatic database backup operations (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nCommon examples:\n- '0 3 * * *': Daily at 3 AM\n- '0 2 * * 0': Weekly on Sunday at 2 AM\n- '0 1 1 * *': Monthly on first day at 1 AM\nDatabase backups temporarily lock database during operation.\nSchedule during absolute lowest system usage periods.\nExample: '0 3 * * *' for daily database backup at 3 AM" toml:"cron_database_backup"`
 	// CronDatabaseCheck is the cron schedule for database checks
@@ -1722,6 +1905,24 @@ type SchedulerConfig struct {
 
 	// CronCacheRefresh is the cron schedule for cache refreshes
 	CronCacheRefresh string `comment:"Cron schedule for automatic cache refresh operations (alternative to interval).\nUse cron format for precise timing" displayname:"Cache Refresh Cron Schedule" longcomment:"Cron schedule for automatic cache refresh operations (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nCommon examples:\n- '0 */6 * * *': Every 6 hours\n- '0 2,8,14,20 * * *': 4 times daily at 2 AM, 8 AM, 2 PM, 8 PM\n- '0 3 * * *': Daily at 3 AM\nCache refreshes rebuild in-memory data from database for consistency.\nSchedule during lower usage periods to minimize performance impact.\nExample: '0 */6 * * *' for every 6 hours cache refresh" toml:"cron_cache_refresh"`
+
+	// IntervalIndexerCaps is the interval for indexer capability refreshes
+	IntervalIndexerCaps string `comment:"Time interval between refreshes of the indexer capabilities (caps).\nControls how often search modes and supported IDs are fetched" displayname:"Indexer Caps Refresh Interval" longcomment:"Time interval between refreshes of the indexer capabilities (caps).\nControls how often the supported search modes, ID parameters, categories and limits\nof every enabled indexer are fetched and stored in the database.\nThe searcher uses them to choose between ID and title searches per indexer.\nCaps are also fetched when an indexer client is created.\nSupports Go duration format: '24h', '3d', '7d'\nRecommended: '7d' - caps rarely change\nExample: '7d' for a weekly caps refresh" toml:"interval_indexer_caps"`
+
+	// CronIndexerCaps is the cron schedule for indexer capability refreshes
+	CronIndexerCaps string `comment:"Cron schedule for refreshes of the indexer capabilities (alternative to interval).\nUse cron format for precise timing" displayname:"Indexer Caps Refresh Cron Schedule" longcomment:"Cron schedule for refreshes of the indexer capabilities (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nExample: '0 0 4 * * sun' for every sunday at 4 AM" toml:"cron_indexer_caps"`
+
+	// IntervalRecycleBin is the interval for the recycle bin retention
+	IntervalRecycleBin string `comment:"Time interval between purges of expired recycle bin files.\nFiles older than recycle_retention_days of their path are deleted" displayname:"Recycle Bin Retention Interval" longcomment:"Time interval between purges of expired recycle bin files.\nFiles recycled longer ago than recycle_retention_days of their path are deleted\nfrom move_replaced_target_path and removed from the recycle bin list.\nPaths with recycle_retention_days set to 0 are never purged.\nSupports Go duration format: '12h', '1d', '7d'\nRecommended: '1d'\nExample: '1d' for a daily purge" toml:"interval_recycle_bin"`
+
+	// CronRecycleBin is the cron schedule for the recycle bin retention
+	CronRecycleBin string `comment:"Cron schedule for purges of expired recycle bin files (alternative to interval).\nUse cron format for precise timing" displayname:"Recycle Bin Retention Cron Schedule" longcomment:"Cron schedule for purges of expired recycle bin files (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nExample: '0 0 5 * * *' for every day at 5 AM" toml:"cron_recycle_bin"`
+
+	// IntervalImportedSources is the interval for the imported sources cleanup
+	IntervalImportedSources string `comment:"Time interval between cleanups of kept import sources.\nSources older than keep_source_days of their path are removed" displayname:"Imported Sources Cleanup Interval" longcomment:"Time interval between cleanups of the sources kept by copy, hardlink, reflink or symlink imports.\nSources imported longer ago than keep_source_days of their path are removed from the source path.\nEntries of sources removed by the download client are dropped and\nunmatched sources are released to be parsed again.\nSupports Go duration format: '12h', '1d', '7d'\nRecommended: '1d'\nExample: '1d' for a daily cleanup" toml:"interval_imported_sources"`
+
+	// CronImportedSources is the cron schedule for the imported sources cleanup
+	CronImportedSources string `comment:"Cron schedule for cleanups of kept import sources (alternative to interval).\nUse cron format for precise timing" displayname:"Imported Sources Cleanup Cron Schedule" longcomment:"Cron schedule for cleanups of kept import sources (alternative to interval).\nUse cron format for precise timing control instead of simple intervals.\nStandard cron format: 'minute hour day month weekday'\nExample: '0 30 5 * * *' for every day at 5:30 AM" toml:"cron_imported_sources"`
 }
 
 // Conf is a struct that contains a Name string field and a Data any field.
@@ -1770,6 +1971,27 @@ func (cfgp *MediaTypeConfig) GetMediaQualityConfigStr(str string) *QualityConfig
 	return GetSettingsQuality(str)
 }
 
+// GetMinimumAvailability returns the minimum availability of movies of the
+// list, falling back to the one of the list's quality profile.
+// Returns empty string if neither is set.
+func (list *MediaListsConfig) GetMinimumAvailability() string {
+	if list.MinimumAvailability != "" {
+		return list.MinimumAvailability
+	}
+
+	if list.CfgQuality != nil {
+		return list.CfgQuality.MinimumAvailability
+	}
+
+	return ""
+}
+
+// KeepsSource reports if the import mode leaves the organized source files
+// and their folders in place for the download client.
+func (path *PathsConfig) KeepsSource() bool {
+	return path.ImportMode != "" && path.ImportMode != ImportModeMove
+}
+
 // Getlistnamefilterignore returns a SQL WHERE clause to filter movies
 // by list name ignore lists. If the list has ignore lists configured,
 // it will generate a clause to exclude movies in those lists.
@@ -1782,6 +2004,43 @@ func (list *MediaListsConfig) Getlistnamefilterignore() string {
 	return ""
 }
 
+// CompileRules compiles the RejectRules and ScoreRules of the quality.
+// Invalid rules are logged and skipped - the config validation reports them
+// before they are saved.
+func (quality *QualityConfig) CompileRules() {
+	quality.RejectRulesCompiled = quality.RejectRulesCompiled[:0]
+	for _, rule := range quality.RejectRules {
+		prog, err := expression.Compile(rule)
+		if err != nil {
+			logger.Logtype(logger.StatusError, 1).
+				Str("quality", quality.Name).
+				Str("rule", rule).
+				Err(err).
+				Msg("Invalid reject rule skipped")
+
+			continue
+		}
+
+		quality.RejectRulesCompiled = append(quality.RejectRulesCompiled, prog)
+	}
+
+	quality.ScoreRulesCompiled = quality.ScoreRulesCompiled[:0]
+	for _, rule := range quality.ScoreRules {
+		scoreRule, err := expression.CompileScoreRule(rule)
+		if err != nil {
+			logger.Logtype(logger.StatusError, 1).
+				Str("quality", quality.Name).
+				Str("rule", rule).
+				Err(err).
+				Msg("Invalid score rule skipped")
+
+			continue
+		}
+
+		quality.ScoreRulesCompiled = append(quality.ScoreRulesCompiled, scoreRule)
+	}
+}
+
 // QualityIndexerByQualityAndTemplate returns the CategoriesIndexer string for the indexer
 // in the given QualityConfig that matches the given IndexersConfig by name.
 // Returns empty string if no match is found.
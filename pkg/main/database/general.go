@@ -762,7 +762,7 @@ func SetVars() {
 	globalCache.addStaticXStmt("select rootpath from series where id = ?", false)
 	globalCache.addStaticXStmt("update series SET listname = ?, dbserie_id = ? where id = ?", false)
 	globalCache.addStaticXStmt(
-		"update series SET aliases=?, search_specials=?, dont_search=?, dont_upgrade=? where dbserie_id = ? and listname = ?",
+		"update series SET aliases=?, search_specials=?, dont_search=?, dont_upgrade=?, search_delay=? where dbserie_id = ? and listname = ?",
 		false,
 	)
 	globalCache.addStaticXStmt("update series set rootpath = ? where id = ?", false)
@@ -843,7 +843,7 @@ func SetVars() {
 	globalCache.addStaticXStmt("select id from dbseries where slug = ?", false)
 	globalCache.addStaticXStmt("select id from dbseries where seriename = ? COLLATE NOCASE", false)
 	globalCache.addStaticXStmt(
-		"select id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id from dbseries where id = ?",
+		"select id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id, moviedb_id, tvmaze_id from dbseries where id = ?",
 		false,
 	)
 	globalCache.addStaticXStmt("select lower(identifiedby) from dbseries where id = ?", false)
@@ -865,7 +865,7 @@ func SetVars() {
 		false,
 	)
 	globalCache.addStaticXStmt(
-		"update dbseries SET Seriename = ?, Season = ?, Status = ?, Firstaired = ?, Network = ?, Runtime = ?, Language = ?, Genre = ?, Overview = ?, Rating = ?, Siterating = ?, Siterating_Count = ?, Slug = ?, Trakt_ID = ?, Imdb_ID = ?, Thetvdb_ID = ?, Freebase_M_ID = ?, Freebase_ID = ?, Tvrage_ID = ?, Facebook = ?, Instagram = ?, Twitter = ?, Banner = ?, Poster = ?, Fanart = ?, Identifiedby = ? where id = ?",
+		"update dbseries SET Seriename = ?, Season = ?, Status = ?, Firstaired = ?, Network = ?, Runtime = ?, Language = ?, Genre = ?, Overview = ?, Rating = ?, Siterating = ?, Siterating_Count = ?, Slug = ?, Trakt_ID = ?, Imdb_ID = ?, Thetvdb_ID = ?, Freebase_M_ID = ?, Freebase_ID = ?, Tvrage_ID = ?, Facebook = ?, Instagram = ?, Twitter = ?, Banner = ?, Poster = ?, Fanart = ?, Identifiedby = ?, Moviedb_ID = ?, Tvmaze_ID = ? where id = ?",
 		false,
 	)
 	globalCache.addStaticXStmt(
@@ -999,7 +999,7 @@ func SetVars() {
 		false,
 	)
 	globalCache.addStaticXStmt(
-		"select location, serie_episode_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended from serie_episode_files where serie_episode_id = ?",
+		"select location, serie_episode_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, release_group from serie_episode_files where serie_episode_id = ?",
 		false,
 	)
 	globalCache.addStaticXStmt(
@@ -1007,7 +1007,7 @@ func SetVars() {
 		false,
 	)
 	globalCache.addStaticXStmt(
-		"insert into serie_episode_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, height, width) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"insert into serie_episode_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, height, width, release_name, release_group, hdr, subtitle_languages) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		false,
 	)
 	globalCache.addStaticXStmt("delete from serie_episode_files where id = ?", false)
@@ -1031,7 +1031,7 @@ func SetVars() {
 	globalCache.addStaticXStmt("select location, id, movie_id from movie_files", false)
 	globalCache.addStaticXStmt("select location, id from movie_files where movie_id = ?", false)
 	globalCache.addStaticXStmt(
-		"select location, movie_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended from movie_files where movie_id = ?",
+		"select location, movie_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, release_group from movie_files where movie_id = ?",
 		false,
 	)
 	globalCache.addStaticXStmt(
@@ -1039,7 +1039,7 @@ func SetVars() {
 		false,
 	)
 	globalCache.addStaticXStmt(
-		"insert into movie_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, movie_id, dbmovie_id, height, width) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"insert into movie_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, movie_id, dbmovie_id, height, width, release_name, release_group, hdr, subtitle_languages) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		false,
 	)
 	globalCache.addStaticXStmt("delete from movie_files where movie_id = ? and location = ?", false)
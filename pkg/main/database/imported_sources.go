@@ -0,0 +1,70 @@
+package database
+
+import "time"
+
+// ImportedSource is a source file which was organized with an import mode
+// keeping the source for the download client.
+type ImportedSource struct {
+	CreatedAt time.Time `comment:"Time the source was first imported" displayname:"Imported"    db:"created_at"`
+	UpdatedAt time.Time `comment:"Time the source was last imported"  displayname:"Updated"     db:"updated_at"`
+	Source    string    `comment:"Path of the kept source file"       displayname:"Source"`
+	Target    string    `comment:"Path of the imported file"          displayname:"Target"`
+	ID        uint      `comment:"Unique imported source identifier"  displayname:"Imported ID"`
+}
+
+const (
+	queryImportedSourceColumns = "id, created_at, updated_at, source, target"
+	queryImportedSourceUpsert  = "insert into imported_sources (source, target) values (?, ?) on conflict (source) do update set target = excluded.target"
+)
+
+// AddImportedSource records the source file of an import which kept the
+// source, together with the file it was imported to. Sources which could not
+// be matched are recorded with an empty target.
+func AddImportedSource(source, target string) {
+	ExecN(queryImportedSourceUpsert, &source, &target)
+}
+
+// IsImportedSource reports if the source file was already imported with an
+// import mode keeping the source.
+func IsImportedSource(source string) bool {
+	return Getdatarow[uint](
+		false,
+		"select count() from imported_sources where source = ?",
+		&source,
+	) >= 1
+}
+
+// GetImportedSources returns all recorded sources.
+func GetImportedSources() []ImportedSource {
+	return StructscanT[ImportedSource](
+		false,
+		0,
+		"select "+queryImportedSourceColumns+" from imported_sources",
+	)
+}
+
+// GetExpiredImportedSources returns the imported sources below the folder
+// which were imported more than days ago. Unmatched sources are not returned.
+func GetExpiredImportedSources(folder string, days int) []ImportedSource {
+	return StructscanT[ImportedSource](
+		false,
+		0,
+		"select "+queryImportedSourceColumns+" from imported_sources where target != '' and instr(source, ?) = 1 and created_at < datetime('now','-'||?||' days')",
+		&folder,
+		&days,
+	)
+}
+
+// DeleteImportedSource removes the imported source entry with the id.
+func DeleteImportedSource(id uint) {
+	ExecN("delete from imported_sources where id = ?", &id)
+}
+
+// ReleaseUnmatchedSources removes the entries of unmatched sources which were
+// last parsed more than hours ago, so they are parsed again.
+func ReleaseUnmatchedSources(hours int) {
+	ExecN(
+		"delete from imported_sources where target = '' and updated_at < datetime('now','-'||?||' hours')",
+		&hours,
+	)
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/syncops"
+)
+
+// IndexerCaps holds the capabilities an indexer reported on its caps endpoint.
+// Search modes, parameters and categories are stored as comma separated lists.
+type IndexerCaps struct {
+	CreatedAt         time.Time `comment:"Record creation timestamp"            displayname:"Date Created"         db:"created_at"`
+	UpdatedAt         time.Time `comment:"Last refresh of the capabilities"     displayname:"Last Updated"         db:"updated_at"`
+	Indexer           string    `comment:"Indexer name from the configuration"  displayname:"Indexer"`
+	ServerTitle       string    `comment:"Server title reported by the indexer" displayname:"Server Title"         db:"server_title"`
+	SearchModes       string    `comment:"Available search modes"               displayname:"Search Modes"         db:"search_modes"`
+	SearchParams      string    `comment:"Parameters of the generic search"     displayname:"Search Parameters"    db:"search_params"`
+	TvSearchParams    string    `comment:"Parameters of the tv search"          displayname:"TV Search Parameters" db:"tv_search_params"`
+	MovieSearchParams string    `comment:"Parameters of the movie search"       displayname:"Movie Parameters"     db:"movie_search_params"`
+	Categories        string    `comment:"Supported category ids"               displayname:"Categories"`
+	ID                uint      `comment:"Unique capabilities identifier"       displayname:"Caps ID"`
+	MaxResults        int       `comment:"Maximum results per request"          displayname:"Max Results"          db:"max_results"`
+	DefaultLimit      int       `comment:"Default results per request"          displayname:"Default Limit"        db:"default_limit"`
+}
+
+const (
+	queryIndexerCapsGet    = "select id, created_at, updated_at, indexer, server_title, search_modes, search_params, tv_search_params, movie_search_params, categories, max_results, default_limit from indexer_caps where indexer = ? COLLATE NOCASE"
+	queryIndexerCapsUpsert = "insert into indexer_caps (indexer, server_title, search_modes, search_params, tv_search_params, movie_search_params, categories, max_results, default_limit) values (?, ?, ?, ?, ?, ?, ?, ?, ?) on conflict (indexer collate nocase) do update set server_title = excluded.server_title, search_modes = excluded.search_modes, search_params = excluded.search_params, tv_search_params = excluded.tv_search_params, movie_search_params = excluded.movie_search_params, categories = excluded.categories, max_results = excluded.max_results, default_limit = excluded.default_limit"
+)
+
+// indexerCapsCache keeps the capabilities read from the database per
+// lowercased indexer name. A nil value marks an indexer without stored caps.
+var indexerCapsCache = syncops.NewSyncMap[*IndexerCaps](10)
+
+// SupportsMode reports whether the indexer offers the search mode
+// (search, tvsearch or movie).
+func (c *IndexerCaps) SupportsMode(mode string) bool {
+	return containsCapsValue(c.SearchModes, mode)
+}
+
+// SupportsParam reports whether the search mode of the indexer accepts the
+// parameter, e.g. imdbid for movie or tvdbid for tvsearch.
+func (c *IndexerCaps) SupportsParam(mode, param string) bool {
+	if !c.SupportsMode(mode) {
+		return false
+	}
+
+	switch mode {
+	case "tvsearch":
+		return containsCapsValue(c.TvSearchParams, param)
+	case "movie":
+		return containsCapsValue(c.MovieSearchParams, param)
+	default:
+		return containsCapsValue(c.SearchParams, param)
+	}
+}
+
+// containsCapsValue checks a comma separated caps list for value.
+func containsCapsValue(list, value string) bool {
+	if list == "" {
+		return false
+	}
+
+	return slices.ContainsFunc(strings.Split(list, ","), func(s string) bool {
+		return strings.EqualFold(strings.TrimSpace(s), value)
+	})
+}
+
+// GetIndexerCaps returns the stored capabilities of the indexer or nil if
+// they were never fetched. Results are cached until the caps are saved again.
+func GetIndexerCaps(indexer string) *IndexerCaps {
+	key := strings.ToLower(indexer)
+	if indexerCapsCache.Check(key) {
+		return indexerCapsCache.GetVal(key)
+	}
+
+	caps, err := Structscan[IndexerCaps](queryIndexerCapsGet, false, &indexer)
+	if err != nil || caps.ID == 0 {
+		caps = nil
+	}
+
+	indexerCapsCache.Add(key, caps, 0, false, 0)
+
+	return caps
+}
+
+// SaveIndexerCaps stores the capabilities of an indexer, replacing any
+// previously stored ones.
+func SaveIndexerCaps(caps *IndexerCaps) error {
+	err := ExecNErr(
+		queryIndexerCapsUpsert,
+		&caps.Indexer,
+		&caps.ServerTitle,
+		&caps.SearchModes,
+		&caps.SearchParams,
+		&caps.TvSearchParams,
+		&caps.MovieSearchParams,
+		&caps.Categories,
+		&caps.MaxResults,
+		&caps.DefaultLimit,
+	)
+
+	indexerCapsCache.Delete(strings.ToLower(caps.Indexer))
+
+	return err
+}
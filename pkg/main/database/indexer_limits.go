@@ -0,0 +1,70 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/syncops"
+)
+
+// IndexerLimits holds the api and grab usage of an indexer in the current
+// window. A max of 0 means the limit is unknown.
+type IndexerLimits struct {
+	CreatedAt   time.Time    `comment:"Record creation timestamp"          displayname:"Date Created"        db:"created_at"`
+	UpdatedAt   time.Time    `comment:"Last update of the counters"        displayname:"Last Updated"        db:"updated_at"`
+	APIReset    sql.NullTime `comment:"End of the current api window"      displayname:"API Reset"           db:"api_reset"`
+	GrabReset   sql.NullTime `comment:"End of the current grab window"     displayname:"Grab Reset"          db:"grab_reset"`
+	Indexer     string       `comment:"Indexer name from the configuration" displayname:"Indexer"`
+	ID          uint         `comment:"Unique limits identifier"           displayname:"Limits ID"`
+	APICurrent  int          `comment:"Api requests in the current window" displayname:"API Requests"        db:"api_current"`
+	APIMax      int          `comment:"Allowed api requests per window"    displayname:"API Limit"           db:"api_max"`
+	GrabCurrent int          `comment:"Grabs in the current window"        displayname:"Grabs"               db:"grab_current"`
+	GrabMax     int          `comment:"Allowed grabs per window"           displayname:"Grab Limit"          db:"grab_max"`
+}
+
+const (
+	queryIndexerLimitsGet    = "select id, created_at, updated_at, api_reset, grab_reset, indexer, api_current, api_max, grab_current, grab_max from indexer_limits where indexer = ? COLLATE NOCASE"
+	queryIndexerLimitsUpsert = "insert into indexer_limits (indexer, api_current, api_max, api_reset, grab_current, grab_max, grab_reset) values (?, ?, ?, ?, ?, ?, ?) on conflict (indexer collate nocase) do update set api_current = excluded.api_current, api_max = excluded.api_max, api_reset = excluded.api_reset, grab_current = excluded.grab_current, grab_max = excluded.grab_max, grab_reset = excluded.grab_reset"
+)
+
+// indexerLimitsCache keeps the limits read from the database per lowercased
+// indexer name. Saved limits replace the cached ones.
+var indexerLimitsCache = syncops.NewSyncMap[IndexerLimits](10)
+
+// GetIndexerLimits returns the stored usage of the indexer. Indexers without
+// stored usage return empty limits with the indexer name set.
+func GetIndexerLimits(indexer string) IndexerLimits {
+	key := strings.ToLower(indexer)
+	if indexerLimitsCache.Check(key) {
+		return indexerLimitsCache.GetVal(key)
+	}
+
+	limits, err := Structscan[IndexerLimits](queryIndexerLimitsGet, false, &indexer)
+	if err != nil || limits.ID == 0 {
+		limits = &IndexerLimits{}
+	}
+
+	limits.Indexer = indexer
+	indexerLimitsCache.Add(key, *limits, 0, false, 0)
+
+	return *limits
+}
+
+// SaveIndexerLimits stores the usage of an indexer.
+func SaveIndexerLimits(limits *IndexerLimits) error {
+	err := ExecNErr(
+		queryIndexerLimitsUpsert,
+		&limits.Indexer,
+		&limits.APICurrent,
+		&limits.APIMax,
+		&limits.APIReset,
+		&limits.GrabCurrent,
+		&limits.GrabMax,
+		&limits.GrabReset,
+	)
+
+	indexerLimitsCache.Add(strings.ToLower(limits.Indexer), *limits, 0, false, 0)
+
+	return err
+}
@@ -54,6 +54,10 @@ type MovieFile struct {
 	Filename       string    `comment:"File name only"              displayname:"File Name"`
 	Extension      string    `comment:"File extension type"         displayname:"File Type"`
 	QualityProfile string    `comment:"File quality settings"       displayname:"Quality Settings" db:"quality_profile"`
+	ReleaseName    string    `comment:"Original scene release name" displayname:"Release Name"     db:"release_name"`
+	ReleaseGroup   string    `comment:"Releasing group name"        displayname:"Release Group"    db:"release_group"`
+	HDR            string    `comment:"HDR format of the video"     displayname:"HDR"              db:"hdr"`
+	SubLanguages   string    `comment:"Embedded subtitle languages" displayname:"Subtitles"        db:"subtitle_languages"`
 	CreatedAt      time.Time `comment:"Record creation timestamp"   displayname:"Date Created"     db:"created_at"`
 	UpdatedAt      time.Time `comment:"Last modification timestamp" displayname:"Last Updated"     db:"updated_at"`
 	ResolutionID   uint      `comment:"Video resolution reference"  displayname:"Video Resolution" db:"resolution_id"`
@@ -110,6 +114,9 @@ type Dbmovie struct {
 	Poster           string       `comment:"Movie poster image"           displayname:"Poster Image"`
 	Slug             string       `comment:"URL friendly identifier"      displayname:"URL Slug"`
 	ReleaseDate      sql.NullTime `comment:"Movie release date"           displayname:"Release Date"        db:"release_date"      json:"release_date" time_format:"2006-01-02" time_utc:"1"`
+	CinemaRelease    sql.NullTime `comment:"Regional cinema release"      displayname:"In Cinemas"          db:"cinema_release"`
+	DigitalRelease   sql.NullTime `comment:"Regional digital release"     displayname:"Digital Release"     db:"digital_release"`
+	PhysicalRelease  sql.NullTime `comment:"Regional physical release"    displayname:"Physical Release"    db:"physical_release"`
 	CreatedAt        time.Time    `comment:"Record creation timestamp"    displayname:"Date Created"        db:"created_at"`
 	UpdatedAt        time.Time    `comment:"Last modification timestamp"  displayname:"Last Updated"        db:"updated_at"`
 	Popularity       float32      `comment:"Movie popularity rating"      displayname:"Popularity Score"`
@@ -435,3 +442,36 @@ func checkYearInSluggedTitle(slugged []byte, yearu uint16, allowpm1 bool) bool {
 
 	return false
 }
+
+// MovieAvailabilityDateExpr returns the SQL expression of the date at which a
+// movie of the dbmovies table alias reaches the given availability level.
+// Unknown digital and physical releases fall back to each other and then to
+// an estimate of 90 days after the cinema release.
+// Returns empty string for announced or unknown levels.
+func MovieAvailabilityDateExpr(alias, level string) string {
+	cinema := "coalesce(" + alias + ".cinema_release, " + alias + ".release_date)"
+	estimated := "date(" + cinema + ", '+90 days')"
+
+	switch level {
+	case config.AvailabilityInCinemas:
+		return cinema
+	case config.AvailabilityDigital:
+		return "coalesce(" + alias + ".digital_release, " + alias + ".physical_release, " + estimated + ")"
+	case config.AvailabilityPhysical:
+		return "coalesce(" + alias + ".physical_release, " + alias + ".digital_release, " + estimated + ")"
+	default:
+		return ""
+	}
+}
+
+// MovieAvailabilityStatusExpr returns the SQL expression of the availability
+// level a movie of the dbmovies table alias has reached today.
+func MovieAvailabilityStatusExpr(alias string) string {
+	return "CASE WHEN date(" + MovieAvailabilityDateExpr(alias, config.AvailabilityPhysical) +
+		") <= date('now') THEN '" + config.AvailabilityPhysical +
+		"' WHEN date(" + MovieAvailabilityDateExpr(alias, config.AvailabilityDigital) +
+		") <= date('now') THEN '" + config.AvailabilityDigital +
+		"' WHEN date(" + MovieAvailabilityDateExpr(alias, config.AvailabilityInCinemas) +
+		") <= date('now') THEN '" + config.AvailabilityInCinemas +
+		"' ELSE '" + config.AvailabilityAnnounced + "' END"
+}
@@ -193,7 +193,9 @@ type ParseInfo struct {
 	Episodes []DbstaticTwoUint `json:"-"`
 	// Languages is a list of language codes
 	Languages []string `json:"languages,omitempty"`
-	Str       string   // used internally
+	// SubtitleLanguages are the languages of the embedded subtitle streams
+	SubtitleLanguages []string `json:"subtitle_languages,omitempty"`
+	Str               string   // used internally
 	// File is the path to the media file
 	File string
 	// SeasonStr is the season number as a string, if applicable
@@ -299,6 +301,12 @@ type ParseInfo struct {
 	Proper bool `json:"proper,omitempty"`
 	// Repack is a flag indicating if it is a repack release
 	Repack bool `json:"repack,omitempty"`
+	// ReleaseName is the scene name of the release without file extension
+	ReleaseName string `json:"release_name,omitempty"`
+	// ReleaseGroup is the group which released it, empty if unknown
+	ReleaseGroup string `json:"release_group,omitempty"`
+	// HDR is the HDR format of the video stream (dolby vision, hdr10+, hdr10, hlg), empty for SDR
+	HDR string `json:"hdr,omitempty"`
 
 	// SluggedTitle     string
 	// Listname         string   `json:"listname,omitempty"`
@@ -3189,12 +3197,19 @@ func (m *ParseInfo) SetEpisodeIDfromM() {
 
 // SetDBEpisodeIDfromM sets the DbserieEpisodeID field on the FileParser struct by looking
 // up the episode ID in the database based on the season, episode, and identifier fields.
+// Scene numbering with a mapping in dbserie_scene_mappings is translated to TVDB numbering first.
 func (m *ParseInfo) SetDBEpisodeIDfromM() {
 	if m.DbserieID == 0 {
 		m.DbserieEpisodeID = 0
 		return
 	}
 
+	// Scene numbering mapped to TVDB takes precedence over a direct match
+	if id := m.sceneMappedEpisodeID(); id != 0 {
+		m.DbserieEpisodeID = id
+		return
+	}
+
 	// Only match by season+episode when they were actually parsed from the title.
 	// Matching on empty SeasonStr/EpisodeStr would hit every scraper-imported episode
 	// (which has season='' and episode='' by default), returning a wrong random episode.
@@ -3236,7 +3251,8 @@ func (m *ParseInfo) GenerateIdentifierString() {
 	m.Identifier = ("S" + m.SeasonStr + "E" + m.EpisodeStr)
 }
 
-// ClearArr resets the Languages field of the ParseInfo struct to nil, effectively clearing the array.
+// ClearArr resets the Languages, Episodes and probed stream fields (HDR,
+// SubtitleLanguages) of the ParseInfo struct.
 func (m *ParseInfo) ClearArr() {
 	if m == nil {
 		return
@@ -3245,6 +3261,8 @@ func (m *ParseInfo) ClearArr() {
 	clear(m.Languages)
 
 	m.Languages = nil
+	m.HDR = ""
+	m.SubtitleLanguages = nil
 	clear(m.Episodes)
 
 	m.Episodes = nil
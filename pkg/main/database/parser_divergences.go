@@ -0,0 +1,140 @@
+package database
+
+import "time"
+
+// ParserDivergence is a field on which the legacy and the v2 file parser
+// returned different values for the same input.
+type ParserDivergence struct {
+	CreatedAt   time.Time `comment:"Time the divergence was first seen" displayname:"First Seen"   db:"created_at"`
+	UpdatedAt   time.Time `comment:"Time the divergence was last seen"  displayname:"Last Seen"    db:"updated_at"`
+	MediaType   string    `comment:"movie or series"                    displayname:"Media Type"   db:"media_type"`
+	Input       string    `comment:"Parsed release or file name"        displayname:"Input"`
+	Field       string    `comment:"Field the parsers disagree on"      displayname:"Field"`
+	LegacyValue string    `comment:"Value of the legacy parser"         displayname:"Legacy Value" db:"legacy_value"`
+	V2Value     string    `comment:"Value of the v2 parser"             displayname:"V2 Value"     db:"v2_value"`
+	ID          uint      `comment:"Unique divergence identifier"       displayname:"Divergence ID"`
+	Hits        int       `comment:"How often the divergence was seen"  displayname:"Hits"`
+}
+
+// ParserDivergenceGroup sums up the divergences of a field with the same pair
+// of values.
+type ParserDivergenceGroup struct {
+	Field       string
+	LegacyValue string `db:"legacy_value"`
+	V2Value     string `db:"v2_value"`
+	Inputs      int
+	Hits        int
+}
+
+// ParserDivergenceField is the number of divergent inputs of a field.
+type ParserDivergenceField struct {
+	Field  string
+	Inputs int
+}
+
+const (
+	queryParserDivergenceColumns = "id, created_at, updated_at, media_type, input, field, legacy_value, v2_value, hits"
+	queryParserDivergenceUpsert  = "insert into parser_divergences (media_type, input, field, legacy_value, v2_value) values (?, ?, ?, ?, ?) on conflict (input, field) do update set legacy_value = excluded.legacy_value, v2_value = excluded.v2_value, hits = hits + 1"
+	queryParserDivergenceTrim    = "delete from parser_divergences where id not in (select id from parser_divergences order by updated_at desc, id desc limit ?)"
+	queryParserDivergenceFilter  = " where (? = '' or field = ?) and (? = '' or input like ? or legacy_value like ? or v2_value like ?)"
+)
+
+// AddParserDivergence records a divergence of the field for the input or
+// counts another hit of an already recorded one.
+func AddParserDivergence(mediaType, input, field, legacyValue, v2Value string) {
+	ExecN(queryParserDivergenceUpsert, &mediaType, &input, &field, &legacyValue, &v2Value)
+}
+
+// TrimParserDivergences removes all but the keep most recently seen
+// divergences.
+func TrimParserDivergences(keep int) {
+	ExecN(queryParserDivergenceTrim, &keep)
+}
+
+// ClearParserDivergences removes all recorded divergences.
+func ClearParserDivergences() {
+	ExecN("delete from parser_divergences")
+}
+
+// CountParserDivergences counts the divergences matching the field and the
+// search text. Empty values match all divergences.
+func CountParserDivergences(field, search string) int {
+	like := "%" + search + "%"
+
+	return Getdatarow[int](
+		false,
+		"select count() from parser_divergences"+queryParserDivergenceFilter,
+		&field,
+		&field,
+		&search,
+		&like,
+		&like,
+		&like,
+	)
+}
+
+// GetParserDivergences returns a page of the divergences matching the field
+// and the search text, most recently seen first.
+func GetParserDivergences(field, search string, limit, offset int) []ParserDivergence {
+	like := "%" + search + "%"
+
+	return StructscanT[ParserDivergence](
+		false,
+		uint(limit),
+		"select "+queryParserDivergenceColumns+" from parser_divergences"+queryParserDivergenceFilter+" order by updated_at desc, id desc limit ? offset ?",
+		&field,
+		&field,
+		&search,
+		&like,
+		&like,
+		&like,
+		&limit,
+		&offset,
+	)
+}
+
+// CountParserDivergenceGroups counts the distinct value pairs of the
+// divergences matching the field and the search text.
+func CountParserDivergenceGroups(field, search string) int {
+	like := "%" + search + "%"
+
+	return Getdatarow[int](
+		false,
+		"select count() from (select 1 from parser_divergences"+queryParserDivergenceFilter+" group by field, legacy_value, v2_value)",
+		&field,
+		&field,
+		&search,
+		&like,
+		&like,
+		&like,
+	)
+}
+
+// GetParserDivergenceGroups returns a page of the divergences matching the
+// field and the search text grouped by field and value pair, most common first.
+func GetParserDivergenceGroups(field, search string, limit, offset int) []ParserDivergenceGroup {
+	like := "%" + search + "%"
+
+	return StructscanT[ParserDivergenceGroup](
+		false,
+		uint(limit),
+		"select field, legacy_value, v2_value, count() as inputs, sum(hits) as hits from parser_divergences"+queryParserDivergenceFilter+" group by field, legacy_value, v2_value order by inputs desc, hits desc limit ? offset ?",
+		&field,
+		&field,
+		&search,
+		&like,
+		&like,
+		&like,
+		&limit,
+		&offset,
+	)
+}
+
+// GetParserDivergenceFields returns the number of divergent inputs per field.
+func GetParserDivergenceFields() []ParserDivergenceField {
+	return StructscanT[ParserDivergenceField](
+		false,
+		0,
+		"select field, count() as inputs from parser_divergences group by field order by inputs desc",
+	)
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTrimParserDivergences(t *testing.T) {
+	useTestDB(t, "000043_parser_divergences")
+
+	// The first input was seen last
+	ages := []string{"-1 minute", "-5 minutes", "-4 minutes", "-3 minutes", "-2 minutes"}
+	for i, age := range ages {
+		ExecN(
+			"insert into parser_divergences (updated_at, media_type, input, field) values (datetime('now', ?), 'movie', ?, 'title')",
+			age,
+			fmt.Sprintf("Movie.%d.1080p", i),
+		)
+	}
+
+	TrimParserDivergences(2)
+
+	if got := CountParserDivergences("", ""); got != 2 {
+		t.Fatalf("CountParserDivergences() after trim = %d, want 2", got)
+	}
+
+	kept := GetParserDivergences("", "", 10, 0)
+	if len(kept) != 2 || kept[0].Input != "Movie.0.1080p" || kept[1].Input != "Movie.4.1080p" {
+		t.Errorf("GetParserDivergences() after trim = %+v, want the most recently seen", kept)
+	}
+}
@@ -51,6 +51,7 @@ type FilePrio struct {
 	Proper       bool
 	Repack       bool
 	Extended     bool
+	ReleaseGroup string
 }
 
 // AudioFilePrio contains audio file priority data for music/audiobooks.
@@ -324,6 +325,7 @@ func getfuncarr[T any](u *T, s *sqlx.Rows) error {
 			&elem.Proper,
 			&elem.Repack,
 			&elem.Extended,
+			&elem.ReleaseGroup,
 		)
 
 	case *AudioFilePrio:
@@ -508,7 +510,7 @@ func GetTableDefaults(table string) QueryParams {
 
 	case "dbseries":
 		q.Table = "dbseries"
-		q.DefaultColumns = "id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id"
+		q.DefaultColumns = "id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id, moviedb_id, tvmaze_id"
 		q.DefaultQuery = " where id like ? or seriename like ? or season like ? or slug like ? or imdb_id like ? or thetvdb_id like ? or trakt_id like ?"
 		q.DefaultQueryParamCount = 7
 		q.DefaultOrderBy = " order by id desc"
@@ -522,6 +524,14 @@ func GetTableDefaults(table string) QueryParams {
 		q.DefaultOrderBy = " order by dbserie_alternates.id desc"
 		q.Object = DbserieAlternate{}
 
+	case "dbserie_scene_mappings":
+		q.Table = "dbserie_scene_mappings LEFT JOIN dbseries ON dbserie_scene_mappings.dbserie_id = dbseries.id"
+		q.DefaultColumns = "dbserie_scene_mappings.id as id,dbserie_scene_mappings.created_at as created_at,dbserie_scene_mappings.updated_at as updated_at,dbserie_scene_mappings.dbserie_id as dbserie_id,dbserie_scene_mappings.scene_season as scene_season,dbserie_scene_mappings.scene_episode as scene_episode,dbserie_scene_mappings.scene_absolute as scene_absolute,dbserie_scene_mappings.tvdb_season as tvdb_season,dbserie_scene_mappings.tvdb_episode as tvdb_episode,dbseries.seriename as series_name"
+		q.DefaultQuery = " where dbserie_scene_mappings.id like ? or dbserie_scene_mappings.dbserie_id like ? or dbserie_scene_mappings.scene_absolute like ?"
+		q.DefaultQueryParamCount = 3
+		q.DefaultOrderBy = " order by dbserie_scene_mappings.dbserie_id desc, dbserie_scene_mappings.scene_season, dbserie_scene_mappings.scene_episode"
+		q.Object = DbserieSceneMapping{}
+
 	case "dbserie_episodes":
 		q.Table = "dbserie_episodes LEFT JOIN dbseries ON dbserie_episodes.dbserie_id = dbseries.id"
 		q.DefaultColumns = "dbserie_episodes.id as id,dbserie_episodes.created_at as created_at,dbserie_episodes.updated_at as updated_at,dbserie_episodes.episode as episode,dbserie_episodes.season as season,dbserie_episodes.identifier as identifier,dbserie_episodes.title as title,dbserie_episodes.first_aired as first_aired,dbserie_episodes.overview as overview,dbserie_episodes.poster as poster,dbserie_episodes.scraper_id as scraper_id,dbserie_episodes.scraper_url as scraper_url,dbserie_episodes.runtime as runtime,dbserie_episodes.dbserie_id as dbserie_id,dbseries.seriename as series_name"
@@ -588,7 +598,7 @@ func GetTableDefaults(table string) QueryParams {
 
 	case "movie_files":
 		q.Table = "movie_files LEFT JOIN dbmovies ON  movie_files.dbmovie_id = dbmovies.id"
-		q.DefaultColumns = "movie_files.id as id,movie_files.location as location,movie_files.filename as filename,movie_files.extension as extension,movie_files.quality_profile as quality_profile,movie_files.created_at as created_at,movie_files.updated_at as updated_at,movie_files.resolution_id as resolution_id,movie_files.quality_id as quality_id,movie_files.codec_id as codec_id,movie_files.audio_id as audio_id,movie_files.movie_id as movie_id,movie_files.dbmovie_id as dbmovie_id,movie_files.height as height,movie_files.width as width,movie_files.proper as proper,movie_files.extended as extended,movie_files.repack as repack,dbmovies.title as movie_title"
+		q.DefaultColumns = "movie_files.id as id,movie_files.location as location,movie_files.filename as filename,movie_files.extension as extension,movie_files.quality_profile as quality_profile,movie_files.created_at as created_at,movie_files.updated_at as updated_at,movie_files.resolution_id as resolution_id,movie_files.quality_id as quality_id,movie_files.codec_id as codec_id,movie_files.audio_id as audio_id,movie_files.movie_id as movie_id,movie_files.dbmovie_id as dbmovie_id,movie_files.height as height,movie_files.width as width,movie_files.proper as proper,movie_files.extended as extended,movie_files.repack as repack,movie_files.release_name as release_name,movie_files.release_group as release_group,dbmovies.title as movie_title,(select case when count() = 0 then '' else group_concat(subtitles.language || ':' || subtitles.status) end from subtitles where subtitles.location = movie_files.location) as subtitles"
 		q.DefaultQuery = " where movie_files.id like ? or movie_files.location like ? or movie_files.filename like ? or movie_files.extension like ? or movie_files.quality_profile like ? or movie_files.movie_id like ? or movie_files.dbmovie_id like ?"
 		q.DefaultQueryParamCount = 7
 		q.DefaultOrderBy = " order by movie_files.id desc"
@@ -596,7 +606,7 @@ func GetTableDefaults(table string) QueryParams {
 
 	case "serie_episode_files":
 		q.Table = "serie_episode_files LEFT JOIN dbserie_episodes ON serie_episode_files.dbserie_episode_id = dbserie_episodes.id"
-		q.DefaultColumns = "serie_episode_files.id as id,serie_episode_files.location as location,serie_episode_files.filename as filename,serie_episode_files.extension as extension,serie_episode_files.quality_profile as quality_profile,serie_episode_files.created_at as created_at,serie_episode_files.updated_at as updated_at,serie_episode_files.resolution_id as resolution_id,serie_episode_files.quality_id as quality_id,serie_episode_files.codec_id as codec_id,serie_episode_files.audio_id as audio_id,serie_episode_files.serie_id as serie_id,serie_episode_files.serie_episode_id as serie_episode_id,serie_episode_files.dbserie_episode_id as dbserie_episode_id,serie_episode_files.dbserie_id as dbserie_id,serie_episode_files.height as height,serie_episode_files.width as width,serie_episode_files.proper as proper,serie_episode_files.extended as extended,serie_episode_files.repack as repack,dbserie_episodes.title as episode_title"
+		q.DefaultColumns = "serie_episode_files.id as id,serie_episode_files.location as location,serie_episode_files.filename as filename,serie_episode_files.extension as extension,serie_episode_files.quality_profile as quality_profile,serie_episode_files.created_at as created_at,serie_episode_files.updated_at as updated_at,serie_episode_files.resolution_id as resolution_id,serie_episode_files.quality_id as quality_id,serie_episode_files.codec_id as codec_id,serie_episode_files.audio_id as audio_id,serie_episode_files.serie_id as serie_id,serie_episode_files.serie_episode_id as serie_episode_id,serie_episode_files.dbserie_episode_id as dbserie_episode_id,serie_episode_files.dbserie_id as dbserie_id,serie_episode_files.height as height,serie_episode_files.width as width,serie_episode_files.proper as proper,serie_episode_files.extended as extended,serie_episode_files.repack as repack,serie_episode_files.release_name as release_name,serie_episode_files.release_group as release_group,dbserie_episodes.title as episode_title,(select case when count() = 0 then '' else group_concat(subtitles.language || ':' || subtitles.status) end from subtitles where subtitles.location = serie_episode_files.location) as subtitles"
 		q.DefaultQuery = " where serie_episode_files.id like ? or serie_episode_files.location like ? or serie_episode_files.filename like ? or serie_episode_files.extension like ? or serie_episode_files.quality_profile like ? or serie_episode_files.serie_id like ? or serie_episode_files.serie_episode_id like ? or serie_episode_files.dbserie_episode_id like ? or serie_episode_files.dbserie_id like ?"
 		q.DefaultQueryParamCount = 9
 		q.DefaultOrderBy = " order by serie_episode_files.id desc"
@@ -893,6 +903,22 @@ func GetTableDefaults(table string) QueryParams {
 		q.DefaultOrderBy = " order by last_fail desc"
 		q.Object = IndexerFail{}
 
+	case "indexer_caps":
+		q.Table = "indexer_caps"
+		q.DefaultColumns = "id,created_at,updated_at,indexer,server_title,search_modes,search_params,tv_search_params,movie_search_params,categories,max_results,default_limit"
+		q.DefaultQuery = " where id like ? or indexer like ? or server_title like ?"
+		q.DefaultQueryParamCount = 3
+		q.DefaultOrderBy = " order by indexer"
+		q.Object = IndexerCaps{}
+
+	case "indexer_limits":
+		q.Table = "indexer_limits"
+		q.DefaultColumns = "id,created_at,updated_at,indexer,api_current,api_max,api_reset,grab_current,grab_max,grab_reset"
+		q.DefaultQuery = " where id like ? or indexer like ?"
+		q.DefaultQueryParamCount = 2
+		q.DefaultOrderBy = " order by indexer"
+		q.Object = IndexerLimits{}
+
 	case "r_sshistories":
 		q.Table = "r_sshistories"
 		q.DefaultColumns = "id,created_at,updated_at,config,list,indexer,last_id"
@@ -900,6 +926,46 @@ func GetTableDefaults(table string) QueryParams {
 		q.DefaultQueryParamCount = 4
 		q.DefaultOrderBy = " order by id desc"
 		q.Object = RSSHistory{}
+
+	case "rss_items":
+		q.Table = "rss_items"
+		q.DefaultColumns = "id,created_at,updated_at,config,quality_profile,indexer,title,download_url,size,pub_date,is_torrent,seeders,category,imdb,tvdb,season,episode,resolution,quality,codec,audio,release_group,identifier,listname,media_id,priority,decision,reason"
+		q.DefaultQuery = " where id like ? or config like ? or indexer like ? or title like ? or decision like ?"
+		q.DefaultQueryParamCount = 5
+		q.DefaultOrderBy = " order by id desc"
+		q.Object = RSSItem{}
+
+	case "parser_divergences":
+		q.Table = "parser_divergences"
+		q.DefaultColumns = "id,created_at,updated_at,media_type,input,field,legacy_value,v2_value,hits"
+		q.DefaultQuery = " where id like ? or input like ? or field like ?"
+		q.DefaultQueryParamCount = 3
+		q.DefaultOrderBy = " order by id desc"
+		q.Object = ParserDivergence{}
+
+	case "imported_sources":
+		q.Table = "imported_sources"
+		q.DefaultColumns = "id,created_at,updated_at,source,target"
+		q.DefaultQuery = " where id like ? or source like ? or target like ?"
+		q.DefaultQueryParamCount = 3
+		q.DefaultOrderBy = " order by id desc"
+		q.Object = ImportedSource{}
+
+	case "subtitles":
+		q.Table = "subtitles"
+		q.DefaultColumns = "id,created_at,updated_at,location,language,status,path,provider,release_name,hearing_impaired,forced,score,last_search"
+		q.DefaultQuery = " where id like ? or location like ? or language like ? or status like ?"
+		q.DefaultQueryParamCount = 4
+		q.DefaultOrderBy = " order by id desc"
+		q.Object = Subtitle{}
+
+	case "recycle_bin":
+		q.Table = "recycle_bin"
+		q.DefaultColumns = "id,created_at,updated_at,config,listname,path_template,media_id,reason,original_path,recycle_path,size"
+		q.DefaultQuery = " where id like ? or config like ? or reason like ? or original_path like ?"
+		q.DefaultQueryParamCount = 4
+		q.DefaultOrderBy = " order by id desc"
+		q.Object = RecycleItem{}
 	}
 
 	return q
@@ -941,7 +1007,7 @@ func QueryDbmovieTitle(qu Querywithargs, args ...any) []DbmovieTitle {
 // Returns an error if there was a problem retrieving the data.
 func GetDbserieByID(id *uint) (*Dbserie, error) {
 	return Structscan[Dbserie](
-		"select id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id from dbseries where id = ?",
+		"select id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id, moviedb_id, tvmaze_id from dbseries where id = ?",
 		false,
 		id,
 	)
@@ -958,7 +1024,7 @@ func QueryDbserie(qu Querywithargs, args ...any) []Dbserie {
 
 	qu.Table = "dbseries"
 
-	qu.defaultcolumns = "id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id"
+	qu.defaultcolumns = "id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id, moviedb_id, tvmaze_id"
 	if qu.QueryString == "" {
 		qu.buildquery()
 	}
@@ -1175,7 +1241,7 @@ func QueryResultSeries(qu Querywithargs, args ...any) []ResultSeries {
 
 	qu.Table = logger.StrSeries
 
-	qu.defaultcolumns = `dbseries.id as dbserie_id,dbseries.created_at,dbseries.updated_at,dbseries.seriename,dbseries.season,dbseries.status,dbseries.firstaired,dbseries.network,dbseries.runtime,dbseries.language,dbseries.genre,dbseries.overview,dbseries.rating,dbseries.siterating,dbseries.siterating_count,dbseries.slug,dbseries.imdb_id,dbseries.thetvdb_id,dbseries.freebase_m_id,dbseries.freebase_id,dbseries.tvrage_id,dbseries.facebook,dbseries.instagram,dbseries.twitter,dbseries.banner,dbseries.poster,dbseries.fanart,dbseries.identifiedby,dbseries.trakt_id,series.listname,series.rootpath,series.aliases,series.id as id`
+	qu.defaultcolumns = `dbseries.id as dbserie_id,dbseries.created_at,dbseries.updated_at,dbseries.seriename,dbseries.season,dbseries.status,dbseries.firstaired,dbseries.network,dbseries.runtime,dbseries.language,dbseries.genre,dbseries.overview,dbseries.rating,dbseries.siterating,dbseries.siterating_count,dbseries.slug,dbseries.imdb_id,dbseries.thetvdb_id,dbseries.freebase_m_id,dbseries.freebase_id,dbseries.tvrage_id,dbseries.facebook,dbseries.instagram,dbseries.twitter,dbseries.banner,dbseries.poster,dbseries.fanart,dbseries.identifiedby,dbseries.trakt_id,dbseries.moviedb_id,dbseries.tvmaze_id,series.listname,series.rootpath,series.aliases,series.id as id`
 	if qu.QueryString == "" {
 		qu.buildquery()
 	}
@@ -1558,6 +1624,24 @@ func GetdatarowArgs(querystring string, arg any, objs ...any) {
 	)
 }
 
+// GetdatarowArgsN executes the given querystring with multiple arguments
+// and scans the single result row into the given objects, handling locking
+// and logging errors.
+func GetdatarowArgsN(querystring string, args []any, objs ...any) {
+	readWriteMu.RLock()
+	defer readWriteMu.RUnlock()
+
+	row := queryRowContext(querystring, false, args)
+	if row == nil {
+		return
+	}
+
+	logSQLError(
+		row.Scan(objs...),
+		querystring,
+	)
+}
+
 // GetdatarowArgsImdb executes the given querystring with the provided argument
 // and scans the result into the given slice of objects, handling locking,
 // logging errors, and returning the scanned objects. This version of the function
@@ -0,0 +1,138 @@
+package database
+
+import "time"
+
+// Reasons stored for recycled files.
+const (
+	RecycleReasonUpgrade       = "upgrade"
+	RecycleReasonWrongLanguage = "wrong language"
+	RecycleReasonWrongRuntime  = "wrong runtime"
+	RecycleReasonManualDelete  = "manual delete"
+)
+
+// RecycleItem is a file which was moved into the recycle bin instead of being
+// deleted, with the media item it belonged to and the reason of the removal.
+type RecycleItem struct {
+	CreatedAt    time.Time `comment:"Time the file was recycled"                              displayname:"Recycled"      db:"created_at"`
+	UpdatedAt    time.Time `comment:"Last update"                                             displayname:"Updated"       db:"updated_at"`
+	Config       string    `comment:"Media configuration of the file"                         displayname:"Configuration"`
+	Listname     string    `comment:"List of the media item"                                  displayname:"List"`
+	PathTemplate string    `comment:"Paths template of the recycle bin"                       displayname:"Path Template" db:"path_template"`
+	Reason       string    `comment:"upgrade, wrong language, wrong runtime or manual delete" displayname:"Reason"`
+	OriginalPath string    `comment:"Path the file was recycled from"                         displayname:"Original Path" db:"original_path"`
+	RecyclePath  string    `comment:"Path of the file in the recycle bin"                     displayname:"Recycle Path"  db:"recycle_path"`
+	Size         int64     `comment:"File size in bytes"                                      displayname:"Size"`
+	ID           uint      `comment:"Unique recycle bin identifier"                           displayname:"Recycle ID"`
+	MediaID      uint      `comment:"ID of the movie, serie, book, audiobook or album"        displayname:"Media ID"      db:"media_id"`
+}
+
+const (
+	queryRecycleItemColumns = "id, created_at, updated_at, config, listname, path_template, media_id, reason, original_path, recycle_path, size"
+	queryRecycleItemInsert  = "insert into recycle_bin (config, listname, path_template, media_id, reason, original_path, recycle_path, size) values (?, ?, ?, ?, ?, ?, ?, ?)"
+	queryRecycleItemsFilter = " where (? = '' or reason = ?) and (? = '' or original_path like ? or config like ? or listname like ?)"
+)
+
+// AddRecycleItem records a file which was moved into the recycle bin.
+func AddRecycleItem(item *RecycleItem) {
+	ExecN(
+		queryRecycleItemInsert,
+		&item.Config,
+		&item.Listname,
+		&item.PathTemplate,
+		&item.MediaID,
+		&item.Reason,
+		&item.OriginalPath,
+		&item.RecyclePath,
+		&item.Size,
+	)
+}
+
+// DeleteRecycleItem removes the recycle bin entry with the id.
+func DeleteRecycleItem(id uint) {
+	ExecN("delete from recycle_bin where id = ?", &id)
+}
+
+// GetRecycleItem returns the recycle bin entry with the id.
+func GetRecycleItem(id uint) (*RecycleItem, error) {
+	return Structscan[RecycleItem](
+		"select "+queryRecycleItemColumns+" from recycle_bin where id = ?",
+		false,
+		&id,
+	)
+}
+
+// GetExpiredRecycleItems returns the recycle bin entries of the paths template
+// which were recycled more than days ago.
+func GetExpiredRecycleItems(pathTemplate string, days int) []RecycleItem {
+	return StructscanT[RecycleItem](
+		false,
+		0,
+		"select "+queryRecycleItemColumns+" from recycle_bin where path_template = ? and created_at < datetime('now','-'||?||' days')",
+		&pathTemplate,
+		&days,
+	)
+}
+
+// CountRecycleItems counts the recycle bin entries matching the reason and the
+// search text. Empty values match all entries.
+func CountRecycleItems(reason, search string) int {
+	like := "%" + search + "%"
+
+	return Getdatarow[int](
+		false,
+		"select count() from recycle_bin"+queryRecycleItemsFilter,
+		&reason,
+		&reason,
+		&search,
+		&like,
+		&like,
+		&like,
+	)
+}
+
+// GetRecycleItems returns a page of the recycle bin entries matching the
+// reason and the search text, newest first.
+func GetRecycleItems(reason, search string, limit, offset int) []RecycleItem {
+	like := "%" + search + "%"
+
+	return StructscanT[RecycleItem](
+		false,
+		uint(limit),
+		"select "+queryRecycleItemColumns+" from recycle_bin"+queryRecycleItemsFilter+" order by id desc limit ? offset ?",
+		&reason,
+		&reason,
+		&search,
+		&like,
+		&like,
+		&like,
+		&limit,
+		&offset,
+	)
+}
+
+// AddRestoredFile records a file restored from the recycle bin which was
+// removed for a wrong language or runtime, so it is allowed from now on.
+func AddRestoredFile(location string) {
+	ExecN("insert or ignore into restored_files (location) values (?)", &location)
+}
+
+// IsRestoredFile reports if the file was restored from the recycle bin after
+// it was removed for a wrong language or runtime.
+func IsRestoredFile(location string) bool {
+	return Getdatarow[uint](
+		false,
+		"select count() from restored_files where location = ?",
+		&location,
+	) >= 1
+}
+
+// GetRestoredFiles returns the locations of the files restored from the
+// recycle bin.
+func GetRestoredFiles() []string {
+	return GetrowsN[string](false, 0, "select location from restored_files")
+}
+
+// DeleteRestoredFile removes the restored file entry of the location.
+func DeleteRestoredFile(location string) {
+	ExecN("delete from restored_files where location = ?", &location)
+}
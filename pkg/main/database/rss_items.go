@@ -0,0 +1,157 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Decisions stored for RSS items.
+const (
+	RSSDecisionAccepted = "accepted"
+	RSSDecisionDenied   = "denied"
+	RSSDecisionGrabbed  = "grabbed"
+)
+
+// RSSItem is a candidate of an RSS search with the parsed values of its title
+// and the decision the searcher made about it.
+type RSSItem struct {
+	CreatedAt      time.Time    `comment:"Time the item was seen"              displayname:"Date Seen"       db:"created_at"`
+	UpdatedAt      time.Time    `comment:"Last decision update"                displayname:"Last Updated"    db:"updated_at"`
+	PubDate        sql.NullTime `comment:"Publish date reported by the feed"   displayname:"Published"       db:"pub_date"`
+	Config         string       `comment:"Media configuration of the search"   displayname:"Configuration"`
+	QualityProfile string       `comment:"Quality profile of the search"       displayname:"Quality Profile" db:"quality_profile"`
+	Indexer        string       `comment:"Indexer the item was found on"       displayname:"Indexer"`
+	Title          string       `comment:"Release title"                       displayname:"Title"`
+	DownloadURL    string       `comment:"Download link of the release"        displayname:"Download URL"    db:"download_url"`
+	Category       string       `comment:"Newznab category of the release"     displayname:"Category"`
+	Imdb           string       `comment:"IMDB ID reported by the feed"        displayname:"IMDB ID"`
+	Season         string       `comment:"Season reported by the feed"         displayname:"Season"`
+	Episode        string       `comment:"Episode reported by the feed"        displayname:"Episode"`
+	Resolution     string       `comment:"Parsed resolution"                   displayname:"Resolution"`
+	Quality        string       `comment:"Parsed quality"                      displayname:"Quality"`
+	Codec          string       `comment:"Parsed codec"                        displayname:"Codec"`
+	Audio          string       `comment:"Parsed audio"                        displayname:"Audio"`
+	ReleaseGroup   string       `comment:"Parsed release group"                displayname:"Release Group"   db:"release_group"`
+	Identifier     string       `comment:"Parsed episode identifier"           displayname:"Identifier"`
+	Listname       string       `comment:"List of the matched media"           displayname:"List"`
+	Decision       string       `comment:"accepted, denied or grabbed"         displayname:"Decision"`
+	Reason         string       `comment:"Reason of a denial"                  displayname:"Reason"`
+	Size           int64        `comment:"Release size in bytes"               displayname:"Size"`
+	ID             uint         `comment:"Unique RSS item identifier"          displayname:"RSS Item ID"`
+	MediaID        uint         `comment:"ID of the matched movie or episode"  displayname:"Media ID"        db:"media_id"`
+	Tvdb           int          `comment:"TVDB ID reported by the feed"        displayname:"TVDB ID"`
+	Seeders        int          `comment:"Seeders of a torrent"                displayname:"Seeders"`
+	Priority       int          `comment:"Priority computed for the release"   displayname:"Priority"`
+	IsTorrent      bool         `comment:"Release is a torrent"                displayname:"Torrent"         db:"is_torrent"`
+}
+
+const (
+	queryRSSItemColumns = "id, created_at, updated_at, config, quality_profile, indexer, title, download_url, size, pub_date, is_torrent, seeders, category, imdb, tvdb, season, episode, resolution, quality, codec, audio, release_group, identifier, listname, media_id, priority, decision, reason"
+	queryRSSItemInsert  = "insert into rss_items (config, quality_profile, indexer, title, download_url, size, pub_date, is_torrent, seeders, category, imdb, tvdb, season, episode, resolution, quality, codec, audio, release_group, identifier, listname, media_id, priority, decision, reason) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	queryRSSItemUpdate  = "update rss_items set resolution = ?, quality = ?, codec = ?, audio = ?, release_group = ?, identifier = ?, listname = ?, media_id = ?, priority = ?, decision = ?, reason = ? where id = ?"
+	queryRSSItemsTrim   = "delete from rss_items where id <= (select id from rss_items order by id desc limit 1 offset ?)"
+	queryRSSItemsFilter = " where (? = '' or decision = ?) and (? = '' or title like ? or indexer like ? or reason like ?)"
+)
+
+// AddRSSItem stores a candidate of an RSS search.
+func AddRSSItem(item *RSSItem) {
+	ExecN(
+		queryRSSItemInsert,
+		&item.Config,
+		&item.QualityProfile,
+		&item.Indexer,
+		&item.Title,
+		&item.DownloadURL,
+		&item.Size,
+		&item.PubDate,
+		&item.IsTorrent,
+		&item.Seeders,
+		&item.Category,
+		&item.Imdb,
+		&item.Tvdb,
+		&item.Season,
+		&item.Episode,
+		&item.Resolution,
+		&item.Quality,
+		&item.Codec,
+		&item.Audio,
+		&item.ReleaseGroup,
+		&item.Identifier,
+		&item.Listname,
+		&item.MediaID,
+		&item.Priority,
+		&item.Decision,
+		&item.Reason,
+	)
+}
+
+// UpdateRSSItem stores the parsed values and the decision of a re-evaluated
+// or grabbed RSS item.
+func UpdateRSSItem(item *RSSItem) {
+	ExecN(
+		queryRSSItemUpdate,
+		&item.Resolution,
+		&item.Quality,
+		&item.Codec,
+		&item.Audio,
+		&item.ReleaseGroup,
+		&item.Identifier,
+		&item.Listname,
+		&item.MediaID,
+		&item.Priority,
+		&item.Decision,
+		&item.Reason,
+		&item.ID,
+	)
+}
+
+// TrimRSSItems removes all but the newest keep RSS items.
+func TrimRSSItems(keep int) {
+	ExecN(queryRSSItemsTrim, &keep)
+}
+
+// GetRSSItem returns the stored RSS item with the id.
+func GetRSSItem(id uint) (*RSSItem, error) {
+	return Structscan[RSSItem](
+		"select "+queryRSSItemColumns+" from rss_items where id = ?",
+		false,
+		&id,
+	)
+}
+
+// CountRSSItems counts the RSS items matching the decision and the search
+// text. Empty values match all items.
+func CountRSSItems(decision, search string) int {
+	like := "%" + search + "%"
+
+	return Getdatarow[int](
+		false,
+		"select count() from rss_items"+queryRSSItemsFilter,
+		&decision,
+		&decision,
+		&search,
+		&like,
+		&like,
+		&like,
+	)
+}
+
+// GetRSSItems returns a page of the RSS items matching the decision and the
+// search text, newest first.
+func GetRSSItems(decision, search string, limit, offset int) []RSSItem {
+	like := "%" + search + "%"
+
+	return StructscanT[RSSItem](
+		false,
+		uint(limit),
+		"select "+queryRSSItemColumns+" from rss_items"+queryRSSItemsFilter+" order by id desc limit ? offset ?",
+		&decision,
+		&decision,
+		&search,
+		&like,
+		&like,
+		&like,
+		&limit,
+		&offset,
+	)
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// useTestDB replaces the data database with a temporary one holding only the
+// tables of the migrations.
+func useTestDB(t *testing.T, migrations ...string) {
+	t.Helper()
+
+	db, err := sqlx.Connect("sqlite", "file:"+filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, migration := range migrations {
+		schema, err := os.ReadFile(
+			filepath.Join("..", "..", "..", "schema", "db", migration+".up.sql"),
+		)
+		if err != nil {
+			db.Close()
+			t.Skip("Skipping: schema not available - ", err)
+		}
+
+		if _, err := db.Exec(string(schema)); err != nil {
+			db.Close()
+			t.Fatal(err)
+		}
+	}
+
+	NewCache(1*time.Hour, 1*time.Hour)
+	InvalidateImdbStmt()
+
+	old := dbData
+	dbData = db
+
+	t.Cleanup(func() {
+		InvalidateImdbStmt()
+
+		dbData = old
+
+		db.Close()
+	})
+}
+
+func TestRSSItems(t *testing.T) {
+	useTestDB(t, "000042_rss_items")
+
+	items := []RSSItem{
+		{
+			Config:   "movie_EN",
+			Indexer:  "nzbgeek",
+			Title:    "Movie.2020.1080p-GRP",
+			Decision: RSSDecisionAccepted,
+		},
+		{
+			Config:   "movie_EN",
+			Indexer:  "nzbgeek",
+			Title:    "Movie.2020.CAM-XYZ",
+			Decision: RSSDecisionDenied,
+			Reason:   "unwanted quality",
+		},
+		{
+			Config:   "serie_EN",
+			Indexer:  "drunken",
+			Title:    "Show.S01E01.720p-GRP",
+			Decision: RSSDecisionDenied,
+			Reason:   "lower Prio",
+		},
+	}
+	for idx := range items {
+		AddRSSItem(&items[idx])
+	}
+
+	counts := []struct {
+		name     string
+		decision string
+		search   string
+		expected int
+	}{
+		{name: "All items", expected: 3},
+		{name: "Decision", decision: RSSDecisionDenied, expected: 2},
+		{name: "Title", search: "Movie.2020", expected: 2},
+		{name: "Indexer", search: "drunken", expected: 1},
+		{name: "Reason and decision", decision: RSSDecisionDenied, search: "quality", expected: 1},
+		{name: "No match", decision: RSSDecisionGrabbed, expected: 0},
+	}
+
+	for _, tt := range counts {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountRSSItems(tt.decision, tt.search); got != tt.expected {
+				t.Errorf("CountRSSItems() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+
+	page := GetRSSItems("", "", 2, 0)
+	if len(page) != 2 || page[0].Title != items[2].Title || page[1].Title != items[1].Title {
+		t.Fatalf("GetRSSItems() first page = %+v, want the newest items first", page)
+	}
+
+	if page = GetRSSItems("", "", 2, 2); len(page) != 1 || page[0].Title != items[0].Title {
+		t.Fatalf("GetRSSItems() second page = %+v, want the oldest item", page)
+	}
+
+	item, err := GetRSSItem(page[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item.Decision = RSSDecisionGrabbed
+	item.MediaID = 5
+	UpdateRSSItem(item)
+
+	if item, err = GetRSSItem(item.ID); err != nil || item.Decision != RSSDecisionGrabbed ||
+		item.MediaID != 5 {
+		t.Errorf("GetRSSItem() after update = %+v, %v", item, err)
+	}
+
+	TrimRSSItems(1)
+
+	if got := CountRSSItems("", ""); got != 1 {
+		t.Errorf("CountRSSItems() after trim = %d, want 1", got)
+	}
+
+	if page = GetRSSItems("", "", 10, 0); len(page) != 1 || page[0].Title != items[2].Title {
+		t.Errorf("GetRSSItems() after trim = %+v, want the newest item", page)
+	}
+}
@@ -0,0 +1,183 @@
+package database
+
+import (
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// DbserieSceneMapping maps one scene numbered episode of a series (as used in
+// release names) to the season and episode TVDB uses for it.
+type DbserieSceneMapping struct {
+	CreatedAt     time.Time `comment:"Record creation timestamp"   displayname:"Date Created"     db:"created_at"`
+	UpdatedAt     time.Time `comment:"Last modification timestamp" displayname:"Last Updated"     db:"updated_at"`
+	ID            uint      `comment:"Unique mapping identifier"   displayname:"Mapping ID"`
+	DbserieID     uint      `comment:"Parent series reference"     displayname:"Series Reference" db:"dbserie_id"`
+	SceneSeason   int       `comment:"Season used by releases"     displayname:"Scene Season"     db:"scene_season"`
+	SceneEpisode  int       `comment:"Episode used by releases"    displayname:"Scene Episode"    db:"scene_episode"`
+	SceneAbsolute int       `comment:"Absolute number in releases" displayname:"Scene Absolute"   db:"scene_absolute"`
+	TvdbSeason    int       `comment:"Season on TVDB"              displayname:"TVDB Season"      db:"tvdb_season"`
+	TvdbEpisode   int       `comment:"Episode on TVDB"             displayname:"TVDB Episode"     db:"tvdb_episode"`
+}
+
+// sceneMappingFile is the layout of the scene mapping import file.
+type sceneMappingFile struct {
+	Series []struct {
+		Mapping []struct {
+			SceneSeason   int `toml:"scene_season"`
+			SceneEpisode  int `toml:"scene_episode"`
+			SceneAbsolute int `toml:"scene_absolute"`
+			TvdbSeason    int `toml:"tvdb_season"`
+			TvdbEpisode   int `toml:"tvdb_episode"`
+		} `toml:"mapping"`
+		TvdbID int `toml:"tvdb_id"`
+	} `toml:"series"`
+}
+
+const (
+	querySceneMappingToTvdb  = "select tvdb_season, tvdb_episode from dbserie_scene_mappings where dbserie_id = ? and ((? > 0 and scene_season = ? and scene_episode = ?) or (? > 0 and scene_absolute = ?)) order by scene_episode = 0 limit 1"
+	querySceneMappingToScene = "select scene_season, scene_episode, scene_absolute from dbserie_scene_mappings where dbserie_id = ? and tvdb_season = ? and tvdb_episode = ? limit 1"
+	querySceneMappingInsert  = "insert into dbserie_scene_mappings (dbserie_id, scene_season, scene_episode, scene_absolute, tvdb_season, tvdb_episode) values (?, ?, ?, ?, ?, ?)"
+)
+
+var errSceneMappingNoSeries = errors.New("no series in scene mapping file")
+
+// SceneToTvdb translates the scene numbering parsed from a release of the
+// series to TVDB numbering. A season/episode mapping takes precedence over an
+// absolute number mapping. It returns false if no mapping exists.
+func SceneToTvdb(dbserieID uint, season, episode, absolute int) (int, int, bool) {
+	if dbserieID == 0 || (episode == 0 && absolute == 0) {
+		return 0, 0, false
+	}
+
+	var tvdbSeason, tvdbEpisode int
+
+	GetdatarowArgsN(
+		querySceneMappingToTvdb,
+		[]any{&dbserieID, &episode, &season, &episode, &absolute, &absolute},
+		&tvdbSeason,
+		&tvdbEpisode,
+	)
+
+	if tvdbEpisode == 0 {
+		return 0, 0, false
+	}
+
+	return tvdbSeason, tvdbEpisode, true
+}
+
+// TvdbToScene translates a TVDB season/episode of the series to the scene
+// numbering used by releases, for building search queries. The absolute
+// number is 0 if the mapping has none. It returns false if no mapping exists.
+func TvdbToScene(dbserieID uint, season, episode int) (int, int, int, bool) {
+	if dbserieID == 0 || episode == 0 {
+		return 0, 0, 0, false
+	}
+
+	var sceneSeason, sceneEpisode, sceneAbsolute int
+
+	GetdatarowArgsN(
+		querySceneMappingToScene,
+		[]any{&dbserieID, &season, &episode},
+		&sceneSeason,
+		&sceneEpisode,
+		&sceneAbsolute,
+	)
+
+	if sceneEpisode == 0 && sceneAbsolute == 0 {
+		return 0, 0, 0, false
+	}
+
+	return sceneSeason, sceneEpisode, sceneAbsolute, true
+}
+
+// ImportSceneMappings reads a scene mapping file and stores its mappings.
+// All existing mappings of a series listed in the file are replaced. Series
+// not yet in the database are skipped. It returns the number of imported mappings.
+func ImportSceneMappings(file string) (int, error) {
+	content, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer content.Close()
+
+	return ImportSceneMappingsFrom(content, file)
+}
+
+// ImportSceneMappingsFrom stores the mappings of the scene mapping content read
+// from r like ImportSceneMappings. source names the content in the log.
+func ImportSceneMappingsFrom(r io.Reader, source string) (int, error) {
+	var mappings sceneMappingFile
+	if err := toml.NewDecoder(r).Decode(&mappings); err != nil {
+		return 0, err
+	}
+
+	if len(mappings.Series) == 0 {
+		return 0, errSceneMappingNoSeries
+	}
+
+	var count int
+
+	for idx := range mappings.Series {
+		serie := &mappings.Series[idx]
+
+		dbserieID := Getdatarow[uint](false, QueryDbseriesGetIDByTvdb, &serie.TvdbID)
+		if dbserieID == 0 {
+			logger.Logtype("warn", 1).
+				Int(logger.StrTvdb, serie.TvdbID).
+				Msg("Scene mapping skipped - series not found")
+
+			continue
+		}
+
+		DeleteRow("dbserie_scene_mappings", "dbserie_id = ?", &dbserieID)
+
+		for i := range serie.Mapping {
+			mapping := &serie.Mapping[i]
+			if mapping.TvdbEpisode == 0 ||
+				(mapping.SceneEpisode == 0 && mapping.SceneAbsolute == 0) {
+				continue
+			}
+
+			ExecN(
+				querySceneMappingInsert,
+				&dbserieID,
+				&mapping.SceneSeason,
+				&mapping.SceneEpisode,
+				&mapping.SceneAbsolute,
+				&mapping.TvdbSeason,
+				&mapping.TvdbEpisode,
+			)
+
+			count++
+		}
+	}
+
+	logger.Logtype("info", 1).
+		Str(logger.StrFile, source).
+		Int("count", count).
+		Msg("Scene mappings imported")
+
+	return count, nil
+}
+
+// sceneMappedEpisodeID looks up the dbserie episode for scene numbering of the
+// series that has a mapping to TVDB numbering. It returns 0 if there is none.
+func (m *ParseInfo) sceneMappedEpisodeID() uint {
+	season, episode, ok := SceneToTvdb(m.DbserieID, m.Season, m.Episode, m.AbsoluteEpisode)
+	if !ok {
+		return 0
+	}
+
+	return Getdatarow[uint](
+		false,
+		"select id from dbserie_episodes where dbserie_id = ? and cast(season as integer) = ? and cast(episode as integer) = ? and episode != '' limit 1",
+		&m.DbserieID,
+		&season,
+		&episode,
+	)
+}
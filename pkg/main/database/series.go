@@ -18,6 +18,7 @@ type Serie struct {
 	DontSearch     bool      `comment:"Disable new searches"                                displayname:"Search Disabled"    db:"dont_search"`
 	SearchSpecials bool      `comment:"Include season zero"                                 displayname:"Include Specials"   db:"search_specials"`
 	IgnoreRuntime  bool      `comment:"Skip runtime validation"                             displayname:"Skip Runtime Check" db:"ignore_runtime"`
+	SearchDelay    int       `comment:"Minutes to wait after airing, -1 none, 0 group"      displayname:"Search Delay"       db:"search_delay"`
 }
 type SerieEpisode struct {
 	QualityProfile   string       `comment:"Episode quality settings"    db:"quality_profile"    displayname:"Quality Settings"`
@@ -49,6 +50,10 @@ type SerieEpisodeFile struct {
 	Filename         string    `comment:"File name only"              displayname:"File Name"`
 	Extension        string    `comment:"File extension type"         displayname:"File Type"`
 	QualityProfile   string    `comment:"File quality settings"       displayname:"Quality Settings"  db:"quality_profile"`
+	ReleaseName      string    `comment:"Original scene release name" displayname:"Release Name"      db:"release_name"`
+	ReleaseGroup     string    `comment:"Releasing group name"        displayname:"Release Group"     db:"release_group"`
+	HDR              string    `comment:"HDR format of the video"     displayname:"HDR"               db:"hdr"`
+	SubLanguages     string    `comment:"Embedded subtitle languages" displayname:"Subtitles"         db:"subtitle_languages"`
 	CreatedAt        time.Time `comment:"Record creation timestamp"   displayname:"Date Created"      db:"created_at"`
 	UpdatedAt        time.Time `comment:"Last modification timestamp" displayname:"Last Updated"      db:"updated_at"`
 	ID               uint      `comment:"Unique file identifier"      displayname:"File ID"`
@@ -124,6 +129,8 @@ type Dbserie struct {
 	TraktID         int       `comment:"Trakt database identifier"     displayname:"Trakt Identifier"    db:"trakt_id"`
 	ThetvdbID       int       `comment:"TheTVDB database identifier"   displayname:"TVDB Identifier"     db:"thetvdb_id"`
 	TvrageID        int       `comment:"TVRage database identifier"    displayname:"TVRage Identifier"   db:"tvrage_id"`
+	MoviedbID       int       `comment:"TMDB database identifier"      displayname:"TMDB Identifier"     db:"moviedb_id"`
+	TvmazeID        int       `comment:"TVmaze database identifier"    displayname:"TVmaze Identifier"   db:"tvmaze_id"`
 	ID              uint      `comment:"Unique series identifier"      displayname:"Series ID"`
 }
 
@@ -159,10 +166,13 @@ type DbserieEpisode struct {
 	ScraperID       string       `comment:"Source scraper identifier"   displayname:"Scraper ID"         db:"scraper_id"`
 	ScraperURL      string       `comment:"Source scraper page URL"     displayname:"Scraper URL"        db:"scraper_url"`
 	FirstAired      sql.NullTime `comment:"Original air date"           displayname:"Original Air Date"  db:"first_aired"      json:"first_aired" time_format:"2006-01-02" time_utc:"1"`
+	AirTime         sql.NullTime `comment:"Air timestamp in UTC"        displayname:"Air Time"           db:"air_time"`
 	CreatedAt       time.Time    `comment:"Record creation timestamp"   displayname:"Date Created"       db:"created_at"`
 	UpdatedAt       time.Time    `comment:"Last modification timestamp" displayname:"Last Updated"       db:"updated_at"`
 	Runtime         int          `comment:"Episode duration minutes"    displayname:"Episode Duration"`
 	AbsoluteEpisode int          `comment:"Absolute episode number"     displayname:"Absolute Episode"   db:"absolute_episode"`
+	ThetvdbID       int          `comment:"TheTVDB episode identifier"  displayname:"TVDB Identifier"    db:"thetvdb_id"`
+	ImdbID          string       `comment:"IMDb episode identifier"     displayname:"IMDb Identifier"    db:"imdb_id"`
 	ID              uint         `comment:"Unique episode identifier"   displayname:"Episode ID"`
 	DbserieID       uint         `comment:"Parent series reference"     displayname:"Series Reference"   db:"dbserie_id"`
 }
@@ -174,7 +184,7 @@ type DbserieEpisode struct {
 // Returns an error if there was a problem retrieving the data.
 func (s *Dbserie) GetDbserieByIDP(id *uint) error {
 	return structscan1(
-		"select id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id from dbseries where id = ?",
+		"select id,created_at,updated_at,seriename,season,status,firstaired,network,runtime,language,genre,overview,rating,siterating,siterating_count,slug,imdb_id,thetvdb_id,freebase_m_id,freebase_id,tvrage_id,facebook,instagram,twitter,banner,poster,fanart,identifiedby, trakt_id, moviedb_id, tvmaze_id from dbseries where id = ?",
 		s,
 		id,
 	)
@@ -187,7 +197,7 @@ func (s *Dbserie) GetDbserieByIDP(id *uint) error {
 // Returns an error if there was a problem retrieving the data.
 func (u *DbserieEpisode) GetDbserieEpisodesByIDP(id *uint) error {
 	return structscan1(
-		"select id,created_at,updated_at,episode,season,identifier,title,first_aired,overview,poster,scraper_id,scraper_url,runtime,dbserie_id from dbserie_episodes where id = ?",
+		"select id,created_at,updated_at,episode,season,identifier,title,first_aired,overview,poster,scraper_id,scraper_url,runtime,thetvdb_id,imdb_id,dbserie_id from dbserie_episodes where id = ?",
 		u,
 		id,
 	)
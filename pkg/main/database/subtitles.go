@@ -0,0 +1,109 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Status values of a subtitle language of a video file.
+const (
+	SubtitleStatusExisting   = "existing"
+	SubtitleStatusDownloaded = "downloaded"
+	SubtitleStatusMissing    = "missing"
+)
+
+// Subtitle is the status of one wanted subtitle language of a video file.
+type Subtitle struct {
+	CreatedAt       time.Time    `comment:"Time the language was first checked"      displayname:"Created"          db:"created_at"`
+	UpdatedAt       time.Time    `comment:"Last status update"                       displayname:"Updated"          db:"updated_at"`
+	LastSearch      sql.NullTime `comment:"Last provider search for the language"    displayname:"Last Search"      db:"last_search"`
+	Location        string       `comment:"Path of the video file"                   displayname:"Video"`
+	Language        string       `comment:"ISO 639-1 code of the subtitle language"  displayname:"Language"`
+	Status          string       `comment:"existing, downloaded or missing"          displayname:"Status"`
+	Path            string       `comment:"Path of the subtitle file"                displayname:"Subtitle"`
+	Provider        string       `comment:"Provider of the downloaded subtitle"      displayname:"Provider"`
+	ReleaseName     string       `comment:"Release the subtitle was made for"        displayname:"Release"          db:"release_name"`
+	ID              uint         `comment:"Unique subtitle identifier"               displayname:"Subtitle ID"`
+	Score           int          `comment:"Match score of the downloaded subtitle"   displayname:"Score"`
+	HearingImpaired bool         `comment:"Subtitle is for the hearing impaired"     displayname:"Hearing Impaired" db:"hearing_impaired"`
+	Forced          bool         `comment:"Subtitle only covers foreign parts"       displayname:"Forced"`
+}
+
+const (
+	querySubtitleFilesFilter = " where (? = '' or status = ?) and (? = '' or location like ?)"
+	querySubtitleColumns     = "id, created_at, updated_at, location, language, status, path, provider, release_name, hearing_impaired, forced, score, last_search"
+	querySubtitleUpsert      = "insert into subtitles (location, language, status, path, provider, release_name, hearing_impaired, forced, score, last_search) values (?, ?, ?, ?, ?, ?, ?, ?, ?, case when ? then datetime('now','localtime') end) on conflict (location, language) do update set status = excluded.status, path = excluded.path, provider = excluded.provider, release_name = excluded.release_name, hearing_impaired = excluded.hearing_impaired, forced = excluded.forced, score = excluded.score, last_search = coalesce(excluded.last_search, subtitles.last_search)"
+)
+
+// SetSubtitle stores the status of the subtitle language of a video file.
+// The last search time is set to now if searched is true and kept otherwise.
+func SetSubtitle(sub *Subtitle, searched bool) {
+	ExecN(
+		querySubtitleUpsert,
+		&sub.Location,
+		&sub.Language,
+		&sub.Status,
+		&sub.Path,
+		&sub.Provider,
+		&sub.ReleaseName,
+		&sub.HearingImpaired,
+		&sub.Forced,
+		&sub.Score,
+		&searched,
+	)
+}
+
+// GetSubtitles returns the stored subtitle languages of a video file.
+func GetSubtitles(location string) []Subtitle {
+	return StructscanT[Subtitle](
+		false,
+		0,
+		"select "+querySubtitleColumns+" from subtitles where location = ? order by language",
+		&location,
+	)
+}
+
+// CountSubtitleFiles counts the video files with a subtitle language of the
+// status whose path contains the search text. Empty values match all files.
+func CountSubtitleFiles(status, search string) int {
+	like := "%" + search + "%"
+
+	return Getdatarow[int](
+		false,
+		"select count(distinct location) from subtitles"+querySubtitleFilesFilter,
+		&status,
+		&status,
+		&search,
+		&like,
+	)
+}
+
+// GetSubtitleFiles returns a page of the paths of the video files matching
+// the status and the search text like CountSubtitleFiles, ordered by path.
+func GetSubtitleFiles(status, search string, limit, offset int) []string {
+	like := "%" + search + "%"
+
+	return GetrowsN[string](
+		false,
+		uint(limit),
+		"select distinct location from subtitles"+querySubtitleFilesFilter+" order by location limit ? offset ?",
+		&status,
+		&status,
+		&search,
+		&like,
+		&limit,
+		&offset,
+	)
+}
+
+// SubtitleSearchedRecently reports if the language of the video file was
+// searched without a result within the last hours.
+func SubtitleSearchedRecently(location, language string, hours int) bool {
+	return Getdatarow[uint](
+		false,
+		"select count() from subtitles where location = ? and language = ? and status = 'missing' and last_search > datetime('now','localtime','-'||?||' hours')",
+		&location,
+		&language,
+		&hours,
+	) >= 1
+}
@@ -19,6 +19,7 @@ import (
 var (
 	errDownloaderTemplateNotFound = errors.New("downloader template not found")
 	errUnknownDownloader          = errors.New("unknown downloader")
+	errGrabQuotaReached           = errors.New("grab quota of indexer exhausted")
 )
 
 type downloadertype struct {
@@ -59,7 +60,16 @@ type downloadertype struct {
 //
 // The function sets up all necessary configuration before delegating to the specific
 // downloader implementation (SABnzbd, NZBGet, etc.) for the actual download operation.
-func (d *downloadertype) downloadNzb() {
+func (d *downloadertype) downloadNzb() error {
+	if apiexternal.IndexerGrabQuotaReached(d.Nzb.NZB.Indexer) {
+		logger.Logtype("warn", 2).
+			Str(logger.StrIndexer, d.Nzb.NZB.Indexer.Name).
+			Str("nzb", d.Nzb.NZB.Title).
+			Msg("Skipping download - grab quota of indexer exhausted")
+
+		return errGrabQuotaReached
+	}
+
 	for idx := range d.Quality.Indexer {
 		if !strings.EqualFold(d.Quality.Indexer[idx].TemplateIndexer, d.Nzb.NZB.Indexer.Name) {
 			continue
@@ -100,14 +110,14 @@ func (d *downloadertype) downloadNzb() {
 			logger.Logtype("error", 0).
 				Err(logger.ErrPathTemplateNotFound).
 				Msg("Error get Nzb Config")
-			return
+			return logger.ErrPathTemplateNotFound
 		}
 
 		if d.Quality.Indexer[0].CfgDownloader == nil {
 			logger.Logtype("error", 0).
 				Err(errDownloaderTemplateNotFound).
 				Msg("Error get Nzb Config")
-			return
+			return errDownloaderTemplateNotFound
 		}
 
 		logger.Logtype("debug", 1).
@@ -155,19 +165,23 @@ func (d *downloadertype) downloadNzb() {
 		logger.Logtype("error", 0).
 			Err(errUnknownDownloader).
 			Msg("Download")
-		return
+		return errUnknownDownloader
 	}
 
 	if err != nil {
 		logger.Logtype("error", 0).
 			Err(err).
 			Msg("Download")
-		return
+		return err
 	}
 
+	apiexternal.CountIndexerGrab(d.Nzb.NZB.Indexer)
+
 	d.notify()
 
 	d.downloadNzbType(d.Cfgp.IsType)
+
+	return nil
 }
 
 func (d *downloadertype) downloadNzbType(isType uint) {
@@ -487,7 +501,8 @@ func newDownloader(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio
 
 // DownloadMovie initializes a downloader, gets the movie and related database
 // objects by ID, sets the quality config, and calls the download method.
-func DownloadMovie(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) {
+// Returns an error if nothing was grabbed.
+func DownloadMovie(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) error {
 	d := newDownloader(cfgp, nzb)
 
 	err := d.Movie.GetMoviesByIDP(&nzb.NzbmovieID)
@@ -497,7 +512,7 @@ func DownloadMovie(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Dbmovie.GetDbmovieByIDP(&d.Movie.DbmovieID)
@@ -507,16 +522,17 @@ func DownloadMovie(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	d.Quality = database.GetMediaQualityConfig(cfgp, &nzb.NzbmovieID)
-	d.downloadNzb()
+	return d.downloadNzb()
 }
 
 // DownloadSeriesEpisode initializes a downloader, gets the episode and related database
 // objects by ID, sets the quality config, and calls the download method.
-func DownloadSeriesEpisode(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) {
+// Returns an error if nothing was grabbed.
+func DownloadSeriesEpisode(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) error {
 	d := newDownloader(cfgp, nzb)
 
 	err := d.Serieepisode.GetSerieEpisodesByIDP(&nzb.NzbepisodeID)
@@ -526,7 +542,7 @@ func DownloadSeriesEpisode(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzb
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Dbserie.GetDbserieByIDP(&d.Serieepisode.DbserieID)
@@ -536,7 +552,7 @@ func DownloadSeriesEpisode(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzb
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Dbserieepisode.GetDbserieEpisodesByIDP(&d.Serieepisode.DbserieEpisodeID)
@@ -546,7 +562,7 @@ func DownloadSeriesEpisode(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzb
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Serie.GetSerieByIDP(&d.Serieepisode.SerieID)
@@ -556,16 +572,17 @@ func DownloadSeriesEpisode(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzb
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	d.Quality = database.GetMediaQualityConfig(cfgp, &nzb.NzbepisodeID)
-	d.downloadNzb()
+	return d.downloadNzb()
 }
 
 // DownloadBook initializes a downloader, gets the book and related database
 // objects by ID, sets the quality config, and calls the download method.
-func DownloadBook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) {
+// Returns an error if nothing was grabbed.
+func DownloadBook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) error {
 	d := newDownloader(cfgp, nzb)
 
 	err := d.Book.GetBooksByIDP(&nzb.NzbbookID)
@@ -575,7 +592,7 @@ func DownloadBook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio)
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Dbbook.GetDbbookByIDP(&d.Book.DbbookID)
@@ -585,16 +602,17 @@ func DownloadBook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio)
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	d.Quality = database.GetMediaQualityConfig(cfgp, &nzb.NzbbookID)
-	d.downloadNzb()
+	return d.downloadNzb()
 }
 
 // DownloadAudiobook initializes a downloader, gets the audiobook and related database
 // objects by ID, sets the quality config, and calls the download method.
-func DownloadAudiobook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) {
+// Returns an error if nothing was grabbed.
+func DownloadAudiobook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) error {
 	d := newDownloader(cfgp, nzb)
 
 	err := d.Audiobook.GetAudiobooksByIDP(&nzb.NzbaudiobookID)
@@ -604,7 +622,7 @@ func DownloadAudiobook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwith
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Dbaudiobook.GetDbaudiobookByIDP(&d.Audiobook.DbaudiobookID)
@@ -614,16 +632,17 @@ func DownloadAudiobook(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwith
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	d.Quality = database.GetMediaQualityConfig(cfgp, &nzb.NzbaudiobookID)
-	d.downloadNzb()
+	return d.downloadNzb()
 }
 
 // DownloadAlbum initializes a downloader, gets the album and related database
 // objects by ID, sets the quality config, and calls the download method.
-func DownloadAlbum(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) {
+// Returns an error if nothing was grabbed.
+func DownloadAlbum(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio) error {
 	d := newDownloader(cfgp, nzb)
 
 	err := d.Album.GetAlbumsByIDP(&nzb.NzbalbumID)
@@ -633,7 +652,7 @@ func DownloadAlbum(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	err = d.Dbalbum.GetDbalbumByIDP(&d.Album.DbalbumID)
@@ -643,9 +662,9 @@ func DownloadAlbum(cfgp *config.MediaTypeConfig, nzb *apiexternal_v2.Nzbwithprio
 			Err(err).
 			Msg("not found")
 
-		return
+		return err
 	}
 
 	d.Quality = database.GetMediaQualityConfig(cfgp, &nzb.NzbalbumID)
-	d.downloadNzb()
+	return d.downloadNzb()
 }
@@ -0,0 +1,174 @@
+package expression
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Env holds the release values an expression is evaluated against.
+// Each field is exposed to expressions under the name of its variable.
+type Env struct {
+	// Title is the release title (variable title)
+	Title string
+	// Indexer is the name of the indexer the release was found on (variable indexer)
+	Indexer string
+	// Resolution is the parsed resolution, e.g. 1080p (variable resolution)
+	Resolution string
+	// Quality is the parsed quality, e.g. bluray (variable quality)
+	Quality string
+	// Codec is the parsed video codec, e.g. x265 (variable codec)
+	Codec string
+	// Audio is the parsed audio codec, e.g. dts (variable audio)
+	Audio string
+	// Group is the release group (variable group)
+	Group string
+	// Languages are the parsed languages (variable languages)
+	Languages []string
+	// Size is the release size in bytes (variable size)
+	Size int64
+	// Age is the age of the release in days (variable age)
+	Age float64
+	// Seeders is the number of seeders of a torrent, 0 if unknown (variable seeders)
+	Seeders int
+	// Year is the parsed year (variable year)
+	Year int
+	// Priority is the priority computed from the quality profile (variable priority)
+	Priority int
+	// Proper is set for PROPER releases (variable proper)
+	Proper bool
+	// Repack is set for REPACK releases (variable repack)
+	Repack bool
+	// Extended is set for extended releases (variable extended)
+	Extended bool
+	// Torrent is set for releases from torrent indexers (variable torrent)
+	Torrent bool
+}
+
+// kind is the static type of an expression node.
+type kind uint8
+
+const (
+	kindBool kind = iota
+	kindNumber
+	kindString
+	kindList
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindBool:
+		return "boolean"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "text"
+	default:
+		return "list"
+	}
+}
+
+// value is the result of evaluating a node. Only the field matching the
+// static kind of the node is set.
+type value struct {
+	str  string
+	list []value
+	num  float64
+	b    bool
+}
+
+// variable describes a name usable in expressions.
+type variable struct {
+	get  func(*Env) value
+	kind kind
+	elem kind
+}
+
+// variables are all names an expression may reference.
+var variables = map[string]variable{
+	"title":      {kind: kindString, get: func(e *Env) value { return value{str: e.Title} }},
+	"indexer":    {kind: kindString, get: func(e *Env) value { return value{str: e.Indexer} }},
+	"resolution": {kind: kindString, get: func(e *Env) value { return value{str: e.Resolution} }},
+	"quality":    {kind: kindString, get: func(e *Env) value { return value{str: e.Quality} }},
+	"codec":      {kind: kindString, get: func(e *Env) value { return value{str: e.Codec} }},
+	"audio":      {kind: kindString, get: func(e *Env) value { return value{str: e.Audio} }},
+	"group":      {kind: kindString, get: func(e *Env) value { return value{str: e.Group} }},
+	"languages":  {kind: kindList, elem: kindString, get: languagesValue},
+	"size":       {kind: kindNumber, get: func(e *Env) value { return value{num: float64(e.Size)} }},
+	"age":        {kind: kindNumber, get: func(e *Env) value { return value{num: e.Age} }},
+	"seeders":    {kind: kindNumber, get: func(e *Env) value { return value{num: float64(e.Seeders)} }},
+	"year":       {kind: kindNumber, get: func(e *Env) value { return value{num: float64(e.Year)} }},
+	"priority":   {kind: kindNumber, get: func(e *Env) value { return value{num: float64(e.Priority)} }},
+	"proper":     {kind: kindBool, get: func(e *Env) value { return value{b: e.Proper} }},
+	"repack":     {kind: kindBool, get: func(e *Env) value { return value{b: e.Repack} }},
+	"extended":   {kind: kindBool, get: func(e *Env) value { return value{b: e.Extended} }},
+	"torrent":    {kind: kindBool, get: func(e *Env) value { return value{b: e.Torrent} }},
+}
+
+// Variables returns the sorted names usable in expressions.
+func Variables() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func languagesValue(e *Env) value {
+	list := make([]value, len(e.Languages))
+	for i := range e.Languages {
+		list[i] = value{str: e.Languages[i]}
+	}
+
+	return value{list: list}
+}
+
+// equalValues compares two values of kind k. Text is compared
+// case-insensitively as release names use any casing.
+func equalValues(k kind, a, b value) bool {
+	switch k {
+	case kindBool:
+		return a.b == b.b
+	case kindNumber:
+		return a.num == b.num
+	case kindString:
+		return strings.EqualFold(a.str, b.str)
+	default:
+		return false
+	}
+}
+
+// listContains reports if list holds an element equal to v. elem is the kind
+// of the list elements.
+func listContains(list []value, v value, elem kind) bool {
+	for i := range list {
+		if equalValues(elem, list[i], v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatValue renders v of kind k for results shown to the user. elem is the
+// kind of the elements if v is a list.
+func formatValue(k, elem kind, v value) string {
+	switch k {
+	case kindBool:
+		return strconv.FormatBool(v.b)
+	case kindNumber:
+		return strconv.FormatFloat(v.num, 'f', -1, 64)
+	case kindString:
+		return v.str
+	default:
+		parts := make([]string, len(v.list))
+		for i := range v.list {
+			parts[i] = formatValue(elem, elem, v.list[i])
+		}
+
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+}
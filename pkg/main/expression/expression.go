@@ -0,0 +1,572 @@
+// Package expression implements the small rule language of the quality
+// profiles. Rules are conditions like
+//
+//	size > 8GB and resolution == '1080p'
+//	codec == 'x265' and age < 2
+//	group in ['NTb', 'FLUX'] or title matches 'remux'
+//
+// evaluated against the values of a release (see Env). The language has no
+// functions, loops or assignments - a rule can only read the release values,
+// so it is safe to evaluate rules taken from the configuration.
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	errEmpty         = errors.New("expression is empty")
+	errScoreRule     = errors.New("score rule needs the format 'expression => points'")
+	errScorePoints   = errors.New("score rule points must be a whole number")
+	errNotCondition  = errors.New("expression must be a condition")
+	errMixedListKind = errors.New("list elements must all be text or all be numbers")
+)
+
+// Program is a compiled expression.
+type Program struct {
+	root   *node
+	source string
+}
+
+// ScoreRule is a compiled score rule. Points are added to the priority of
+// releases matching the condition.
+type ScoreRule struct {
+	Program *Program
+	Points  int
+}
+
+// node is an element of the syntax tree. op is the operator, or the literal
+// and variable markers "lit" and "var".
+type node struct {
+	get  func(*Env) value
+	re   *regexp.Regexp
+	op   string
+	args []*node
+	val  value
+	kind kind
+	elem kind
+}
+
+// Compile parses src and checks the types of all operands. The expression
+// must evaluate to true or false.
+func Compile(src string) (*Program, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errEmpty
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", describe(tok), tok.pos)
+	}
+
+	if root.kind != kindBool {
+		return nil, fmt.Errorf("%w, got %s", errNotCondition, root.kind)
+	}
+
+	return &Program{root: root, source: src}, nil
+}
+
+// CompileScoreRule compiles a rule of the format "expression => points".
+func CompileScoreRule(rule string) (ScoreRule, error) {
+	idx := strings.LastIndex(rule, "=>")
+	if idx == -1 {
+		return ScoreRule{}, errScoreRule
+	}
+
+	points, err := strconv.Atoi(strings.TrimSpace(rule[idx+2:]))
+	if err != nil {
+		return ScoreRule{}, errScorePoints
+	}
+
+	prog, err := Compile(rule[:idx])
+	if err != nil {
+		return ScoreRule{}, err
+	}
+
+	return ScoreRule{Program: prog, Points: points}, nil
+}
+
+// String returns the source of the expression.
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval evaluates the expression against env.
+func (p *Program) Eval(env *Env) bool {
+	return eval(p.root, env).b
+}
+
+// eval evaluates n. Types were checked by the compiler, so every operator
+// can rely on the kinds of its operands.
+func eval(n *node, env *Env) value {
+	switch n.op {
+	case "lit":
+		return n.val
+
+	case "var":
+		return n.get(env)
+
+	case "list":
+		list := make([]value, len(n.args))
+		for i := range n.args {
+			list[i] = eval(n.args[i], env)
+		}
+
+		return value{list: list}
+
+	case "and":
+		return value{b: eval(n.args[0], env).b && eval(n.args[1], env).b}
+
+	case "or":
+		return value{b: eval(n.args[0], env).b || eval(n.args[1], env).b}
+
+	case "not":
+		return value{b: !eval(n.args[0], env).b}
+
+	case "neg":
+		return value{num: -eval(n.args[0], env).num}
+	}
+
+	left := eval(n.args[0], env)
+	right := eval(n.args[1], env)
+
+	switch n.op {
+	case "+":
+		return value{num: left.num + right.num}
+	case "-":
+		return value{num: left.num - right.num}
+	case "*":
+		return value{num: left.num * right.num}
+	case "/":
+		if right.num == 0 {
+			return value{}
+		}
+
+		return value{num: left.num / right.num}
+	case "==":
+		return value{b: equalValues(n.args[0].kind, left, right)}
+	case "!=":
+		return value{b: !equalValues(n.args[0].kind, left, right)}
+	case "<":
+		return value{b: left.num < right.num}
+	case "<=":
+		return value{b: left.num <= right.num}
+	case ">":
+		return value{b: left.num > right.num}
+	case ">=":
+		return value{b: left.num >= right.num}
+	case "in", "not in":
+		var found bool
+		if n.args[1].kind == kindList {
+			found = listContains(right.list, left, n.args[1].elem)
+		} else {
+			found = containsFold(right.str, left.str)
+		}
+
+		return value{b: found == (n.op == "in")}
+	case "contains":
+		if n.args[0].kind == kindList {
+			return value{b: listContains(left.list, right, n.args[0].elem)}
+		}
+
+		return value{b: containsFold(left.str, right.str)}
+	case "matches":
+		return value{b: n.re.MatchString(left.str)}
+	}
+
+	return value{}
+}
+
+// containsFold reports if substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// parser is a recursive descent parser. Operator precedence from low to
+// high: or, and, not, comparisons, + -, * /, unary minus.
+type parser struct {
+	tokens []token
+	idx    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.idx]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.idx]
+	if tok.kind != tokEOF {
+		p.idx++
+	}
+
+	return tok
+}
+
+// isWord reports if tok is the keyword or operator word.
+func isWord(tok token, words ...string) bool {
+	if tok.kind != tokIdent && tok.kind != tokOperator {
+		return false
+	}
+
+	for _, word := range words {
+		if tok.text == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) parseOr() (*node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for isWord(p.peek(), "or", "||") {
+		tok := p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = binary(tok, "or", left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (*node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for isWord(p.peek(), "and", "&&") {
+		tok := p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = binary(tok, "and", left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (*node, error) {
+	if !isWord(p.peek(), "not", "!") {
+		return p.parseComparison()
+	}
+
+	tok := p.next()
+
+	arg, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	if arg.kind != kindBool {
+		return nil, fmt.Errorf(
+			"'%s' at position %d needs a condition, got %s",
+			tok.text,
+			tok.pos,
+			arg.kind,
+		)
+	}
+
+	return &node{op: "not", kind: kindBool, args: []*node{arg}}, nil
+}
+
+func (p *parser) parseComparison() (*node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+
+	var op string
+	switch {
+	case isWord(tok, "==", "!=", "<", "<=", ">", ">=", "in", "contains", "matches"):
+		op = tok.text
+	case isWord(tok, "not") && isWord(p.tokens[p.idx+1], "in"):
+		p.next()
+
+		op = "not in"
+	default:
+		return left, nil
+	}
+
+	p.next()
+
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	if op == "matches" {
+		return matches(tok, left, right)
+	}
+
+	return binary(tok, op, left, right)
+}
+
+func (p *parser) parseSum() (*node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for isWord(p.peek(), "+", "-") {
+		tok := p.next()
+
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = binary(tok, tok.text, left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseProduct() (*node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for isWord(p.peek(), "*", "/") {
+		tok := p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left, err = binary(tok, tok.text, left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (*node, error) {
+	if !isWord(p.peek(), "-") {
+		return p.parsePrimary()
+	}
+
+	tok := p.next()
+
+	arg, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if arg.kind != kindNumber {
+		return nil, fmt.Errorf("'-' at position %d needs a number, got %s", tok.pos, arg.kind)
+	}
+
+	return &node{op: "neg", kind: kindNumber, args: []*node{arg}}, nil
+}
+
+func (p *parser) parsePrimary() (*node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokNumber:
+		return &node{op: "lit", kind: kindNumber, val: value{num: tok.num}}, nil
+
+	case tokString:
+		return &node{op: "lit", kind: kindString, val: value{str: tok.text}}, nil
+
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, fmt.Errorf(
+				"missing ')' for '(' at position %d, got %s at position %d",
+				tok.pos,
+				describe(closing),
+				closing.pos,
+			)
+		}
+
+		return inner, nil
+
+	case tokLBracket:
+		return p.parseList(tok)
+
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			return &node{op: "lit", kind: kindBool, val: value{b: tok.text == "true"}}, nil
+		}
+
+		v, ok := variables[tok.text]
+		if !ok {
+			return nil, fmt.Errorf(
+				"unknown name '%s' at position %d (known: %s)",
+				tok.text,
+				tok.pos,
+				strings.Join(Variables(), ", "),
+			)
+		}
+
+		return &node{op: "var", kind: v.kind, elem: v.elem, get: v.get}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %s at position %d", describe(tok), tok.pos)
+}
+
+// parseList parses the elements of a list literal after its opening bracket.
+func (p *parser) parseList(open token) (*node, error) {
+	list := &node{op: "list", kind: kindList, elem: kindString}
+
+	for p.peek().kind != tokRBracket {
+		if len(list.args) > 0 {
+			if tok := p.next(); tok.kind != tokComma {
+				return nil, fmt.Errorf(
+					"expected ',' or ']' at position %d, got %s",
+					tok.pos,
+					describe(tok),
+				)
+			}
+		}
+
+		tok := p.peek()
+
+		elem, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+
+		if elem.kind != kindString && elem.kind != kindNumber {
+			return nil, fmt.Errorf(
+				"list element at position %d must be text or a number, got %s",
+				tok.pos,
+				elem.kind,
+			)
+		}
+
+		if len(list.args) == 0 {
+			list.elem = elem.kind
+		} else if elem.kind != list.elem {
+			return nil, fmt.Errorf("%w (position %d)", errMixedListKind, tok.pos)
+		}
+
+		list.args = append(list.args, elem)
+	}
+
+	if p.next().kind != tokRBracket {
+		return nil, fmt.Errorf("missing ']' for '[' at position %d", open.pos)
+	}
+
+	return list, nil
+}
+
+// binary builds an operator node and checks the kinds of its operands.
+func binary(tok token, op string, left, right *node) (*node, error) {
+	n := &node{op: op, kind: kindBool, args: []*node{left, right}}
+
+	var ok bool
+	switch op {
+	case "and", "or":
+		ok = left.kind == kindBool && right.kind == kindBool
+
+	case "+", "-", "*", "/":
+		ok = left.kind == kindNumber && right.kind == kindNumber
+		n.kind = kindNumber
+
+	case "<", "<=", ">", ">=":
+		ok = left.kind == kindNumber && right.kind == kindNumber
+
+	case "==", "!=":
+		ok = left.kind == right.kind && left.kind != kindList
+
+	case "in", "not in":
+		ok = (right.kind == kindList && left.kind == right.elem) ||
+			(left.kind == kindString && right.kind == kindString)
+
+	case "contains":
+		ok = (left.kind == kindList && right.kind == left.elem) ||
+			(left.kind == kindString && right.kind == kindString)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf(
+			"'%s' at position %d cannot be used with %s and %s",
+			op,
+			tok.pos,
+			left.kind,
+			right.kind,
+		)
+	}
+
+	return n, nil
+}
+
+// matches builds a regular expression match. The pattern must be a text
+// literal so it is compiled once. Matching ignores case as release names
+// use any casing.
+func matches(tok token, left, right *node) (*node, error) {
+	if left.kind != kindString || right.op != "lit" || right.kind != kindString {
+		return nil, fmt.Errorf(
+			"'matches' at position %d needs text on the left and a quoted pattern on the right",
+			tok.pos,
+		)
+	}
+
+	re, err := regexp.Compile("(?i)" + right.val.str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for 'matches' at position %d: %w", tok.pos, err)
+	}
+
+	return &node{op: "matches", kind: kindBool, re: re, args: []*node{left, right}}, nil
+}
+
+// describe names a token for error messages.
+func describe(tok token) string {
+	switch tok.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return "text '" + tok.text + "'"
+	default:
+		return "'" + tok.text + "'"
+	}
+}
@@ -0,0 +1,111 @@
+package expression
+
+import (
+	"strings"
+	"testing"
+)
+
+func testEnv() *Env {
+	return &Env{
+		Title:      "Movie.Title.2023.1080p.BluRay.x265-NTb",
+		Indexer:    "nzbgeek",
+		Resolution: "1080p",
+		Quality:    "bluray",
+		Codec:      "x265",
+		Group:      "NTb",
+		Languages:  []string{"en", "de"},
+		Size:       9 << 30,
+		Age:        1.5,
+		Seeders:    12,
+		Year:       2023,
+		Proper:     true,
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"size > 8GB and resolution == '1080p'", true},
+		{"size > 10GB", false},
+		{"codec == 'X265' and age < 2", true},
+		{"group in ['ntb', 'FLUX']", true},
+		{"group not in ['ntb', 'FLUX']", false},
+		{"languages contains 'DE'", true},
+		{"'fr' in languages", false},
+		{"title contains 'bluray'", true},
+		{"title matches '\\.x26[45]-'", true},
+		{"not proper || repack", false},
+		{"!(seeders < 10) && torrent == false", true},
+		{"year in [2022, 2023]", true},
+		{"size / 1GB >= 9 and -age < 0", true},
+		{"indexer = 'nzbgeek'", true},
+		{"size / 0 == 0", true},
+	}
+
+	env := testEnv()
+	for _, tt := range tests {
+		prog, err := Compile(tt.expr)
+		if err != nil {
+			t.Fatalf("Compile(%q) error: %v", tt.expr, err)
+		}
+
+		if got := prog.Eval(env); got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "empty"},
+		{"size > 8XB", "unknown unit"},
+		{"resolution == 1080p", "unknown unit"},
+		{"seders > 5", "unknown name 'seders'"},
+		{"size > '8GB'", "cannot be used with number and text"},
+		{"size + 1", "must be a condition"},
+		{"(size > 1", "missing ')'"},
+		{"group in ['a', 1]", "list elements"},
+		{"title matches '['", "invalid pattern"},
+		{"title matches group", "quoted pattern"},
+		{"size > 1 size", "unexpected 'size' at position 10"},
+		{"title == 'abc", "unterminated string"},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(tt.expr)
+		if err == nil {
+			t.Errorf("Compile(%q) expected error", tt.expr)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q) error = %q, want it to contain %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestCompileScoreRule(t *testing.T) {
+	rule, err := CompileScoreRule("codec == 'x265' and age < 2 => 25")
+	if err != nil {
+		t.Fatalf("CompileScoreRule error: %v", err)
+	}
+
+	if rule.Points != 25 || !rule.Program.Eval(testEnv()) {
+		t.Errorf("CompileScoreRule = %d points, match %v", rule.Points, rule.Program.Eval(testEnv()))
+	}
+
+	if _, err := CompileScoreRule("codec == 'x265' => -10"); err != nil {
+		t.Errorf("negative points error: %v", err)
+	}
+
+	for _, bad := range []string{"codec == 'x265'", "codec == 'x265' => many"} {
+		if _, err := CompileScoreRule(bad); err == nil {
+			t.Errorf("CompileScoreRule(%q) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind uint8
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOperator
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+// token is a single lexical element of an expression. pos is the 1-based
+// column of its first character.
+type token struct {
+	text string
+	num  float64
+	kind tokenKind
+	pos  int
+}
+
+// sizeSuffixes are the units accepted directly after a number literal.
+// Like the size limits of the path configs they are binary units.
+var sizeSuffixes = map[string]float64{
+	"kb": 1 << 10,
+	"mb": 1 << 20,
+	"gb": 1 << 30,
+	"tb": 1 << 40,
+}
+
+// lex splits src into tokens. Keywords like and, or, in are returned as
+// identifiers and resolved by the parser.
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			tok, next, err := lexNumber(src, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, tok)
+			i = next
+
+		case c == '\'' || c == '"':
+			tok, next, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, tok)
+			i = next
+
+		case isIdentStart(c):
+			start := i
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+
+			tokens = append(
+				tokens,
+				token{kind: tokIdent, text: strings.ToLower(src[start:i]), pos: start + 1},
+			)
+
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i + 1})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i + 1})
+			i++
+
+		case c == '[':
+			tokens = append(tokens, token{kind: tokLBracket, text: "[", pos: i + 1})
+			i++
+
+		case c == ']':
+			tokens = append(tokens, token{kind: tokRBracket, text: "]", pos: i + 1})
+			i++
+
+		case c == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i + 1})
+			i++
+
+		default:
+			op, n := lexOperator(src[i:])
+			if n == 0 {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i+1)
+			}
+
+			tokens = append(tokens, token{kind: tokOperator, text: op, pos: i + 1})
+			i += n
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(src) + 1}), nil
+}
+
+// lexNumber reads a number literal with an optional size suffix at src[start].
+func lexNumber(src string, start int) (token, int, error) {
+	i := start
+
+	dot := false
+	for i < len(src) && (isDigit(src[i]) || (src[i] == '.' && !dot)) {
+		if src[i] == '.' {
+			dot = true
+		}
+
+		i++
+	}
+
+	var num float64
+	if _, err := fmt.Sscan(src[start:i], &num); err != nil {
+		return token{}, 0, fmt.Errorf("invalid number %q at position %d", src[start:i], start+1)
+	}
+
+	suffixStart := i
+	for i < len(src) && isIdentPart(src[i]) {
+		i++
+	}
+
+	if suffix := src[suffixStart:i]; suffix != "" {
+		mult, ok := sizeSuffixes[strings.ToLower(suffix)]
+		if !ok {
+			return token{}, 0, fmt.Errorf(
+				"unknown unit %q at position %d (use KB, MB, GB or TB, quote text like '1080p')",
+				suffix,
+				suffixStart+1,
+			)
+		}
+
+		num *= mult
+	}
+
+	return token{kind: tokNumber, text: src[start:i], num: num, pos: start + 1}, i, nil
+}
+
+// lexString reads a quoted string literal at src[start]. A backslash escapes
+// the following character.
+func lexString(src string, start int) (token, int, error) {
+	quote := src[start]
+
+	var sb strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			if i+1 < len(src) {
+				i++
+				sb.WriteByte(src[i])
+			}
+
+		case quote:
+			return token{kind: tokString, text: sb.String(), pos: start + 1}, i + 1, nil
+
+		default:
+			sb.WriteByte(src[i])
+		}
+	}
+
+	return token{}, 0, fmt.Errorf("unterminated string starting at position %d", start+1)
+}
+
+// lexOperator returns the operator at the start of s and the number of bytes
+// it spans, or 0 if s does not start with an operator.
+func lexOperator(s string) (string, int) {
+	if len(s) >= 2 {
+		switch s[:2] {
+		case "==", "!=", "<=", ">=", "&&", "||":
+			return s[:2], 2
+		}
+	}
+
+	switch s[0] {
+	case '<', '>', '!', '+', '-', '*', '/':
+		return s[:1], 1
+	case '=':
+		// A single = is accepted as equality, as users of SQL expect.
+		return "==", 1
+	}
+
+	return "", 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
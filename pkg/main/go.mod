@@ -12,6 +12,7 @@ require (
 	github.com/fsnotify/fsnotify v1.10.1 //config watcher
 	github.com/gin-gonic/gin v1.12.0 //web framework
 	github.com/goccy/go-json v0.10.6 //json parser
+	github.com/goccy/go-yaml v1.19.2 //yaml parser for indexer definitions
 	github.com/golang-migrate/migrate/v4 v4.19.1 //initialize db
 	github.com/google/uuid v1.6.0 //uuid generation
 	github.com/jmoiron/sqlx v1.4.0 //structscan for db
@@ -46,7 +47,6 @@ require (
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-playground/validator/v10 v10.30.1 // indirect
-	github.com/goccy/go-yaml v1.19.2 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/icza/bitio v1.1.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
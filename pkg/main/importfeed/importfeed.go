@@ -1126,7 +1126,7 @@ func jobImportDBSeries(
 					serieconfig.AlternateName,
 				)
 				database.ExecN(
-					"update dbseries SET Seriename = ?, Season = ?, Status = ?, Firstaired = ?, Network = ?, Runtime = ?, Language = ?, Genre = ?, Overview = ?, Rating = ?, Siterating = ?, Siterating_Count = ?, Slug = ?, Trakt_ID = ?, Imdb_ID = ?, Thetvdb_ID = ?, Freebase_M_ID = ?, Freebase_ID = ?, Tvrage_ID = ?, Facebook = ?, Instagram = ?, Twitter = ?, Banner = ?, Poster = ?, Fanart = ?, Identifiedby = ? where id = ?",
+					"update dbseries SET Seriename = ?, Season = ?, Status = ?, Firstaired = ?, Network = ?, Runtime = ?, Language = ?, Genre = ?, Overview = ?, Rating = ?, Siterating = ?, Siterating_Count = ?, Slug = ?, Trakt_ID = ?, Imdb_ID = ?, Thetvdb_ID = ?, Freebase_M_ID = ?, Freebase_ID = ?, Tvrage_ID = ?, Facebook = ?, Instagram = ?, Twitter = ?, Banner = ?, Poster = ?, Fanart = ?, Identifiedby = ?, Moviedb_ID = ?, Tvmaze_ID = ? where id = ?",
 					&dbserie.Seriename,
 					&dbserie.Season,
 					&dbserie.Status,
@@ -1153,6 +1153,8 @@ func jobImportDBSeries(
 					&dbserie.Poster,
 					&dbserie.Fanart,
 					&dbserie.Identifiedby,
+					&dbserie.MoviedbID,
+					&dbserie.TvmazeID,
 					&dbserie.ID,
 				)
 				database.Scanrowsdyn(
@@ -1284,6 +1286,9 @@ func jobImportDBSeries(
 						apiexternal.MergeTraktIntoCollectedEpisodes(dbserie.ImdbID, episodes)
 					}
 
+					// Add the exact broadcast times used by the search delay
+					apiexternal.MergeTVmazeAirTimesIntoCollectedEpisodes(&dbserie, episodes)
+
 					// Write all collected episodes to the database
 					apiexternal.WriteCollectedEpisodesToDB(episodes, &dbserie.ID)
 				}
@@ -1339,7 +1344,7 @@ func jobImportDBSeries(
 			}
 
 			serieid, err := database.ExecNid(
-				"Insert into series (dbserie_id, listname, rootpath, aliases, search_specials, dont_search, dont_upgrade, quality_profile) values (?, ?, ?, ?, ?, ?, ?, ?)",
+				"Insert into series (dbserie_id, listname, rootpath, aliases, search_specials, dont_search, dont_upgrade, quality_profile, search_delay) values (?, ?, ?, ?, ?, ?, ?, ?, ?)",
 				&dbserie.ID,
 				&cfgp.Lists[listid].Name,
 				&serieconfig.Target,
@@ -1348,6 +1353,7 @@ func jobImportDBSeries(
 				&serieconfig.DontSearch,
 				&serieconfig.DontUpgrade,
 				&serieQuality,
+				&serieconfig.SearchDelay,
 			)
 			if err != nil {
 				return err
@@ -1366,11 +1372,12 @@ func jobImportDBSeries(
 		} else {
 			serieAliases := logger.JoinStringsSep(serieconfig.AlternateName, ",")
 			database.ExecN(
-				"update series SET aliases=?, search_specials=?, dont_search=?, dont_upgrade=? where dbserie_id = ? and listname = ?",
+				"update series SET aliases=?, search_specials=?, dont_search=?, dont_upgrade=?, search_delay=? where dbserie_id = ? and listname = ?",
 				&serieAliases,
 				&serieconfig.SearchSpecials,
 				&serieconfig.DontSearch,
 				&serieconfig.DontUpgrade,
+				&serieconfig.SearchDelay,
 				&dbserie.ID,
 				&cfgp.Lists[listid].Name,
 			)
@@ -136,7 +136,14 @@ const (
 	SearchGenReached           = "SearchGenReached"
 	SearchGenLastScan          = "SearchGenLastScan"
 	SearchGenDate              = "SearchGenDate"
+	SearchGenAirTime           = "SearchGenAirTime"
+	SearchAirTimePending       = "SearchAirTimePending"
 	SearchGenOrder             = "SearchGenOrder"
+	SearchGenNextSearch        = "SearchGenNextSearch"
+	SearchBackoffCount         = "SearchBackoffCount"
+	SearchBackoffRecent        = "SearchBackoffRecent"
+	UpdateMediaSearchBackoff   = "UpdateMediaSearchBackoff"
+	ResetMediaSearchBackoff    = "ResetMediaSearchBackoff"
 	CacheMovie                 = "CacheMovie"
 	CacheSeries                = "CacheSeries"
 	CacheDBMovie               = "CacheDBMovie"
@@ -196,6 +203,8 @@ var (
 	StrFeeds        = "feeds"
 	StrDataFull     = "datafull"
 	StrStructure    = "structure"
+	StrArtwork      = "artwork"
+	StrSubtitles    = "searchsubtitles"
 	V0              = 0
 	StrMovie        = "movie"
 	StrSeries       = "series"
@@ -109,6 +109,23 @@ func buildBaseURL(hostname string, port int, useSSL bool) string {
 	return protocol + "://" + hostname + ":" + strconv.Itoa(port)
 }
 
+// setClientProxy sets the proxy of a provider client and logs invalid proxies.
+func setClientProxy(
+	name string,
+	client interface {
+		SetProxy(cfg base.ProxyConfig) error
+	},
+	proxy base.ProxyConfig,
+) {
+	if err := client.SetProxy(proxy); err != nil {
+		logger.Logtype(logger.StatusError, 0).
+			Str("provider", name).
+			Str(logger.StrURL, proxy.URL).
+			Err(err).
+			Msg("invalid proxy")
+	}
+}
+
 func initproviders() {
 	clientManager := apiexternal_v2.NewClientManager()
 
@@ -245,6 +262,8 @@ func initproviders() {
 			return
 		}
 
+		dlProxy := apiexternal.ProxyConfig(dlCfg.ProxyURL, dlCfg.ProxyUsername, dlCfg.ProxyPassword)
+
 		switch dlCfg.DlType {
 		case "qbittorrent":
 			if dlCfg.Hostname == "" {
@@ -259,6 +278,7 @@ func initproviders() {
 				strings.HasPrefix(dlCfg.Hostname, "https"),
 			); err == nil &&
 				provider != nil {
+				setClientProxy(name, provider, dlProxy)
 				providers.SetQBittorrent(name, provider)
 				logger.Logtype(logger.StatusDebug, 0).
 					Str("downloader", name).
@@ -280,6 +300,7 @@ func initproviders() {
 				dlCfg.Username,
 				dlCfg.Password,
 			); provider != nil {
+				setClientProxy(name, provider, dlProxy)
 				providers.SetDeluge(name, provider)
 				logger.Logtype(logger.StatusDebug, 0).
 					Str("downloader", name).
@@ -301,6 +322,7 @@ func initproviders() {
 				dlCfg.Username,
 				dlCfg.Password,
 			); provider != nil {
+				setClientProxy(name, provider, dlProxy)
 				providers.SetTransmission(name, provider)
 				logger.Logtype(logger.StatusDebug, 0).
 					Str("downloader", name).
@@ -322,6 +344,7 @@ func initproviders() {
 				urlBase,
 			); err == nil &&
 				provider != nil {
+				setClientProxy(name, provider, dlProxy)
 				providers.SetRTorrent(name, provider)
 				logger.Logtype(logger.StatusDebug, 0).
 					Str("downloader", name).
@@ -340,6 +363,7 @@ func initproviders() {
 				strings.HasPrefix(dlCfg.Hostname, "https"),
 			); err == nil &&
 				provider != nil {
+				setClientProxy(name, provider, dlProxy)
 				providers.SetSABnzbd(name, provider)
 				logger.Logtype(logger.StatusDebug, 0).
 					Str("downloader", name).
@@ -359,6 +383,7 @@ func initproviders() {
 				strings.HasPrefix(dlCfg.Hostname, "https"),
 			); err == nil &&
 				provider != nil {
+				setClientProxy(name, provider, dlProxy)
 				providers.SetNZBGet(name, provider)
 				logger.Logtype(logger.StatusDebug, 0).
 					Str("downloader", name).
@@ -369,6 +394,7 @@ func initproviders() {
 
 	// Register book/audiobook/music metadata providers
 	general := config.GetSettingsGeneral()
+	metadataProxy := apiexternal.MetadataProxy()
 
 	// Initialize OpenLibrary provider (free, no API key required)
 	if provider := openlibrary.NewProviderWithConfig(base.ClientConfig{
@@ -376,6 +402,7 @@ func initproviders() {
 		RateLimitCalls:   100,
 		RateLimitSeconds: 60,
 		UserAgent:        general.UserAgent,
+		Proxy:            metadataProxy,
 	}); provider != nil {
 		providers.SetOpenLibrary(provider)
 		logger.Logtype(logger.StatusDebug, 0).Msg("Registered OpenLibrary provider")
@@ -384,6 +411,7 @@ func initproviders() {
 	// Initialize Goodreads provider if API key is configured
 	if apiKey := general.GoodreadsAPIKey; apiKey != "" {
 		if provider := goodreads.NewProvider(apiKey); provider != nil {
+			setClientProxy("goodreads", provider, metadataProxy)
 			providers.SetGoodreads(provider)
 			logger.Logtype(logger.StatusDebug, 0).Msg("Registered Goodreads provider")
 		}
@@ -399,6 +427,7 @@ func initproviders() {
 				RateLimitCalls:   1,
 				RateLimitSeconds: 1,
 				UserAgent:        general.UserAgent,
+				Proxy:            metadataProxy,
 			}); provider != nil {
 				providers.SetMusicBrainz(provider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered MusicBrainz provider")
@@ -411,6 +440,7 @@ func initproviders() {
 				RateLimitCalls:   3,
 				RateLimitSeconds: 1,
 				UserAgent:        general.UserAgent,
+				Proxy:            metadataProxy,
 			}, general.AcoustIDAPIKey); provider != nil {
 				providers.SetAcoustID(provider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered AcoustID provider")
@@ -419,6 +449,7 @@ func initproviders() {
 		case "lastfm":
 			// API key already validated by GetMusicMetaSourcePriority.
 			if provider := lastfm.NewProvider(); provider != nil {
+				setClientProxy(source, provider, metadataProxy)
 				providers.SetLastFM(provider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered Last.fm provider")
 			}
@@ -432,24 +463,28 @@ func initproviders() {
 			}
 
 			if dProvider != nil {
+				setClientProxy(source, dProvider, metadataProxy)
 				providers.SetDiscogs(dProvider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered Discogs provider")
 			}
 
 		case "deezer":
 			if dzProvider := deezer.NewProvider(); dzProvider != nil {
+				setClientProxy(source, dzProvider, metadataProxy)
 				providers.SetDeezer(dzProvider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered Deezer provider")
 			}
 
 		case "theaudiodb":
 			if tadbProvider := theaudiodb.NewProvider(); tadbProvider != nil {
+				setClientProxy(source, tadbProvider, metadataProxy)
 				providers.SetTheAudioDB(tadbProvider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered TheAudioDB provider")
 			}
 
 		case "itunes":
 			if itProvider := itunes.NewProvider(); itProvider != nil {
+				setClientProxy(source, itProvider, metadataProxy)
 				providers.SetITunes(itProvider)
 				logger.Logtype(logger.StatusDebug, 0).Msg("Registered iTunes provider")
 			}
@@ -462,6 +497,7 @@ func initproviders() {
 		RateLimitCalls:   10,
 		RateLimitSeconds: 1,
 		UserAgent:        general.UserAgent,
+		Proxy:            metadataProxy,
 	}); provider != nil {
 		providers.SetAudnex(provider)
 		logger.Logtype(logger.StatusDebug, 0).Msg("Registered Audnex provider")
@@ -474,6 +510,7 @@ func initproviders() {
 		RateLimitCalls:   1,
 		RateLimitSeconds: 2,
 		UserAgent:        general.UserAgent,
+		Proxy:            metadataProxy,
 	}, audible.RegionUS); provider != nil {
 		providers.SetAudible("us", provider)
 		logger.Logtype(logger.StatusDebug, 0).
@@ -575,6 +612,7 @@ func main() {
 					if strings.Contains(e.Name, "config.toml") {
 						if e.Has(fsnotify.Write) {
 							config.Loadallsettings(true)
+							apiexternal.ClearResponseCache()
 							parser.GenerateAllQualityPriorities()
 							parser.GenerateCutoffPriorities()
 							utils.LoadGlobalSchedulerConfig()
@@ -628,6 +666,7 @@ func main() {
 
 	logger.Logtype("info", 0).Msg("------------------------------")
 
+	apiexternal.ApplyDefaultProxy()
 	apiexternal.NewOmdbClient(
 		general.OmdbAPIKey,
 		general.OmdbLimiterSeconds,
@@ -675,6 +714,15 @@ func main() {
 		general.JellyfinDisableTLSVerify,
 		general.JellyfinTimeoutSeconds,
 	)
+	apiexternal.NewOpenSubtitlesClient(
+		general.OpenSubtitlesAPIKey,
+		general.OpenSubtitlesUsername,
+		general.OpenSubtitlesPassword,
+		general.OpenSubtitlesURL,
+		general.OpenSubtitlesLimiterSeconds,
+		general.OpenSubtitlesLimiterCalls,
+		general.OpenSubtitlesTimeoutSeconds,
+	)
 
 	logger.Logtype("info", 0).Msg("Initialize Database")
 
@@ -736,6 +784,17 @@ func main() {
 	logger.Logtype("info", 0).Msg("Load DB Cutoff")
 	parser.GenerateCutoffPriorities()
 
+	if general.SceneMappingFile != "" {
+		logger.Logtype("info", 0).Msg("Import Scene Mappings")
+
+		if _, err := database.ImportSceneMappings(general.SceneMappingFile); err != nil {
+			logger.Logtype("error", 0).
+				Str(logger.StrFile, general.SceneMappingFile).
+				Err(err).
+				Msg("Scene mapping import failed")
+		}
+	}
+
 	// Surface a missing/misconfigured ffprobe or mediainfo once at startup
 	// instead of as per-file errors during scans.
 	parser.CheckAnalyzerPaths()
@@ -765,7 +824,7 @@ func main() {
 
 	logger.Logtype("info", 0).Msg("Range Indexers")
 	config.RangeSettingsIndexer(func(_ string, idx *config.IndexersConfig) {
-		apiexternal.Getnewznabclient(idx)
+		apiexternal.GetIndexerClient(idx)
 	})
 
 	// logger.Logtype("info", 0).Msg("Range Notification")
@@ -898,6 +957,7 @@ func main() {
 	logger.Logtype("info", 0).Msg("Queues stopped")
 
 	config.Slepping(true, 5)
+	apiexternal.FlushIndexerLimits()
 	database.StopCache()
 	database.DBClose()
 	logger.Logtype("info", 0).Msg("Databases and cache stopped")
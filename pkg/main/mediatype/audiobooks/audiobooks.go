@@ -79,7 +79,7 @@ var (
 		"DBCountHistoriesByTitle":  "select count() from audiobook_histories where title = ?",
 		"DBCountHistoriesByUrl":    "select count() from audiobook_histories where url = ?",
 		"DBLocationIDFilesByID":    "select location, id from audiobook_files where audiobook_id = ?",
-		"DBFilePrioFilesByID":      "select location, audiobook_id, id, 0, 0, 0, 0, 0, 0, 0 from audiobook_files where audiobook_id = ?",
+		"DBFilePrioFilesByID":      "select location, audiobook_id, id, 0, 0, 0, 0, 0, 0, 0, '' from audiobook_files where audiobook_id = ?",
 		"DBAudioFilePrioFilesByID": "select location, audiobook_id, id, format, bitrate, 0, 0 from audiobook_files where audiobook_id = ?",
 		"UpdateMediaLastscan":      "update audiobooks set lastscan = datetime('now','localtime') where id = ?",
 		"DBQualityMediaByID":       "select quality_profile from audiobooks where id = ?",
@@ -91,6 +91,11 @@ var (
 		"SearchGenLastScan":        " and (audiobooks.lastscan is null or audiobooks.Lastscan < ?)",
 		"SearchGenDate":            " and (dbaudiobooks.release_date < ? or dbaudiobooks.release_date is null)",
 		"SearchGenOrder":           " order by audiobooks.Lastscan asc",
+		"SearchGenNextSearch":      " and (audiobooks.next_search is null or audiobooks.next_search < ?)",
+		"SearchBackoffCount":       "select search_empty_count from audiobooks where id = ?",
+		"SearchBackoffRecent":      "select count() from audiobooks inner join dbaudiobooks on dbaudiobooks.id=audiobooks.dbaudiobook_id where audiobooks.id = ? and (audiobooks.created_at > ? or dbaudiobooks.release_date > ?)",
+		"UpdateMediaSearchBackoff": "update audiobooks set search_empty_count = ?, next_search = ? where id = ?",
+		"ResetMediaSearchBackoff":  "update audiobooks set search_empty_count = 0, next_search = null where id = ?",
 		"DBIDUnmatchedPathList":    "select id from audiobook_file_unmatcheds where filepath = ? and listname = ? COLLATE NOCASE",
 		"InsertUnmatched":          "Insert into audiobook_file_unmatcheds (parsed_data, listname, filepath, last_checked) values (?, ?, ?, datetime('now','localtime'))",
 		"UpdateUnmatched":          "update audiobook_file_unmatcheds SET parsed_data = ?, last_checked = datetime('now','localtime') where id = ?",
@@ -330,6 +335,7 @@ func (*handler) PerformIDSearch(
 	_ *config.IndexersConfig,
 	_ *config.QualityConfig,
 	_ *apiexternal_v2.Nzbwithprio,
+	_ []mediatype.SearchID,
 	_ int,
 	_ *apiexternal.NzbSlice,
 ) error {
@@ -841,7 +847,14 @@ func (*handler) SupportsSeasonSearch() bool { return false }
 func (*handler) RequiresYearCheck() bool { return false }
 
 // HasSearchID returns false - audiobooks use query-based search only (no standard ID in NZB results).
-func (*handler) HasSearchID(_ *apiexternal_v2.Nzbwithprio) bool { return false }
+func (*handler) HasSearchID(_ *config.IndexersConfig, _ []mediatype.SearchID) bool {
+	return false
+}
+
+// SearchIDs returns nil - audiobooks have no IDs to search indexers by.
+func (*handler) SearchIDs(_ *apiexternal_v2.Nzbwithprio) []mediatype.SearchID {
+	return nil
+}
 
 // SupportsAbsoluteEpisode returns false - audiobooks don't have episode structure.
 func (*handler) SupportsAbsoluteEpisode() bool { return false }
@@ -73,7 +73,7 @@ var (
 		"DBCountHistoriesByTitle":  "select count() from book_histories where title = ?",
 		"DBCountHistoriesByUrl":    "select count() from book_histories where url = ?",
 		"DBLocationIDFilesByID":    "select location, id from book_files where book_id = ?",
-		"DBFilePrioFilesByID":      "select location, book_id, id, 0, 0, 0, 0, 0, 0, 0 from book_files where book_id = ?",
+		"DBFilePrioFilesByID":      "select location, book_id, id, 0, 0, 0, 0, 0, 0, 0, '' from book_files where book_id = ?",
 		"DBAudioFilePrioFilesByID": "select location, book_id, id, format, 0, 0, 0 from book_files where book_id = ?",
 		"UpdateMediaLastscan":      "update books set lastscan = datetime('now','localtime') where id = ?",
 		"DBQualityMediaByID":       "select quality_profile from books where id = ?",
@@ -85,6 +85,11 @@ var (
 		"SearchGenLastScan":        " and (books.lastscan is null or books.Lastscan < ?)",
 		"SearchGenDate":            " and (dbbooks.publish_date < ? or dbbooks.publish_date is null)",
 		"SearchGenOrder":           " order by books.Lastscan asc",
+		"SearchGenNextSearch":      " and (books.next_search is null or books.next_search < ?)",
+		"SearchBackoffCount":       "select search_empty_count from books where id = ?",
+		"SearchBackoffRecent":      "select count() from books inner join dbbooks on dbbooks.id=books.dbbook_id where books.id = ? and (books.created_at > ? or dbbooks.publish_date > ?)",
+		"UpdateMediaSearchBackoff": "update books set search_empty_count = ?, next_search = ? where id = ?",
+		"ResetMediaSearchBackoff":  "update books set search_empty_count = 0, next_search = null where id = ?",
 		"DBIDUnmatchedPathList":    "select id from book_file_unmatcheds where filepath = ? and listname = ? COLLATE NOCASE",
 		"InsertUnmatched":          "Insert into book_file_unmatcheds (parsed_data, listname, filepath, last_checked) values (?, ?, ?, datetime('now','localtime'))",
 		"UpdateUnmatched":          "update book_file_unmatcheds SET parsed_data = ?, last_checked = datetime('now','localtime') where id = ?",
@@ -324,6 +329,7 @@ func (*handler) PerformIDSearch(
 	_ *config.IndexersConfig,
 	_ *config.QualityConfig,
 	_ *apiexternal_v2.Nzbwithprio,
+	_ []mediatype.SearchID,
 	_ int,
 	_ *apiexternal.NzbSlice,
 ) error {
@@ -726,7 +732,14 @@ func (*handler) SupportsSeasonSearch() bool { return false }
 func (*handler) RequiresYearCheck() bool { return false }
 
 // HasSearchID returns false - books use query-based search only (no standard ID in NZB results).
-func (*handler) HasSearchID(_ *apiexternal_v2.Nzbwithprio) bool { return false }
+func (*handler) HasSearchID(_ *config.IndexersConfig, _ []mediatype.SearchID) bool {
+	return false
+}
+
+// SearchIDs returns nil - books have no IDs to search indexers by.
+func (*handler) SearchIDs(_ *apiexternal_v2.Nzbwithprio) []mediatype.SearchID {
+	return nil
+}
 
 // SupportsAbsoluteEpisode returns false - books don't have episode structure.
 func (*handler) SupportsAbsoluteEpisode() bool { return false }
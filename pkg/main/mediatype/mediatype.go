@@ -117,14 +117,21 @@ type Handler interface {
 	SetEntryTempID(entry *apiexternal_v2.Nzbwithprio)
 
 	// PerformIDSearch executes a search by external ID (IMDB or TVDB)
+	// ids are the IDs of the entry as returned by SearchIDs
 	PerformIDSearch(
 		indcfg *config.IndexersConfig,
 		quality *config.QualityConfig,
 		entry *apiexternal_v2.Nzbwithprio,
+		ids []SearchID,
 		cats int,
 		raw *apiexternal.NzbSlice,
 	) error
 
+	// SearchIDs returns the IDs of the entry to search indexers by, the
+	// primary ID first. The IDs are loaded once per search.
+	// For books/audiobooks/music: always returns nil (query-only)
+	SearchIDs(entry *apiexternal_v2.Nzbwithprio) []SearchID
+
 	// ClearUnmatchedCache removes the file from the unmatched cache
 	ClearUnmatchedCache(fpath string)
 
@@ -331,11 +338,11 @@ type Handler interface {
 	// while series and other media types have different release date semantics.
 	RequiresYearCheck() bool
 
-	// HasSearchID returns true if the entry has a valid ID for ID-based search.
-	// For movies: checks if IMDB ID is present
-	// For series: checks if TVDB ID is present
+	// HasSearchID returns true if one of the ids can be searched by on the indexer.
+	// For movies: IMDB, TMDB or Trakt ID
+	// For series: TVDB, IMDB, TVmaze, TMDB, Trakt or TVRage ID
 	// For books/audiobooks/music: always returns false (query-only)
-	HasSearchID(entry *apiexternal_v2.Nzbwithprio) bool
+	HasSearchID(indcfg *config.IndexersConfig, ids []SearchID) bool
 
 	// SupportsAbsoluteEpisode returns true if this media type supports
 	// absolute episode numbering (e.g., anime). Only series supports this.
@@ -657,16 +664,57 @@ func RequiresYearCheck(mediaType uint) bool {
 	return false
 }
 
-// HasSearchID returns true if the entry has a valid search ID for the media type.
+// HasSearchID returns true if one of the ids can be searched by on the indexer.
 // Returns false if no handler is registered for the type.
-func HasSearchID(mediaType uint, entry *apiexternal_v2.Nzbwithprio) bool {
+func HasSearchID(mediaType uint, indcfg *config.IndexersConfig, ids []SearchID) bool {
 	if h := Get(mediaType); h != nil {
-		return h.HasSearchID(entry)
+		return h.HasSearchID(indcfg, ids)
 	}
 
 	return false
 }
 
+// SearchIDs returns the IDs of the entry to search indexers by.
+// Returns nil if no handler is registered for the type.
+func SearchIDs(mediaType uint, entry *apiexternal_v2.Nzbwithprio) []SearchID {
+	if h := Get(mediaType); h != nil {
+		return h.SearchIDs(entry)
+	}
+
+	return nil
+}
+
+// SearchID is an ID of a media item and the newznab parameter to search it by.
+type SearchID struct {
+	Param string
+	Value string
+}
+
+// PickSearchID returns the first set ID the caps accept for the search mode.
+// ids[0] is the primary ID (imdbid or tvdbid) - it is the only one used for
+// indexers without stored caps.
+func PickSearchID(caps *database.IndexerCaps, mode string, ids []SearchID) (SearchID, bool) {
+	for idx := range ids {
+		if ids[idx].Value == "" || ids[idx].Value == "0" {
+			continue
+		}
+
+		if caps == nil {
+			if idx == 0 {
+				return ids[idx], true
+			}
+
+			break
+		}
+
+		if caps.SupportsParam(mode, ids[idx].Param) {
+			return ids[idx], true
+		}
+	}
+
+	return SearchID{}, false
+}
+
 // SupportsAbsoluteEpisode returns true if the media type supports absolute episode numbering.
 // Returns false if no handler is registered for the type.
 func SupportsAbsoluteEpisode(mediaType uint) bool {
@@ -0,0 +1,69 @@
+package mediatype
+
+import (
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+)
+
+func TestPickSearchID(t *testing.T) {
+	caps := &database.IndexerCaps{
+		SearchModes:    "search,tvsearch",
+		TvSearchParams: "q,season,ep,tvmazeid,tmdbid",
+	}
+
+	tests := []struct {
+		name     string
+		caps     *database.IndexerCaps
+		ids      []SearchID
+		expected SearchID
+		ok       bool
+	}{
+		{
+			name: "Series with TVDB ID and no caps",
+			ids: []SearchID{
+				{Param: "tvdbid", Value: "81189"},
+				{Param: "tmdbid", Value: "1396"},
+			},
+			expected: SearchID{Param: "tvdbid", Value: "81189"},
+			ok:       true,
+		},
+		{
+			name: "Series without TVDB ID uses the first supported ID",
+			caps: caps,
+			ids: []SearchID{
+				{Param: "tvdbid", Value: "0"},
+				{Param: "imdbid", Value: "0903747"},
+				{Param: "tmdbid", Value: "1396"},
+				{Param: "traktid", Value: "1388"},
+			},
+			expected: SearchID{Param: "tmdbid", Value: "1396"},
+			ok:       true,
+		},
+		{
+			name: "Series without TVDB ID and no caps",
+			ids: []SearchID{
+				{Param: "tvdbid", Value: "0"},
+				{Param: "tmdbid", Value: "1396"},
+			},
+		},
+		{
+			name: "Series without any supported ID",
+			caps: caps,
+			ids: []SearchID{
+				{Param: "tvdbid", Value: "0"},
+				{Param: "traktid", Value: "1388"},
+				{Param: "tmdbid", Value: "0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PickSearchID(tt.caps, "tvsearch", tt.ids)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("PickSearchID() = %v, %v, want %v, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package movies
 import (
 	"context"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
@@ -81,7 +82,7 @@ var (
 		"DBCountHistoriesByTitle":  "select count() from movie_histories where title = ?",
 		"DBCountHistoriesByUrl":    "select count() from movie_histories where url = ?",
 		"DBLocationIDFilesByID":    "select location, id from movie_files where movie_id = ?",
-		"DBFilePrioFilesByID":      "select location, movie_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended from movie_files where movie_id = ?",
+		"DBFilePrioFilesByID":      "select location, movie_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, release_group from movie_files where movie_id = ?",
 		"UpdateMediaLastscan":      "update movies set lastscan = datetime('now','localtime') where id = ?",
 		"DBQualityMediaByID":       "select quality_profile from movies where id = ?",
 		"SearchGenSelect":          "select movies.quality_profile, movies.id ",
@@ -92,6 +93,11 @@ var (
 		"SearchGenLastScan":        " and (movies.lastscan is null or movies.Lastscan < ?)",
 		"SearchGenDate":            " and (dbmovies.release_date < ? or dbmovies.release_date is null)",
 		"SearchGenOrder":           " order by movies.Lastscan asc",
+		"SearchGenNextSearch":      " and (movies.next_search is null or movies.next_search < ?)",
+		"SearchBackoffCount":       "select search_empty_count from movies where id = ?",
+		"SearchBackoffRecent":      "select count() from movies inner join dbmovies on dbmovies.id=movies.dbmovie_id where movies.id = ? and (movies.created_at > ? or dbmovies.release_date > ?)",
+		"UpdateMediaSearchBackoff": "update movies set search_empty_count = ?, next_search = ? where id = ?",
+		"ResetMediaSearchBackoff":  "update movies set search_empty_count = 0, next_search = null where id = ?",
 		"DBIDUnmatchedPathList":    "select id from movie_file_unmatcheds where filepath = ? and listname = ? COLLATE NOCASE",
 		"InsertUnmatched":          "Insert into movie_file_unmatcheds (parsed_data, listname, filepath, last_checked) values (?, ?, ?, datetime('now','localtime'))",
 		"UpdateUnmatched":          "update movie_file_unmatcheds SET parsed_data = ?, last_checked = datetime('now','localtime') where id = ?",
@@ -102,12 +108,12 @@ var (
 		"QueryMediaCountByList":    "select count() from movies where listname = ? COLLATE NOCASE",
 		"UpdateQualityReached":     "update movies set quality_reached = ? where id = ?",
 		"SelectRootpath":           "select rootpath from movies where id = ?",
-		"InsertFile":               "insert into movie_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, movie_id, dbmovie_id, height, width) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"InsertFile":               "insert into movie_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, movie_id, dbmovie_id, height, width, release_name, release_group, hdr, subtitle_languages) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		"UpdateMissingByID":        "update movies set missing = 0 where id = ?",
 		"UpdateQualityReachedByID": "update movies set quality_reached = ? where id = ?",
 		"DeleteUnmatchedByPath":    "delete from movie_file_unmatcheds where filepath = ?",
 		"SelectRuntime":            "select runtime from dbmovies where id = ?",
-		"InsertFileOrganize":       "insert into movie_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, movie_id, dbmovie_id, height, width) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"InsertFileOrganize":       "insert into movie_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, movie_id, dbmovie_id, height, width, release_name, release_group, hdr, subtitle_languages) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		"UpdateMissingReached":     "update movies SET missing = 0, quality_reached = ? where id = ?",
 	}
 )
@@ -359,25 +365,54 @@ func (*handler) SetEntryTempID(entry *apiexternal_v2.Nzbwithprio) {
 	entry.Info.TempID = entry.NzbmovieID
 }
 
-// PerformIDSearch executes a search by IMDB ID.
+// PerformIDSearch executes a search by IMDB ID, or by TMDB or Trakt ID if the
+// stored caps of the indexer support only those. Without stored caps the IMDB
+// ID is used. apiexternal.ErrNoIDSearch is returned if the caps support none
+// of the IDs of the movie.
 func (*handler) PerformIDSearch(
 	indcfg *config.IndexersConfig,
 	quality *config.QualityConfig,
-	entry *apiexternal_v2.Nzbwithprio,
+	_ *apiexternal_v2.Nzbwithprio,
+	ids []mediatype.SearchID,
 	cats int,
 	raw *apiexternal.NzbSlice,
 ) error {
-	if entry.Info.Imdb == "" {
-		return nil
+	id, ok := mediatype.PickSearchID(database.GetIndexerCaps(indcfg.Name), "movie", ids)
+	if !ok {
+		return apiexternal.ErrNoIDSearch
 	}
 
-	_, _, err := apiexternal.QueryNewznabMovieImdb(
-		indcfg, quality, logger.Trim(entry.Info.Imdb, 't'), cats, raw,
-	)
+	if id.Param == "imdbid" {
+		_, _, err := apiexternal.QueryNewznabMovieImdb(indcfg, quality, id.Value, cats, raw)
+
+		return err
+	}
+
+	_, _, err := apiexternal.QueryNewznabMovieID(indcfg, quality, id.Param, id.Value, cats, raw)
 
 	return err
 }
 
+// SearchIDs returns the IDs of the movie to search by, the IMDB ID first.
+func (*handler) SearchIDs(entry *apiexternal_v2.Nzbwithprio) []mediatype.SearchID {
+	var tmdbid, traktid int
+
+	if entry.Dbid != 0 {
+		database.GetdatarowArgs(
+			"select moviedb_id, trakt_id from dbmovies where id = ?",
+			&entry.Dbid,
+			&tmdbid,
+			&traktid,
+		)
+	}
+
+	return []mediatype.SearchID{
+		{Param: "imdbid", Value: logger.Trim(entry.Info.Imdb, 't')},
+		{Param: "tmdbid", Value: strconv.Itoa(tmdbid)},
+		{Param: "traktid", Value: strconv.Itoa(traktid)},
+	}
+}
+
 // ClearUnmatchedCache removes the file from the movie unmatched cache.
 func (*handler) ClearUnmatchedCache(fpath string) {
 	database.SlicesCacheContainsDelete(logger.CacheUnmatchedMovie, fpath)
@@ -868,9 +903,11 @@ func (*handler) SupportsSeasonSearch() bool { return false }
 // RequiresYearCheck returns true - movies require strict year matching in searches.
 func (*handler) RequiresYearCheck() bool { return true }
 
-// HasSearchID returns true if the movie entry has a valid IMDB ID.
-func (*handler) HasSearchID(entry *apiexternal_v2.Nzbwithprio) bool {
-	return entry.Info.Imdb != ""
+// HasSearchID returns true if one of the movie ids is supported by the indexer.
+func (*handler) HasSearchID(indcfg *config.IndexersConfig, ids []mediatype.SearchID) bool {
+	_, ok := mediatype.PickSearchID(database.GetIndexerCaps(indcfg.Name), "movie", ids)
+
+	return ok
 }
 
 // SupportsAbsoluteEpisode returns false - movies don't have episode structure.
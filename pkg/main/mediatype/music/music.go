@@ -79,7 +79,7 @@ var (
 		"DBCountHistoriesByTitle":  "select count() from album_histories where title = ?",
 		"DBCountHistoriesByUrl":    "select count() from album_histories where url = ?",
 		"DBLocationIDFilesByID":    "select location, id from album_files where album_id = ?",
-		"DBFilePrioFilesByID":      "select location, album_id, id, 0, 0, 0, 0, 0, 0, 0 from album_files where album_id = ?",
+		"DBFilePrioFilesByID":      "select location, album_id, id, 0, 0, 0, 0, 0, 0, 0, '' from album_files where album_id = ?",
 		"DBAudioFilePrioFilesByID": "select location, album_id, id, format, bitrate, sample_rate, bit_depth from album_files where album_id = ?",
 		"UpdateMediaLastscan":      "update albums set lastscan = datetime('now','localtime') where id = ?",
 		"DBQualityMediaByID":       "select quality_profile from albums where id = ?",
@@ -91,6 +91,11 @@ var (
 		"SearchGenLastScan":        " and (albums.lastscan is null or albums.Lastscan < ?)",
 		"SearchGenDate":            " and (dbalbums.release_date < ? or dbalbums.release_date is null)",
 		"SearchGenOrder":           " order by albums.Lastscan asc",
+		"SearchGenNextSearch":      " and (albums.next_search is null or albums.next_search < ?)",
+		"SearchBackoffCount":       "select search_empty_count from albums where id = ?",
+		"SearchBackoffRecent":      "select count() from albums inner join dbalbums on dbalbums.id=albums.dbalbum_id where albums.id = ? and (albums.created_at > ? or dbalbums.release_date > ?)",
+		"UpdateMediaSearchBackoff": "update albums set search_empty_count = ?, next_search = ? where id = ?",
+		"ResetMediaSearchBackoff":  "update albums set search_empty_count = 0, next_search = null where id = ?",
 		"DBIDUnmatchedPathList":    "select id from album_file_unmatcheds where filepath = ? and listname = ? COLLATE NOCASE",
 		"InsertUnmatched":          "Insert into album_file_unmatcheds (parsed_data, listname, filepath, last_checked) values (?, ?, ?, datetime('now','localtime'))",
 		"UpdateUnmatched":          "update album_file_unmatcheds SET parsed_data = ?, last_checked = datetime('now','localtime') where id = ?",
@@ -352,6 +357,7 @@ func (*handler) PerformIDSearch(
 	_ *config.IndexersConfig,
 	_ *config.QualityConfig,
 	_ *apiexternal_v2.Nzbwithprio,
+	_ []mediatype.SearchID,
 	_ int,
 	_ *apiexternal.NzbSlice,
 ) error {
@@ -861,7 +867,14 @@ func (*handler) SupportsSeasonSearch() bool { return false }
 func (*handler) RequiresYearCheck() bool { return false }
 
 // HasSearchID returns false - music uses query-based search only (no standard ID in NZB results).
-func (*handler) HasSearchID(_ *apiexternal_v2.Nzbwithprio) bool { return false }
+func (*handler) HasSearchID(_ *config.IndexersConfig, _ []mediatype.SearchID) bool {
+	return false
+}
+
+// SearchIDs returns nil - music has no IDs to search indexers by.
+func (*handler) SearchIDs(_ *apiexternal_v2.Nzbwithprio) []mediatype.SearchID {
+	return nil
+}
 
 // SupportsAbsoluteEpisode returns false - music doesn't have episode structure.
 func (*handler) SupportsAbsoluteEpisode() bool { return false }
@@ -83,7 +83,7 @@ var (
 		"DBCountHistoriesByTitle":  "select count() from serie_episode_histories where title = ?",
 		"DBCountHistoriesByUrl":    "select count() from serie_episode_histories where url = ?",
 		"DBLocationIDFilesByID":    "select location, id from serie_episode_files where serie_episode_id = ?",
-		"DBFilePrioFilesByID":      "select location, serie_episode_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended from serie_episode_files where serie_episode_id = ?",
+		"DBFilePrioFilesByID":      "select location, serie_episode_id, id, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, release_group from serie_episode_files where serie_episode_id = ?",
 		"UpdateMediaLastscan":      "update serie_episodes set lastscan = datetime('now','localtime') where id = ?",
 		"DBQualityMediaByID":       "select quality_profile from serie_episodes where id = ?",
 		"SearchGenSelect":          "select serie_episodes.quality_profile, serie_episodes.id ",
@@ -93,7 +93,14 @@ var (
 		"SearchGenReached":         "serie_episodes.missing = 0 and serie_episodes.quality_reached = 0 and ((dbserie_episodes.Season != '0' and series.search_specials=0) or (series.search_specials=1)) and series.listname in (?",
 		"SearchGenLastScan":        " and (serie_episodes.lastscan is null or serie_episodes.lastscan < ?)",
 		"SearchGenDate":            " and (dbserie_episodes.first_aired < ? or dbserie_episodes.first_aired is null)",
+		"SearchGenAirTime":         " and (dbserie_episodes.air_time is null or dbserie_episodes.air_time <= datetime('now', '-' || (case when series.search_delay = 0 then ? else max(series.search_delay, 0) end) || ' minutes'))",
+		"SearchAirTimePending":     "select serie_episodes.id from serie_episodes inner join dbserie_episodes on dbserie_episodes.id=serie_episodes.dbserie_episode_id inner join series on series.id=serie_episodes.serie_id where serie_episodes.serie_id = ? and dbserie_episodes.air_time > datetime('now', '-' || (case when series.search_delay = 0 then ? else max(series.search_delay, 0) end) || ' minutes')",
 		"SearchGenOrder":           " order by serie_episodes.Lastscan asc",
+		"SearchGenNextSearch":      " and (serie_episodes.next_search is null or serie_episodes.next_search < ?)",
+		"SearchBackoffCount":       "select search_empty_count from serie_episodes where id = ?",
+		"SearchBackoffRecent":      "select count() from serie_episodes inner join dbserie_episodes on dbserie_episodes.id=serie_episodes.dbserie_episode_id where serie_episodes.id = ? and (serie_episodes.created_at > ? or dbserie_episodes.first_aired > ?)",
+		"UpdateMediaSearchBackoff": "update serie_episodes set search_empty_count = ?, next_search = ? where id = ?",
+		"ResetMediaSearchBackoff":  "update serie_episodes set search_empty_count = 0, next_search = null where id = ?",
 		"DBIDUnmatchedPathList":    "select id from serie_file_unmatcheds where filepath = ? and listname = ? COLLATE NOCASE",
 		"InsertUnmatched":          "Insert into serie_file_unmatcheds (parsed_data, listname, filepath, last_checked) values (?, ?, ?, datetime('now','localtime'))",
 		"UpdateUnmatched":          "update serie_file_unmatcheds SET parsed_data = ?, last_checked = datetime('now','localtime') where id = ?",
@@ -104,7 +111,7 @@ var (
 		"QueryMediaCountByList":    "select count() from serie_episodes where serie_id in (Select id from series where listname = ? COLLATE NOCASE)",
 		"UpdateQualityReached":     "update serie_episodes set quality_reached = ? where id = ?",
 		"SelectRootpath":           "select rootpath from series where id = ?",
-		"InsertFile":               "insert into serie_episode_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, height, width) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"InsertFile":               "insert into serie_episode_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, height, width, release_name, release_group, hdr, subtitle_languages) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		"UpdateMissingByID":        "update serie_episodes set missing = 0 where id = ?",
 		"UpdateQualityReachedByID": "update serie_episodes set quality_reached = ? where id = ?",
 		"UpdateQualityProfileByID": "update serie_episodes set quality_profile = ? where id = ?",
@@ -114,7 +121,7 @@ var (
 		"SelectEpisodeRuntime":     "select runtime, season from dbserie_episodes where id = ?",
 		"SelectIdentifiedBy":       "select identifiedby from dbseries where id = ?",
 		"SelectIgnoreRuntime":      "select ignore_runtime from serie_episodes where id = ?",
-		"InsertFileOrganize":       "insert into serie_episode_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, height, width) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		"InsertFileOrganize":       "insert into serie_episode_files (location, filename, extension, quality_profile, resolution_id, quality_id, codec_id, audio_id, proper, repack, extended, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, height, width, release_name, release_group, hdr, subtitle_languages) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		"UpdateMissingReached":     "update serie_episodes SET missing = 0, quality_reached = ? where id = ?",
 	}
 )
@@ -345,26 +352,93 @@ func (*handler) SetEntryTempID(entry *apiexternal_v2.Nzbwithprio) {
 	entry.Info.TempID = entry.NzbepisodeID
 }
 
-// PerformIDSearch executes a search by TVDB ID.
+// PerformIDSearch executes a search by TVDB ID. If the series has no TVDB ID
+// or the stored caps of the indexer do not support tvdbid, the IMDB, TVmaze,
+// TMDB, Trakt or TVRage ID of the series is used instead. Without stored caps
+// the TVDB ID is used. apiexternal.ErrNoIDSearch is returned if the caps
+// support none of the IDs of the series.
 func (*handler) PerformIDSearch(
 	indcfg *config.IndexersConfig,
 	quality *config.QualityConfig,
 	entry *apiexternal_v2.Nzbwithprio,
+	ids []mediatype.SearchID,
 	cats int,
 	raw *apiexternal.NzbSlice,
 ) error {
-	if entry.NZB.TVDBID == 0 {
-		return nil
+	id, ok := mediatype.PickSearchID(database.GetIndexerCaps(indcfg.Name), "tvsearch", ids)
+	if !ok {
+		return apiexternal.ErrNoIDSearch
 	}
 
-	_, _, err := apiexternal.QueryNewznabTvTvdb(
-		indcfg, quality, entry.NZB.TVDBID, cats,
-		entry.NZB.Season, entry.NZB.Episode, true, true, raw,
+	season, episode := sceneSearchNumbering(entry)
+
+	if id.Param == "tvdbid" {
+		_, _, err := apiexternal.QueryNewznabTvTvdb(
+			indcfg, quality, entry.NZB.TVDBID, cats,
+			season, episode, true, true, raw,
+		)
+
+		return err
+	}
+
+	_, _, err := apiexternal.QueryNewznabTvID(
+		indcfg, quality, id.Param, id.Value, cats, season, episode, raw,
 	)
 
 	return err
 }
 
+// SearchIDs returns the IDs of the series to search by, the TVDB ID first.
+func (*handler) SearchIDs(entry *apiexternal_v2.Nzbwithprio) []mediatype.SearchID {
+	var (
+		imdbid                              string
+		tvmazeid, tmdbid, traktid, tvrageid int
+	)
+
+	if entry.Dbid != 0 {
+		database.GetdatarowArgs(
+			"select imdb_id, tvmaze_id, moviedb_id, trakt_id, tvrage_id from dbseries where id = ?",
+			&entry.Dbid,
+			&imdbid,
+			&tvmazeid,
+			&tmdbid,
+			&traktid,
+			&tvrageid,
+		)
+	}
+
+	return []mediatype.SearchID{
+		{Param: "tvdbid", Value: strconv.Itoa(entry.NZB.TVDBID)},
+		{Param: "imdbid", Value: logger.Trim(imdbid, 't')},
+		{Param: "tvmazeid", Value: strconv.Itoa(tvmazeid)},
+		{Param: "tmdbid", Value: strconv.Itoa(tmdbid)},
+		{Param: "traktid", Value: strconv.Itoa(traktid)},
+		{Param: "rid", Value: strconv.Itoa(tvrageid)},
+	}
+}
+
+// sceneSearchNumbering returns the season and episode to query indexers with.
+// If the series has a scene mapping for the wanted TVDB episode, the scene
+// numbering is used as releases are tagged with it.
+func sceneSearchNumbering(entry *apiexternal_v2.Nzbwithprio) (string, string) {
+	tvdbSeason, err := strconv.Atoi(entry.NZB.Season)
+	if err != nil {
+		return entry.NZB.Season, entry.NZB.Episode
+	}
+
+	tvdbEpisode, err := strconv.Atoi(entry.NZB.Episode)
+	if err != nil {
+		return entry.NZB.Season, entry.NZB.Episode
+	}
+
+	season, episode, _, ok := database.TvdbToScene(entry.Dbid, tvdbSeason, tvdbEpisode)
+	if !ok || episode == 0 {
+		return entry.NZB.Season, entry.NZB.Episode
+	}
+
+	return strconv.Itoa(season), strconv.Itoa(episode)
+}
+
 // ClearUnmatchedCache removes the file from the series unmatched cache.
 func (*handler) ClearUnmatchedCache(fpath string) {
 	database.SlicesCacheContainsDelete(logger.CacheUnmatchedSeries, fpath)
@@ -568,6 +642,26 @@ func (*handler) RecordDownloadHistory(nzb *apiexternal_v2.Nzbwithprio, targetPat
 		&qualityProfile,
 	)
 
+	// A season pack covers every episode of its season - record the missing
+	// and upgradable ones so they are treated as downloaded until the
+	// organizer imports the pack. Episodes with a final file stay untouched.
+	if nzb.SeasonPack && serieID != 0 && nzb.Info.SeasonStr != "" {
+		database.ExecN(
+			"Insert into serie_episode_histories (title, url, target, indexer, downloaded_at, serie_id, serie_episode_id, dbserie_episode_id, dbserie_id, resolution_id, quality_id, codec_id, audio_id, quality_profile) select ?, ?, ?, ?, datetime('now','localtime'), serie_episodes.serie_id, serie_episodes.id, serie_episodes.dbserie_episode_id, serie_episodes.dbserie_id, ?, ?, ?, ?, serie_episodes.quality_profile from serie_episodes inner join dbserie_episodes on dbserie_episodes.id = serie_episodes.dbserie_episode_id where serie_episodes.serie_id = ? and dbserie_episodes.season = ? and serie_episodes.id != ? and (serie_episodes.missing = 1 or (serie_episodes.quality_reached = 0 and serie_episodes.dont_upgrade = 0))",
+			&nzb.NZB.Title,
+			&nzb.NZB.DownloadURL,
+			&targetPath,
+			&nzb.NZB.Indexer.Name,
+			&nzb.Info.ResolutionID,
+			&nzb.Info.QualityID,
+			&nzb.Info.CodecID,
+			&nzb.Info.AudioID,
+			&serieID,
+			&nzb.Info.SeasonStr,
+			&nzb.NzbepisodeID,
+		)
+	}
+
 	return nil
 }
 
@@ -1045,9 +1139,11 @@ func (*handler) SupportsSeasonSearch() bool { return true }
 // RequiresYearCheck returns false - series don't require strict year matching.
 func (*handler) RequiresYearCheck() bool { return false }
 
-// HasSearchID returns true if the series entry has a valid TVDB ID.
-func (*handler) HasSearchID(entry *apiexternal_v2.Nzbwithprio) bool {
-	return entry.NZB.TVDBID != 0
+// HasSearchID returns true if one of the series ids is supported by the indexer.
+func (*handler) HasSearchID(indcfg *config.IndexersConfig, ids []mediatype.SearchID) bool {
+	_, ok := mediatype.PickSearchID(database.GetIndexerCaps(indcfg.Name), "tvsearch", ids)
+
+	return ok
 }
 
 // SupportsAbsoluteEpisode returns true - series support absolute episode numbering (e.g., anime).
@@ -0,0 +1,114 @@
+package series
+
+import (
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// airTimeTestDB returns a database with the episodes 1 to 4 of the series
+// 1 to 4, aired 30 minutes ago. Series 1 uses the delay of the media group,
+// series 2 waits 15 minutes, series 3 has the delay disabled and series 4
+// has no known air time.
+func airTimeTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		create table series (id integer primary key, search_delay integer not null default 0);
+		create table dbserie_episodes (id integer primary key, air_time datetime);
+		create table serie_episodes (id integer primary key, serie_id integer,
+			dbserie_episode_id integer);
+		insert into series (id, search_delay) values (1, 0), (2, 15), (3, -1), (4, 0);
+		insert into dbserie_episodes (id, air_time) values
+			(1, datetime('now', '-30 minutes')), (2, datetime('now', '-30 minutes')),
+			(3, datetime('now', '-30 minutes')), (4, null);
+		insert into serie_episodes (id, serie_id, dbserie_episode_id) values
+			(1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 4, 4);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func queryIDs(t *testing.T, db *sql.DB, query string, args ...any) []int {
+	t.Helper()
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func TestSearchGenAirTime(t *testing.T) {
+	db := airTimeTestDB(t)
+
+	query := "select serie_episodes.id" + StringsMap["SearchGenTable"] + "1 = 1" +
+		StringsMap["SearchGenAirTime"] + " order by serie_episodes.id"
+
+	tests := []struct {
+		name       string
+		groupDelay int
+		expected   []int
+	}{
+		{name: "Group delay passed", groupDelay: 10, expected: []int{1, 2, 3, 4}},
+		{name: "Group delay pending", groupDelay: 60, expected: []int{2, 3, 4}},
+		{name: "No group delay", groupDelay: 0, expected: []int{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryIDs(t, db, query, tt.groupDelay); !slices.Equal(got, tt.expected) {
+				t.Errorf("searched episodes = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSearchAirTimePending(t *testing.T) {
+	db := airTimeTestDB(t)
+
+	tests := []struct {
+		name       string
+		serieID    int
+		groupDelay int
+		expected   []int
+	}{
+		{name: "Group delay pending", serieID: 1, groupDelay: 60, expected: []int{1}},
+		{name: "Group delay passed", serieID: 1, groupDelay: 10},
+		{name: "Series delay passed", serieID: 2, groupDelay: 60},
+		{name: "Series delay disabled", serieID: 3, groupDelay: 60},
+		{name: "Unknown air time", serieID: 4, groupDelay: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := queryIDs(t, db, StringsMap["SearchAirTimePending"], tt.serieID, tt.groupDelay)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("pending episodes = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package metadata
 
 import (
+	"database/sql"
 	"errors"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
@@ -19,8 +21,17 @@ var (
 	// invalidRuntimes is a sorted slice for binary search.
 	// This is more memory efficient than a map for small sets.
 	invalidRuntimes = []int{1, 2, 3, 4, 60, 90, 120}
+
+	// tmdbSerieNotFound holds the TVDB IDs (or the titles of series without
+	// TVDB ID) TMDB had no series for, so they are not looked up again on
+	// every metadata run.
+	tmdbSerieNotFound = syncops.NewSyncMap[struct{}](10)
 )
 
+// tmdbNotFoundRetry is the time until a series TMDB did not find is looked up
+// again.
+const tmdbNotFoundRetry = 7 * 24 * time.Hour
+
 // checkaddmovietitlewoslug adds a movie title to the dbmovie_titles table if it does not already exist.
 // It takes the title string, movie ID uint, region string, current movie titles slice, and cache setting.
 // It returns nothing.
@@ -225,6 +236,63 @@ func movieGetTmdbMetadata(movie *database.Dbmovie, overwrite bool) error {
 	return nil
 }
 
+// movieGetTmdbReleaseDates sets the cinema, digital and physical release dates
+// of the movie from the TMDB release dates of the region. Release types the
+// region has no date for use the earliest date of all countries.
+func movieGetTmdbReleaseDates(movie *database.Dbmovie, region string) error {
+	dates, err := apiexternal.GetTmdbMovieReleaseDates(movie.MoviedbID)
+	if err != nil {
+		return err
+	}
+
+	// cinema, digital and physical dates of the region and of all countries
+	var regional, global [3]time.Time
+	for _, d := range dates {
+		if d.Date.IsZero() {
+			continue
+		}
+
+		// TMDB release types: 2 limited theatrical, 3 theatrical, 4 digital, 5 physical
+		var idx int
+		switch d.Type {
+		case 2, 3:
+			idx = 0
+		case 4:
+			idx = 1
+		case 5:
+			idx = 2
+		default:
+			continue
+		}
+
+		global[idx] = earliestDate(global[idx], d.Date)
+		if region != "" && strings.EqualFold(d.ISO3166_1, region) {
+			regional[idx] = earliestDate(regional[idx], d.Date)
+		}
+	}
+
+	for idx := range regional {
+		if regional[idx].IsZero() {
+			regional[idx] = global[idx]
+		}
+	}
+
+	movie.CinemaRelease = sql.NullTime{Time: regional[0], Valid: !regional[0].IsZero()}
+	movie.DigitalRelease = sql.NullTime{Time: regional[1], Valid: !regional[1].IsZero()}
+	movie.PhysicalRelease = sql.NullTime{Time: regional[2], Valid: !regional[2].IsZero()}
+
+	return nil
+}
+
+// earliestDate returns the earlier of both dates, ignoring a zero current date.
+func earliestDate(current, date time.Time) time.Time {
+	if current.IsZero() || date.Before(current) {
+		return date
+	}
+
+	return current
+}
+
 // movieGetOmdbMetadata retrieves movie metadata from the OMDB API and merges it into the provided Dbmovie struct.
 // It will overwrite existing data in the Dbmovie if the overwrite param is true.
 // The OMDB API is queried using the ImdbID field in the Dbmovie.
@@ -439,6 +507,18 @@ func Getmoviemetadata(
 		&dbmovie.ID,
 	)
 
+	// Regional release dates for the minimum availability of missing searches
+	if slices.Contains(priorities, "tmdb") && dbmovie.MoviedbID != 0 &&
+		movieGetTmdbReleaseDates(dbmovie, cfgp.ReleaseRegion) == nil {
+		database.ExecN(
+			"update dbmovies SET cinema_release = ?, digital_release = ?, physical_release = ? where id = ?",
+			&dbmovie.CinemaRelease,
+			&dbmovie.DigitalRelease,
+			&dbmovie.PhysicalRelease,
+			&dbmovie.ID,
+		)
+	}
+
 	if cfgp.Name != "" {
 		// size +5
 		titles := database.Getrowssize[database.DbstaticTwoString](
@@ -720,29 +800,64 @@ func insertMovieTitle(
 
 // serieGetMetadataTmdb queries TheMovieDB API to get metadata for the given serie.
 // It populates various serie fields like name, slug etc if empty or overwrite is set.
+// Series without TVDB ID are searched by their title and first air year.
 // It handles API errors and logs them.
 func serieGetMetadataTmdb(serie *database.Dbserie, overwrite bool) error {
-	if serie.ThetvdbID == 0 {
+	if serie.ThetvdbID == 0 && serie.Seriename == "" {
 		return logger.ErrTvdbEmpty
 	}
 
-	// Name already present and no overwrite requested: nothing to do. This is
-	// the normal case for every known series - returning an error here made
-	// the caller log it at error level on every metadata run.
-	if serie.Seriename != "" && !overwrite {
+	// Name and TMDB ID already present and no overwrite requested: nothing to
+	// do. This is the normal case for every known series - returning an error
+	// here made the caller log it at error level on every metadata run.
+	if serie.Seriename != "" && serie.MoviedbID != 0 && !overwrite {
 		return nil
 	}
 
-	moviedb, err := apiexternal.FindTmdbTvdb(serie.ThetvdbID)
+	key := strconv.Itoa(serie.ThetvdbID)
+	if serie.ThetvdbID == 0 {
+		key = "title:" + logger.StringToSlugCached(serie.Seriename) + ":" + serie.Firstaired
+	}
+
+	if !overwrite && tmdbSerieNotFound.Check(key) &&
+		!tmdbSerieNotFound.CheckExpires(key, false, 0) {
+		return nil
+	}
+
+	var (
+		found *apiexternal.TheMovieDBFindTvresults
+		err   error
+	)
+
+	if serie.ThetvdbID != 0 {
+		var moviedb *apiexternal.TheMovieDBFind
+
+		moviedb, err = apiexternal.FindTmdbTvdb(serie.ThetvdbID)
+		if err == nil && len(moviedb.TvResults) > 0 {
+			found = &moviedb.TvResults[0]
+		}
+	} else {
+		found, err = tmdbSerieByTitle(serie)
+	}
+
 	if err != nil {
 		return err
 	}
 
-	if len(moviedb.TvResults) == 0 {
+	if found == nil {
+		tmdbSerieNotFound.Add(
+			key, struct{}{}, time.Now().Add(tmdbNotFoundRetry).UnixNano(), false, 0)
+
 		return errTmdbNotFound
 	}
 
-	UpdateString(&serie.Seriename, moviedb.TvResults[0].Name, overwrite, nil)
+	tmdbSerieNotFound.Delete(key)
+
+	UpdateString(&serie.Seriename, found.Name, overwrite, nil)
+
+	if serie.MoviedbID == 0 || overwrite {
+		serie.MoviedbID = found.ID
+	}
 
 	if (serie.Slug == "" || overwrite) && serie.Seriename != "" {
 		serie.Slug = logger.StringToSlugCached(serie.Seriename)
@@ -751,6 +866,34 @@ func serieGetMetadataTmdb(serie *database.Dbserie, overwrite bool) error {
 	return nil
 }
 
+// tmdbSerieByTitle searches TMDB for the series by its title. The first result
+// with the same title is returned, its first air year has to match if both
+// years are known. nil is returned if no result matches.
+func tmdbSerieByTitle(serie *database.Dbserie) (*apiexternal.TheMovieDBFindTvresults, error) {
+	search, err := apiexternal.SearchTmdbTV(serie.Seriename)
+	if err != nil {
+		return nil, err
+	}
+
+	slug := logger.StringToSlugCached(serie.Seriename)
+	for idx := range search.Results {
+		result := &search.Results[idx]
+		if logger.StringToSlugCached(result.Name) != slug &&
+			logger.StringToSlugCached(result.OriginalName) != slug {
+			continue
+		}
+
+		if len(serie.Firstaired) >= 4 && len(result.FirstAirDate) >= 4 &&
+			serie.Firstaired[:4] != result.FirstAirDate[:4] {
+			continue
+		}
+
+		return result, nil
+	}
+
+	return nil, nil
+}
+
 // serieGetMetadataTrakt queries the Trakt API to get metadata for the given serie.
 // It populates various serie fields like name, status, genres etc if empty or overwrite is set.
 // It handles API errors and logs them.
@@ -810,6 +953,10 @@ func serieGetMetadataTrakt(serie *database.Dbserie, overwrite bool) error {
 		serie.TvrageID = traktdetails.IDs.Tvrage
 	}
 
+	if (serie.MoviedbID == 0 || overwrite) && traktdetails.IDs.Tmdb != 0 {
+		serie.MoviedbID = traktdetails.IDs.Tmdb
+	}
+
 	// Handle dates
 	if (serie.Firstaired == "" || overwrite) && traktdetails.FirstAired.String() != "" {
 		serie.Firstaired = traktdetails.FirstAired.String()
@@ -944,7 +1091,7 @@ func SerieGetMetadata(
 			Msg("Failed to retrieve TVmaze metadata")
 	}
 
-	if querytmdb && serie.ThetvdbID != 0 {
+	if querytmdb && (serie.ThetvdbID != 0 || serie.Seriename != "") {
 		err = serieGetMetadataTmdb(serie, false)
 		if err != nil {
 			logger.Logtype("error", 1).
@@ -296,6 +296,11 @@ func applyTVmazeMetadata(
 func applyExternalIDs(serie *database.Dbserie, show *apiexternal_v2.SeriesDetails) bool {
 	var added bool
 
+	if serie.TvmazeID == 0 && show.ID > 0 {
+		serie.TvmazeID = show.ID
+		added = true
+	}
+
 	if serie.ThetvdbID == 0 && show.TVDbID > 0 {
 		serie.ThetvdbID = show.TVDbID
 		added = true
@@ -3,9 +3,12 @@ package parser
 import (
 	"context"
 	"errors"
+	"math"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
@@ -27,6 +30,58 @@ type ffProbeStream struct {
 	Channels       int               `json:"channels"`
 	BitRate        string            `json:"bit_rate"`
 	Duration       string            `json:"duration"`
+	ColorPrimaries string            `json:"color_primaries,omitempty"`
+	ColorTransfer  string            `json:"color_transfer,omitempty"`
+	SideDataList   []ffProbeSideData `json:"side_data_list,omitempty"`
+}
+
+// ffProbeSideData is one side data entry of a stream, e.g. the Dolby Vision
+// configuration record or the mastering display metadata of HDR10.
+type ffProbeSideData struct {
+	SideDataType string `json:"side_data_type"`
+}
+
+// Side data types as reported by ffprobe.
+const (
+	sideDataDOVI        = "DOVI configuration record"
+	sideDataMastering   = "Mastering display metadata"
+	sideDataContentLite = "Content light level metadata"
+	sideDataHDR10Plus   = "HDR Dynamic Metadata SMPTE2094-40 (HDR10+)"
+)
+
+// hasSideData reports whether the stream carries side data of the given type.
+func (s *ffProbeStream) hasSideData(typ string) bool {
+	for i := range s.SideDataList {
+		if strings.EqualFold(s.SideDataList[i].SideDataType, typ) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addSideData adds side data of the given type once.
+func (s *ffProbeStream) addSideData(typ string) {
+	if !s.hasSideData(typ) {
+		s.SideDataList = append(s.SideDataList, ffProbeSideData{SideDataType: typ})
+	}
+}
+
+// hdrFormat returns the HDR format signaled by a video stream: dolby vision,
+// hdr10+, hdr10 or hlg. It is empty for SDR streams.
+func (s *ffProbeStream) hdrFormat() string {
+	switch {
+	case s.hasSideData(sideDataDOVI):
+		return "dolby vision"
+	case s.hasSideData(sideDataHDR10Plus):
+		return "hdr10+"
+	case strings.EqualFold(s.ColorTransfer, "smpte2084"):
+		return "hdr10"
+	case strings.EqualFold(s.ColorTransfer, "arib-std-b67"):
+		return "hlg"
+	}
+
+	return ""
 }
 
 // language returns the stream's language tag. ffprobe normally emits the
@@ -232,3 +287,32 @@ func ExecCmdString[t []byte | mediaInfoJSON | ffProbeJSON](
 
 	return outputBuf.String(), err
 }
+
+// ProbeDuration returns the duration of the video file in seconds, read from
+// the container header where possible and with ffprobe otherwise.
+// It returns 0 if the duration cannot be determined.
+func ProbeDuration(ctx context.Context, file string) int {
+	var result *ffProbeJSON
+	if nativeProbeSupportsExt(file) {
+		result, _ = nativeProbe(file)
+	}
+
+	if result == nil || result.Format.Duration == "" {
+		out, err := ExecCmdString[ffProbeJSON](ctx, file, "ffprobe")
+		if err != nil {
+			return 0
+		}
+
+		result = &ffProbeJSON{}
+		if json.Unmarshal([]byte(out), result) != nil {
+			return 0
+		}
+	}
+
+	duration, err := strconv.ParseFloat(result.Format.Duration, 64)
+	if err != nil || duration <= 0 {
+		return 0
+	}
+
+	return int(math.Round(duration))
+}
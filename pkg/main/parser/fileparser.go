@@ -572,6 +572,17 @@ func newFileParser(
 	m.ParsegroupEntry("proper")
 	m.ParsegroupEntry("repack")
 
+	if !onlyifempty || m.ReleaseGroup == "" {
+		if group := parser_v2.ReleaseGroup(cleanName); group != "" {
+			m.ReleaseGroup = group
+			m.ReleaseName = parser_v2.ReleaseName(cleanName)
+		}
+	}
+
+	if m.ReleaseName == "" {
+		m.ReleaseName = parser_v2.ReleaseName(cleanName)
+	}
+
 	var (
 		start, end = 0, len(m.Str)
 		conttt     = logger.ContainsI(m.Str, logger.StrTt)
@@ -656,12 +667,32 @@ func ParseFile(
 // It accepts the video file path, booleans to determine parsing behavior,
 // a media type config, list ID, and existing parser to populate.
 // It returns the populated parser after attempting to extract metadata.
+// Movies and series are parsed by the v2 parser like searches, scans and
+// imports; in shadow mode the legacy parser runs as well and its divergences
+// are recorded.
 func ParseFileP(
 	videofile string,
 	usepath, usefolder bool,
 	cfgp *config.MediaTypeConfig,
 	listid int,
 	m *database.ParseInfo,
+) {
+	if !isShadowType(cfgp) {
+		parseFileLegacy(videofile, usepath, usefolder, cfgp, listid, m)
+		return
+	}
+
+	// shadowed by the parser_v2 hook
+	parser_v2.ParseFileP(videofile, usepath, usefolder, cfgp, listid, m)
+}
+
+// parseFileLegacy parses a video file with the legacy parser.
+func parseFileLegacy(
+	videofile string,
+	usepath, usefolder bool,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	m *database.ParseInfo,
 ) {
 	filename := videofile
 	if usepath {
@@ -820,6 +851,14 @@ func parseffprobe(m *database.ParseInfo, quality *config.QualityConfig, result *
 			if updateVideo(m, stream) {
 				redetermineprio = true
 			}
+
+			if m.HDR == "" {
+				m.HDR = stream.hdrFormat()
+			}
+		} else if isSubtitleStream(stream) {
+			if lang := stream.language(); lang != "" {
+				m.SubtitleLanguages = append(m.SubtitleLanguages, lang)
+			}
 		}
 	}
 
@@ -842,6 +881,11 @@ func isVideoStream(stream *ffProbeStream) bool {
 	return strings.EqualFold(stream.CodecType, "video")
 }
 
+// isSubtitleStream checks if the given stream is a subtitle stream by comparing its codec type.
+func isSubtitleStream(stream *ffProbeStream) bool {
+	return strings.EqualFold(stream.CodecType, "subtitle")
+}
+
 // normalizeDimensions ensures height is always smaller than width by swapping if necessary.
 // This normalizes dimensions for consistent processing.
 func normalizeDimensions(m *database.ParseInfo) {
@@ -1126,7 +1170,7 @@ func GetPriorityMapQual(
 		return
 	}
 
-	m.Priority = prio
+	m.Priority = prio + ReleaseGroupBonus(quality, m.ReleaseGroup)
 
 	if quality.UseForPriorityOther || useall {
 		applyPriorityModifiers(m)
@@ -1184,7 +1228,7 @@ func GetPriorityMapQualAudio(
 		return
 	}
 
-	m.Priority = prio
+	m.Priority = prio + ReleaseGroupBonus(quality, m.ReleaseGroup)
 
 	// Bitrate bonus modifier
 	if quality.UseForPriorityAudioBitrate || useall {
@@ -1264,6 +1308,8 @@ func GetPriorityMapQualBook(
 		m.Priority += 20
 	}
 
+	m.Priority += ReleaseGroupBonus(quality, m.ReleaseGroup)
+
 	// Apply standard modifiers (proper, repack)
 	if quality.UseForPriorityOther || useall {
 		applyPriorityModifiers(m)
@@ -1377,6 +1423,22 @@ func applyPriorityModifiers(m *database.ParseInfo) {
 	}
 }
 
+// ReleaseGroupBonus returns the priority bonus of a release group - the
+// preferred_groups_bonus of the quality if the group is a preferred group.
+func ReleaseGroupBonus(quality *config.QualityConfig, group string) int {
+	if group == "" || quality.PreferredGroupsBonus == 0 {
+		return 0
+	}
+
+	for _, preferred := range quality.PreferredGroups {
+		if strings.EqualFold(preferred, group) {
+			return quality.PreferredGroupsBonus
+		}
+	}
+
+	return 0
+}
+
 // GenerateAllQualityPriorities generates all possible quality priority combinations
 // by iterating through resolutions, qualities, codecs and audios. It builds up
 // a target Prioarr struct containing the ID and name for each, and calculates
@@ -499,3 +499,36 @@ func TestSplitByFull(t *testing.T) {
 		})
 	}
 }
+
+func TestReleaseGroupBonus(t *testing.T) {
+	quality := &config.QualityConfig{
+		PreferredGroups:      []string{"FLUX", "ntb"},
+		PreferredGroupsBonus: 20,
+	}
+
+	tests := []struct {
+		name     string
+		quality  *config.QualityConfig
+		group    string
+		expected int
+	}{
+		{name: "Preferred group", quality: quality, group: "FLUX", expected: 20},
+		{name: "Case insensitive", quality: quality, group: "NTb", expected: 20},
+		{name: "Other group", quality: quality, group: "YIFY", expected: 0},
+		{name: "No group", quality: quality, group: "", expected: 0},
+		{
+			name:     "No bonus configured",
+			quality:  &config.QualityConfig{PreferredGroups: []string{"FLUX"}},
+			group:    "FLUX",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReleaseGroupBonus(tt.quality, tt.group); got != tt.expected {
+				t.Errorf("ReleaseGroupBonus() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
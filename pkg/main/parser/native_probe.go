@@ -13,7 +13,7 @@ import (
 )
 
 // nativeProbeExts are the container extensions the native prober handles. Other
-// extensions (e.g. .mpg/.ts/.wmv/.flv) skip straight to ffprobe without an open.
+// extensions (e.g. .mpg/.vob) skip straight to ffprobe without an open.
 var nativeProbeExts = map[string]struct{}{
 	".mp4":  {},
 	".m4v":  {},
@@ -21,6 +21,12 @@ var nativeProbeExts = map[string]struct{}{
 	".mkv":  {},
 	".webm": {},
 	".avi":  {},
+	".ts":   {},
+	".m2ts": {},
+	".mts":  {},
+	".wmv":  {},
+	".asf":  {},
+	".flv":  {},
 }
 
 // nativeProbeSupportsExt reports whether file's extension is one the native
@@ -31,8 +37,9 @@ func nativeProbeSupportsExt(file string) bool {
 }
 
 // Native container probing extracts the same fields ffprobe provides
-// (video/audio codec, resolution, channels, language, duration) directly from
-// MP4/MOV, Matroska/WebM and AVI headers, avoiding an ffprobe subprocess per
+// (video/audio codec, resolution, channels, language, duration, subtitle
+// streams and HDR signaling) directly from MP4/MOV, Matroska/WebM, AVI,
+// MPEG-TS/M2TS, ASF/WMV and FLV headers, avoiding an ffprobe subprocess per
 // file. It is best-effort: on any malformed/unsupported input it returns an
 // error so the caller falls back to ffprobe/mediainfo. The result is shaped as
 // an *ffProbeJSON so the existing parseffprobe mapping is reused unchanged.
@@ -53,9 +60,10 @@ func nativeProbe(file string) (*ffProbeJSON, error) {
 	}
 	defer f.Close()
 
-	var magic [12]byte
+	// Three packets are needed to recognize a transport stream.
+	var head [3 * 192]byte
 
-	n, _ := io.ReadFull(f, magic[:])
+	n, _ := io.ReadFull(f, head[:])
 	if n < 12 {
 		return nil, errNativeUnsupported
 	}
@@ -64,6 +72,8 @@ func nativeProbe(file string) (*ffProbeJSON, error) {
 		return nil, err
 	}
 
+	magic := head[:n]
+
 	switch {
 	case magic[4] == 'f' && magic[5] == 't' && magic[6] == 'y' && magic[7] == 'p':
 		return probeMP4(f)
@@ -71,6 +81,14 @@ func nativeProbe(file string) (*ffProbeJSON, error) {
 		return probeMKV(f)
 	case string(magic[0:4]) == "RIFF" && string(magic[8:12]) == "AVI ":
 		return probeAVI(f)
+	case string(magic[0:3]) == "FLV":
+		return probeFLV(f)
+	case isASF(magic):
+		return probeASF(f)
+	}
+
+	if size := tsPacketSize(magic); size != 0 {
+		return probeTS(f, size)
 	}
 
 	return nil, errNativeUnsupported
@@ -314,8 +332,16 @@ func mp4TrakStream(trak []byte) (ffProbeStream, bool) {
 			st.Height = int(binary.BigEndian.Uint16(se.body[26:28]))
 		}
 
+		// VisualSampleEntry fields end at 78, followed by the codec config boxes.
+		if len(se.body) > 78 {
+			mp4VideoHeaders(se.typ, mp4Boxes(se.body[78:]), &st)
+		}
+
 		return st, true
 
+	case "sbtl", "text", "subt":
+		return langStream("subtitle", mp4SubtitleCodec(se.typ), lang), true
+
 	case "soun":
 		st := langStream("audio", mp4AudioCodec(se.typ), lang)
 		if len(se.body) >= 28 {
@@ -358,12 +384,63 @@ func parseMdhdLanguage(b []byte) string {
 	return string([]byte{c1, c2, c3})
 }
 
+// mp4VideoHeaders reads the HDR signaling of a video sample entry: the codec
+// configuration (parameter sets and SEI), the colr box and the Dolby Vision
+// and HDR10 metadata boxes.
+func mp4VideoHeaders(typ string, boxes []mp4Box, st *ffProbeStream) {
+	var headers videoHeaders
+
+	if typ == "dvh1" || typ == "dvhe" || typ == "dva1" || typ == "dvav" {
+		headers.addSideData(sideDataDOVI)
+	}
+
+	for i := range boxes {
+		switch boxes[i].typ {
+		case "hvcC":
+			headers.parseHVCC(boxes[i].body)
+		case "avcC":
+			headers.parseAVCC(boxes[i].body)
+		case "dvcC", "dvvC", "dvwC":
+			headers.addSideData(sideDataDOVI)
+		case "mdcv":
+			headers.addSideData(sideDataMastering)
+		case "clli":
+			headers.addSideData(sideDataContentLite)
+		}
+	}
+
+	// The colr box describes the whole track and wins over the bitstream.
+	if b, ok := findMP4Box(boxes, "colr"); ok && len(b) >= 10 && string(b[0:4]) == "nclx" {
+		headers.primaries = uint8(binary.BigEndian.Uint16(b[4:6]))
+		headers.transfer = uint8(binary.BigEndian.Uint16(b[6:8]))
+	}
+
+	headers.apply(st)
+}
+
+func mp4SubtitleCodec(typ string) string {
+	switch typ {
+	case "tx3g", "text":
+		return "mov_text"
+	case "wvtt":
+		return "webvtt"
+	case "stpp":
+		return "ttml"
+	case "c608":
+		return "eia_608"
+	}
+
+	return strings.TrimSpace(typ)
+}
+
 func mp4VideoCodec(typ string) string {
 	switch typ {
 	case "avc1", "avc3":
 		return "h264"
 	case "hvc1", "hev1", "dvh1", "dvhe":
 		return "hevc"
+	case "dva1", "dvav":
+		return "h264"
 	case "vp09":
 		return "vp9"
 	case "av01":
@@ -422,6 +499,20 @@ const (
 	ebmlChannels      = 0x9F
 	ebmlSampleFreq    = 0xB5
 	ebmlCluster       = 0x1F43B675
+	ebmlCodecPrivate  = 0x63A2
+	ebmlColour        = 0x55B0
+	ebmlTransfer      = 0x55BA
+	ebmlPrimaries     = 0x55BB
+	ebmlMaxCLL        = 0x55BC
+	ebmlMastering     = 0x55D0
+	ebmlBlockAddMap   = 0x41E4
+	ebmlBlockAddType  = 0x41E7
+)
+
+// Block addition types of the Dolby Vision configuration ('dvcC', 'dvvC').
+const (
+	mkvBlockAddDvcC = 0x64766343
+	mkvBlockAddDvvC = 0x64767643
 )
 
 // ebmlReader walks EBML elements from a byte slice.
@@ -642,6 +733,8 @@ func parseMKVTrackEntry(content []byte) (ffProbeStream, bool) {
 		trackType uint64
 		codecID   string
 		lang      string // only set from an explicit Language element (matches ffprobe)
+		private   []byte
+		headers   videoHeaders
 		width     int
 		height    int
 		channels  int
@@ -663,9 +756,15 @@ func parseMKVTrackEntry(content []byte) (ffProbeStream, bool) {
 		case ebmlLanguage:
 			lang = string(c)
 		case ebmlVideo:
-			width, height = parseMKVVideo(c)
+			width, height = parseMKVVideo(c, &headers)
 		case ebmlAudio:
 			channels, freq = parseMKVAudio(c)
+		case ebmlCodecPrivate:
+			private = c
+		case ebmlBlockAddMap:
+			if parseMKVBlockAddType(c) {
+				headers.addSideData(sideDataDOVI)
+			}
 		}
 	}
 
@@ -676,8 +775,32 @@ func parseMKVTrackEntry(content []byte) (ffProbeStream, bool) {
 		st.Width = width
 		st.Height = height
 
+		// The Colour element is parsed first and wins over the bitstream.
+		colour := headers
+		headers = videoHeaders{sideData: colour.sideData}
+
+		switch st.CodecName {
+		case "hevc":
+			headers.parseHVCC(private)
+		case "h264":
+			headers.parseAVCC(private)
+		}
+
+		if colour.primaries != 0 {
+			headers.primaries = colour.primaries
+		}
+
+		if colour.transfer != 0 {
+			headers.transfer = colour.transfer
+		}
+
+		headers.apply(&st)
+
 		return st, true
 
+	case 17: // subtitle
+		return langStream("subtitle", mkvSubtitleCodec(codecID), lang), true
+
 	case 2: // audio
 		st := langStream("audio", mkvAudioCodec(codecID), lang)
 
@@ -693,7 +816,7 @@ func parseMKVTrackEntry(content []byte) (ffProbeStream, bool) {
 	return ffProbeStream{}, false
 }
 
-func parseMKVVideo(content []byte) (int, int) {
+func parseMKVVideo(content []byte, headers *videoHeaders) (int, int) {
 	var w, h int
 
 	r := &ebmlReader{data: content}
@@ -708,12 +831,53 @@ func parseMKVVideo(content []byte) (int, int) {
 			w = int(ebmlUint(c))
 		case ebmlPixelHeight:
 			h = int(ebmlUint(c))
+		case ebmlColour:
+			parseMKVColour(c, headers)
 		}
 	}
 
 	return w, h
 }
 
+// parseMKVColour reads the colour description and HDR10 metadata of a video track.
+func parseMKVColour(content []byte, headers *videoHeaders) {
+	r := &ebmlReader{data: content}
+	for {
+		id, c, ok := r.elem()
+		if !ok {
+			break
+		}
+
+		switch id {
+		case ebmlPrimaries:
+			headers.primaries = uint8(ebmlUint(c))
+		case ebmlTransfer:
+			headers.transfer = uint8(ebmlUint(c))
+		case ebmlMastering:
+			headers.addSideData(sideDataMastering)
+		case ebmlMaxCLL:
+			headers.addSideData(sideDataContentLite)
+		}
+	}
+}
+
+// parseMKVBlockAddType reports whether a block addition mapping carries the
+// Dolby Vision configuration.
+func parseMKVBlockAddType(content []byte) bool {
+	r := &ebmlReader{data: content}
+	for {
+		id, c, ok := r.elem()
+		if !ok {
+			return false
+		}
+
+		if id == ebmlBlockAddType {
+			t := ebmlUint(c)
+			return t == mkvBlockAddDvcC || t == mkvBlockAddDvvC
+		}
+	}
+}
+
 func parseMKVAudio(content []byte) (int, int) {
 	var ch, freq int
 
@@ -758,6 +922,29 @@ func mkvVideoCodec(id string) string {
 	return ""
 }
 
+func mkvSubtitleCodec(id string) string {
+	switch id {
+	case "S_TEXT/UTF8", "S_TEXT/ASCII":
+		return "subrip"
+	case "S_TEXT/ASS", "S_ASS":
+		return "ass"
+	case "S_TEXT/SSA", "S_SSA":
+		return "ssa"
+	case "S_TEXT/WEBVTT":
+		return "webvtt"
+	case "S_HDMV/PGS":
+		return "hdmv_pgs_subtitle"
+	case "S_HDMV/TEXTST":
+		return "hdmv_text_subtitle"
+	case "S_VOBSUB":
+		return "dvd_subtitle"
+	case "S_DVBSUB":
+		return "dvb_subtitle"
+	}
+
+	return ""
+}
+
 func mkvAudioCodec(id string) string {
 	switch {
 	case strings.HasPrefix(id, "A_AAC"):
@@ -898,6 +1085,11 @@ func parseAVIStream(strl []byte) (ffProbeStream, bool) {
 	case "vids":
 		st := ffProbeStream{CodecType: "video"}
 		if len(strf) >= 20 { // BITMAPINFOHEADER
+			// DivX XSUB subtitles are stored as video streams
+			if fourcc := string(strf[16:20]); fourcc == "DXSB" || fourcc == "DXSA" {
+				return ffProbeStream{CodecType: "subtitle", CodecName: "xsub"}, true
+			}
+
 			st.Width = int(int32(binary.LittleEndian.Uint32(strf[4:8])))
 			st.Height = abs32(int32(binary.LittleEndian.Uint32(strf[8:12])))
 			st.CodecName = aviVideoCodec(string(strf[16:20]))
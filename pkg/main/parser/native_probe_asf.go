@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ---------------------------------------------------------------------------
+// ASF / WMV (Advanced Systems Format)
+// ---------------------------------------------------------------------------
+
+// ASF object GUIDs in their on-disk (little endian) byte order.
+var (
+	asfHeaderObject = []byte{
+		0x30, 0x26, 0xB2, 0x75, 0x8E, 0x66, 0xCF, 0x11,
+		0xA6, 0xD9, 0x00, 0xAA, 0x00, 0x62, 0xCE, 0x6C,
+	}
+	asfFileProperties = []byte{
+		0xA1, 0xDC, 0xAB, 0x8C, 0x47, 0xA9, 0xCF, 0x11,
+		0x8E, 0xE4, 0x00, 0xC0, 0x0C, 0x20, 0x53, 0x65,
+	}
+	asfStreamProperties = []byte{
+		0x91, 0x07, 0xDC, 0xB7, 0xB7, 0xA9, 0xCF, 0x11,
+		0x8E, 0xE6, 0x00, 0xC0, 0x0C, 0x20, 0x53, 0x65,
+	}
+	asfHeaderExtension = []byte{
+		0xB5, 0x03, 0xBF, 0x5F, 0x2E, 0xA9, 0xCF, 0x11,
+		0x8E, 0xE3, 0x00, 0xC0, 0x0C, 0x20, 0x53, 0x65,
+	}
+	asfLanguageList = []byte{
+		0xA9, 0x46, 0x43, 0x7C, 0xE0, 0xEF, 0xFC, 0x4B,
+		0xB2, 0x29, 0x39, 0x3E, 0xDE, 0x41, 0x5C, 0x85,
+	}
+	asfExtendedStreamProperties = []byte{
+		0xCB, 0xA5, 0xE6, 0x14, 0x72, 0xC6, 0x32, 0x43,
+		0x83, 0x99, 0xA9, 0x69, 0x52, 0x06, 0x5B, 0x5A,
+	}
+	asfAudioMedia = []byte{
+		0x40, 0x9E, 0x69, 0xF8, 0x4D, 0x5B, 0xCF, 0x11,
+		0xA8, 0xFD, 0x00, 0x80, 0x5F, 0x5C, 0x44, 0x2B,
+	}
+	asfVideoMedia = []byte{
+		0xC0, 0xEF, 0x19, 0xBC, 0x4D, 0x5B, 0xCF, 0x11,
+		0xA8, 0xFD, 0x00, 0x80, 0x5F, 0x5C, 0x44, 0x2B,
+	}
+)
+
+// isASF reports whether head starts with the ASF header object.
+func isASF(head []byte) bool {
+	return len(head) >= 16 && bytes.Equal(head[:16], asfHeaderObject)
+}
+
+// walkASF iterates ASF objects in data, calling fn with each object's GUID and body.
+func walkASF(data []byte, fn func(guid, body []byte)) {
+	for off := 0; off+24 <= len(data); {
+		size := binary.LittleEndian.Uint64(data[off+16 : off+24])
+		if size < 24 || size > uint64(len(data)-off) {
+			return
+		}
+
+		fn(data[off:off+16], data[off+24:off+int(size)])
+
+		off += int(size)
+	}
+}
+
+func probeASF(f *os.File) (*ffProbeJSON, error) {
+	var hdr [30]byte
+	if _, err := io.ReadFull(f, hdr[:]); err != nil || !isASF(hdr[:]) {
+		return nil, errNativeUnsupported
+	}
+
+	size := binary.LittleEndian.Uint64(hdr[16:24])
+	if size < 30 || size > maxHeaderProbe {
+		return nil, errNativeUnsupported
+	}
+
+	// The header object holds the object count and two reserved bytes before
+	// its child objects.
+	body := make([]byte, size-30)
+	if _, err := io.ReadFull(f, body); err != nil {
+		return nil, errNativeUnsupported
+	}
+
+	var (
+		out       = &ffProbeJSON{}
+		numbers   []uint16
+		languages []string
+		langIndex = make(map[uint16]uint16)
+	)
+
+	walkASF(body, func(guid, obj []byte) {
+		switch {
+		case bytes.Equal(guid, asfFileProperties):
+			// play duration in 100ns units at 40, preroll in ms at 56
+			if len(obj) >= 64 {
+				play := binary.LittleEndian.Uint64(obj[40:48])
+				preroll := binary.LittleEndian.Uint64(obj[56:64])
+
+				if secs := float64(play)/1e7 - float64(preroll)/1e3; secs > 0 {
+					out.Format.Duration = strconv.FormatFloat(secs, 'f', 3, 64)
+				}
+			}
+
+		case bytes.Equal(guid, asfStreamProperties):
+			if st, number, ok := parseASFStream(obj); ok {
+				out.Streams = append(out.Streams, st)
+				numbers = append(numbers, number)
+			}
+
+		case bytes.Equal(guid, asfHeaderExtension):
+			if len(obj) < 22 {
+				return
+			}
+
+			walkASF(obj[22:], func(guid, ext []byte) {
+				switch {
+				case bytes.Equal(guid, asfLanguageList):
+					languages = parseASFLanguages(ext)
+				case bytes.Equal(guid, asfExtendedStreamProperties):
+					if len(ext) >= 52 {
+						number := binary.LittleEndian.Uint16(ext[48:50]) & 0x7f
+						langIndex[number] = binary.LittleEndian.Uint16(ext[50:52])
+					}
+				}
+			})
+		}
+	})
+
+	for i := range out.Streams {
+		idx, ok := langIndex[numbers[i]]
+		if !ok || int(idx) >= len(languages) {
+			continue
+		}
+
+		st := langStream(out.Streams[i].CodecType, out.Streams[i].CodecName, languages[idx])
+		st.Width, st.Height = out.Streams[i].Width, out.Streams[i].Height
+		st.Channels, st.SampleRate = out.Streams[i].Channels, out.Streams[i].SampleRate
+		out.Streams[i] = st
+	}
+
+	if !hasUsableVideo(out.Streams) {
+		return nil, errNativeUnsupported
+	}
+
+	return out, nil
+}
+
+// parseASFStream reads a stream properties object into a stream entry and
+// returns its stream number.
+func parseASFStream(obj []byte) (ffProbeStream, uint16, bool) {
+	if len(obj) < 54 {
+		return ffProbeStream{}, 0, false
+	}
+
+	typeLen := int(binary.LittleEndian.Uint32(obj[40:44]))
+	number := binary.LittleEndian.Uint16(obj[48:50]) & 0x7f
+
+	if 54+typeLen > len(obj) {
+		return ffProbeStream{}, 0, false
+	}
+
+	data := obj[54 : 54+typeLen]
+
+	switch {
+	case bytes.Equal(obj[0:16], asfVideoMedia):
+		// encoded width/height, reserved flags, format data size, BITMAPINFOHEADER
+		if len(data) < 31 {
+			return ffProbeStream{}, 0, false
+		}
+
+		st := ffProbeStream{CodecType: "video"}
+		st.Width = int(binary.LittleEndian.Uint32(data[0:4]))
+		st.Height = int(binary.LittleEndian.Uint32(data[4:8]))
+		st.CodecName = asfVideoCodec(string(data[27:31]))
+
+		return st, number, true
+
+	case bytes.Equal(obj[0:16], asfAudioMedia):
+		if len(data) < 8 { // WAVEFORMATEX
+			return ffProbeStream{}, 0, false
+		}
+
+		st := ffProbeStream{CodecType: "audio"}
+		st.CodecName = asfAudioCodec(binary.LittleEndian.Uint16(data[0:2]))
+		st.Channels = int(binary.LittleEndian.Uint16(data[2:4]))
+		st.SampleRate = strconv.Itoa(int(binary.LittleEndian.Uint32(data[4:8])))
+
+		return st, number, true
+	}
+
+	return ffProbeStream{}, 0, false
+}
+
+// parseASFLanguages reads the RFC 1766 language ids of a language list object.
+func parseASFLanguages(obj []byte) []string {
+	if len(obj) < 2 {
+		return nil
+	}
+
+	count := int(binary.LittleEndian.Uint16(obj[0:2]))
+	langs := make([]string, 0, count)
+
+	for pos := 2; len(langs) < count && pos < len(obj); {
+		n := int(obj[pos])
+		pos++
+
+		if pos+n > len(obj) {
+			break
+		}
+
+		units := make([]uint16, 0, n/2)
+		for i := pos; i+1 < pos+n; i += 2 {
+			if u := binary.LittleEndian.Uint16(obj[i : i+2]); u != 0 {
+				units = append(units, u)
+			}
+		}
+
+		langs = append(langs, string(utf16.Decode(units)))
+		pos += n
+	}
+
+	return langs
+}
+
+func asfVideoCodec(fourcc string) string {
+	switch strings.ToUpper(fourcc) {
+	case "WMV1":
+		return "wmv1"
+	case "WMV2":
+		return "wmv2"
+	case "WMV3":
+		return "wmv3"
+	case "WVC1", "WMVA":
+		return "vc1"
+	}
+
+	return aviVideoCodec(fourcc)
+}
+
+func asfAudioCodec(tag uint16) string {
+	switch tag {
+	case 0x0160:
+		return "wmav1"
+	case 0x0161:
+		return "wmav2"
+	case 0x0162:
+		return "wmapro"
+	case 0x0163:
+		return "wmalossless"
+	}
+
+	return aviAudioCodec(tag)
+}
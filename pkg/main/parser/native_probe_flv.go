@@ -0,0 +1,324 @@
+package parser
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+// ---------------------------------------------------------------------------
+// FLV (Flash Video)
+// ---------------------------------------------------------------------------
+
+// flvProbeTags caps how many tags are read while waiting for the stream
+// headers. Audio and video configuration tags follow the metadata tag.
+const flvProbeTags = 200
+
+func probeFLV(f *os.File) (*ffProbeJSON, error) {
+	var hdr [9]byte
+	if _, err := io.ReadFull(f, hdr[:]); err != nil || string(hdr[0:3]) != "FLV" {
+		return nil, errNativeUnsupported
+	}
+
+	offset := int64(binary.BigEndian.Uint32(hdr[5:9]))
+	if _, err := f.Seek(offset+4, io.SeekStart); err != nil { // skip PreviousTagSize0
+		return nil, err
+	}
+
+	var (
+		meta    map[string]any
+		video   *ffProbeStream
+		audio   *ffProbeStream
+		headers videoHeaders
+		tag     [11]byte
+	)
+
+	hasVideo := hdr[4]&0x01 != 0
+	hasAudio := hdr[4]&0x04 != 0
+
+	for range flvProbeTags {
+		if _, err := io.ReadFull(f, tag[:]); err != nil {
+			break
+		}
+
+		size := int(tag[1])<<16 | int(tag[2])<<8 | int(tag[3])
+		if size > maxHeaderProbe {
+			break
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(f, data); err != nil {
+			break
+		}
+
+		if _, err := f.Seek(4, io.SeekCurrent); err != nil { // PreviousTagSize
+			break
+		}
+
+		switch tag[0] & 0x1f {
+		case 18:
+			if meta == nil {
+				meta = parseFLVMetadata(data)
+			}
+
+		case 9:
+			if video == nil && len(data) > 0 {
+				st := parseFLVVideo(data, &headers)
+				video = &st
+			} else if video != nil && !headers.complete() {
+				parseFLVVideo(data, &headers)
+			}
+
+		case 8:
+			if audio == nil && len(data) > 0 {
+				st := parseFLVAudio(data[0])
+				audio = &st
+			}
+		}
+
+		if meta != nil && (!hasVideo || (video != nil && headers.complete())) &&
+			(!hasAudio || audio != nil) {
+			break
+		}
+	}
+
+	out := &ffProbeJSON{}
+
+	if video != nil {
+		if w, ok := meta["width"].(float64); ok {
+			video.Width = int(w)
+		}
+
+		if h, ok := meta["height"].(float64); ok {
+			video.Height = int(h)
+		}
+
+		headers.apply(video)
+		out.Streams = append(out.Streams, *video)
+	}
+
+	if audio != nil {
+		out.Streams = append(out.Streams, *audio)
+	}
+
+	if d, ok := meta["duration"].(float64); ok && d > 0 {
+		out.Format.Duration = strconv.FormatFloat(d, 'f', 3, 64)
+	}
+
+	if !hasUsableVideo(out.Streams) {
+		return nil, errNativeUnsupported
+	}
+
+	return out, nil
+}
+
+// parseFLVVideo reads the codec of a video tag. AVC and HEVC sequence headers
+// are passed to headers for the resolution and colour description.
+func parseFLVVideo(data []byte, headers *videoHeaders) ffProbeStream {
+	st := ffProbeStream{CodecType: "video"}
+
+	// Enhanced RTMP: packet type in the low bits, FourCC following
+	if data[0]&0x80 != 0 {
+		if len(data) < 5 {
+			return st
+		}
+
+		fourcc := string(data[1:5])
+		st.CodecName = mp4VideoCodec(fourcc)
+
+		if data[0]&0x0f == 0 { // PacketTypeSequenceStart
+			switch fourcc {
+			case "hvc1":
+				headers.parseHVCC(data[5:])
+			case "avc1":
+				headers.parseAVCC(data[5:])
+			}
+		}
+
+		return st
+	}
+
+	switch data[0] & 0x0f {
+	case 2:
+		st.CodecName = "flv1"
+	case 3:
+		st.CodecName = "flashsv"
+	case 4:
+		st.CodecName = "vp6f"
+	case 5:
+		st.CodecName = "vp6a"
+	case 7:
+		st.CodecName = "h264"
+		if len(data) > 5 && data[1] == 0 { // AVC sequence header
+			headers.parseAVCC(data[5:])
+		}
+
+	case 12:
+		st.CodecName = "hevc"
+		if len(data) > 5 && data[1] == 0 {
+			headers.parseHVCC(data[5:])
+		}
+	}
+
+	return st
+}
+
+// parseFLVAudio reads codec, sample rate and channels from the first byte of
+// an audio tag.
+func parseFLVAudio(b byte) ffProbeStream {
+	st := ffProbeStream{CodecType: "audio", Channels: 1}
+	if b&0x01 != 0 {
+		st.Channels = 2
+	}
+
+	st.SampleRate = strconv.Itoa([]int{5512, 11025, 22050, 44100}[(b>>2)&0x03])
+
+	switch b >> 4 {
+	case 0, 3:
+		st.CodecName = "pcm"
+	case 1:
+		st.CodecName = "adpcm_swf"
+	case 2, 14:
+		st.CodecName = "mp3"
+	case 4, 5, 6:
+		st.CodecName = "nellymoser"
+	case 10:
+		st.CodecName = "aac"
+	case 11:
+		st.CodecName = "speex"
+	}
+
+	return st
+}
+
+// parseFLVMetadata reads the onMetaData script tag (AMF0) into a map. Only
+// numbers, booleans and strings are kept.
+func parseFLVMetadata(data []byte) map[string]any {
+	r := &amfReader{data: data}
+
+	if name, ok := r.value().(string); !ok || name != "onMetaData" {
+		return nil
+	}
+
+	if obj, ok := r.value().(map[string]any); ok {
+		return obj
+	}
+
+	return nil
+}
+
+// amfReader decodes AMF0 values.
+type amfReader struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (r *amfReader) bytes(n int) []byte {
+	if n < 0 || r.pos+n > len(r.data) {
+		r.pos = len(r.data)
+		return nil
+	}
+
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+
+	return b
+}
+
+func (r *amfReader) str(lenBytes int) string {
+	b := r.bytes(lenBytes)
+	if b == nil {
+		return ""
+	}
+
+	n := int(binary.BigEndian.Uint16(b))
+	if lenBytes == 4 {
+		n = int(binary.BigEndian.Uint32(b))
+	}
+
+	return string(r.bytes(n))
+}
+
+// object reads key/value pairs until the object end marker.
+func (r *amfReader) object() map[string]any {
+	obj := make(map[string]any)
+
+	for r.pos < len(r.data) {
+		key := r.str(2)
+		if key == "" && r.pos < len(r.data) && r.data[r.pos] == 0x09 {
+			r.pos++
+			break
+		}
+
+		val := r.value()
+		if val == nil && r.pos >= len(r.data) {
+			break
+		}
+
+		obj[key] = val
+	}
+
+	return obj
+}
+
+func (r *amfReader) value() any {
+	t := r.bytes(1)
+	if t == nil {
+		return nil
+	}
+
+	r.depth++
+	defer func() { r.depth-- }()
+
+	if r.depth > 16 {
+		r.pos = len(r.data)
+		return nil
+	}
+
+	switch t[0] {
+	case 0x00:
+		if b := r.bytes(8); b != nil {
+			return math.Float64frombits(binary.BigEndian.Uint64(b))
+		}
+
+	case 0x01:
+		if b := r.bytes(1); b != nil {
+			return b[0] != 0
+		}
+
+	case 0x02:
+		return r.str(2)
+	case 0x0C:
+		return r.str(4)
+	case 0x03:
+		return r.object()
+	case 0x08:
+		r.bytes(4) // approximate count
+		return r.object()
+	case 0x0A:
+		b := r.bytes(4)
+		if b == nil {
+			return nil
+		}
+
+		n := int(binary.BigEndian.Uint32(b))
+		for i := 0; i < n && r.pos < len(r.data); i++ {
+			r.value()
+		}
+
+		return []any{}
+
+	case 0x0B:
+		r.bytes(10) // date: double + timezone
+		return 0.0
+	case 0x05, 0x06:
+		return false
+	default:
+		r.pos = len(r.data)
+	}
+
+	return nil
+}
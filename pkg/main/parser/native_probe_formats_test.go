@@ -0,0 +1,367 @@
+package parser
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The containers below are built in memory, so these tests run without sample
+// media.
+
+// bitWriter writes the bit fields and Exp-Golomb codes read by bitReader.
+type bitWriter struct {
+	data []byte
+	bits int
+}
+
+func (w *bitWriter) u(n int, v uint32) {
+	for i := n - 1; i >= 0; i-- {
+		if w.bits%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+
+		if v>>i&1 == 1 {
+			w.data[len(w.data)-1] |= 0x80 >> (w.bits % 8)
+		}
+
+		w.bits++
+	}
+}
+
+func (w *bitWriter) ue(v uint32) {
+	v++
+
+	n := 0
+	for x := v; x > 1; x >>= 1 {
+		n++
+	}
+
+	w.u(n, 0)
+	w.u(n+1, v)
+}
+
+// testH264SPS returns a 1920x1080 H.264 SPS NAL unit signaling BT.2020 / PQ.
+func testH264SPS() []byte {
+	w := &bitWriter{}
+	w.u(8, 66) // baseline profile
+	w.u(16, 40)
+	w.ue(0)   // seq_parameter_set_id
+	w.ue(0)   // log2_max_frame_num_minus4
+	w.ue(2)   // pic_order_cnt_type
+	w.ue(1)   // max_num_ref_frames
+	w.u(1, 0) // gaps_in_frame_num_value_allowed_flag
+	w.ue(119) // pic_width_in_mbs_minus1
+	w.ue(67)  // pic_height_in_map_units_minus1
+	w.u(1, 1) // frame_mbs_only_flag
+	w.u(1, 1) // direct_8x8_inference_flag
+	w.u(1, 1) // frame_cropping_flag
+	w.ue(0)
+	w.ue(0)
+	w.ue(0)
+	w.ue(4)
+	w.u(1, 1) // vui_parameters_present_flag
+	w.u(1, 0) // aspect_ratio_info_present_flag
+	w.u(1, 0) // overscan_info_present_flag
+	w.u(1, 1) // video_signal_type_present_flag
+	w.u(4, 5<<1)
+	w.u(1, 1) // colour_description_present_flag
+	w.u(8, 9)
+	w.u(8, 16)
+	w.u(8, 9)
+	w.u(1, 1) // rbsp_stop_one_bit
+
+	return append([]byte{0x67}, w.data...)
+}
+
+// testAVCC wraps an SPS into an AVCDecoderConfigurationRecord.
+func testAVCC(sps []byte) []byte {
+	b := []byte{1, sps[1], sps[2], sps[3], 0xFF, 0xE1}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(sps)))
+	b = append(b, sps...)
+
+	return append(b, 0) // no PPS
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(file, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func findStream(r *ffProbeJSON, codecType string) *ffProbeStream {
+	for i := range r.Streams {
+		if r.Streams[i].CodecType == codecType {
+			return &r.Streams[i]
+		}
+	}
+
+	return nil
+}
+
+func TestParseH264SPS(t *testing.T) {
+	var v videoHeaders
+	v.parseNAL("h264", testH264SPS())
+
+	if v.width != 1920 || v.height != 1080 {
+		t.Errorf("resolution = %dx%d, want 1920x1080", v.width, v.height)
+	}
+
+	var st ffProbeStream
+	v.apply(&st)
+
+	if st.ColorPrimaries != "bt2020" || st.ColorTransfer != "smpte2084" {
+		t.Errorf("colour = %q/%q, want bt2020/smpte2084", st.ColorPrimaries, st.ColorTransfer)
+	}
+
+	if got := st.hdrFormat(); got != "hdr10" {
+		t.Errorf("hdrFormat = %q, want hdr10", got)
+	}
+}
+
+func TestParseSEIHDR(t *testing.T) {
+	// mastering display, then an HDR10+ T.35 payload
+	sei := []byte{6, 137, 24}
+	sei = append(sei, make([]byte, 24)...)
+	sei = append(sei, 4, 7, 0xB5, 0x00, 0x3C, 0x00, 0x01, 0x04, 0x01, 0x80)
+
+	var v videoHeaders
+	v.parseNAL("h264", sei)
+
+	var st ffProbeStream
+	v.apply(&st)
+
+	if !st.hasSideData(sideDataMastering) || !st.hasSideData(sideDataHDR10Plus) {
+		t.Errorf("side data = %v", st.SideDataList)
+	}
+
+	if got := st.hdrFormat(); got != "hdr10+" {
+		t.Errorf("hdrFormat = %q, want hdr10+", got)
+	}
+
+	st.addSideData(sideDataDOVI)
+
+	if got := st.hdrFormat(); got != "dolby vision" {
+		t.Errorf("hdrFormat = %q, want dolby vision", got)
+	}
+}
+
+// tsPacket builds a 188 byte packet padded with 0xFF.
+func tsPacket(pid uint16, start bool, payload []byte) []byte {
+	pkt := make([]byte, 188)
+	for i := range pkt {
+		pkt[i] = 0xFF
+	}
+
+	pkt[0] = 0x47
+	binary.BigEndian.PutUint16(pkt[1:3], pid)
+
+	if start {
+		pkt[1] |= 0x40
+	}
+
+	pkt[3] = 0x10 // payload only
+	copy(pkt[4:], payload)
+
+	return pkt
+}
+
+// tsSection prefixes a PSI section body with pointer field, table id and
+// length and appends a (dummy) CRC.
+func tsSection(table byte, body []byte) []byte {
+	s := []byte{0, table, 0xB0, byte(len(body) + 4)}
+	s = append(s, body...)
+
+	return append(s, 0, 0, 0, 0)
+}
+
+func tsPES(pts int64, es []byte) []byte {
+	b := []byte{0, 0, 1, 0xE0, 0, 0, 0x80, 0x80, 5,
+		byte(0x21 | (pts>>29)&0x0E),
+		byte(pts >> 22),
+		byte((pts>>14)&0xFE | 1),
+		byte(pts >> 7),
+		byte((pts<<1)&0xFE | 1),
+	}
+
+	return append(b, es...)
+}
+
+func TestNativeProbeTS(t *testing.T) {
+	pat := tsSection(0x00, []byte{0, 1, 0xC1, 0, 0, 0, 1, 0xE1, 0x00})
+	pmt := tsSection(0x02, []byte{
+		0, 1, 0xC1, 0, 0, 0xE1, 0x01, 0xF0, 0,
+		0x1B, 0xE1, 0x01, 0xF0, 0,
+		0x06, 0xE1, 0x02, 0xF0, 10, 0x59, 8, 'g', 'e', 'r', 0x10, 0, 1, 0, 1,
+		0x81, 0xE1, 0x03, 0xF0, 6, 0x0A, 4, 'e', 'n', 'g', 0,
+	})
+
+	es := append([]byte{0, 0, 0, 1}, testH264SPS()...)
+
+	var data []byte
+	data = append(data, tsPacket(0, true, pat)...)
+	data = append(data, tsPacket(0x100, true, pmt)...)
+	data = append(data, tsPacket(0x101, true, tsPES(90000, es))...)
+	data = append(data, tsPacket(0x101, true, tsPES(90000*11, nil))...)
+
+	r, err := nativeProbe(writeTestFile(t, "test.ts", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dumpStreams(t, r)
+	wantVideo(t, r, "h264", 1920, 1080, 10)
+
+	if v := findStream(r, "video"); v.hdrFormat() != "hdr10" {
+		t.Errorf("hdrFormat = %q, want hdr10", v.hdrFormat())
+	}
+
+	if s := findStream(r, "subtitle"); s == nil || s.CodecName != "dvb_subtitle" || s.language() != "ger" {
+		t.Errorf("subtitle = %+v", s)
+	}
+
+	if a := findStream(r, "audio"); a == nil || a.CodecName != "ac3" || a.language() != "eng" {
+		t.Errorf("audio = %+v", a)
+	}
+}
+
+func TestTSPacketSize(t *testing.T) {
+	ts := make([]byte, 3*188)
+	ts[0], ts[188], ts[376] = 0x47, 0x47, 0x47
+
+	if got := tsPacketSize(ts); got != 188 {
+		t.Errorf("tsPacketSize(ts) = %d, want 188", got)
+	}
+
+	m2ts := make([]byte, 3*192)
+	m2ts[4], m2ts[196], m2ts[388] = 0x47, 0x47, 0x47
+
+	if got := tsPacketSize(m2ts); got != 192 {
+		t.Errorf("tsPacketSize(m2ts) = %d, want 192", got)
+	}
+
+	if got := tsPacketSize(make([]byte, 3*192)); got != 0 {
+		t.Errorf("tsPacketSize(zero) = %d, want 0", got)
+	}
+}
+
+func flvTag(typ byte, data []byte) []byte {
+	b := []byte{typ, byte(len(data) >> 16), byte(len(data) >> 8), byte(len(data)), 0, 0, 0, 0, 0, 0, 0}
+	b = append(b, data...)
+
+	return binary.BigEndian.AppendUint32(b, uint32(len(b)))
+}
+
+func amfString(s string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(s))), s...)
+}
+
+func amfNumber(v float64) []byte {
+	return binary.BigEndian.AppendUint64([]byte{0x00}, math.Float64bits(v))
+}
+
+func TestNativeProbeFLV(t *testing.T) {
+	meta := append([]byte{0x02}, amfString("onMetaData")...)
+	meta = append(meta, 0x08, 0, 0, 0, 2)
+	meta = append(meta, amfString("duration")...)
+	meta = append(meta, amfNumber(42.5)...)
+	meta = append(meta, amfString("encoder")...)
+	meta = append(meta, 0x02)
+	meta = append(meta, amfString("test")...)
+	meta = append(meta, 0, 0, 0x09)
+
+	video := append([]byte{0x17, 0, 0, 0, 0}, testAVCC(testH264SPS())...)
+
+	data := []byte{'F', 'L', 'V', 1, 0x05, 0, 0, 0, 9, 0, 0, 0, 0}
+	data = append(data, flvTag(18, meta)...)
+	data = append(data, flvTag(9, video)...)
+	data = append(data, flvTag(8, []byte{0xAF, 0})...)
+
+	r, err := nativeProbe(writeTestFile(t, "test.flv", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dumpStreams(t, r)
+	wantVideo(t, r, "h264", 1920, 1080, 42.5)
+
+	if a := findStream(r, "audio"); a == nil || a.CodecName != "aac" || a.Channels != 2 ||
+		a.SampleRate != "44100" {
+		t.Errorf("audio = %+v", a)
+	}
+}
+
+func asfObject(guid []byte, body []byte) []byte {
+	b := append([]byte(nil), guid...)
+	b = binary.LittleEndian.AppendUint64(b, uint64(24+len(body)))
+
+	return append(b, body...)
+}
+
+func asfStream(media []byte, number uint16, typeData []byte) []byte {
+	b := append([]byte(nil), media...)
+	b = append(b, make([]byte, 24)...) // error correction type, time offset
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(typeData)))
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	b = binary.LittleEndian.AppendUint16(b, number)
+	b = append(b, 0, 0, 0, 0)
+
+	return asfObject(asfStreamProperties, append(b, typeData...))
+}
+
+func TestNativeProbeASF(t *testing.T) {
+	props := make([]byte, 80)
+	binary.LittleEndian.PutUint64(props[40:48], 61*10_000_000)
+	binary.LittleEndian.PutUint64(props[56:64], 1000)
+
+	video := binary.LittleEndian.AppendUint32(nil, 1280)
+	video = binary.LittleEndian.AppendUint32(video, 720)
+	video = append(video, 2, 40, 0)
+	bih := make([]byte, 40)
+	copy(bih[16:20], "WMV3")
+	video = append(video, bih...)
+
+	audio := binary.LittleEndian.AppendUint16(nil, 0x0161)
+	audio = binary.LittleEndian.AppendUint16(audio, 2)
+	audio = binary.LittleEndian.AppendUint32(audio, 48000)
+	audio = append(audio, make([]byte, 10)...)
+
+	langs := []byte{1, 0, 6, 'd', 0, 'e', 0, 0, 0}
+	ext := make([]byte, 64)
+	binary.LittleEndian.PutUint16(ext[48:50], 2)
+
+	extBody := append(asfObject(asfLanguageList, langs), asfObject(asfExtendedStreamProperties, ext)...)
+	extension := append(make([]byte, 18), binary.LittleEndian.AppendUint32(nil, uint32(len(extBody)))...)
+	extension = append(extension, extBody...)
+
+	var children []byte
+	children = append(children, asfObject(asfFileProperties, props)...)
+	children = append(children, asfStream(asfVideoMedia, 1, video)...)
+	children = append(children, asfStream(asfAudioMedia, 2, audio)...)
+	children = append(children, asfObject(asfHeaderExtension, extension)...)
+
+	data := append([]byte(nil), asfHeaderObject...)
+	data = binary.LittleEndian.AppendUint64(data, uint64(30+len(children)))
+	data = append(data, 4, 0, 0, 0, 1, 2)
+	data = append(data, children...)
+
+	r, err := nativeProbe(writeTestFile(t, "test.wmv", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dumpStreams(t, r)
+	wantVideo(t, r, "wmv3", 1280, 720, 60)
+
+	if a := findStream(r, "audio"); a == nil || a.CodecName != "wmav2" || a.language() != "de" ||
+		a.Channels != 2 {
+		t.Errorf("audio = %+v", a)
+	}
+}
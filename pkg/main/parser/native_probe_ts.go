@@ -0,0 +1,481 @@
+package parser
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"strconv"
+)
+
+// ---------------------------------------------------------------------------
+// MPEG-TS / M2TS (transport stream)
+// ---------------------------------------------------------------------------
+
+// tsProbeSize caps how much of the start and the end of a transport stream is
+// read. The PAT/PMT and the first video parameter sets follow within the
+// first few hundred packets; the end is only needed for the last timestamp.
+const tsProbeSize = 8 << 20
+
+// tsPacketSize returns the packet size of a transport stream starting with
+// head: 188 for MPEG-TS, 192 for M2TS (4 byte timestamp prefix), 0 otherwise.
+func tsPacketSize(head []byte) int {
+	for _, size := range []int{188, 192} {
+		off := size - 188 // sync byte offset within a packet
+
+		if len(head) < off+2*size+1 {
+			continue
+		}
+
+		if head[off] == 0x47 && head[off+size] == 0x47 && head[off+2*size] == 0x47 {
+			return size
+		}
+	}
+
+	return 0
+}
+
+// tsStream is an elementary stream announced in the PMT.
+type tsStream struct {
+	stream  ffProbeStream
+	headers videoHeaders
+	es      []byte
+	next    int // es length at which the codec headers are parsed next
+	pid     uint16
+	isVideo bool
+	done    bool
+}
+
+// tsProbe holds the state while walking the packets of a transport stream.
+type tsProbe struct {
+	sections map[uint16][]byte
+	streams  map[uint16]*tsStream
+	order    []uint16
+	pmtPID   uint16
+	hasPMT   bool
+	firstPTS int64
+	ptsPID   uint16
+}
+
+func probeTS(f *os.File, packetSize int) (*ffProbeJSON, error) {
+	head, err := io.ReadAll(io.LimitReader(f, tsProbeSize))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &tsProbe{
+		sections: make(map[uint16][]byte),
+		streams:  make(map[uint16]*tsStream),
+		pmtPID:   0xffff,
+		firstPTS: -1,
+	}
+
+	off := packetSize - 188
+	for ; off+188 <= len(head); off += packetSize {
+		if head[off] != 0x47 {
+			continue
+		}
+
+		p.packet(head[off : off+188])
+	}
+
+	if !p.hasPMT {
+		return nil, errNativeUnsupported
+	}
+
+	out := &ffProbeJSON{}
+
+	for _, pid := range p.order {
+		st := p.streams[pid]
+		if st.isVideo {
+			if !st.done && len(st.es) > 0 {
+				st.headers = videoHeaders{}
+				st.headers.parseAnnexB(st.stream.CodecName, st.es)
+			}
+
+			st.headers.apply(&st.stream)
+		}
+
+		out.Streams = append(out.Streams, st.stream)
+	}
+
+	if p.firstPTS >= 0 {
+		if last := tsLastPTS(f, packetSize, p.ptsPID); last >= 0 {
+			diff := last - p.firstPTS
+			if diff < 0 {
+				diff += 1 << 33 // PTS wrap around
+			}
+
+			out.Format.Duration = strconv.FormatFloat(float64(diff)/90000, 'f', 3, 64)
+		}
+	}
+
+	if !hasUsableVideo(out.Streams) {
+		return nil, errNativeUnsupported
+	}
+
+	return out, nil
+}
+
+// tsPayload returns the PID, the payload unit start flag and the payload of a
+// 188 byte packet.
+func tsPayload(pkt []byte) (uint16, bool, []byte) {
+	pid := binary.BigEndian.Uint16(pkt[1:3]) & 0x1fff
+	start := pkt[1]&0x40 != 0
+
+	afc := (pkt[3] >> 4) & 0x3
+	pos := 4
+
+	if afc&0x2 != 0 {
+		pos += 1 + int(pkt[4])
+	}
+
+	if afc&0x1 == 0 || pos >= len(pkt) {
+		return pid, start, nil
+	}
+
+	return pid, start, pkt[pos:]
+}
+
+// packet handles one 188 byte transport packet.
+func (p *tsProbe) packet(pkt []byte) {
+	pid, start, payload := tsPayload(pkt)
+	if payload == nil {
+		return
+	}
+
+	switch {
+	case pid == 0:
+		if section := p.section(pid, start, payload); section != nil {
+			p.parsePAT(section)
+		}
+
+	case pid == p.pmtPID && !p.hasPMT:
+		if section := p.section(pid, start, payload); section != nil {
+			p.parsePMT(section)
+		}
+
+	default:
+		if st, ok := p.streams[pid]; ok {
+			p.pes(st, start, payload)
+		}
+	}
+}
+
+// section assembles a PSI section which may span several packets. It returns
+// the complete section once available.
+func (p *tsProbe) section(pid uint16, start bool, payload []byte) []byte {
+	if start {
+		pointer := int(payload[0])
+		if 1+pointer >= len(payload) {
+			return nil
+		}
+
+		p.sections[pid] = append([]byte(nil), payload[1+pointer:]...)
+	} else if buf, ok := p.sections[pid]; ok {
+		p.sections[pid] = append(buf, payload...)
+	} else {
+		return nil
+	}
+
+	buf := p.sections[pid]
+	if len(buf) < 3 {
+		return nil
+	}
+
+	length := int(binary.BigEndian.Uint16(buf[1:3])&0x0fff) + 3
+	if len(buf) < length {
+		return nil
+	}
+
+	delete(p.sections, pid)
+
+	return buf[:length]
+}
+
+// parsePAT takes the PMT PID of the first program.
+func (p *tsProbe) parsePAT(s []byte) {
+	if len(s) < 12 || s[0] != 0x00 {
+		return
+	}
+
+	// 8 byte header, 4 byte CRC
+	for pos := 8; pos+4 <= len(s)-4; pos += 4 {
+		program := binary.BigEndian.Uint16(s[pos : pos+2])
+		if program == 0 { // network PID
+			continue
+		}
+
+		p.pmtPID = binary.BigEndian.Uint16(s[pos+2:pos+4]) & 0x1fff
+
+		return
+	}
+}
+
+// parsePMT reads the elementary streams of the program.
+func (p *tsProbe) parsePMT(s []byte) {
+	if len(s) < 16 || s[0] != 0x02 {
+		return
+	}
+
+	infoLen := int(binary.BigEndian.Uint16(s[10:12]) & 0x0fff)
+	end := len(s) - 4 // CRC
+
+	for pos := 12 + infoLen; pos+5 <= end; {
+		streamType := s[pos]
+		pid := binary.BigEndian.Uint16(s[pos+1:pos+3]) & 0x1fff
+		esLen := int(binary.BigEndian.Uint16(s[pos+3:pos+5]) & 0x0fff)
+
+		pos += 5
+		if pos+esLen > end {
+			break
+		}
+
+		if st, ok := tsStreamFromPMT(streamType, s[pos:pos+esLen]); ok {
+			st.pid = pid
+			p.streams[pid] = st
+			p.order = append(p.order, pid)
+		}
+
+		pos += esLen
+	}
+
+	p.hasPMT = true
+}
+
+// tsStreamFromPMT maps a PMT stream type and its descriptors to a stream entry.
+func tsStreamFromPMT(streamType byte, desc []byte) (*tsStream, bool) {
+	var (
+		lang     string
+		private  string // codec derived from descriptors of private streams
+		subtitle bool
+		dovi     bool
+	)
+
+	for pos := 0; pos+2 <= len(desc); {
+		tag := desc[pos]
+		n := int(desc[pos+1])
+
+		pos += 2
+		if pos+n > len(desc) {
+			break
+		}
+
+		body := desc[pos : pos+n]
+		pos += n
+
+		switch tag {
+		case 0x0A: // ISO 639 language
+			if len(body) >= 3 && lang == "" {
+				lang = string(body[0:3])
+			}
+
+		case 0x59: // DVB subtitling
+			subtitle = true
+			private = "dvb_subtitle"
+
+			if len(body) >= 3 && lang == "" {
+				lang = string(body[0:3])
+			}
+
+		case 0x56: // DVB teletext
+			subtitle = true
+			private = "dvb_teletext"
+
+			if len(body) >= 3 && lang == "" {
+				lang = string(body[0:3])
+			}
+
+		case 0x6A:
+			private = "ac3"
+		case 0x7A:
+			private = "eac3"
+		case 0x7B:
+			private = "dts"
+		case 0xB0: // Dolby Vision video stream descriptor
+			dovi = true
+		case 0x05: // registration
+			if len(body) >= 4 {
+				switch string(body[0:4]) {
+				case "AC-3":
+					private = "ac3"
+				case "EAC3":
+					private = "eac3"
+				case "DTS1", "DTS2", "DTS3":
+					private = "dts"
+				case "HEVC":
+					private = "hevc"
+				}
+			}
+		}
+	}
+
+	kind, codec := tsStreamType(streamType)
+	if streamType == 0x06 && private != "" {
+		codec = private
+
+		switch {
+		case subtitle:
+			kind = "subtitle"
+		case private == "hevc":
+			kind = "video"
+		default:
+			kind = "audio"
+		}
+	}
+
+	if kind == "" {
+		return nil, false
+	}
+
+	st := &tsStream{stream: langStream(kind, codec, lang), isVideo: kind == "video"}
+	if dovi {
+		st.stream.addSideData(sideDataDOVI)
+	}
+
+	return st, true
+}
+
+// tsStreamType maps an ISO 13818-1 / Blu-ray stream type to codec type and name.
+func tsStreamType(t byte) (string, string) {
+	switch t {
+	case 0x01, 0x02:
+		return "video", "mpeg2"
+	case 0x10:
+		return "video", "mpeg4"
+	case 0x1B:
+		return "video", "h264"
+	case 0x24:
+		return "video", "hevc"
+	case 0xEA:
+		return "video", "vc1"
+	case 0x03, 0x04:
+		return "audio", "mp2"
+	case 0x0F, 0x11:
+		return "audio", "aac"
+	case 0x80:
+		return "audio", "pcm_bluray"
+	case 0x81:
+		return "audio", "ac3"
+	case 0x82, 0x85, 0x86, 0xA2:
+		return "audio", "dts"
+	case 0x83:
+		return "audio", "truehd"
+	case 0x84, 0x87, 0xA1:
+		return "audio", "eac3"
+	case 0x90:
+		return "subtitle", "hdmv_pgs_subtitle"
+	case 0x92:
+		return "subtitle", "hdmv_text_subtitle"
+	}
+
+	return "", ""
+}
+
+// tsVideoESLimit caps the elementary stream bytes collected per video stream
+// while waiting for the parameter sets.
+const tsVideoESLimit = 2 << 20
+
+// pes handles the payload of an elementary stream packet: the first PTS is
+// taken for the duration and video payload is scanned for codec headers.
+func (p *tsProbe) pes(st *tsStream, start bool, payload []byte) {
+	if start {
+		pts, data := pesPTS(payload)
+		if pts >= 0 && (p.firstPTS < 0 || (st.isVideo && !p.streams[p.ptsPID].isVideo)) {
+			p.firstPTS = pts
+			p.ptsPID = st.pid
+		}
+
+		payload = data
+	}
+
+	if !st.isVideo || st.done || payload == nil {
+		return
+	}
+
+	st.es = append(st.es, payload...)
+	if st.next == 0 {
+		st.next = 256 << 10
+	}
+
+	if len(st.es) < st.next && len(st.es) < tsVideoESLimit {
+		return
+	}
+
+	// The SEI messages follow the parameter sets within the first frames, so
+	// the headers are parsed from a growing buffer until the resolution is known.
+	st.headers = videoHeaders{}
+	st.headers.parseAnnexB(st.stream.CodecName, st.es)
+	st.next *= 2
+
+	if st.headers.complete() || len(st.es) >= tsVideoESLimit {
+		st.done = true
+		st.es = nil
+	}
+}
+
+// pesPTS returns the presentation timestamp of a PES packet start and the
+// payload following the PES header. The PTS is -1 if absent.
+func pesPTS(b []byte) (int64, []byte) {
+	if len(b) < 9 || b[0] != 0 || b[1] != 0 || b[2] != 1 {
+		return -1, nil
+	}
+
+	dataStart := 9 + int(b[8])
+	if dataStart > len(b) {
+		return -1, nil
+	}
+
+	if b[7]&0x80 == 0 || len(b) < 14 {
+		return -1, b[dataStart:]
+	}
+
+	pts := int64(b[9]>>1&0x07)<<30 |
+		int64(b[10])<<22 |
+		int64(b[11]>>1)<<15 |
+		int64(b[12])<<7 |
+		int64(b[13]>>1)
+
+	return pts, b[dataStart:]
+}
+
+// tsLastPTS returns the highest PTS of the given PID found in the tail of the
+// file, -1 if none was found.
+func tsLastPTS(f *os.File, packetSize int, pid uint16) int64 {
+	info, err := f.Stat()
+	if err != nil {
+		return -1
+	}
+
+	offset := max(info.Size()-tsProbeSize, 0)
+	offset -= offset % int64(packetSize)
+
+	tail := make([]byte, info.Size()-offset)
+
+	n, err := f.ReadAt(tail, offset)
+	if err != nil && n == 0 {
+		return -1
+	}
+
+	tail = tail[:n]
+
+	last := int64(-1)
+
+	for off := packetSize - 188; off+188 <= len(tail); off += packetSize {
+		pkt := tail[off : off+188]
+		if pkt[0] != 0x47 {
+			continue
+		}
+
+		ppid, start, payload := tsPayload(pkt)
+		if ppid != pid || !start || payload == nil {
+			continue
+		}
+
+		// Video with B-frames is stored in decode order, so take the highest PTS.
+		if pts, _ := pesPTS(payload); pts > last {
+			last = pts
+		}
+	}
+
+	return last
+}
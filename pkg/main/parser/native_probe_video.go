@@ -0,0 +1,736 @@
+package parser
+
+import (
+	"encoding/binary"
+)
+
+// ---------------------------------------------------------------------------
+// Video elementary stream headers (H.264 / HEVC / MPEG-2)
+// ---------------------------------------------------------------------------
+
+// Containers without a reliable resolution in their headers (MPEG-TS, FLV) and
+// the HDR signaling of all containers need the codec headers of the video
+// stream: the sequence parameter set (resolution, VUI colour description) and
+// SEI messages (mastering display, HDR10+). Dolby Vision is signaled by its
+// RPU NAL units or container level configuration records.
+
+// videoHeaders collects what was read from the codec headers of a video stream.
+type videoHeaders struct {
+	sideData  []string
+	width     int
+	height    int
+	primaries uint8
+	transfer  uint8
+}
+
+// apply copies the collected values into st. Container values win for the
+// resolution; the colour description is only known from the bitstream.
+func (v *videoHeaders) apply(st *ffProbeStream) {
+	if st.Width == 0 || st.Height == 0 {
+		st.Width = v.width
+		st.Height = v.height
+	}
+
+	if name := colorPrimariesName(v.primaries); name != "" {
+		st.ColorPrimaries = name
+	}
+
+	if name := colorTransferName(v.transfer); name != "" {
+		st.ColorTransfer = name
+	}
+
+	for _, typ := range v.sideData {
+		st.addSideData(typ)
+	}
+}
+
+// addSideData records side data of the given type once.
+func (v *videoHeaders) addSideData(typ string) {
+	for _, t := range v.sideData {
+		if t == typ {
+			return
+		}
+	}
+
+	v.sideData = append(v.sideData, typ)
+}
+
+// complete reports whether the resolution is known. SEI messages usually
+// follow the parameter sets, so callers keep feeding NAL units for a while.
+func (v *videoHeaders) complete() bool {
+	return v.width > 0 && v.height > 0
+}
+
+// colorPrimariesName maps ITU-T H.273 colour primaries to the ffprobe name.
+func colorPrimariesName(v uint8) string {
+	switch v {
+	case 1:
+		return "bt709"
+	case 5:
+		return "bt470bg"
+	case 6:
+		return "smpte170m"
+	case 9:
+		return "bt2020"
+	case 12:
+		return "smpte432"
+	}
+
+	return ""
+}
+
+// colorTransferName maps ITU-T H.273 transfer characteristics to the ffprobe name.
+func colorTransferName(v uint8) string {
+	switch v {
+	case 1:
+		return "bt709"
+	case 6:
+		return "smpte170m"
+	case 14:
+		return "bt2020-10"
+	case 15:
+		return "bt2020-12"
+	case 16:
+		return "smpte2084"
+	case 18:
+		return "arib-std-b67"
+	}
+
+	return ""
+}
+
+// bitReader reads big-endian bit fields and Exp-Golomb codes. Reads past the
+// end return zero and set the overrun flag.
+type bitReader struct {
+	data    []byte
+	pos     int
+	overrun bool
+}
+
+func (r *bitReader) u(n int) uint32 {
+	var v uint32
+	for range n {
+		if r.pos >= len(r.data)*8 {
+			r.overrun = true
+			return v
+		}
+
+		bit := (r.data[r.pos/8] >> (7 - r.pos%8)) & 1
+		v = v<<1 | uint32(bit)
+		r.pos++
+	}
+
+	return v
+}
+
+func (r *bitReader) flag() bool {
+	return r.u(1) == 1
+}
+
+func (r *bitReader) skip(n int) {
+	r.pos += n
+	if r.pos > len(r.data)*8 {
+		r.overrun = true
+	}
+}
+
+// ue reads an unsigned Exp-Golomb code.
+func (r *bitReader) ue() uint32 {
+	zeros := 0
+	for !r.flag() {
+		if r.overrun || zeros > 31 {
+			r.overrun = true
+			return 0
+		}
+
+		zeros++
+	}
+
+	return (1<<zeros - 1) + r.u(zeros)
+}
+
+// se reads a signed Exp-Golomb code.
+func (r *bitReader) se() int32 {
+	v := r.ue()
+	if v&1 == 1 {
+		return int32((v + 1) / 2)
+	}
+
+	return -int32(v / 2)
+}
+
+// unescapeRBSP removes the emulation prevention bytes (00 00 03) of a NAL unit.
+func unescapeRBSP(nal []byte) []byte {
+	out := make([]byte, 0, len(nal))
+
+	zeros := 0
+	for _, b := range nal {
+		if zeros >= 2 && b == 3 {
+			zeros = 0
+			continue
+		}
+
+		if b == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+
+		out = append(out, b)
+	}
+
+	return out
+}
+
+// splitAnnexB splits an Annex B byte stream into NAL units (without start codes).
+func splitAnnexB(data []byte, fn func(nal []byte)) {
+	start := -1
+
+	for i := 0; i+2 < len(data); i++ {
+		if data[i] != 0 || data[i+1] != 0 || data[i+2] != 1 {
+			continue
+		}
+
+		if start >= 0 {
+			end := i
+			if end > start && data[end-1] == 0 {
+				end-- // 4 byte start code
+			}
+
+			fn(data[start:end])
+		}
+
+		start = i + 3
+		i += 2
+	}
+
+	if start >= 0 && start < len(data) {
+		fn(data[start:])
+	}
+}
+
+// parseNAL reads a NAL unit (with its header) of an H.264 or HEVC stream.
+func (v *videoHeaders) parseNAL(codec string, nal []byte) {
+	switch codec {
+	case "h264":
+		if len(nal) < 2 {
+			return
+		}
+
+		switch nal[0] & 0x1f {
+		case 7:
+			v.parseH264SPS(unescapeRBSP(nal[1:]))
+		case 6:
+			v.parseSEI(unescapeRBSP(nal[1:]))
+		}
+
+	case "hevc":
+		if len(nal) < 3 {
+			return
+		}
+
+		switch (nal[0] >> 1) & 0x3f {
+		case 33:
+			v.parseHEVCSPS(unescapeRBSP(nal[2:]))
+		case 39, 40:
+			v.parseSEI(unescapeRBSP(nal[2:]))
+		case 62, 63:
+			// Dolby Vision RPU and enhancement layer
+			v.addSideData(sideDataDOVI)
+		}
+	}
+}
+
+// parseAnnexB reads the NAL units of an Annex B byte stream.
+func (v *videoHeaders) parseAnnexB(codec string, data []byte) {
+	if codec == "mpeg2" {
+		v.parseMPEG2(data)
+		return
+	}
+
+	splitAnnexB(data, func(nal []byte) {
+		v.parseNAL(codec, nal)
+	})
+}
+
+// parseMPEG2 reads the resolution from an MPEG-1/2 sequence header.
+func (v *videoHeaders) parseMPEG2(data []byte) {
+	for i := 0; i+7 < len(data); i++ {
+		if data[i] == 0 && data[i+1] == 0 && data[i+2] == 1 && data[i+3] == 0xB3 {
+			v.width = int(data[i+4])<<4 | int(data[i+5]>>4)
+			v.height = int(data[i+5]&0x0f)<<8 | int(data[i+6])
+
+			return
+		}
+	}
+}
+
+// parseH264SPS reads the resolution and colour description of an H.264
+// sequence parameter set (RBSP without the NAL header).
+func (v *videoHeaders) parseH264SPS(rbsp []byte) {
+	r := &bitReader{data: rbsp}
+
+	profile := r.u(8)
+	r.skip(16) // constraint flags, level
+	r.ue()     // seq_parameter_set_id
+
+	chromaFormat := uint32(1)
+
+	switch profile {
+	case 100, 110, 122, 244, 44, 83, 86, 118, 128, 138, 139, 134, 135:
+		chromaFormat = r.ue()
+		if chromaFormat == 3 {
+			r.skip(1) // separate_colour_plane_flag
+		}
+
+		r.ue()        // bit_depth_luma_minus8
+		r.ue()        // bit_depth_chroma_minus8
+		r.skip(1)     // qpprime_y_zero_transform_bypass_flag
+		if r.flag() { // seq_scaling_matrix_present_flag
+			lists := 8
+			if chromaFormat == 3 {
+				lists = 12
+			}
+
+			for i := range lists {
+				if !r.flag() {
+					continue
+				}
+
+				size := 16
+				if i >= 6 {
+					size = 64
+				}
+
+				skipScalingList(r, size)
+			}
+		}
+	}
+
+	r.ue() // log2_max_frame_num_minus4
+
+	switch r.ue() { // pic_order_cnt_type
+	case 0:
+		r.ue() // log2_max_pic_order_cnt_lsb_minus4
+	case 1:
+		r.skip(1) // delta_pic_order_always_zero_flag
+		r.se()    // offset_for_non_ref_pic
+		r.se()    // offset_for_top_to_bottom_field
+
+		cycle := r.ue()
+		for i := uint32(0); i < cycle && !r.overrun; i++ {
+			r.se()
+		}
+	}
+
+	r.ue()    // max_num_ref_frames
+	r.skip(1) // gaps_in_frame_num_value_allowed_flag
+
+	widthMbs := r.ue() + 1
+	heightMapUnits := r.ue() + 1
+
+	frameMbsOnly := r.u(1)
+	if frameMbsOnly == 0 {
+		r.skip(1) // mb_adaptive_frame_field_flag
+	}
+
+	r.skip(1) // direct_8x8_inference_flag
+
+	var cropLeft, cropRight, cropTop, cropBottom uint32
+	if r.flag() {
+		cropLeft, cropRight, cropTop, cropBottom = r.ue(), r.ue(), r.ue(), r.ue()
+	}
+
+	if r.overrun {
+		return
+	}
+
+	cropX, cropY := uint32(1), 2-frameMbsOnly
+	switch chromaFormat {
+	case 1:
+		cropX, cropY = 2, 2*(2-frameMbsOnly)
+	case 2:
+		cropX = 2
+	}
+
+	width := int(widthMbs*16) - int(cropX*(cropLeft+cropRight))
+	height := int((2-frameMbsOnly)*heightMapUnits*16) - int(cropY*(cropTop+cropBottom))
+
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	v.width, v.height = width, height
+
+	if r.flag() { // vui_parameters_present_flag
+		v.parseVUIColour(r)
+	}
+}
+
+// skipScalingList skips an H.264 scaling list of the given size.
+func skipScalingList(r *bitReader, size int) {
+	last, next := int32(8), int32(8)
+	for range size {
+		if next != 0 {
+			next = (last + r.se() + 256) % 256
+		}
+
+		if next != 0 {
+			last = next
+		}
+	}
+}
+
+// parseVUIColour reads the colour description at the start of the VUI
+// parameters, which is identical for H.264 and HEVC.
+func (v *videoHeaders) parseVUIColour(r *bitReader) {
+	if r.flag() { // aspect_ratio_info_present_flag
+		if r.u(8) == 255 { // Extended_SAR
+			r.skip(32)
+		}
+	}
+
+	if r.flag() { // overscan_info_present_flag
+		r.skip(1)
+	}
+
+	if !r.flag() { // video_signal_type_present_flag
+		return
+	}
+
+	r.skip(4) // video_format, video_full_range_flag
+
+	if !r.flag() { // colour_description_present_flag
+		return
+	}
+
+	primaries := uint8(r.u(8))
+	transfer := uint8(r.u(8))
+
+	if !r.overrun {
+		v.primaries, v.transfer = primaries, transfer
+	}
+}
+
+// parseHEVCSPS reads the resolution and colour description of an HEVC
+// sequence parameter set (RBSP without the NAL header).
+func (v *videoHeaders) parseHEVCSPS(rbsp []byte) {
+	r := &bitReader{data: rbsp}
+
+	r.skip(4) // sps_video_parameter_set_id
+
+	maxSubLayers := int(r.u(3))
+	r.skip(1) // sps_temporal_id_nesting_flag
+
+	// profile_tier_level: general profile (88 bits) and level (8 bits)
+	r.skip(96)
+
+	subProfile := make([]bool, maxSubLayers)
+	subLevel := make([]bool, maxSubLayers)
+
+	for i := range maxSubLayers {
+		subProfile[i] = r.flag()
+		subLevel[i] = r.flag()
+	}
+
+	if maxSubLayers > 0 {
+		r.skip(2 * (8 - maxSubLayers)) // reserved_zero_2bits
+	}
+
+	for i := range maxSubLayers {
+		if subProfile[i] {
+			r.skip(88)
+		}
+
+		if subLevel[i] {
+			r.skip(8)
+		}
+	}
+
+	r.ue() // sps_seq_parameter_set_id
+
+	chromaFormat := r.ue()
+	if chromaFormat == 3 {
+		r.skip(1) // separate_colour_plane_flag
+	}
+
+	width := r.ue()
+	height := r.ue()
+
+	var cropLeft, cropRight, cropTop, cropBottom uint32
+	if r.flag() { // conformance_window_flag
+		cropLeft, cropRight, cropTop, cropBottom = r.ue(), r.ue(), r.ue(), r.ue()
+	}
+
+	if r.overrun {
+		return
+	}
+
+	subWidth, subHeight := uint32(1), uint32(1)
+	switch chromaFormat {
+	case 1:
+		subWidth, subHeight = 2, 2
+	case 2:
+		subWidth = 2
+	}
+
+	w := int(width) - int(subWidth*(cropLeft+cropRight))
+	h := int(height) - int(subHeight*(cropTop+cropBottom))
+
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	v.width, v.height = w, h
+
+	r.ue() // bit_depth_luma_minus8
+	r.ue() // bit_depth_chroma_minus8
+
+	pocBits := int(r.ue()) + 4 // log2_max_pic_order_cnt_lsb_minus4
+
+	first := maxSubLayers
+	if r.flag() { // sps_sub_layer_ordering_info_present_flag
+		first = 0
+	}
+
+	for i := first; i <= maxSubLayers && !r.overrun; i++ {
+		r.ue()
+		r.ue()
+		r.ue()
+	}
+
+	for range 6 { // coding block, transform block and hierarchy sizes
+		r.ue()
+	}
+
+	if r.flag() { // scaling_list_enabled_flag
+		if r.flag() { // sps_scaling_list_data_present_flag
+			skipHEVCScalingListData(r)
+		}
+	}
+
+	r.skip(2) // amp_enabled_flag, sample_adaptive_offset_enabled_flag
+
+	if r.flag() { // pcm_enabled_flag
+		r.skip(8)
+		r.ue()
+		r.ue()
+		r.skip(1)
+	}
+
+	if !skipHEVCShortTermRefPicSets(r, int(r.ue())) {
+		return
+	}
+
+	if r.flag() { // long_term_ref_pics_present_flag
+		count := r.ue()
+		for i := uint32(0); i < count && !r.overrun; i++ {
+			r.skip(pocBits + 1)
+		}
+	}
+
+	r.skip(2) // sps_temporal_mvp_enabled_flag, strong_intra_smoothing_enabled_flag
+
+	if r.flag() && !r.overrun { // vui_parameters_present_flag
+		v.parseVUIColour(r)
+	}
+}
+
+// skipHEVCScalingListData skips the scaling_list_data of an HEVC SPS.
+func skipHEVCScalingListData(r *bitReader) {
+	for sizeID := range 4 {
+		step := 1
+		if sizeID == 3 {
+			step = 3
+		}
+
+		for matrixID := 0; matrixID < 6; matrixID += step {
+			if !r.flag() { // scaling_list_pred_mode_flag
+				r.ue()
+				continue
+			}
+
+			coefs := min(64, 1<<(4+(sizeID<<1)))
+			if sizeID > 1 {
+				r.se()
+			}
+
+			for range coefs {
+				r.se()
+			}
+		}
+	}
+}
+
+// skipHEVCShortTermRefPicSets skips the st_ref_pic_set structures of an HEVC
+// SPS. It returns false for streams it cannot follow.
+func skipHEVCShortTermRefPicSets(r *bitReader, count int) bool {
+	if count > 64 {
+		return false
+	}
+
+	deltas := make([]int, count)
+
+	for idx := range count {
+		if idx != 0 && r.flag() { // inter_ref_pic_set_prediction_flag
+			r.skip(1) // delta_rps_sign
+			r.ue()    // abs_delta_rps_minus1
+
+			n := 0
+			for j := 0; j <= deltas[idx-1]; j++ {
+				if r.flag() || r.flag() { // used_by_curr_pic_flag, use_delta_flag
+					n++
+				}
+			}
+
+			deltas[idx] = n
+
+			continue
+		}
+
+		negative := r.ue()
+		positive := r.ue()
+
+		if negative > 16 || positive > 16 {
+			return false
+		}
+
+		for range negative + positive {
+			r.ue()
+			r.skip(1)
+		}
+
+		deltas[idx] = int(negative + positive)
+	}
+
+	return !r.overrun
+}
+
+// parseSEI reads the SEI messages of an H.264 or HEVC SEI NAL unit for HDR
+// metadata.
+func (v *videoHeaders) parseSEI(rbsp []byte) {
+	for pos := 0; pos < len(rbsp) && rbsp[pos] != 0x80; {
+		payloadType := 0
+		for pos < len(rbsp) && rbsp[pos] == 0xff {
+			payloadType += 255
+			pos++
+		}
+
+		if pos >= len(rbsp) {
+			return
+		}
+
+		payloadType += int(rbsp[pos])
+		pos++
+
+		size := 0
+		for pos < len(rbsp) && rbsp[pos] == 0xff {
+			size += 255
+			pos++
+		}
+
+		if pos >= len(rbsp) {
+			return
+		}
+
+		size += int(rbsp[pos])
+		pos++
+
+		if pos+size > len(rbsp) {
+			return
+		}
+
+		payload := rbsp[pos : pos+size]
+		pos += size
+
+		switch payloadType {
+		case 137:
+			v.addSideData(sideDataMastering)
+		case 144:
+			v.addSideData(sideDataContentLite)
+		case 4:
+			if isHDR10PlusT35(payload) {
+				v.addSideData(sideDataHDR10Plus)
+			}
+		}
+	}
+}
+
+// isHDR10PlusT35 reports whether an ITU-T T.35 payload carries SMPTE ST
+// 2094-40 (HDR10+) dynamic metadata.
+func isHDR10PlusT35(p []byte) bool {
+	return len(p) >= 6 && p[0] == 0xB5 &&
+		binary.BigEndian.Uint16(p[1:3]) == 0x003C &&
+		binary.BigEndian.Uint16(p[3:5]) == 0x0001 &&
+		p[5] == 4
+}
+
+// parseAVCC reads the parameter sets of an AVCDecoderConfigurationRecord.
+func (v *videoHeaders) parseAVCC(b []byte) {
+	if len(b) < 6 {
+		return
+	}
+
+	pos := 5
+
+	for _, mask := range []byte{0x1f, 0xff} { // SPS, then PPS
+		if pos >= len(b) {
+			return
+		}
+
+		count := int(b[pos] & mask)
+		pos++
+
+		for range count {
+			if pos+2 > len(b) {
+				return
+			}
+
+			n := int(binary.BigEndian.Uint16(b[pos : pos+2]))
+			pos += 2
+
+			if pos+n > len(b) {
+				return
+			}
+
+			v.parseNAL("h264", b[pos:pos+n])
+			pos += n
+		}
+	}
+}
+
+// parseHVCC reads the parameter sets and SEI messages of an
+// HEVCDecoderConfigurationRecord.
+func (v *videoHeaders) parseHVCC(b []byte) {
+	if len(b) < 23 {
+		return
+	}
+
+	arrays := int(b[22])
+	pos := 23
+
+	for range arrays {
+		if pos+3 > len(b) {
+			return
+		}
+
+		count := int(binary.BigEndian.Uint16(b[pos+1 : pos+3]))
+		pos += 3
+
+		for range count {
+			if pos+2 > len(b) {
+				return
+			}
+
+			n := int(binary.BigEndian.Uint16(b[pos : pos+2]))
+			pos += 2
+
+			if pos+n > len(b) {
+				return
+			}
+
+			v.parseNAL("hevc", b[pos:pos+n])
+			pos += n
+		}
+	}
+}
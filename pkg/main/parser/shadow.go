@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
+)
+
+// Shadow mode runs the legacy parser next to parser_v2 on the same input and
+// records the fields on which they disagree. parser_v2 is authoritative, the
+// legacy parser only runs in the shadow. The counters cover the parses since
+// the start of the process and give the divergence rate shown on the admin
+// page.
+var (
+	shadowParses    atomic.Int64
+	shadowDivergent atomic.Int64
+
+	// shadowSink queues the divergences for the writer goroutine, so parses
+	// do not wait for the database. Divergences are dropped while it is full.
+	shadowSink     = make(chan shadowDivergence, 256)
+	shadowSinkOnce sync.Once
+)
+
+const (
+	// shadowMaxDivergences is the number of most recently seen divergences
+	// kept in the database.
+	shadowMaxDivergences = 10000
+
+	// shadowTrimInterval is the number of written divergences after which the
+	// older ones beyond shadowMaxDivergences are removed.
+	shadowTrimInterval = 100
+)
+
+// shadowDivergence is a divergence waiting to be written.
+type shadowDivergence struct {
+	mediaType, input, field, legacyValue, v2Value string
+}
+
+// shadowField is a compared field of the parse result.
+type shadowField struct {
+	name  string
+	value func(m *database.ParseInfo) string
+}
+
+// shadowFields are the fields compared in shadow mode. Values are normalized
+// so formatting differences (case, IMDB prefix) are not reported.
+var shadowFields = []shadowField{
+	{"title", func(m *database.ParseInfo) string { return strings.ToLower(logger.TrimSpace(m.Title)) }},
+	{"imdb", func(m *database.ParseInfo) string { return normalizeShadowID(m.Imdb, "tt") }},
+	{"tvdb", func(m *database.ParseInfo) string { return normalizeShadowID(m.Tvdb, logger.StrTvdb) }},
+	{"season", func(m *database.ParseInfo) string { return strconv.Itoa(m.Season) }},
+	{"episode", func(m *database.ParseInfo) string { return strconv.Itoa(m.Episode) }},
+	{"identifier", func(m *database.ParseInfo) string { return strings.ToLower(m.Identifier) }},
+	{"quality", func(m *database.ParseInfo) string { return strings.ToLower(m.Quality) }},
+	{"resolution", func(m *database.ParseInfo) string { return strings.ToLower(m.Resolution) }},
+}
+
+// Parses of parser_v2.ParseFileP and ParseReleaseP are shadowed by the
+// legacy parser.
+func init() {
+	parser_v2.SetShadowHook(func(
+		videofile, indexer string,
+		usepath, usefolder bool,
+		cfgp *config.MediaTypeConfig,
+		listid int,
+		m *database.ParseInfo,
+	) {
+		if config.GetSettingsGeneral().ParserShadowMode && isShadowType(cfgp) {
+			shadowParse(videofile, indexer, usepath, usefolder, cfgp, listid, m)
+		}
+	})
+}
+
+// ShadowStats returns the number of parses compared in shadow mode since the
+// start and how many of them diverged.
+func ShadowStats() (parses, divergent int64) {
+	return shadowParses.Load(), shadowDivergent.Load()
+}
+
+// isShadowType reports whether the media type is parsed by both parsers.
+// Books, audiobooks and music have their own parsers.
+func isShadowType(cfgp *config.MediaTypeConfig) bool {
+	return cfgp == nil || cfgp.IsType == config.MediaTypeMovie ||
+		cfgp.IsType == config.MediaTypeSeries
+}
+
+// shadowParse parses the input with the legacy parser and records every
+// compared field whose value differs from the v2 result in m.
+func shadowParse(
+	videofile, indexer string,
+	usepath, usefolder bool,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	m *database.ParseInfo,
+) {
+	legacy := database.PLParseInfo.Get()
+	defer database.PLParseInfo.Put(legacy)
+
+	parseFileLegacy(
+		shadowLegacyInput(videofile, indexer, usepath, cfgp, listid),
+		usepath, usefolder, cfgp, listid, legacy,
+	)
+
+	var mediaType string
+	switch {
+	case cfgp == nil:
+	case cfgp.IsType == config.MediaTypeSeries:
+		mediaType = "series"
+	default:
+		mediaType = "movie"
+	}
+
+	diverged := false
+
+	for i := range shadowFields {
+		legacyValue := shadowFields[i].value(legacy)
+
+		v2Value := shadowFields[i].value(m)
+		if legacyValue == v2Value {
+			continue
+		}
+
+		diverged = true
+
+		queueShadowDivergence(shadowDivergence{
+			mediaType:   mediaType,
+			input:       videofile,
+			field:       shadowFields[i].name,
+			legacyValue: legacyValue,
+			v2Value:     v2Value,
+		})
+	}
+
+	shadowParses.Add(1)
+
+	if diverged {
+		shadowDivergent.Add(1)
+		logger.Logtype("debug", 1).
+			Str(logger.StrFile, videofile).
+			Msg("parser divergence recorded")
+	}
+}
+
+// shadowLegacyInput applies the parser rewrite rules to the input like
+// parser_v2 does, to the file and folder name if usepath is set.
+func shadowLegacyInput(
+	videofile, indexer string,
+	usepath bool,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+) string {
+	if !usepath {
+		rewritten, _ := parser_v2.RewriteName(videofile, cfgp, listid, indexer)
+		return rewritten
+	}
+
+	folder := filepath.Dir(videofile)
+	file, _ := parser_v2.RewriteName(filepath.Base(videofile), cfgp, listid, indexer)
+	foldername, _ := parser_v2.RewriteName(filepath.Base(folder), cfgp, listid, indexer)
+
+	return filepath.Join(filepath.Dir(folder), foldername, file)
+}
+
+// queueShadowDivergence hands the divergence to the writer goroutine, which
+// is started with the first divergence. The writer keeps only the
+// shadowMaxDivergences most recently seen divergences.
+func queueShadowDivergence(d shadowDivergence) {
+	shadowSinkOnce.Do(func() {
+		go func() {
+			var written int
+			for d := range shadowSink {
+				database.AddParserDivergence(d.mediaType, d.input, d.field, d.legacyValue, d.v2Value)
+
+				if written++; written%shadowTrimInterval == 0 {
+					database.TrimParserDivergences(shadowMaxDivergences)
+				}
+			}
+		}()
+	})
+
+	select {
+	case shadowSink <- d:
+	default:
+		logger.Logtype("debug", 1).
+			Str(logger.StrFile, d.input).
+			Msg("parser divergence dropped - queue full")
+	}
+}
+
+// normalizeShadowID strips the prefix and leading zeros of an IMDB or TVDB ID.
+func normalizeShadowID(id, prefix string) string {
+	id = logger.TrimSpace(id)
+	if len(id) >= len(prefix) && strings.EqualFold(id[:len(prefix)], prefix) {
+		id = id[len(prefix):]
+	}
+
+	return strings.TrimLeft(id, "0")
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+)
+
+func TestNormalizeShadowID(t *testing.T) {
+	tests := []struct {
+		id, prefix, expected string
+	}{
+		{"tt0133093", "tt", "133093"},
+		{"0133093", "tt", "133093"},
+		{"TT133093", "tt", "133093"},
+		{"tvdb81189", "tvdb", "81189"},
+		{"81189", "tvdb", "81189"},
+		{"", "tt", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeShadowID(tt.id, tt.prefix); got != tt.expected {
+			t.Errorf("normalizeShadowID(%q, %q) = %q, want %q", tt.id, tt.prefix, got, tt.expected)
+		}
+	}
+}
+
+func TestShadowFieldsIgnoreFormatting(t *testing.T) {
+	legacy := database.ParseInfo{
+		Title:      "The Matrix ",
+		Imdb:       "tt0133093",
+		Quality:    "BluRay",
+		Resolution: "1080p",
+	}
+	v2 := database.ParseInfo{
+		Title:      "the matrix",
+		Imdb:       "133093",
+		Quality:    "bluray",
+		Resolution: "1080P",
+	}
+
+	for i := range shadowFields {
+		if a, b := shadowFields[i].value(&legacy), shadowFields[i].value(&v2); a != b {
+			t.Errorf("field %s: %q != %q", shadowFields[i].name, a, b)
+		}
+	}
+
+	v2.Season = 1
+	if shadowFields[3].value(&legacy) == shadowFields[3].value(&v2) {
+		t.Error("season divergence not detected")
+	}
+}
+
+func TestIsShadowType(t *testing.T) {
+	if !isShadowType(nil) {
+		t.Error("nil config should be compared")
+	}
+
+	if !isShadowType(&config.MediaTypeConfig{IsType: config.MediaTypeSeries}) {
+		t.Error("series should be compared")
+	}
+
+	if isShadowType(&config.MediaTypeConfig{IsType: config.MediaTypeMusic}) {
+		t.Error("music should not be compared")
+	}
+}
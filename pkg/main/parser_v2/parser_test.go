@@ -1432,3 +1432,78 @@ func TestAudiobookParser_Parse_NZBTitles(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSeasonPack(t *testing.T) {
+	tests := []struct {
+		name   string
+		title  string
+		season int
+		ok     bool
+	}{
+		{name: "Scene season pack", title: "Show.Name.S01.1080p.WEB-DL.x264-GRP", season: 1, ok: true},
+		{name: "Season word", title: "Show Name Season 2 Complete 720p", season: 2, ok: true},
+		{name: "Single episode", title: "Show.Name.S01E03.1080p.WEB"},
+		{name: "Multi season", title: "Show.Name.S01-S03.1080p"},
+		{name: "Date episode", title: "Show.Name.2024.05.12.720p"},
+		{name: "No season", title: "Movie.Name.2020.1080p.BluRay"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, season, ok := ParseSeasonPack(tt.title)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if title != "Show Name" {
+				t.Errorf("Title = %q, want %q", title, "Show Name")
+			}
+
+			if season != tt.season {
+				t.Errorf("Season = %d, want %d", season, tt.season)
+			}
+		})
+	}
+}
+
+func TestApplySeasonPackFolder(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		ident string
+		tvdb  string
+		ok    bool
+	}{
+		{name: "Leading number", file: "/dl/Show.Name.S02.1080p.WEB-GRP/03 - Pilot.mkv", ident: "S02E03", ok: true},
+		{name: "Episode only", file: "/dl/Show Name Season 1/E07 Something.mkv", ident: "S01E07", ok: true},
+		{name: "No pack folder", file: "/dl/Show.Name.1080p/03 - Pilot.mkv"},
+		{name: "Download folder", file: "/dl/Show.Name.S03.720p-GRP (tvdb1234)/Show.Name.E05.720p.mkv", ident: "S03E05", tvdb: "1234", ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ParseFile(tt.file, true, true, nil, -1)
+			defer m.Close()
+
+			if ok := ApplySeasonPackFolder(tt.file, m); ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			if tt.ok && m.Identifier != tt.ident {
+				t.Errorf("Identifier = %q, want %q", m.Identifier, tt.ident)
+			}
+
+			if tt.ok && m.Title != "Show Name" {
+				t.Errorf("Title = %q, want %q", m.Title, "Show Name")
+			}
+
+			if tt.tvdb != "" && m.Tvdb != tt.tvdb {
+				t.Errorf("Tvdb = %q, want %q", m.Tvdb, tt.tvdb)
+			}
+		})
+	}
+}
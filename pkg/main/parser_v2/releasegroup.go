@@ -0,0 +1,90 @@
+package parser_v2
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// releaseGroupTags are quality tags ending in -x that are no release group
+// (WEB-DL, BD-RIP, DTS-HD, DTS-HD.MA).
+var releaseGroupTags = []string{"dl", "rip", "hd", "ma", "sd", "hr"}
+
+// ReleaseName strips the file extension from a file or folder name.
+// Extensions are 2-4 letters or digits, so "x264-GROUP" stays intact.
+func ReleaseName(name string) string {
+	name = strings.TrimSpace(name)
+
+	ext := filepath.Ext(name)
+	if len(ext) < 3 || len(ext) > 5 {
+		return name
+	}
+
+	for _, r := range ext[1:] {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return name
+		}
+	}
+
+	return name[:len(name)-len(ext)]
+}
+
+// ReleaseGroup returns the release group of a release name, "" if it has
+// none. Scene names end with -GROUP ("Title.2024.1080p.WEB-DL.x264-GROUP"),
+// anime names start with [Group]. Trailing tags like [eztv] are ignored.
+func ReleaseGroup(name string) string {
+	name = ReleaseName(name)
+
+	for strings.HasSuffix(name, "]") {
+		idx := strings.LastIndexByte(name, '[')
+		if idx <= 0 {
+			break
+		}
+
+		name = strings.TrimSpace(name[:idx])
+	}
+
+	if idx := strings.LastIndexByte(name, '-'); idx != -1 && isSceneGroup(name[idx+1:]) {
+		return name[idx+1:]
+	}
+
+	if strings.HasPrefix(name, "[") {
+		if end := strings.IndexByte(name, ']'); end > 1 && end <= 41 {
+			return strings.TrimSpace(name[1:end])
+		}
+	}
+
+	return ""
+}
+
+// isSceneGroup checks the part after the last dash of a scene name - groups
+// are letters and digits only, not just a number and no quality tag.
+func isSceneGroup(group string) bool {
+	if len(group) < 2 || len(group) > 30 {
+		return false
+	}
+
+	var letter bool
+
+	for _, r := range group {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+		default:
+			return false
+		}
+	}
+
+	if !letter {
+		return false
+	}
+
+	for _, tag := range releaseGroupTags {
+		if strings.EqualFold(group, tag) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,54 @@
+package parser_v2
+
+import "testing"
+
+func TestReleaseGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		expected string
+	}{
+		{
+			name:     "Scene name with extension",
+			str:      "Some.Movie.2024.1080p.BluRay.x264-GROUP.mkv",
+			expected: "GROUP",
+		},
+		{
+			name:     "Scene name with trailing site tag",
+			str:      "Some.Show.S01E01.720p.HDTV.x264-KILLERS[eztv].mkv",
+			expected: "KILLERS",
+		},
+		{
+			name:     "Quality tag is no group",
+			str:      "Some.Movie.2024.1080p.WEB-DL",
+			expected: "",
+		},
+		{
+			name:     "Number is no group",
+			str:      "Some.Movie.1999-2001.1080p",
+			expected: "",
+		},
+		{
+			name:     "Anime group prefix",
+			str:      "[SubsPlease] Some Show - 01 (1080p) [ABCD1234].mkv",
+			expected: "SubsPlease",
+		},
+		{
+			name:     "No group",
+			str:      "Some Movie (2024).mkv",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReleaseGroup(tt.str); got != tt.expected {
+				t.Errorf("ReleaseGroup(%q) = %q, want %q", tt.str, got, tt.expected)
+			}
+		})
+	}
+
+	if got := ReleaseName("Some.Movie.2024.1080p.BluRay.x264-GROUP.mkv"); got != "Some.Movie.2024.1080p.BluRay.x264-GROUP" {
+		t.Errorf("ReleaseName = %q", got)
+	}
+}
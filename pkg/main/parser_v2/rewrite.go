@@ -0,0 +1,84 @@
+package parser_v2
+
+import (
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+// RewriteStep is a parser rewrite rule which changed a name.
+type RewriteStep struct {
+	Rule   string `json:"rule"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// RewriteName applies the configured parser rewrite rules to a release title
+// or file name of the media config, in their configured order. The indexer
+// is the name of the indexer the release was found on, empty for files.
+// It returns the rewritten name and the rules which changed it.
+func RewriteName(
+	name string,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	indexer string,
+) (string, []RewriteStep) {
+	general := config.GetSettingsGeneral()
+	if general == nil || len(general.ParserRewriteRulesCompiled) == 0 {
+		return name, nil
+	}
+
+	var mediaType, list string
+	if cfgp != nil {
+		switch cfgp.IsType {
+		case config.MediaTypeMovie:
+			mediaType = logger.StrMovie
+		case config.MediaTypeSeries:
+			mediaType = logger.StrSeries
+		}
+
+		if listid >= 0 && listid < len(cfgp.Lists) {
+			list = cfgp.Lists[listid].Name
+		}
+	}
+
+	rewritten, steps := applyRewriteRules(
+		general.ParserRewriteRulesCompiled,
+		name,
+		mediaType,
+		indexer,
+		list,
+	)
+	for idx := range steps {
+		logger.Logtype(logger.StatusDebug, 1).
+			Str("rule", steps[idx].Rule).
+			Str("before", steps[idx].Before).
+			Str("after", steps[idx].After).
+			Msg("Parser rewrite rule applied")
+	}
+
+	return rewritten, steps
+}
+
+// applyRewriteRules runs the rules matching the scope on the name one after
+// the other and records the ones which changed it.
+func applyRewriteRules(
+	rules []config.ParserRewriteRule,
+	name, mediaType, indexer, list string,
+) (string, []RewriteStep) {
+	var steps []RewriteStep
+	for idx := range rules {
+		if rules[idx].Regex == nil || !rules[idx].Matches(mediaType, indexer, list) {
+			continue
+		}
+
+		after := rules[idx].Regex.ReplaceAllString(name, rules[idx].Replacement)
+		if after == name {
+			continue
+		}
+
+		steps = append(steps, RewriteStep{Rule: rules[idx].Rule, Before: name, After: after})
+		name = after
+	}
+
+	return name, steps
+}
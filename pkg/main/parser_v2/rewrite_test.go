@@ -0,0 +1,127 @@
+package parser_v2
+
+import (
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+)
+
+func compileRewriteRules(t *testing.T, rules ...string) []config.ParserRewriteRule {
+	t.Helper()
+
+	compiled := make([]config.ParserRewriteRule, 0, len(rules))
+	for _, rule := range rules {
+		r, err := config.ParseRewriteRule(rule)
+		if err != nil {
+			t.Fatalf("ParseRewriteRule(%q) error: %v", rule, err)
+		}
+
+		compiled = append(compiled, r)
+	}
+
+	return compiled
+}
+
+func TestParseRewriteRuleInvalid(t *testing.T) {
+	tests := []string{
+		"no separator",
+		" => replacement",
+		"{series (a) => b",
+		"{music} (a) => b",
+		"{indexer=} (a) => b",
+		"(unclosed => b",
+	}
+
+	for _, rule := range tests {
+		if _, err := config.ParseRewriteRule(rule); err == nil {
+			t.Errorf("ParseRewriteRule(%q) expected an error", rule)
+		}
+	}
+}
+
+func TestApplyRewriteRules(t *testing.T) {
+	rules := compileRewriteRules(t,
+		`{series} (?i)\.(\d{1,2})x(\d{2})\. => .S${1}E${2}.`,
+		`^\[[^\]]+\][ ._-]* => `,
+		`{movie, indexer=nzbgeek} (?i)^Der\.Film\. => The.Movie.`,
+		`{list=anime} (?i)\.Folge\.(\d+)\. => .E${1}.`,
+	)
+
+	tests := []struct {
+		name      string
+		input     string
+		mediaType string
+		indexer   string
+		list      string
+		expected  string
+		steps     int
+	}{
+		{
+			name:      "Season x episode for series",
+			input:     "Show.Name.2x05.720p.HDTV",
+			mediaType: "series",
+			expected:  "Show.Name.S2E05.720p.HDTV",
+			steps:     1,
+		},
+		{
+			name:      "Season x episode not for movies",
+			input:     "Show.Name.2x05.720p.HDTV",
+			mediaType: "movie",
+			expected:  "Show.Name.2x05.720p.HDTV",
+		},
+		{
+			name:      "Fansub prefix for every type",
+			input:     "[SubGroup] Show.Name.1x01.1080p",
+			mediaType: "series",
+			expected:  "Show.Name.S1E01.1080p",
+			steps:     2,
+		},
+		{
+			name:      "Translated title on the indexer",
+			input:     "Der.Film.2024.1080p.WEB",
+			mediaType: "movie",
+			indexer:   "NZBgeek",
+			expected:  "The.Movie.2024.1080p.WEB",
+			steps:     1,
+		},
+		{
+			name:      "Translated title on another indexer",
+			input:     "Der.Film.2024.1080p.WEB",
+			mediaType: "movie",
+			indexer:   "other",
+			expected:  "Der.Film.2024.1080p.WEB",
+		},
+		{
+			name:      "List scoped rule",
+			input:     "Show.Folge.12.720p",
+			mediaType: "series",
+			list:      "anime",
+			expected:  "Show.E12.720p",
+			steps:     1,
+		},
+		{
+			name:      "List scoped rule without list",
+			input:     "Show.Folge.12.720p",
+			mediaType: "series",
+			expected:  "Show.Folge.12.720p",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, steps := applyRewriteRules(rules, tt.input, tt.mediaType, tt.indexer, tt.list)
+			if got != tt.expected {
+				t.Errorf("applyRewriteRules(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+
+			if len(steps) != tt.steps {
+				t.Fatalf("applyRewriteRules(%q) steps = %d, want %d", tt.input, len(steps), tt.steps)
+			}
+
+			if tt.steps > 0 &&
+				(steps[0].Before != tt.input || steps[len(steps)-1].After != tt.expected) {
+				t.Errorf("applyRewriteRules(%q) steps = %+v", tt.input, steps)
+			}
+		})
+	}
+}
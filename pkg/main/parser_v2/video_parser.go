@@ -35,7 +35,6 @@ type videoPatterns struct {
 	quality           *regexp.Regexp
 	codec             *regexp.Regexp
 	audio             *regexp.Regexp
-	group             *regexp.Regexp
 	extended          *regexp.Regexp
 	proper            *regexp.Regexp
 	repack            *regexp.Regexp
@@ -43,6 +42,7 @@ type videoPatterns struct {
 	hdr               *regexp.Regexp
 	complete          *regexp.Regexp
 	multiSeason       *regexp.Regexp
+	seasonPack        *regexp.Regexp
 	language          *regexp.Regexp
 }
 
@@ -101,7 +101,6 @@ const (
 	reVideoQuality     = `(?i)(?:^|[\s._-])(blu[\s-]?ray|bdrip|brrip|web[\s-]?dl|web[\s-]?rip|webrip|web|hdtv|dvd[\s-]?rip|dvd[\s-]?scr|hdcam|hdrip|hd[\s-]?ts|tele[\s-]?sync|ts|cam|r5|ppv[\s-]?rip|pdtv|dsr|sat[\s-]?rip|vod[\s-]?rip|amazon|amzn|nf|netflix|dsnp|disney\+?|hmax|hulu|atvp|atv\+?|pcok|peacock|hbo[\s-]?max|itunes)(?:[\s._-]|$)`
 	reVideoCodec       = `(?i)(x264|x\.264|h\.?264|avc|x265|x\.265|h\.?265|hevc|xvid|divx|av1|mpeg[\s-]?2|vc[\s-]?1)`
 	reVideoAudio       = `(?i)(dts[\s-]?hd[\s-]?ma|dts[\s-]?hd|dts[\s-]?x|dts|dolby[\s-]?atmos|atmos|truehd|ddp?\+?|dd[\s-]?5\.1|dd|ac[\s-]?3|eac[\s-]?3|aac[\s-]?2\.0|aac|flac|mp3|lpcm|pcm|opus|vorbis)`
-	reVideoGroup       = `(?:-|\[)([a-zA-Z0-9]+)(?:\])?$`
 	reVideoExtended    = `(?i)(?:[\[\(\s]|\.)(extended|uncut|unrated|directors?[\s._-]?cut|theatrical)(?:[\]\)\s]|\.)`
 	reVideoProper      = `(?i)(?:[\[\(\s]|\.)(proper|real)(?:[\]\)\s]|\.)`
 	reVideoRepack      = `(?i)(?:[\[\(\s]|\.)(repack|rerip)(?:[\]\)\s]|\.)`
@@ -109,6 +108,8 @@ const (
 	reVideoHDR         = `(?i)(hdr10\+?|dolby[\s-]?vision|(?:^|[\s._-])dv(?:$|[\s._-])|hlg|hdr)`
 	reVideoComplete    = `(?i)(?:[\[\(\s]|\.)(complete|full[\s._-]?series)(?:[\]\)\s]|\.)`
 	reVideoMultiSeason = `(?i)s(\d{1,2})[\s._-]*[-–][\s._-]*s(\d{1,2})`
+	reVideoSeasonPack  = `(?i)(?:^|[\s._-])(?:s|season[\s._-]?)(\d{1,2})(?:[\s._-]|$)`
+	reVideoLeadingEp   = `^(\d{1,2})[\s._-]`
 	reVideoLanguage    = `(?i)[\s._](german|deutsch|french|francais|spanish|espanol|italiano|portuguese|portuguese|russian|japanese|korean|chinese|mandarin|hindi|arabic|dutch|polish|swedish|norwegian|danish|finnish|turkish|greek|hebrew|czech|hungarian|romanian|thai|vietnamese|indonesian|malay|tagalog|multi|dual[\s._-]?audio|dubbed|subbed|subs?)[\s._](?:(?:19|20)\d{2}[\s._]|$)`
 )
 
@@ -135,7 +136,6 @@ func compileVideoPatterns() *videoPatterns {
 			quality:           database.GetCachedRegexp(reVideoQuality),
 			codec:             database.GetCachedRegexp(reVideoCodec),
 			audio:             database.GetCachedRegexp(reVideoAudio),
-			group:             database.GetCachedRegexp(reVideoGroup),
 			extended:          database.GetCachedRegexp(reVideoExtended),
 			proper:            database.GetCachedRegexp(reVideoProper),
 			repack:            database.GetCachedRegexp(reVideoRepack),
@@ -143,6 +143,7 @@ func compileVideoPatterns() *videoPatterns {
 			hdr:               database.GetCachedRegexp(reVideoHDR),
 			complete:          database.GetCachedRegexp(reVideoComplete),
 			multiSeason:       database.GetCachedRegexp(reVideoMultiSeason),
+			seasonPack:        database.GetCachedRegexp(reVideoSeasonPack),
 			language:          database.GetCachedRegexp(reVideoLanguage),
 		}
 	})
@@ -196,9 +197,7 @@ func (vp *VideoParser) Parse(filename string) *VideoParseResult {
 	vp.extractQualityInfo(originalName, result)
 
 	// Extract release group
-	if loc := vp.patterns.group.FindStringSubmatchIndex(originalName); len(loc) > 2 {
-		result.ReleaseGroup = originalName[loc[2]:loc[3]]
-	}
+	result.ReleaseGroup = ReleaseGroup(originalName)
 
 	// Extract title
 	result.Title = vp.extractTitle(cleanName, result)
@@ -280,6 +279,27 @@ func ParseFile(
 	return m
 }
 
+// ShadowFunc receives the result of a ParseFileP or ParseReleaseP call
+// together with its arguments, so the input can be compared with another
+// parser. The indexer is empty for files.
+type ShadowFunc func(
+	videofile, indexer string,
+	usepath, usefolder bool,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	m *database.ParseInfo,
+)
+
+// shadowHook is called after every ParseFileP call. It is set once during
+// initialization.
+var shadowHook ShadowFunc
+
+// SetShadowHook registers fn to be called with the result of every
+// ParseFileP call.
+func SetShadowHook(fn ShadowFunc) {
+	shadowHook = fn
+}
+
 // ParseFileP parses a video file to extract metadata, populating an existing ParseInfo struct.
 // This is a drop-in replacement for parser.ParseFileP with the same signature.
 // It accepts the video file path, booleans to determine parsing behavior,
@@ -295,6 +315,56 @@ func ParseFileP(
 		return
 	}
 
+	ParseFilePUnshadowed(videofile, usepath, usefolder, cfgp, listid, m)
+
+	if shadowHook != nil {
+		shadowHook(videofile, "", usepath, usefolder, cfgp, listid, m)
+	}
+}
+
+// ParseReleaseP parses a release title found on the indexer, populating an
+// existing ParseInfo struct. Unlike ParseFileP it applies the parser rewrite
+// rules scoped to the indexer.
+func ParseReleaseP(
+	title, indexer string,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	m *database.ParseInfo,
+) {
+	if m == nil {
+		return
+	}
+
+	parseFileP(title, indexer, false, false, cfgp, listid, m)
+
+	if shadowHook != nil {
+		shadowHook(title, indexer, false, false, cfgp, listid, m)
+	}
+}
+
+// ParseFilePUnshadowed is ParseFileP without calling the shadow hook.
+func ParseFilePUnshadowed(
+	videofile string,
+	usepath, usefolder bool,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	m *database.ParseInfo,
+) {
+	if m == nil {
+		return
+	}
+
+	parseFileP(videofile, "", usepath, usefolder, cfgp, listid, m)
+}
+
+// parseFileP parses the video file or release title of the indexer into m.
+func parseFileP(
+	videofile, indexer string,
+	usepath, usefolder bool,
+	cfgp *config.MediaTypeConfig,
+	listid int,
+	m *database.ParseInfo,
+) {
 	// Handle audiobooks specially - they need ASIN from folder path
 	if cfgp != nil && cfgp.IsType == config.MediaTypeAudiobook {
 		parseAudiobookFileToParseInfo(videofile, usepath, usefolder, cfgp, listid, m)
@@ -319,7 +389,7 @@ func ParseFileP(
 	}
 
 	// Parse the filename
-	parseFileToParseInfo(filename, false, cfgp, listid, m)
+	parseFileToParseInfo(filename, indexer, false, cfgp, listid, m)
 
 	// If quality and resolution are already set, we're done
 	if m.Quality != "" && m.Resolution != "" {
@@ -328,7 +398,7 @@ func ParseFileP(
 
 	// Try folder name if enabled
 	if usefolder && usepath {
-		parseFileToParseInfo(filepath.Base(filepath.Dir(videofile)), true, cfgp, listid, m)
+		parseFileToParseInfo(filepath.Base(filepath.Dir(videofile)), indexer, true, cfgp, listid, m)
 	}
 }
 
@@ -390,6 +460,8 @@ func parseAudiobookFileToParseInfo(
 	// Populate ParseInfo from AudiobookParseResult
 	m.Title = result.Title
 	m.Artist = result.Author
+	m.ReleaseGroup = result.ReleaseGroup
+	m.ReleaseName = ReleaseName(filename)
 
 	m.ASIN = result.ASIN
 	if result.Year > 0 {
@@ -473,6 +545,8 @@ func parseBookFileToParseInfo(
 	// Populate ParseInfo from BookParseResult //nolint:gosec // safe: value within target type range
 	m.Title = result.Title
 	m.Artist = result.Author
+	m.ReleaseGroup = result.ReleaseGroup
+	m.ReleaseName = ReleaseName(filename)
 
 	m.ASIN = result.ASIN
 	if result.ISBN13 != "" {
@@ -542,6 +616,8 @@ func parseMusicFileToParseInfo(
 	// Populate ParseInfo from MusicParseResult
 	m.Title = result.Album
 	m.Artist = result.Artist
+	m.ReleaseGroup = result.ReleaseGroup
+	m.ReleaseName = ReleaseName(filename)
 
 	if result.Year > 0 {
 		m.Year = uint16(result.Year)
@@ -554,10 +630,12 @@ func parseMusicFileToParseInfo(
 
 // parseFileToParseInfo parses a filename and populates a ParseInfo struct.
 // If onlyIfEmpty is true, only empty fields will be populated.
+// The parser rewrite rules are applied to the filename before it is parsed,
+// m.File and the release name and group keep the original name.
 func parseFileToParseInfo(
-	filename string,
+	filename, indexer string,
 	onlyIfEmpty bool,
-	_ *config.MediaTypeConfig,
+	cfgp *config.MediaTypeConfig,
 	listid int,
 	m *database.ParseInfo,
 ) {
@@ -566,6 +644,9 @@ func parseFileToParseInfo(
 		m.File = filename
 	}
 
+	original := filename
+	filename, _ = RewriteName(filename, cfgp, listid, indexer)
+
 	ps := GetPatternStore()
 	ps.LoadDBPatterns()
 
@@ -613,6 +694,18 @@ func parseFileToParseInfo(
 	// Extract quality attributes using database patterns if available
 	extractQualityToParseInfo(vp, originalName, m, onlyIfEmpty)
 
+	// Extract release group - the release name is the one carrying the group
+	if !onlyIfEmpty || m.ReleaseGroup == "" {
+		if group := ReleaseGroup(original); group != "" {
+			m.ReleaseGroup = group
+			m.ReleaseName = ReleaseName(original)
+		}
+	}
+
+	if m.ReleaseName == "" {
+		m.ReleaseName = ReleaseName(filename)
+	}
+
 	// Extract title
 	if onlyIfEmpty && m.Title != "" {
 		return
@@ -892,6 +985,77 @@ func (vp *VideoParser) detectMediaType(name string, result *VideoParseResult) Me
 	return MediaTypeMovie
 }
 
+// ParseSeasonPack reports whether name is a full-season release such as
+// "Show.Name.S01.1080p" or "Show Name Season 2 Complete". It returns the series
+// title in front of the season token and the season number. Names carrying an
+// episode number, an air date or a season range are not treated as packs.
+func ParseSeasonPack(name string) (string, int, bool) {
+	p := compileVideoPatterns()
+	if p.seasonEpisode.MatchString(name) || p.seasonEpisodeAlt.MatchString(name) ||
+		p.seasonEpisodeDate.MatchString(name) || p.multiSeason.MatchString(name) {
+		return "", 0, false
+	}
+
+	loc := p.seasonPack.FindStringSubmatchIndex(name)
+	if len(loc) < 4 {
+		return "", 0, false
+	}
+
+	title := cleanTitle(strings.NewReplacer(".", " ", "_", " ").Replace(name[:loc[0]]))
+	if title == "" {
+		return "", 0, false
+	}
+
+	return title, parseInt(name[loc[2]:loc[3]]), true
+}
+
+// ApplySeasonPackFolder completes the episode info of a file unpacked from a
+// full-season release whose own name only carries an episode number, for
+// example "Show.S02.1080p/E03 - Title.mkv" or "Show.S02.1080p/03 - Title.mkv".
+// Season, series title and TVDB ID are taken from the parent folder. It returns true if
+// m was updated.
+func ApplySeasonPackFolder(videofile string, m *database.ParseInfo) bool {
+	if m == nil || m.Identifier != "" || m.Date != "" {
+		return false
+	}
+
+	folder := filepath.Base(filepath.Dir(videofile))
+
+	title, season, ok := ParseSeasonPack(folder)
+	if !ok {
+		return false
+	}
+
+	episode := m.Episode
+	if episode == 0 {
+		base := filepath.Base(videofile)
+		if loc := database.GetCachedRegexp(reVideoLeadingEp).FindStringSubmatchIndex(base); len(loc) > 2 {
+			episode = parseInt(base[loc[2]:loc[3]])
+		}
+	}
+
+	if episode == 0 {
+		return false
+	}
+
+	m.Title = title
+	m.Season = season
+	m.Episode = episode
+	m.SeasonStr = padInt(season)
+	m.EpisodeStr = padInt(episode)
+	m.AbsoluteEpisode = 0
+	m.Identifier = formatIdentifier(season, episode)
+
+	// Download folders are named "<release> (tvdbNNN)"
+	if m.Tvdb == "" {
+		if loc := database.GetCachedRegexp(reVideoTVDB).FindStringSubmatchIndex(folder); len(loc) > 2 {
+			m.Tvdb = folder[loc[2]:loc[3]]
+		}
+	}
+
+	return true
+}
+
 // extractQualityInfo extracts resolution, quality, codec, and audio information.
 func (vp *VideoParser) extractQualityInfo(name string, result *VideoParseResult) {
 	// Use database patterns if available, otherwise use hardcoded patterns
@@ -4,9 +4,12 @@ import (
 	"maps"
 	"sync"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/acoustid"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/audible"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/audnex"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/cardigann"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/deezer"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/deluge"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/discogs"
@@ -71,6 +74,9 @@ var (
 	// Indexer providers - map by name for configuration-driven lookup.
 	indexerProviders = make(map[string]*newznab.Provider)
 
+	// Definition driven torrent site indexers - map by name.
+	cardigannProviders = make(map[string]*cardigann.Provider)
+
 	// Download providers - map by name
 	// Stored as concrete types for full type safety.
 	qbittorrentProviders  = make(map[string]*qbittorrent.Provider)
@@ -79,6 +85,10 @@ var (
 	rtorrentProviders     = make(map[string]*rtorrent.Provider)
 	sabnzbdProviders      = make(map[string]*sabnzbd.Provider)
 	nzbgetProviders       = make(map[string]*nzbget.Provider)
+
+	// Subtitle providers - map by name, stored by interface so new
+	// providers only need to implement apiexternal_v2.SubtitleProvider.
+	subtitleProviders = make(map[string]apiexternal_v2.SubtitleProvider)
 )
 
 //
@@ -196,6 +206,44 @@ func GetAllIndexers() map[string]*newznab.Provider {
 	return providers
 }
 
+// SetCardigann registers a definition driven indexer provider by name.
+func SetCardigann(name string, provider *cardigann.Provider) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	cardigannProviders[name] = provider
+}
+
+// GetCardigann returns a definition driven indexer provider by name.
+// Returns nil if not found.
+func GetCardigann(name string) *cardigann.Provider {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return cardigannProviders[name]
+}
+
+// GetAllIndexerClients returns the search clients of all registered newznab
+// and definition driven indexers.
+func GetAllIndexerClients() map[string]*base.BaseClient {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	clients := make(map[string]*base.BaseClient, len(indexerProviders)+len(cardigannProviders))
+	for name, provider := range indexerProviders {
+		if provider != nil {
+			clients[name] = provider.BaseClient
+		}
+	}
+
+	for name, provider := range cardigannProviders {
+		if provider != nil {
+			clients[name] = provider.BaseClient
+		}
+	}
+
+	return clients
+}
+
 // GetAllIndexerDownloadClients returns the download clients from all indexer providers.
 // These track download statistics separately from search statistics.
 func GetAllIndexerDownloadClients() map[string]any {
@@ -210,6 +258,12 @@ func GetAllIndexerDownloadClients() map[string]any {
 		}
 	}
 
+	for name, provider := range cardigannProviders {
+		if provider != nil && provider.DownloadClient != nil {
+			clients[name+"_download"] = provider.DownloadClient
+		}
+	}
+
 	return clients
 }
 
@@ -425,6 +479,12 @@ func GetAllDownloadProviders() map[string]any {
 		}
 	}
 
+	for name, provider := range cardigannProviders {
+		if provider != nil && provider.DownloadClient != nil {
+			providers[name+"_download"] = provider.DownloadClient
+		}
+	}
+
 	return providers
 }
 
@@ -618,3 +678,35 @@ func GetAcoustID() *acoustid.Provider {
 	defer registryMutex.RUnlock()
 	return acoustidProvider
 }
+
+//
+// Subtitle Providers
+//
+
+// SetSubtitleProvider registers a subtitle provider. A nil provider removes the entry.
+func SetSubtitleProvider(name string, provider apiexternal_v2.SubtitleProvider) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if provider == nil {
+		delete(subtitleProviders, name)
+		return
+	}
+
+	subtitleProviders[name] = provider
+}
+
+// GetSubtitleProvider returns the subtitle provider registered under name.
+// Returns nil if not initialized.
+func GetSubtitleProvider(name string) apiexternal_v2.SubtitleProvider {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return subtitleProviders[name]
+}
+
+// GetAllSubtitleProviders returns all registered subtitle providers.
+func GetAllSubtitleProviders() map[string]apiexternal_v2.SubtitleProvider {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return maps.Clone(subtitleProviders)
+}
@@ -20,12 +20,18 @@ import (
 type MoveFileOptions struct {
 	UseOther bool // Check "other" extensions (subtitles, NFOs, etc.) instead of primary
 	UseNil   bool // Skip extension validation entirely
+	// ForceRename renames the file to newname even if the extension is not
+	// validated or configured not to be renamed.
+	ForceRename bool
 	// UseBufferCopy is kept for compatibility; cross-device moves always use a
 	// buffered, size-verified copy with the configured MoveBufferSizeKB now.
 	UseBufferCopy bool
 	ChmodFolder   string // Folder permissions in octal format (e.g., "0755" or "755")
 	Chmod         string // File permissions in octal format (e.g., "0644" or "644")
 	MediaType     uint   // Media type constant for extension checking
+	// Mode is the import mode (config.ImportMode*) - empty moves the file.
+	// All other modes leave the source in place.
+	Mode string
 }
 
 // tmpMoveSuffix marks the staging file MoveFile writes next to the final
@@ -33,9 +39,10 @@ type MoveFileOptions struct {
 const tmpMoveSuffix = ".tmp-move"
 
 var (
-	strto           = "to"
-	errSameFile     = errors.New("same file")
-	errSizeMismatch = errors.New("copied size does not match source size")
+	strto                 = "to"
+	errSameFile           = errors.New("same file")
+	errSizeMismatch       = errors.New("copied size does not match source size")
+	errReflinkUnsupported = errors.New("reflink is not supported on this platform")
 )
 
 // MoveFile moves a file from one path to another. It handles checking
@@ -47,6 +54,10 @@ var (
 // destination file. At every instant either the old or the complete new file
 // exists, and a failed move leaves the source at its original path.
 //
+// With an import mode other than move the source is kept: the staging file
+// is a copy, a hardlink, a reflink or a symlink of it instead. Hardlinks and
+// reflinks fall back to a copy when the filesystem cannot provide them.
+//
 // Returns the new file path on success, or an error if the move failed.
 func MoveFile(
 	file string,
@@ -71,6 +82,10 @@ func MoveFile(
 		return "", logger.ErrNotAllowed
 	}
 
+	if opts.ForceRename {
+		oknorename = false
+	}
+
 	newfilename := determineNewFilename(file, newname, ext, oknorename)
 	logger.Path(&newfilename, false)
 
@@ -85,14 +100,30 @@ func MoveFile(
 		return newpath, nil
 	}
 
-	logger.Logtype("info", 0).Str(logger.StrFile, file).Str(strto, newpath).Msg("File move start")
+	logger.Logtype("info", 0).
+		Str(logger.StrFile, file).
+		Str(strto, newpath).
+		Str("mode", opts.Mode).
+		Msg("File move start")
+
+	var err error
+	if opts.Mode == "" || opts.Mode == config.ImportModeMove {
+		err = moveToTempThenSwap(
+			file,
+			newpath,
+			parseFileMode(opts.ChmodFolder),
+			parseFileMode(opts.Chmod),
+		)
+	} else {
+		err = importToTempThenSwap(
+			file,
+			newpath,
+			opts.Mode,
+			parseFileMode(opts.ChmodFolder),
+			parseFileMode(opts.Chmod),
+		)
+	}
 
-	err := moveToTempThenSwap(
-		file,
-		newpath,
-		parseFileMode(opts.ChmodFolder),
-		parseFileMode(opts.Chmod),
-	)
 	if err != nil {
 		return "", err
 	}
@@ -107,27 +138,9 @@ func MoveFile(
 // Failures leave the source untouched (copy path) or restore it to its
 // original path (rename path), so the move can always be retried.
 func moveToTempThenSwap(file, newpath string, folderMode, fileMode fs.FileMode) error {
-	if folderMode == 0 {
-		folderMode = 0o777
-	}
-
-	targetDir := filepath.Dir(newpath)
-	if !CheckFileExist(targetDir) {
-		if err := os.MkdirAll(targetDir, folderMode); err != nil {
-			return err
-		}
-
-		// Ensure permissions are set (MkdirAll may apply umask)
-		os.Chmod(targetDir, folderMode)
-	}
-
-	tmppath := newpath + tmpMoveSuffix
-	// Remove a stale staging file from a previously interrupted move so the
-	// retry isn't blocked.
-	if CheckFileExist(tmppath) {
-		if _, err := SecureRemove(tmppath); err != nil {
-			return err
-		}
+	tmppath, err := prepareStaging(newpath, folderMode)
+	if err != nil {
+		return err
 	}
 
 	// Fast path: same-filesystem rename is atomic and instant.
@@ -175,6 +188,99 @@ func moveToTempThenSwap(file, newpath string, folderMode, fileMode fs.FileMode)
 	return nil
 }
 
+// importToTempThenSwap stages a copy, hardlink, reflink or symlink of the
+// file at newpath+".tmp-move" and swaps it in like moveToTempThenSwap. The
+// source is never removed - it may still be seeded by the download client.
+func importToTempThenSwap(file, newpath, mode string, folderMode, fileMode fs.FileMode) error {
+	tmppath, err := prepareStaging(newpath, folderMode)
+	if err != nil {
+		return err
+	}
+
+	shared, err := placeFile(file, tmppath, mode)
+	if err != nil {
+		return err
+	}
+
+	// Hardlinks and symlinks share the permissions of the source - changing
+	// them would change the file of the download client too.
+	if fileMode != 0 && !shared {
+		os.Chmod(tmppath, fileMode)
+	}
+
+	if err := swapInto(tmppath, newpath); err != nil {
+		os.Remove(tmppath)
+		return err
+	}
+
+	return nil
+}
+
+// placeFile creates dst from src with the import mode. It reports if dst
+// shares its inode with src (hardlink) or points to it (symlink).
+func placeFile(src, dst, mode string) (bool, error) {
+	switch mode {
+	case config.ImportModeHardlink:
+		err := os.Link(src, dst)
+		if err == nil {
+			return true, nil
+		}
+
+		logger.Logtype("debug", 2).
+			Str(logger.StrFile, src).
+			Err(err).
+			Msg("Hardlink not possible - copying file")
+	case config.ImportModeReflink:
+		err := reflinkFile(src, dst)
+		if err == nil {
+			return false, nil
+		}
+
+		logger.Logtype("debug", 2).
+			Str(logger.StrFile, src).
+			Err(err).
+			Msg("Reflink not possible - copying file")
+	case config.ImportModeSymlink:
+		srcAbs, err := filepath.Abs(src)
+		if err != nil {
+			return false, err
+		}
+
+		return true, os.Symlink(srcAbs, dst)
+	}
+
+	return false, copyFileVerified(src, dst)
+}
+
+// prepareStaging creates the folder of newpath and removes a stale staging
+// file from a previously interrupted move so the retry isn't blocked.
+// It returns the staging path.
+func prepareStaging(newpath string, folderMode fs.FileMode) (string, error) {
+	if folderMode == 0 {
+		folderMode = 0o777
+	}
+
+	targetDir := filepath.Dir(newpath)
+	if !CheckFileExist(targetDir) {
+		if err := os.MkdirAll(targetDir, folderMode); err != nil {
+			return "", err
+		}
+
+		// Ensure permissions are set (MkdirAll may apply umask)
+		os.Chmod(targetDir, folderMode)
+	}
+
+	// Lstat also finds a dangling staging symlink.
+	tmppath := newpath + tmpMoveSuffix
+	if _, err := os.Lstat(tmppath); err == nil {
+		if _, err := SecureRemove(tmppath); err != nil {
+			return "", err
+		}
+	}
+
+	return tmppath, nil
+}
+
 // swapInto replaces newpath with the staged file at tmppath. The existing
 // destination is removed only now, when the complete new content already
 // sits next to it, shrinking the data-loss window to a same-directory rename.
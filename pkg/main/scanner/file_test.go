@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 )
 
 func writeTestFile(t *testing.T, path, content string) {
@@ -58,6 +60,75 @@ func TestMoveFileBasic(t *testing.T) {
 	}
 }
 
+// TestMoveFileImportModes verifies the modes keeping the source: the target
+// has the content, the source is still there, hardlinks share the inode and
+// symlinks point to the source. Reflinks fall back to a copy on filesystems
+// without shared extents.
+func TestMoveFileImportModes(t *testing.T) {
+	for _, mode := range []string{
+		config.ImportModeCopy,
+		config.ImportModeHardlink,
+		config.ImportModeReflink,
+		config.ImportModeSymlink,
+	} {
+		t.Run(mode, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "source.mkv")
+			writeTestFile(t, src, "content-"+mode)
+
+			target := filepath.Join(dir, "target")
+
+			newpath, err := MoveFile(
+				src,
+				nil,
+				target,
+				"",
+				MoveFileOptions{UseNil: true, Mode: mode},
+			)
+			if err != nil {
+				t.Fatalf("MoveFile failed: %v", err)
+			}
+
+			if got := readTestFile(t, newpath); got != "content-"+mode {
+				t.Fatalf("content = %q; want %q", got, "content-"+mode)
+			}
+
+			if got := readTestFile(t, src); got != "content-"+mode {
+				t.Fatalf("source content = %q; want %q", got, "content-"+mode)
+			}
+
+			if _, err := os.Lstat(newpath + tmpMoveSuffix); err == nil {
+				t.Fatal("staging file left behind after import")
+			}
+
+			srcInfo, err := os.Stat(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dstInfo, err := os.Lstat(newpath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch mode {
+			case config.ImportModeHardlink:
+				if !os.SameFile(srcInfo, dstInfo) {
+					t.Fatal("hardlink does not share the source inode")
+				}
+			case config.ImportModeSymlink:
+				if dstInfo.Mode()&os.ModeSymlink == 0 {
+					t.Fatal("target is not a symlink")
+				}
+			default:
+				if os.SameFile(srcInfo, dstInfo) {
+					t.Fatal("copy shares the source inode")
+				}
+			}
+		})
+	}
+}
+
 // TestMoveFileReplacesExisting verifies an existing destination is replaced
 // by the new content.
 func TestMoveFileReplacesExisting(t *testing.T) {
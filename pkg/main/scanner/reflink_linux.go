@@ -0,0 +1,45 @@
+//go:build linux
+
+package scanner
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// reflinkFile creates dst as a copy-on-write clone of src (FICLONE). It only
+// works on filesystems with shared extents like btrfs or XFS and when src and
+// dst are on the same filesystem. A failed clone leaves no dst behind.
+func reflinkFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	srcInfo, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
+
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+	if err != nil {
+		return err
+	}
+
+	err = unix.IoctlFileClone(int(dstFile.Fd()), int(srcFile.Fd()))
+	if errc := dstFile.Close(); err == nil {
+		err = errc
+	}
+
+	if err != nil {
+		os.Remove(dst)
+		return err
+	}
+
+	os.Chtimes(dst, time.Now(), srcInfo.ModTime())
+
+	return nil
+}
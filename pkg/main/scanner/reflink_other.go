@@ -0,0 +1,8 @@
+//go:build !linux
+
+package scanner
+
+// reflinkFile is only supported on Linux - the caller falls back to a copy.
+func reflinkFile(_, _ string) error {
+	return errReflinkUnsupported
+}
@@ -33,6 +33,9 @@ func InitScheduler() {
 			IntervalScanDataMissing:    "1d",
 			IntervalScanDataimport:     "60m",
 			IntervalCacheRefresh:       "6h",
+			IntervalIndexerCaps:        "7d",
+			IntervalRecycleBin:         "1d",
+			IntervalImportedSources:    "1d",
 		}})
 		config.WriteCfg()
 	}
@@ -42,7 +45,7 @@ func InitScheduler() {
 		name := cfgp.Name
 		groupnamestr := mediatype.GetCategoryName(cfgp.IsType)
 
-		for _, str := range []string{"refreshseriesfull", "refreshseriesinc", "refreshmoviesfull", "refreshmoviesinc", logger.StrSearchMissingInc, logger.StrSearchMissingFull, logger.StrSearchUpgradeInc, logger.StrSearchUpgradeFull, logger.StrSearchMissingIncTitle, logger.StrSearchMissingFullTitle, logger.StrSearchUpgradeIncTitle, logger.StrSearchUpgradeFullTitle, logger.StrRss, logger.StrDataFull, logger.StrStructure, logger.StrFeeds, logger.StrCheckMissing, logger.StrCheckMissingFlag, logger.StrUpgradeFlag, logger.StrRssSeasons, logger.StrRssSeasonsAll, logger.StrRssArtists, logger.StrRssArtistsUpgrade, logger.StrRssAuthors, logger.StrRssAuthorsUpgrade} {
+		for _, str := range []string{"refreshseriesfull", "refreshseriesinc", "refreshmoviesfull", "refreshmoviesinc", logger.StrSearchMissingInc, logger.StrSearchMissingFull, logger.StrSearchUpgradeInc, logger.StrSearchUpgradeFull, logger.StrSearchMissingIncTitle, logger.StrSearchMissingFullTitle, logger.StrSearchUpgradeIncTitle, logger.StrSearchUpgradeFullTitle, logger.StrRss, logger.StrDataFull, logger.StrStructure, logger.StrArtwork, logger.StrSubtitles, logger.StrFeeds, logger.StrCheckMissing, logger.StrCheckMissingFlag, logger.StrUpgradeFlag, logger.StrRssSeasons, logger.StrRssSeasonsAll, logger.StrRssArtists, logger.StrRssArtistsUpgrade, logger.StrRssAuthors, logger.StrRssAuthorsUpgrade} {
 			var (
 				usequeuename         string
 				intervalstr, cronstr string
@@ -51,6 +54,8 @@ func InitScheduler() {
 			switch str {
 			case logger.StrDataFull,
 				logger.StrStructure,
+				logger.StrArtwork,
+				logger.StrSubtitles,
 				logger.StrCheckMissing,
 				logger.StrCheckMissingFlag,
 				logger.StrUpgradeFlag:
@@ -155,6 +160,22 @@ func InitScheduler() {
 				intervalstr = cfgp.CfgScheduler.IntervalScanDataimport
 				cronstr = cfgp.CfgScheduler.CronScanDataimport
 
+			case logger.StrArtwork:
+				if cfgp.IsType != config.MediaTypeMovie && cfgp.IsType != config.MediaTypeSeries {
+					continue
+				}
+
+				intervalstr = cfgp.CfgScheduler.IntervalArtwork
+				cronstr = cfgp.CfgScheduler.CronArtwork
+
+			case logger.StrSubtitles:
+				if cfgp.IsType != config.MediaTypeMovie && cfgp.IsType != config.MediaTypeSeries {
+					continue
+				}
+
+				intervalstr = cfgp.CfgScheduler.IntervalSearchSubtitles
+				cronstr = cfgp.CfgScheduler.CronSearchSubtitles
+
 			case logger.StrFeeds:
 				intervalstr = cfgp.CfgScheduler.IntervalFeeds
 				cronstr = cfgp.CfgScheduler.CronFeeds
@@ -196,7 +217,15 @@ func InitScheduler() {
 		return nil
 	})
 
-	for _, str := range []string{"backupdb", "checkdb", "imdb", "cacherefresh"} {
+	for _, str := range []string{
+		"backupdb",
+		"checkdb",
+		"imdb",
+		"cacherefresh",
+		"indexercaps",
+		"recyclebin",
+		"importedsources",
+	} {
 		var (
 			usequeuename, name   string
 			intervalstr, cronstr string
@@ -205,7 +234,7 @@ func InitScheduler() {
 		var jobname string
 
 		switch str {
-		case "backupdb", "checkdb", "cacherefresh":
+		case "backupdb", "checkdb", "cacherefresh", "recyclebin", "importedsources":
 			usequeuename = "Data"
 		default:
 			usequeuename = "Feeds"
@@ -236,6 +265,24 @@ func InitScheduler() {
 			name = "Refresh Cache"
 			jobname = "RefreshCache"
 
+		case "indexercaps":
+			intervalstr = config.GetSettingsScheduler("Default").IntervalIndexerCaps
+			cronstr = config.GetSettingsScheduler("Default").CronIndexerCaps
+			name = "Refresh Indexer Caps"
+			jobname = "RefreshIndexerCaps"
+
+		case "recyclebin":
+			intervalstr = config.GetSettingsScheduler("Default").IntervalRecycleBin
+			cronstr = config.GetSettingsScheduler("Default").CronRecycleBin
+			name = "Purge Recycle Bin"
+			jobname = "PurgeRecycleBin"
+
+		case "importedsources":
+			intervalstr = config.GetSettingsScheduler("Default").IntervalImportedSources
+			cronstr = config.GetSettingsScheduler("Default").CronImportedSources
+			name = "Prune Imported Sources"
+			jobname = "PruneImportedSources"
+
 		default:
 			continue
 		}
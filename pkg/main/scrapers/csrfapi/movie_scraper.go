@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/goccy/go-json"
 )
@@ -23,6 +24,9 @@ type MovieConfig struct {
 	StartURL string
 	BaseURL  string
 
+	// Proxy used for all requests, an empty URL uses the default proxy
+	Proxy base.ProxyConfig
+
 	// CSRF settings
 	CSRFCookieName string // Name of cookie containing CSRF token
 	CSRFHeaderName string // Name of header to send CSRF token in
@@ -116,9 +120,15 @@ func NewMovieScraper(cfg *MovieConfig) (*MovieScraper, error) {
 		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
 	}
 
+	transport, err := cfg.Proxy.Transport()
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %w", err)
+	}
+
 	client := &http.Client{
-		Timeout: 30 * time.Second,
-		Jar:     jar,
+		Timeout:   30 * time.Second,
+		Jar:       jar,
+		Transport: transport,
 	}
 
 	return &MovieScraper{
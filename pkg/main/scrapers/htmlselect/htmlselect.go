@@ -0,0 +1,110 @@
+// Package htmlselect selects nodes of parsed HTML documents with XPath or CSS
+// selectors. It is shared by the HTML scrapers and the definition based
+// torrent indexers.
+package htmlselect
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+// IsXPath reports whether selector is an XPath expression. XPath expressions
+// start with "/", "./", ".." or "(" - everything else is a CSS selector.
+func IsXPath(selector string) bool {
+	return strings.HasPrefix(selector, "/") ||
+		strings.HasPrefix(selector, "./") ||
+		strings.HasPrefix(selector, "..") ||
+		strings.HasPrefix(selector, "(")
+}
+
+// Find returns all nodes below node matching the XPath or CSS selector.
+func Find(node *html.Node, selector string) []*html.Node {
+	if node == nil || selector == "" {
+		return nil
+	}
+
+	if IsXPath(selector) {
+		nodes, err := htmlquery.QueryAll(node, selector)
+		if err != nil {
+			return nil
+		}
+
+		return nodes
+	}
+
+	return goquery.NewDocumentFromNode(node).Find(selector).Nodes
+}
+
+// FindOne returns the first node below node matching the XPath or CSS
+// selector, nil if none matches.
+func FindOne(node *html.Node, selector string) *html.Node {
+	if node == nil || selector == "" {
+		return nil
+	}
+
+	if IsXPath(selector) {
+		found, err := htmlquery.Query(node, selector)
+		if err != nil {
+			return nil
+		}
+
+		return found
+	}
+
+	nodes := goquery.NewDocumentFromNode(node).Find(selector).First().Nodes
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	return nodes[0]
+}
+
+// Text returns the trimmed value of attribute of node, or its inner text if
+// attribute is empty.
+func Text(node *html.Node, attribute string) string {
+	if node == nil {
+		return ""
+	}
+
+	if attribute != "" {
+		for i := range node.Attr {
+			if node.Attr[i].Key == attribute {
+				return strings.TrimSpace(node.Attr[i].Val)
+			}
+		}
+
+		return ""
+	}
+
+	return strings.TrimSpace(htmlquery.InnerText(node))
+}
+
+// ExtractText extracts the text of the first node matching selector, or the
+// value of attribute of that node if attribute is set.
+func ExtractText(node *html.Node, selector, attribute string) string {
+	return Text(FindOne(node, selector), attribute)
+}
+
+// ResolveURL turns a possibly-relative reference into an absolute URL using base.
+// Returns "" for an empty reference and the reference unchanged on parse errors.
+func ResolveURL(base, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+
+	return b.ResolveReference(r).String()
+}
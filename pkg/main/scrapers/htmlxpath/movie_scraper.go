@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/antchfx/htmlquery"
@@ -22,6 +23,9 @@ type MovieConfig struct {
 	StartURL string
 	BaseURL  string
 
+	// Proxy used for all requests, an empty URL uses the default proxy
+	Proxy base.ProxyConfig
+
 	// XPath selectors for extracting movie data
 	SceneNodeXPath   string // XPath to select each movie container
 	TitleXPath       string // XPath relative to movie node for title
@@ -105,9 +109,15 @@ func NewMovieScraper(cfg *MovieConfig) (*MovieScraper, error) {
 		cfg.URLAttribute = "href"
 	}
 
+	transport, err := cfg.Proxy.Transport()
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %w", err)
+	}
+
 	// Create HTTP client with timeout
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: transport,
 	}
 
 	return &MovieScraper{
@@ -6,12 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scrapers/htmlselect"
 	"github.com/antchfx/htmlquery"
 	"golang.org/x/net/html"
 )
@@ -222,22 +222,7 @@ func (s *Scraper) fetchPage(ctx context.Context, pageNum int) (*html.Node, error
 // Returns:
 //   - string: Extracted text value
 func (*Scraper) extractText(node *html.Node, xpath, attribute string) string {
-	targetNode := htmlquery.FindOne(node, xpath)
-	if targetNode == nil {
-		return ""
-	}
-
-	if attribute != "" {
-		for i := range targetNode.Attr {
-			if targetNode.Attr[i].Key == attribute {
-				return strings.TrimSpace(targetNode.Attr[i].Val)
-			}
-		}
-
-		return ""
-	}
-
-	return strings.TrimSpace(htmlquery.InnerText(targetNode))
+	return htmlselect.Text(htmlquery.FindOne(node, xpath), attribute)
 }
 
 // extractActors extracts actor names from nodes using XPath.
@@ -297,27 +282,6 @@ func (s *Scraper) parseDate(dateStr string) (time.Time, error) {
 //
 // Returns:
 //   - error: Any errors during database operations
-//
-// resolveURL turns a possibly-relative reference into an absolute URL using base.
-// Returns "" for an empty reference and the reference unchanged on parse errors.
-func resolveURL(base, ref string) string {
-	if ref == "" {
-		return ""
-	}
-
-	b, err := url.Parse(base)
-	if err != nil {
-		return ref
-	}
-
-	r, err := url.Parse(ref)
-	if err != nil {
-		return ref
-	}
-
-	return b.ResolveReference(r).String()
-}
-
 func (s *Scraper) createEpisode(
 	_ context.Context,
 	title, episodeURL string,
@@ -458,7 +422,7 @@ func (s *Scraper) Scrape(ctx context.Context, firstpageonly bool) (int, error) {
 			title := s.extractText(sceneNode, s.config.TitleXPath, s.config.TitleAttribute)
 			// Resolve possibly-relative hrefs against the page base so the stored
 			// scraper URL is usable for re-scraping later.
-			url := resolveURL(
+			url := htmlselect.ResolveURL(
 				s.config.StartURL,
 				s.extractText(sceneNode, s.config.URLXPath, s.config.URLAttribute),
 			)
@@ -13,7 +13,7 @@ import (
 // records for every request. Indexers without history default to 1.0 so
 // new indexers are tried first rather than penalized.
 func indexerSuccessRate(indcfg *config.IndexersConfig) float64 {
-	provider := apiexternal.Getnewznabclient(indcfg)
+	provider := apiexternal.GetIndexerClient(indcfg)
 	if provider == nil {
 		return 1.0
 	}
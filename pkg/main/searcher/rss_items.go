@@ -0,0 +1,255 @@
+package searcher
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
+)
+
+var (
+	errRSSItemConfig   = errors.New("media configuration of the rss item not found")
+	errRSSItemIndexer  = errors.New("indexer of the rss item not found")
+	errRSSItemQuality  = errors.New("quality of the media not found")
+	errRSSItemNoMedia  = errors.New("media id missing")
+	errRSSItemNoResult = errors.New("rss item was not evaluated")
+)
+
+// recordRSSItems stores the accepted and denied entries of an RSS search so
+// they can be reviewed, re-evaluated or grabbed later. Older items beyond the
+// configured limit are removed.
+func (s *ConfigSearcher) recordRSSItems(quality *config.QualityConfig) {
+	keep := config.GetSettingsGeneral().RSSHistoryItems
+	if keep <= 0 || s.searchActionType != logger.StrRss {
+		return
+	}
+
+	if len(s.Accepted) == 0 && len(s.Denied) == 0 {
+		return
+	}
+
+	handler := mediatype.Get(s.Cfgp.IsType)
+
+	for idx := range s.Denied {
+		s.addRSSItem(&s.Denied[idx], quality, handler, database.RSSDecisionDenied)
+	}
+
+	for idx := range s.Accepted {
+		s.addRSSItem(&s.Accepted[idx], quality, handler, database.RSSDecisionAccepted)
+	}
+
+	database.TrimRSSItems(keep)
+}
+
+// addRSSItem stores a single entry of an RSS search with the decision.
+func (s *ConfigSearcher) addRSSItem(
+	entry *apiexternal_v2.Nzbwithprio,
+	quality *config.QualityConfig,
+	handler mediatype.Handler,
+	decision string,
+) {
+	item := database.RSSItem{
+		Config:      s.Cfgp.NamePrefix,
+		Title:       entry.NZB.Title,
+		DownloadURL: entry.NZB.DownloadURL,
+		Size:        entry.NZB.Size,
+		IsTorrent:   entry.NZB.IsTorrent,
+		Seeders:     entry.NZB.Seeders,
+		Category:    entry.NZB.Category,
+		Imdb:        entry.NZB.IMDBID,
+		Tvdb:        entry.NZB.TVDBID,
+		Season:      entry.NZB.Season,
+		Episode:     entry.NZB.Episode,
+		Decision:    decision,
+	}
+
+	if !entry.NZB.PubDate.IsZero() {
+		item.PubDate = sql.NullTime{Time: entry.NZB.PubDate, Valid: true}
+	}
+
+	if entry.NZB.Indexer != nil {
+		item.Indexer = entry.NZB.Indexer.Name
+	}
+
+	switch {
+	case entry.NZB.Quality != nil:
+		item.QualityProfile = entry.NZB.Quality.Name
+	case quality != nil:
+		item.QualityProfile = quality.Name
+	}
+
+	setRSSItemResult(&item, entry, handler)
+	database.AddRSSItem(&item)
+}
+
+// setRSSItemResult copies the parsed values and the outcome of the evaluation
+// of entry into item.
+func setRSSItemResult(
+	item *database.RSSItem,
+	entry *apiexternal_v2.Nzbwithprio,
+	handler mediatype.Handler,
+) {
+	item.Resolution = entry.Info.Resolution
+	item.Quality = entry.Info.Quality
+	item.Codec = entry.Info.Codec
+	item.Audio = entry.Info.Audio
+	item.ReleaseGroup = entry.Info.ReleaseGroup
+	item.Identifier = entry.Info.Identifier
+	item.Listname = entry.Listname
+	item.Priority = entry.Info.Priority
+	item.Reason = entry.Reason
+
+	item.MediaID = 0
+	if handler != nil {
+		handler.SetEntryTempID(entry)
+		item.MediaID = entry.Info.TempID
+	}
+}
+
+// rssItemEntry rebuilds the search entry of a stored RSS item. The indexer
+// is resolved from the current configuration.
+func rssItemEntry(
+	item *database.RSSItem,
+) (*config.MediaTypeConfig, apiexternal_v2.Nzbwithprio, error) {
+	var entry apiexternal_v2.Nzbwithprio
+
+	cfgp := config.GetSettingsMedia(item.Config)
+	if cfgp == nil {
+		return nil, entry, errRSSItemConfig
+	}
+
+	entry.NZB = apiexternal_v2.Nzb{
+		Title:       item.Title,
+		DownloadURL: item.DownloadURL,
+		Size:        item.Size,
+		IsTorrent:   item.IsTorrent,
+		Seeders:     item.Seeders,
+		Category:    item.Category,
+		IMDBID:      item.Imdb,
+		TVDBID:      item.Tvdb,
+		Season:      item.Season,
+		Episode:     item.Episode,
+		Indexer:     config.GetSettingsIndexer(item.Indexer),
+	}
+
+	if entry.NZB.Indexer == nil {
+		return nil, entry, errRSSItemIndexer
+	}
+
+	if item.PubDate.Valid {
+		entry.NZB.PubDate = item.PubDate.Time
+	}
+
+	if item.QualityProfile != "" {
+		entry.NZB.Quality = config.GetSettingsQuality(item.QualityProfile)
+	}
+
+	return cfgp, entry, nil
+}
+
+// ReevaluateRSSItem runs a stored RSS item through the searcher again using
+// the current configuration and stores the new decision. The item is not
+// downloaded even if it is accepted now.
+func ReevaluateRSSItem(id uint) (*database.RSSItem, error) {
+	item, err := database.GetRSSItem(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cfgp, entry, err := rssItemEntry(item)
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewSearcher(cfgp, entry.NZB.Quality, logger.StrRss, nil)
+	defer s.Close()
+
+	s.Raw.Arr = append(s.Raw.Arr, entry)
+	s.searchparse(nil, nil)
+
+	handler := mediatype.Get(cfgp.IsType)
+
+	switch {
+	case len(s.Accepted) > 0:
+		setRSSItemResult(item, &s.Accepted[0], handler)
+		item.Decision = database.RSSDecisionAccepted
+	case len(s.Denied) > 0:
+		setRSSItemResult(item, &s.Denied[0], handler)
+		item.Decision = database.RSSDecisionDenied
+	default:
+		return nil, errRSSItemNoResult
+	}
+
+	database.UpdateRSSItem(item)
+
+	logger.Logtype("info", 3).
+		Uint(logger.StrID, id).
+		Str(logger.StrTitle, item.Title).
+		Str("decision", item.Decision).
+		Msg("RSS item re-evaluated")
+
+	return item, nil
+}
+
+// GrabRSSItem downloads a stored RSS item for the given movie, episode,
+// book, audiobook or album ID of the media configuration of the item,
+// skipping all checks of the searcher.
+func GrabRSSItem(id, mediaid uint) error {
+	if mediaid == 0 {
+		return errRSSItemNoMedia
+	}
+
+	item, err := database.GetRSSItem(id)
+	if err != nil {
+		return err
+	}
+
+	cfgp, entry, err := rssItemEntry(item)
+	if err != nil {
+		return err
+	}
+
+	qual := database.GetMediaQualityConfig(cfgp, &mediaid)
+	if qual == nil {
+		return errRSSItemQuality
+	}
+
+	entry.NZB.Quality = qual
+	entry.Quality = qual.Name
+
+	parser_v2.ParseReleaseP(entry.NZB.Title, entry.NZB.Indexer.Name, cfgp, -1, &entry.Info)
+	parser.GetPriorityMapQual(&entry.Info, cfgp, qual, false, true)
+
+	handler := mediatype.Get(cfgp.IsType)
+	if handler == nil {
+		return errRSSItemConfig
+	}
+
+	handler.SetNzbID(&entry, mediaid)
+
+	s := NewSearcher(cfgp, qual, logger.StrRss, nil)
+	defer s.Close()
+
+	if err := s.downloadEntry(&entry); err != nil {
+		return err
+	}
+
+	setRSSItemResult(item, &entry, handler)
+	item.Decision = database.RSSDecisionGrabbed
+	item.Reason = ""
+	database.UpdateRSSItem(item)
+
+	logger.Logtype("info", 3).
+		Uint(logger.StrID, id).
+		Uint("media_id", mediaid).
+		Str(logger.StrTitle, item.Title).
+		Msg("RSS item grabbed")
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package searcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+)
+
+func TestSetRSSItemResult(t *testing.T) {
+	item := database.RSSItem{
+		Title:    "Movie.2020.1080p.BluRay.x264-GRP",
+		MediaID:  7,
+		Decision: database.RSSDecisionDenied,
+	}
+
+	entry := apiexternal_v2.Nzbwithprio{
+		Info: database.ParseInfo{
+			Resolution:   "1080p",
+			Quality:      "bluray",
+			Codec:        "x264",
+			ReleaseGroup: "GRP",
+			Priority:     120,
+		},
+		Listname: "movies",
+		Reason:   "lower Prio",
+	}
+
+	setRSSItemResult(&item, &entry, nil)
+
+	if item.Resolution != "1080p" || item.Quality != "bluray" || item.Codec != "x264" ||
+		item.ReleaseGroup != "GRP" || item.Listname != "movies" || item.Priority != 120 {
+		t.Errorf("parsed values not copied: %+v", item)
+	}
+
+	if item.Reason != "lower Prio" {
+		t.Errorf("Reason = %q, want %q", item.Reason, "lower Prio")
+	}
+
+	// Without a handler the media can't be resolved
+	if item.MediaID != 0 {
+		t.Errorf("MediaID = %d, want 0", item.MediaID)
+	}
+
+	// The decision is set by the caller
+	if item.Decision != database.RSSDecisionDenied {
+		t.Errorf("Decision = %q, want %q", item.Decision, database.RSSDecisionDenied)
+	}
+}
+
+func TestRSSItemEntryUnknownConfig(t *testing.T) {
+	item := database.RSSItem{Config: "movie_does_not_exist", Title: "Movie.2020.1080p-GRP"}
+	if _, _, err := rssItemEntry(&item); !errors.Is(err, errRSSItemConfig) {
+		t.Errorf("rssItemEntry() error = %v, want %v", err, errRSSItemConfig)
+	}
+}
+
+func TestGrabRSSItemWithoutMedia(t *testing.T) {
+	if err := GrabRSSItem(1, 0); !errors.Is(err, errRSSItemNoMedia) {
+		t.Errorf("GrabRSSItem() error = %v, want %v", err, errRSSItemNoMedia)
+	}
+}
+
+func TestDownloadEntryWithoutMedia(t *testing.T) {
+	for _, isType := range []uint{
+		config.MediaTypeMovie,
+		config.MediaTypeSeries,
+		config.MediaTypeBook,
+		config.MediaTypeAudiobook,
+		config.MediaTypeMusic,
+	} {
+		s := &ConfigSearcher{Cfgp: &config.MediaTypeConfig{IsType: isType}}
+
+		// Nothing is grabbed, so a stored RSS item must not be marked as grabbed
+		err := s.downloadEntry(&apiexternal_v2.Nzbwithprio{})
+		if !errors.Is(err, errNoMediaID) {
+			t.Errorf("downloadEntry() of type %d error = %v, want %v", isType, err, errNoMediaID)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package searcher
+
+import (
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype/mtstrings"
+)
+
+// searchBackoffDays returns the days to wait before the next missing search of
+// an item after emptycount consecutive empty searches. The wait starts at
+// basedays and doubles per empty search up to maxdays.
+func searchBackoffDays(basedays, emptycount, maxdays int) int {
+	if basedays <= 0 {
+		basedays = 1
+	}
+
+	if emptycount <= 1 {
+		return min(basedays, maxdays)
+	}
+
+	days := basedays
+	for range emptycount - 1 {
+		days *= 2
+		if days >= maxdays {
+			return maxdays
+		}
+	}
+
+	return days
+}
+
+// updateSearchBackoff records the outcome of a missing search of the media.
+// A search with accepted results and searches of recently released or added
+// media reset the backoff, every other scheduled search doubles the wait until
+// the next missing search up to missing_search_backoff_max_days. Empty manual
+// searches leave the backoff untouched.
+func (s *ConfigSearcher) updateSearchBackoff(mediaid uint) {
+	if s.Cfgp == nil || s.Cfgp.DataLen == 0 || s.Cfgp.Data[0].CfgPath == nil {
+		return
+	}
+
+	cfgpath := s.Cfgp.Data[0].CfgPath
+	if cfgpath.MissingSearchBackoffMaxDays <= 0 {
+		return
+	}
+
+	if len(s.Accepted) > 0 {
+		database.ExecN(
+			mtstrings.GetStringsMap(s.Cfgp.IsType, logger.ResetMediaSearchBackoff),
+			&mediaid,
+		)
+
+		return
+	}
+
+	if !s.scheduled {
+		return
+	}
+
+	now := logger.TimeGetNow()
+
+	if cfgpath.MissingSearchRecentDays > 0 {
+		recent := now.AddDate(0, 0, -cfgpath.MissingSearchRecentDays)
+		if database.Getdatarow[uint](
+			false,
+			mtstrings.GetStringsMap(s.Cfgp.IsType, logger.SearchBackoffRecent),
+			&mediaid,
+			&recent,
+			&recent,
+		) > 0 {
+			database.ExecN(
+				mtstrings.GetStringsMap(s.Cfgp.IsType, logger.ResetMediaSearchBackoff),
+				&mediaid,
+			)
+
+			return
+		}
+	}
+
+	emptycount := database.Getdatarow[int](
+		false,
+		mtstrings.GetStringsMap(s.Cfgp.IsType, logger.SearchBackoffCount),
+		&mediaid,
+	) + 1
+
+	nextsearch := now.Add(
+		time.Duration(
+			searchBackoffDays(
+				cfgpath.MissingScanInterval,
+				emptycount,
+				cfgpath.MissingSearchBackoffMaxDays,
+			),
+		) * 24 * time.Hour,
+	)
+
+	database.ExecN(
+		mtstrings.GetStringsMap(s.Cfgp.IsType, logger.UpdateMediaSearchBackoff),
+		&emptycount,
+		&nextsearch,
+		&mediaid,
+	)
+}
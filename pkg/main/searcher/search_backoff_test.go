@@ -0,0 +1,34 @@
+package searcher
+
+import "testing"
+
+func TestSearchBackoffDays(t *testing.T) {
+	tests := []struct {
+		name       string
+		basedays   int
+		emptycount int
+		maxdays    int
+		expected   int
+	}{
+		{name: "No empty search", basedays: 2, emptycount: 0, maxdays: 30, expected: 2},
+		{name: "First empty search", basedays: 2, emptycount: 1, maxdays: 30, expected: 2},
+		{name: "Second empty search doubles", basedays: 2, emptycount: 2, maxdays: 30, expected: 4},
+		{name: "Third empty search", basedays: 2, emptycount: 3, maxdays: 30, expected: 8},
+		{name: "Fourth empty search", basedays: 2, emptycount: 4, maxdays: 30, expected: 16},
+		{name: "Capped at the maximum", basedays: 2, emptycount: 5, maxdays: 30, expected: 30},
+		{name: "Stays at the maximum", basedays: 2, emptycount: 1000, maxdays: 30, expected: 30},
+		{name: "Exactly the maximum", basedays: 2, emptycount: 4, maxdays: 16, expected: 16},
+		{name: "Base above the maximum", basedays: 40, emptycount: 1, maxdays: 30, expected: 30},
+		{name: "Base defaults to one day", basedays: 0, emptycount: 3, maxdays: 30, expected: 4},
+		{name: "Negative base", basedays: -5, emptycount: 1, maxdays: 30, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchBackoffDays(tt.basedays, tt.emptycount, tt.maxdays)
+			if got != tt.expected {
+				t.Errorf("searchBackoffDays() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
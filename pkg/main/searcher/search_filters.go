@@ -4,12 +4,15 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/expression"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype/mtstrings"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/parser"
 )
 
@@ -212,6 +215,11 @@ func (s *ConfigSearcher) validateEntry(
 		return true
 	}
 
+	// Episode not yet aired plus the search delay
+	if s.searchActionType == logger.StrRss && s.checkairtime(entry) {
+		return true
+	}
+
 	// Reject multi-episode packs for date-identified series.
 	// A title containing 2+ date patterns is a pack, not a single episode.
 	if entry.Info.Date != "" &&
@@ -235,6 +243,11 @@ func (s *ConfigSearcher) validateEntry(
 		return true
 	}
 
+	// Release group filtering
+	if s.filterBlockedGroups(entry, qual) {
+		return true
+	}
+
 	// Priority calculation
 	if entry.Info.Priority == 0 {
 		parser.GetPriorityMapQual(&entry.Info, s.Cfgp, qual, false, true)
@@ -252,17 +265,30 @@ func (s *ConfigSearcher) validateEntry(
 		return true
 	}
 
+	// Custom reject rules of the quality
+	if s.filterRejectRules(entry, qual) {
+		return true
+	}
+
 	// Priority validation
 	if s.getminimumpriority(entry, qual) {
 		return true
 	}
 
-	if entry.MinimumPriority != 0 && entry.MinimumPriority == entry.Info.Priority {
+	// A PROPER/REPACK of the existing file replaces it regardless of its priority
+	properUpgrade := s.isProperUpgrade(entry, qual)
+
+	var prio int
+	if !properUpgrade && entry.MinimumPriority != 0 {
+		prio = upgradePriority(entry, qual, s.existingHasReleaseGroup(entry, qual))
+	}
+
+	if !properUpgrade && entry.MinimumPriority != 0 && entry.MinimumPriority == prio {
 		s.logdenied("same Prio", entry)
 		return true
 	}
 
-	if entry.MinimumPriority != 0 {
+	if !properUpgrade && entry.MinimumPriority != 0 {
 		minDiff := qual.UseForPriorityMinDifference
 
 		threshold := entry.MinimumPriority
@@ -270,7 +296,7 @@ func (s *ConfigSearcher) validateEntry(
 			threshold += minDiff
 		}
 
-		if entry.Info.Priority <= threshold {
+		if prio <= threshold {
 			logger.Logtype("debug", 0).
 				Str(logger.StrReason, "lower Prio").
 				Str(logger.StrTitle, entry.NZB.Title).
@@ -295,6 +321,9 @@ func (s *ConfigSearcher) validateEntry(
 		return true
 	}
 
+	// Score rules only change the order of the accepted releases
+	s.applyScoreRules(entry, qual)
+
 	logger.Logtype("debug", 4).
 		Str(logger.StrQuality, qual.Name).
 		Str(logger.StrTitle, entry.NZB.Title).
@@ -305,6 +334,262 @@ func (s *ConfigSearcher) validateEntry(
 	return false
 }
 
+// filterBlockedGroups rejects releases of a blocked release group of the
+// quality.
+func (s *ConfigSearcher) filterBlockedGroups(
+	entry *apiexternal_v2.Nzbwithprio,
+	qual *config.QualityConfig,
+) bool {
+	if entry.Info.ReleaseGroup == "" || len(qual.BlockedGroups) == 0 {
+		return false
+	}
+
+	for _, group := range qual.BlockedGroups {
+		if strings.EqualFold(group, entry.Info.ReleaseGroup) {
+			s.logdenied1Str("blocked group", entry, "group", entry.Info.ReleaseGroup)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RuleEnv returns the values of the release used by the reject and score
+// rules of the quality.
+func RuleEnv(entry *apiexternal_v2.Nzbwithprio) expression.Env {
+	env := expression.Env{
+		Title:      entry.NZB.Title,
+		Resolution: entry.Info.Resolution,
+		Quality:    entry.Info.Quality,
+		Codec:      entry.Info.Codec,
+		Audio:      entry.Info.Audio,
+		Group:      entry.Info.ReleaseGroup,
+		Languages:  entry.Info.Languages,
+		Size:       entry.NZB.Size,
+		Seeders:    entry.NZB.Seeders,
+		Year:       int(entry.Info.Year),
+		Priority:   entry.Info.Priority,
+		Proper:     entry.Info.Proper,
+		Repack:     entry.Info.Repack,
+		Extended:   entry.Info.Extended,
+		Torrent:    entry.NZB.IsTorrent,
+	}
+
+	if entry.NZB.Indexer != nil {
+		env.Indexer = entry.NZB.Indexer.Name
+	}
+
+	if !entry.NZB.PubDate.IsZero() {
+		env.Age = time.Since(entry.NZB.PubDate).Hours() / 24
+	}
+
+	return env
+}
+
+// filterRejectRules rejects releases matching one of the reject rules of the
+// quality.
+func (s *ConfigSearcher) filterRejectRules(
+	entry *apiexternal_v2.Nzbwithprio,
+	qual *config.QualityConfig,
+) bool {
+	if len(qual.RejectRulesCompiled) == 0 {
+		return false
+	}
+
+	env := RuleEnv(entry)
+	for _, rule := range qual.RejectRulesCompiled {
+		if rule.Eval(&env) {
+			s.logdenied1Str("reject rule", entry, "rule", rule.String())
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyScoreRules adds the points of all matching score rules of the quality
+// to the priority of the release.
+func (*ConfigSearcher) applyScoreRules(
+	entry *apiexternal_v2.Nzbwithprio,
+	qual *config.QualityConfig,
+) {
+	if len(qual.ScoreRulesCompiled) == 0 {
+		return
+	}
+
+	env := RuleEnv(entry)
+	for _, rule := range qual.ScoreRulesCompiled {
+		if rule.Program.Eval(&env) {
+			entry.Info.Priority += rule.Points
+		}
+	}
+}
+
+// checkairtime rejects RSS releases of episodes whose broadcast time plus
+// the search delay of the series or media group has not passed yet.
+// Episodes without a known air time are not delayed. The pending episodes
+// are read once per series of the batch.
+func (s *ConfigSearcher) checkairtime(entry *apiexternal_v2.Nzbwithprio) bool {
+	if s.Cfgp.IsType != config.MediaTypeSeries || entry.Info.SerieEpisodeID == 0 ||
+		entry.Info.SerieID == 0 {
+		return false
+	}
+
+	pending, ok := s.airPending[entry.Info.SerieID]
+	if !ok {
+		ids := database.GetrowsN[uint](
+			false,
+			0,
+			mtstrings.GetStringsMap(s.Cfgp.IsType, logger.SearchAirTimePending),
+			&entry.Info.SerieID,
+			&s.Cfgp.SearchDelay,
+		)
+
+		pending = make(map[uint]struct{}, len(ids))
+		for _, id := range ids {
+			pending[id] = struct{}{}
+		}
+
+		s.airPending[entry.Info.SerieID] = pending
+	}
+
+	if _, ok := pending[entry.Info.SerieEpisodeID]; !ok {
+		return false
+	}
+
+	s.logdenied("not aired yet", entry)
+
+	return true
+}
+
+// properRank orders the fix flags of a release - a proper beats a repack
+// beats the original release.
+func properRank(proper, repack bool) int {
+	switch {
+	case proper:
+		return 2
+	case repack:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// upgradePriority returns the priority of the entry to compare with the
+// priority of the existing files. The release group bonus only counts if the
+// existing files have a release group too - music, book and audiobook files
+// store none, so every release of a preferred group would look like an upgrade.
+func upgradePriority(
+	entry *apiexternal_v2.Nzbwithprio,
+	qual *config.QualityConfig,
+	existingGroup bool,
+) int {
+	if existingGroup {
+		return entry.Info.Priority
+	}
+
+	return entry.Info.Priority - parser.ReleaseGroupBonus(qual, entry.Info.ReleaseGroup)
+}
+
+// existingHasReleaseGroup checks if an existing movie or episode file of the
+// entry has a release group. Only queried if the entry gets a group bonus.
+func (s *ConfigSearcher) existingHasReleaseGroup(
+	entry *apiexternal_v2.Nzbwithprio,
+	qual *config.QualityConfig,
+) bool {
+	if entry.Info.TempID == 0 || parser.ReleaseGroupBonus(qual, entry.Info.ReleaseGroup) == 0 ||
+		(s.Cfgp.IsType != config.MediaTypeMovie && s.Cfgp.IsType != config.MediaTypeSeries) {
+		return false
+	}
+
+	files := database.Getrowssize[database.FilePrio](
+		false,
+		mtstrings.GetStringsMap(s.Cfgp.IsType, logger.DBCountFilesByMediaID),
+		mtstrings.GetStringsMap(s.Cfgp.IsType, logger.DBFilePrioFilesByID),
+		&entry.Info.TempID,
+	)
+	for idx := range files {
+		if files[idx].ReleaseGroup != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProperUpgrade checks if the entry is a PROPER/REPACK of an existing movie
+// or episode file with the same resolution and quality. With proper_upgrade
+// same_group the existing file must have been released by the same group.
+func (s *ConfigSearcher) isProperUpgrade(
+	entry *apiexternal_v2.Nzbwithprio,
+	qual *config.QualityConfig,
+) bool {
+	if qual.ProperUpgrade == "" || entry.MinimumPriority == 0 ||
+		(!entry.Info.Proper && !entry.Info.Repack) {
+		return false
+	}
+
+	if s.Cfgp.IsType != config.MediaTypeMovie && s.Cfgp.IsType != config.MediaTypeSeries {
+		return false
+	}
+
+	if entry.Info.TempID == 0 {
+		if h := mediatype.Get(s.Cfgp.IsType); h != nil {
+			h.SetEntryTempID(entry)
+		}
+
+		if entry.Info.TempID == 0 {
+			return false
+		}
+	}
+
+	files := database.Getrowssize[database.FilePrio](
+		false,
+		mtstrings.GetStringsMap(s.Cfgp.IsType, logger.DBCountFilesByMediaID),
+		mtstrings.GetStringsMap(s.Cfgp.IsType, logger.DBFilePrioFilesByID),
+		&entry.Info.TempID,
+	)
+
+	idx := properUpgradeFile(qual.ProperUpgrade, &entry.Info, files)
+	if idx == -1 {
+		return false
+	}
+
+	logger.Logtype("debug", 1).
+		Str(logger.StrTitle, entry.NZB.Title).
+		Str(logger.StrFile, files[idx].Location).
+		Msg("Proper/Repack upgrade of existing file")
+
+	return true
+}
+
+// properUpgradeFile returns the index of the file which the PROPER/REPACK info
+// replaces with the proper_upgrade mode, or -1 if it replaces none of them.
+func properUpgradeFile(mode string, info *database.ParseInfo, files []database.FilePrio) int {
+	if mode == "" || (!info.Proper && !info.Repack) {
+		return -1
+	}
+
+	rank := properRank(info.Proper, info.Repack)
+	for idx := range files {
+		if files[idx].ResolutionID != info.ResolutionID ||
+			files[idx].QualityID != info.QualityID ||
+			properRank(files[idx].Proper, files[idx].Repack) >= rank {
+			continue
+		}
+
+		if mode == "same_group" &&
+			(files[idx].ReleaseGroup == "" ||
+				!strings.EqualFold(files[idx].ReleaseGroup, info.ReleaseGroup)) {
+			continue
+		}
+
+		return idx
+	}
+
+	return -1
+}
+
 // filterSizeNzbs checks if the NZB entry size is within the configured
 // minimum and maximum size limits, and returns true if it should be
 // rejected based on its size.
@@ -716,6 +1001,14 @@ func (s *ConfigSearcher) checkepisode(sourceentry, entry *apiexternal_v2.Nzbwith
 		return false
 	}
 
+	switch checkSceneMapping(sourceentry, entry) {
+	case sceneMappingMatch:
+		return false
+	case sceneMappingOther:
+		s.logdenied1StrNo("scene mapping to other episode", entry, &sourceentry.Info)
+		return true
+	}
+
 	if s.checkAlternativeFormats(sourceentry, entry) {
 		return false
 	}
@@ -733,6 +1026,59 @@ func (s *ConfigSearcher) checkepisode(sourceentry, entry *apiexternal_v2.Nzbwith
 	return s.checkEpisodeFormat(sourceentry, entry, sourceentry.Info.Identifier)
 }
 
+// sceneMappingResult is the outcome of the scene mapping check of an entry.
+type sceneMappingResult uint8
+
+const (
+	// sceneMappingNone - no mapping exists, the identifier decides.
+	sceneMappingNone sceneMappingResult = iota
+	// sceneMappingMatch - the mapping points to the wanted episode.
+	sceneMappingMatch
+	// sceneMappingOther - the mapping points to a different episode.
+	sceneMappingOther
+)
+
+// checkSceneMapping checks whether the scene numbering parsed from the entry
+// maps to the wanted TVDB season and episode of the source entry.
+func checkSceneMapping(sourceentry, entry *apiexternal_v2.Nzbwithprio) sceneMappingResult {
+	if sourceentry.Dbid == 0 || (entry.Info.Episode == 0 && entry.Info.AbsoluteEpisode == 0) {
+		return sceneMappingNone
+	}
+
+	season, episode, ok := database.SceneToTvdb(
+		sourceentry.Dbid,
+		entry.Info.Season,
+		entry.Info.Episode,
+		entry.Info.AbsoluteEpisode,
+	)
+	if !ok {
+		return sceneMappingNone
+	}
+
+	return compareSceneMapping(season, episode, sourceentry.NZB.Season, sourceentry.NZB.Episode)
+}
+
+// compareSceneMapping compares the mapped TVDB season and episode with the
+// wanted ones. Without a numeric wanted season and episode the identifier
+// decides.
+func compareSceneMapping(season, episode int, wantedSeason, wantedEpisode string) sceneMappingResult {
+	wantseason, err := strconv.Atoi(wantedSeason)
+	if err != nil {
+		return sceneMappingNone
+	}
+
+	wantepisode, err := strconv.Atoi(wantedEpisode)
+	if err != nil {
+		return sceneMappingNone
+	}
+
+	if season == wantseason && episode == wantepisode {
+		return sceneMappingMatch
+	}
+
+	return sceneMappingOther
+}
+
 // checkEpisodeFormat validates the episode identifier format for a given entry.
 // It checks if the identifier matches the expected season and episode format.
 // Returns false if the identifier is valid, true if the entry should be skipped.
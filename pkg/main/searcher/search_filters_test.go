@@ -0,0 +1,288 @@
+package searcher
+
+import (
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+)
+
+func TestCompareSceneMapping(t *testing.T) {
+	tests := []struct {
+		name          string
+		season        int
+		episode       int
+		wantedSeason  string
+		wantedEpisode string
+		expected      sceneMappingResult
+	}{
+		{
+			name:          "Mapping to the wanted episode",
+			season:        2,
+			episode:       5,
+			wantedSeason:  "2",
+			wantedEpisode: "5",
+			expected:      sceneMappingMatch,
+		},
+		{
+			name:          "Padded wanted numbers",
+			season:        2,
+			episode:       5,
+			wantedSeason:  "02",
+			wantedEpisode: "05",
+			expected:      sceneMappingMatch,
+		},
+		{
+			name:          "Mapping to another episode is denied",
+			season:        2,
+			episode:       6,
+			wantedSeason:  "2",
+			wantedEpisode: "5",
+			expected:      sceneMappingOther,
+		},
+		{
+			name:          "Mapping to another season is denied",
+			season:        3,
+			episode:       5,
+			wantedSeason:  "2",
+			wantedEpisode: "5",
+			expected:      sceneMappingOther,
+		},
+		{
+			name:          "Wanted episode not numeric",
+			season:        2,
+			episode:       5,
+			wantedSeason:  "2",
+			wantedEpisode: "",
+			expected:      sceneMappingNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compareSceneMapping(tt.season, tt.episode, tt.wantedSeason, tt.wantedEpisode)
+			if got != tt.expected {
+				t.Errorf("compareSceneMapping() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpgradePriority(t *testing.T) {
+	quality := &config.QualityConfig{
+		PreferredGroups:      []string{"FLUX"},
+		PreferredGroupsBonus: 20,
+	}
+
+	// The parsed priority already includes the bonus of the release group
+	tests := []struct {
+		name          string
+		group         string
+		priority      int
+		existingGroup bool
+		expected      int
+	}{
+		{
+			name:          "Bonus if the existing file has a group",
+			group:         "FLUX",
+			priority:      120,
+			existingGroup: true,
+			expected:      120,
+		},
+		{
+			name:     "No bonus if the existing file has no group",
+			group:    "FLUX",
+			priority: 120,
+			expected: 100,
+		},
+		{
+			name:          "Other group",
+			group:         "YIFY",
+			priority:      100,
+			existingGroup: true,
+			expected:      100,
+		},
+		{
+			name:     "Neither has a group",
+			priority: 100,
+			expected: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &apiexternal_v2.Nzbwithprio{
+				Info: database.ParseInfo{ReleaseGroup: tt.group, Priority: tt.priority},
+			}
+
+			if got := upgradePriority(entry, quality, tt.existingGroup); got != tt.expected {
+				t.Errorf("upgradePriority() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProperUpgradeFile(t *testing.T) {
+	files := []database.FilePrio{
+		{Location: "other.mkv", ResolutionID: 2, QualityID: 3, ReleaseGroup: "NTb"},
+		{Location: "movie.mkv", ResolutionID: 1, QualityID: 3, ReleaseGroup: "FLUX"},
+	}
+
+	tests := []struct {
+		name     string
+		mode     string
+		info     database.ParseInfo
+		files    []database.FilePrio
+		expected int
+	}{
+		{
+			name: "Disabled",
+			info: database.ParseInfo{
+				ResolutionID: 1,
+				QualityID:    3,
+				Proper:       true,
+				ReleaseGroup: "FLUX",
+			},
+			files:    files,
+			expected: -1,
+		},
+		{
+			name: "Same group",
+			mode: "same_group",
+			info: database.ParseInfo{
+				ResolutionID: 1,
+				QualityID:    3,
+				Proper:       true,
+				ReleaseGroup: "flux",
+			},
+			files:    files,
+			expected: 1,
+		},
+		{
+			name: "Same group denies other groups",
+			mode: "same_group",
+			info: database.ParseInfo{
+				ResolutionID: 1,
+				QualityID:    3,
+				Repack:       true,
+				ReleaseGroup: "YIFY",
+			},
+			files:    files,
+			expected: -1,
+		},
+		{
+			name: "Same group denies files without group",
+			mode: "same_group",
+			info: database.ParseInfo{
+				ResolutionID: 1,
+				QualityID:    3,
+				Repack:       true,
+				ReleaseGroup: "FLUX",
+			},
+			files: []database.FilePrio{
+				{Location: "movie.mkv", ResolutionID: 1, QualityID: 3},
+			},
+			expected: -1,
+		},
+		{
+			name: "Any group",
+			mode: "any_group",
+			info: database.ParseInfo{
+				ResolutionID: 1,
+				QualityID:    3,
+				Repack:       true,
+				ReleaseGroup: "YIFY",
+			},
+			files:    files,
+			expected: 1,
+		},
+		{
+			name:     "Any group needs the same resolution and quality",
+			mode:     "any_group",
+			info:     database.ParseInfo{ResolutionID: 1, QualityID: 4, Proper: true},
+			files:    files,
+			expected: -1,
+		},
+		{
+			name:     "Not a PROPER or REPACK",
+			mode:     "any_group",
+			info:     database.ParseInfo{ResolutionID: 1, QualityID: 3},
+			files:    files,
+			expected: -1,
+		},
+		{
+			name: "REPACK does not replace a PROPER",
+			mode: "any_group",
+			info: database.ParseInfo{ResolutionID: 1, QualityID: 3, Repack: true},
+			files: []database.FilePrio{
+				{Location: "movie.mkv", ResolutionID: 1, QualityID: 3, Proper: true},
+			},
+			expected: -1,
+		},
+		{
+			name: "PROPER replaces a REPACK",
+			mode: "any_group",
+			info: database.ParseInfo{ResolutionID: 1, QualityID: 3, Proper: true},
+			files: []database.FilePrio{
+				{Location: "movie.mkv", ResolutionID: 1, QualityID: 3, Repack: true},
+			},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := properUpgradeFile(tt.mode, &tt.info, tt.files); got != tt.expected {
+				t.Errorf("properUpgradeFile() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckairtime(t *testing.T) {
+	s := &ConfigSearcher{
+		Cfgp:            &config.MediaTypeConfig{IsType: config.MediaTypeSeries},
+		airPending:      map[uint]map[uint]struct{}{1: {10: {}}},
+		processedURLs:   make(map[string]struct{}),
+		processedTitles: make(map[string]struct{}),
+		processedNorm:   make(map[string]struct{}),
+	}
+
+	tests := []struct {
+		name      string
+		isType    uint
+		serieID   uint
+		episodeID uint
+		expected  bool
+	}{
+		{
+			name:      "Pending episode",
+			isType:    config.MediaTypeSeries,
+			serieID:   1,
+			episodeID: 10,
+			expected:  true,
+		},
+		{name: "Aired episode", isType: config.MediaTypeSeries, serieID: 1, episodeID: 11},
+		{name: "Unknown episode", isType: config.MediaTypeSeries, serieID: 1},
+		{name: "Unknown series", isType: config.MediaTypeSeries, episodeID: 10},
+		{name: "Movies are not delayed", isType: config.MediaTypeMovie, serieID: 1, episodeID: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.Cfgp.IsType = tt.isType
+			entry := &apiexternal_v2.Nzbwithprio{
+				Info: database.ParseInfo{SerieID: tt.serieID, SerieEpisodeID: tt.episodeID},
+			}
+
+			if got := s.checkairtime(entry); got != tt.expected {
+				t.Errorf("checkairtime() = %v, want %v", got, tt.expected)
+			}
+
+			if tt.expected && entry.Reason != "not aired yet" {
+				t.Errorf("Reason = %q, want %q", entry.Reason, "not aired yet")
+			}
+		})
+	}
+}
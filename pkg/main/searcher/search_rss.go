@@ -194,21 +194,21 @@ func (s *ConfigSearcher) getRSSFeed(
 		return errRegexEmpty
 	}
 
-	if s.isIndexerBlocked(listentry.CfgList.URL) {
+	if s.Cfgp == nil {
+		return errOther
+	}
+
+	customindexer := setupIndexerConfig(listentry)
+
+	if s.isIndexerBlocked(customindexer) {
 		logger.Logtype("debug", 2).
 			Str(logger.StrListname, listentry.TemplateList).
 			Int(strMinutes, -1*config.GetSettingsGeneral().FailedIndexerBlockTime).
-			Msg("Indexer temporarily disabled due to fail in last")
+			Msg("Indexer temporarily disabled due to fail in last or exhausted quota")
 
 		return logger.ErrDisabled
 	}
 
-	if s.Cfgp == nil {
-		return errOther
-	}
-
-	customindexer := setupIndexerConfig(listentry)
-
 	firstid, err := apiexternal.QueryNewznabRSSLastCustom(
 		customindexer,
 		s.Quality,
@@ -276,6 +276,11 @@ func (s *ConfigSearcher) searchSeriesRSSSeason(
 	p.useseason = useseason
 	s.isSeasonSearch = true
 
+	if quality != nil && quality.SeasonPackWantedPercent > 0 {
+		s.seasonPackTvdb = thetvdbid
+		s.seasonPackSeason = season
+	}
+
 	logger.Logtype("info", 2).
 		Str(logger.StrSeason, p.season).
 		Int(logger.StrTvdb, p.thetvdbid).
@@ -0,0 +1,223 @@
+package searcher
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
+)
+
+const (
+	querySeasonPackEpisode  = "select serie_episodes.serie_id, serie_episodes.dbserie_id, dbserie_episodes.season from serie_episodes inner join dbserie_episodes on dbserie_episodes.id=serie_episodes.dbserie_episode_id where serie_episodes.id = ?"
+	querySeasonPackTotal    = "select count() from serie_episodes inner join dbserie_episodes on dbserie_episodes.id=serie_episodes.dbserie_episode_id where serie_episodes.serie_id = ? and dbserie_episodes.season = ?"
+	querySeasonPackMissing  = "select count() from serie_episodes inner join dbserie_episodes on dbserie_episodes.id=serie_episodes.dbserie_episode_id where serie_episodes.serie_id = ? and dbserie_episodes.season = ? and serie_episodes.missing = 1 and serie_episodes.dont_search = 0"
+	querySeasonPackUnaired  = "select count() from dbserie_episodes where dbserie_id = ? and season = ? and (first_aired is null or first_aired > datetime('now','localtime') or air_time > datetime('now'))"
+	querySeasonPackFirst    = "select serie_episodes.id as num1, serie_episodes.dbserie_episode_id as num2 from serie_episodes inner join dbserie_episodes on dbserie_episodes.id=serie_episodes.dbserie_episode_id where serie_episodes.serie_id = ? and dbserie_episodes.season = ? order by serie_episodes.missing desc, cast(dbserie_episodes.episode as integer) asc limit 1"
+	querySeasonPackLastscan = "update serie_episodes set lastscan = datetime('now','localtime') where serie_id = ? and missing = 1 and dbserie_episode_id in (select id from dbserie_episodes where dbserie_id = ? and season = ?)"
+)
+
+// seasonPackWanted checks whether a full-season release of the given season
+// should be grabbed for the series. The season must have finished airing -
+// episodes without an air date count as unaired - and at least percent of its
+// episodes must still be missing. It returns an empty
+// string if a pack is wanted, otherwise the reason why it is not.
+func seasonPackWanted(serieID, dbserieID uint, season string, percent int) string {
+	if percent <= 0 {
+		return "season packs disabled"
+	}
+
+	total := database.Getdatarow[uint](false, querySeasonPackTotal, &serieID, &season)
+	if total == 0 {
+		return "unwanted Season"
+	}
+
+	if database.Getdatarow[uint](false, querySeasonPackUnaired, &dbserieID, &season) > 0 {
+		return "season still airing"
+	}
+
+	missing := database.Getdatarow[uint](false, querySeasonPackMissing, &serieID, &season)
+	if missing == 0 || missing*100 < total*uint(percent) { //nolint:gosec // percent is validated to 0-100
+		return "season pack not wanted"
+	}
+
+	return ""
+}
+
+// getseasonpack resolves a full-season release found during a season search.
+// Season packs carry no episode identifier, so the IDs are set from the first
+// missing episode of the searched season; the organizer splits the pack into
+// its episodes on import. The first return value reports whether the entry is
+// a season pack, the second whether it was denied.
+func (s *ConfigSearcher) getseasonpack(entry *apiexternal_v2.Nzbwithprio) (bool, bool) {
+	if s.seasonPackSeason == "" || entry.Info.Identifier != "" || entry.Info.Date != "" {
+		return false, false
+	}
+
+	title, season, ok := parser_v2.ParseSeasonPack(entry.NZB.Title)
+	if !ok {
+		return false, false
+	}
+
+	if wanted, err := strconv.Atoi(s.seasonPackSeason); err != nil || wanted != season {
+		s.logdenied1Str("unwanted Season", entry, logger.StrSeason, strconv.Itoa(season))
+		return true, true
+	}
+
+	entry.Info.Title = title
+	entry.Info.SeasonStr = s.seasonPackSeason
+	entry.Info.Season = season
+	entry.NZB.Season = s.seasonPackSeason
+
+	database.Scanrowsdyn(
+		false,
+		database.QueryDbseriesGetIDByTvdb,
+		&entry.Info.DbserieID,
+		&s.seasonPackTvdb,
+	)
+
+	if entry.Info.DbserieID == 0 {
+		s.logdenied("unwanted DBSerie", entry)
+		return true, true
+	}
+
+	for idx := range s.Cfgp.Lists {
+		database.Scanrowsdyn(
+			false,
+			database.QuerySeriesGetIDByDBIDListname,
+			&entry.Info.SerieID,
+			&entry.Info.DbserieID,
+			&s.Cfgp.Lists[idx].Name,
+		)
+
+		if entry.Info.SerieID != 0 {
+			entry.Info.ListID = idx
+			break
+		}
+	}
+
+	if entry.Info.SerieID == 0 {
+		s.logdenied("unwanted Serie", entry)
+		return true, true
+	}
+
+	if reason := seasonPackWanted(
+		entry.Info.SerieID,
+		entry.Info.DbserieID,
+		s.seasonPackSeason,
+		s.Quality.SeasonPackWantedPercent,
+	); reason != "" {
+		s.logdenied(reason, entry)
+		return true, true
+	}
+
+	first := database.GetrowsN[database.DbstaticTwoUint](
+		false,
+		1,
+		querySeasonPackFirst,
+		&entry.Info.SerieID,
+		&s.seasonPackSeason,
+	)
+	if len(first) == 0 {
+		s.logdenied("unwanted Episode", entry)
+		return true, true
+	}
+
+	entry.Info.SerieEpisodeID = first[0].Num1
+	entry.Info.DbserieEpisodeID = first[0].Num2
+	entry.SeasonPack = true
+
+	return true, false
+}
+
+// SearchSeasonPack searches for a full-season release of the season the given
+// missing episode belongs to, if enough of that season is wanted according to
+// the quality profile. Results are cached per series and season in seasons so
+// that a single missing search run queries each season at most once.
+// It returns true if a season pack covering the episode was grabbed and the
+// episode does not need to be searched on its own.
+func SearchSeasonPack(
+	ctx context.Context,
+	cfgp *config.MediaTypeConfig,
+	quality *config.QualityConfig,
+	serieEpisodeID uint,
+	seasons map[string]bool,
+) bool {
+	if cfgp == nil || quality == nil || quality.SeasonPackWantedPercent <= 0 ||
+		!mediatype.SupportsSeasonSearch(cfgp.IsType) {
+		return false
+	}
+
+	var (
+		row    database.DbstaticTwoUint
+		season string
+	)
+
+	database.GetdatarowArgs(querySeasonPackEpisode, &serieEpisodeID, &row.Num1, &row.Num2, &season)
+
+	if row.Num1 == 0 || season == "" || season == "0" {
+		return false
+	}
+
+	key := logger.JoinStrings(strconv.FormatUint(uint64(row.Num1), 10), "_", season)
+	if grabbed, ok := seasons[key]; ok {
+		return grabbed
+	}
+
+	seasons[key] = false
+
+	if database.Getdatarow[string](
+		false,
+		database.QueryDbseriesGetIdentifiedByID,
+		&row.Num2,
+	) == logger.StrDate {
+		return false
+	}
+
+	if seasonPackWanted(row.Num1, row.Num2, season, quality.SeasonPackWantedPercent) != "" {
+		return false
+	}
+
+	tvdbid := database.Getdatarow[int](
+		false,
+		"select thetvdb_id from dbseries where id = ?",
+		&row.Num2,
+	)
+	if tvdbid == 0 {
+		return false
+	}
+
+	s := NewSearcher(cfgp, quality, logger.StrRss, nil)
+	defer s.Close()
+
+	s.seasonPackOnly = true
+
+	err := s.searchSeriesRSSSeason(ctx, cfgp, quality, tvdbid, season, true, true, false)
+	if err != nil {
+		logger.Logtype("error", 0).
+			Int(logger.StrTvdb, tvdbid).
+			Str(logger.StrSeason, season).
+			Err(err).
+			Msg("Season pack search failed")
+
+		return false
+	}
+
+	for idx := range s.Accepted {
+		if !s.Accepted[idx].SeasonPack {
+			continue
+		}
+
+		database.ExecN(querySeasonPackLastscan, &row.Num1, &row.Num2, &season)
+
+		seasons[key] = true
+
+		return true
+	}
+
+	return false
+}
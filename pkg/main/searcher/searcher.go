@@ -50,6 +50,15 @@ type ConfigSearcher struct {
 	// isSeasonSearch indicates this is a season or date-series name search
 	// (searchTypeSeason or searchTypeSeasonDate) that should use getmediadatarss
 	isSeasonSearch bool
+	// seasonPackOnly denies every result that is not a full-season release
+	seasonPackOnly bool
+	// scheduled marks searches of the scheduler - only they extend the backoff
+	// of missing searches, manual searches can just reset it
+	scheduled bool
+	// seasonPackTvdb and seasonPackSeason identify the season searched for,
+	// used to resolve full-season releases which lack an episode identifier
+	seasonPackTvdb   int
+	seasonPackSeason string
 	// Cfgp is a pointer to a MediaTypeConfig
 	Cfgp *config.MediaTypeConfig
 	// Quality is a pointer to a QualityConfig
@@ -65,11 +74,15 @@ type ConfigSearcher struct {
 	// keyed by lowercased title + size bucket, so case or indexer differences
 	// don't cause the same release to be parsed twice.
 	processedNorm map[string]struct{}
+	// airPending holds the episodes per series whose air time plus search
+	// delay has not passed, loaded once per series for an RSS batch
+	airPending map[uint]map[uint]struct{}
 }
 
 type searchParams struct {
 	e               apiexternal_v2.Nzbwithprio
 	sourcealttitles []syncops.DbstaticTwoStringOneInt
+	searchids       []mediatype.SearchID
 	season          string
 	searchtype      int
 	thetvdbid       int
@@ -112,6 +125,7 @@ var (
 	errSearchQualityEmpty              = errors.New("search quality empty")
 	errRegexEmpty                      = errors.New("regex template empty")
 	errQualityConfigNotFoundForIndexer = errors.New("quality configuration not found for indexer")
+	errNoMediaID                       = errors.New("media id of the entry missing")
 	plsearcher                         pool.Poolobj[ConfigSearcher]
 	plsearchparam                      pool.Poolobj[searchParams]
 )
@@ -137,6 +151,10 @@ func (s *ConfigSearcher) reset() {
 	s.searchActionType = ""
 	s.isArtistAuthorSearch = false
 	s.isSeasonSearch = false
+	s.seasonPackOnly = false
+	s.scheduled = false
+	s.seasonPackTvdb = 0
+	s.seasonPackSeason = ""
 	// Clear config references so a pooled searcher can never carry a stale
 	// quality profile into its next use (NewSearcher only overwrites Quality
 	// when the caller provides one).
@@ -159,6 +177,7 @@ func (s *ConfigSearcher) reset() {
 	clear(s.processedTitles)
 	clear(s.processedNorm)
 	clear(s.indexerConfigMap)
+	clear(s.airPending)
 }
 
 // Init initializes the searcher subsystem by setting up object pools for efficient
@@ -186,6 +205,7 @@ func Init() {
 		cs.processedURLs = make(map[string]struct{}, defaultProcessedCap)
 		cs.processedTitles = make(map[string]struct{}, defaultProcessedCap)
 		cs.processedNorm = make(map[string]struct{}, defaultProcessedCap)
+		cs.airPending = make(map[uint]map[uint]struct{}, 10)
 	}, func(cs *ConfigSearcher) bool {
 		cs.reset()
 		return false
@@ -219,6 +239,7 @@ func NewSearcher(
 			processedURLs:    make(map[string]struct{}, defaultProcessedCap),
 			processedTitles:  make(map[string]struct{}, defaultProcessedCap),
 			processedNorm:    make(map[string]struct{}, defaultProcessedCap),
+			airPending:       make(map[uint]map[uint]struct{}, 10),
 		}
 	}
 
@@ -234,6 +255,13 @@ func NewSearcher(
 	return s
 }
 
+// Scheduled marks the search as run by the scheduler. Empty missing searches
+// of scheduled searches extend the search backoff of the media.
+func (s *ConfigSearcher) Scheduled() *ConfigSearcher {
+	s.scheduled = true
+	return s
+}
+
 // MediaSearch searches indexers for the given media entry (movie or TV episode)
 // using the configured quality profile. It handles filling search variables,
 // executing searches across enabled indexers, parsing results, and optionally
@@ -371,6 +399,11 @@ func (s *ConfigSearcher) MediaSearch(
 		)
 	}
 
+	// The IDs are the same for every indexer - load them once per search
+	if !titlesearch && mediatype.SupportsIDSearch(cfgp.IsType) {
+		p.searchids = mediatype.SearchIDs(cfgp.IsType, &p.e)
+	}
+
 	// logger.Logtype("debug", 1).Uint("mediaid", mediaid).Msg("Pre searchindexers")
 	s.searchindexers(ctx, false, p)
 	// logger.Logtype("debug", 1).Uint("mediaid", mediaid).Msg("Post searchindexers")
@@ -393,6 +426,10 @@ func (s *ConfigSearcher) MediaSearch(
 		}
 	}
 
+	if s.searchActionType == "missing" {
+		s.updateSearchBackoff(p.mediaid)
+	}
+
 	return nil
 }
 
@@ -434,7 +471,8 @@ func (s *ConfigSearcher) searchindexers(ctx context.Context, userss bool, p *sea
 	indexers := make([]*config.IndexersConfig, 0, len(s.Quality.IndexerCfg))
 
 	for _, indcfg := range s.Quality.IndexerCfg {
-		if indcfg == nil || !strings.EqualFold(indcfg.IndexerType, "newznab") {
+		if indcfg == nil ||
+			(!strings.EqualFold(indcfg.IndexerType, "newznab") && !apiexternal.IsCardigann(indcfg)) {
 			continue
 		}
 
@@ -446,6 +484,15 @@ func (s *ConfigSearcher) searchindexers(ctx context.Context, userss bool, p *sea
 			continue
 		}
 
+		if s.isIndexerBlocked(indcfg) {
+			logger.Logtype("debug", 2).
+				Str(logger.StrIndexer, indcfg.Name).
+				Str("quality", s.Quality.Name).
+				Msg("Skipping indexer - blocked after failures or quota exhausted")
+
+			continue
+		}
+
 		indexers = append(indexers, indcfg)
 	}
 
@@ -533,7 +580,7 @@ func (s *ConfigSearcher) searchnameid(p *searchParams, indcfg *config.IndexersCo
 	// HasSearchID checks if the entry has a valid search ID for the media type.
 	usequerysearch := p.titlesearch ||
 		!mediatype.SupportsIDSearch(s.Cfgp.IsType) ||
-		!mediatype.HasSearchID(s.Cfgp.IsType, &p.e)
+		!mediatype.HasSearchID(s.Cfgp.IsType, indcfg, p.searchids)
 
 	// Collect this indexer's results in a goroutine-local slice so result-count
 	// decisions (title fallback, first-found short-circuit) are per-indexer and
@@ -543,6 +590,10 @@ func (s *ConfigSearcher) searchnameid(p *searchParams, indcfg *config.IndexersCo
 
 	var err error
 
+	// Set if the indexer caps rule out an ID search; the title search then
+	// runs even without BackupSearchForTitle.
+	var capstitlesearch bool
+
 	// ID-based search (more efficient)
 	if !usequerysearch {
 		logger.Logtype("debug", 2).
@@ -552,8 +603,18 @@ func (s *ConfigSearcher) searchnameid(p *searchParams, indcfg *config.IndexersCo
 
 		err = s.performIDSearch(p, indcfg, cats, &local)
 
-		// Check if we should fallback to title search
-		if s.Quality.SearchForTitleIfEmpty && len(local.Arr) == 0 {
+		if errors.Is(err, apiexternal.ErrNoIDSearch) {
+			// The indexer caps support none of the IDs of the media
+			logger.Logtype("debug", 2).
+				Str(logger.StrIndexer, indcfg.Name).
+				Str("search_type", s.searchActionType).
+				Msg("Indexer caps lack a usable ID search, using title search")
+
+			err = nil
+			usequerysearch = true
+			capstitlesearch = true
+		} else if s.Quality.SearchForTitleIfEmpty && len(local.Arr) == 0 {
+			// Check if we should fallback to title search
 			logger.Logtype("debug", 2).
 				Str(logger.StrIndexer, indcfg.Name).
 				Str("search_type", s.searchActionType).
@@ -570,7 +631,7 @@ func (s *ConfigSearcher) searchnameid(p *searchParams, indcfg *config.IndexersCo
 			Str("search_type", s.searchActionType).
 			Msg("Using title-based search strategy")
 
-		errsub := s.performTitleSearch(p, indcfg, cats, &local)
+		errsub := s.performTitleSearch(p, indcfg, cats, capstitlesearch, &local)
 		if err == nil && errsub != nil {
 			err = errsub
 		}
@@ -595,17 +656,19 @@ func (s *ConfigSearcher) searchnameid(p *searchParams, indcfg *config.IndexersCo
 // queries against one indexer, collecting results into the goroutine-local
 // results slice. With CheckUntilFirstFound, it stops querying this indexer
 // as soon as any query returned candidate results - the remaining fallback
-// queries would only produce redundant candidates.
+// queries would only produce redundant candidates. forcetitle runs the primary
+// title query even if BackupSearchForTitle is disabled.
 func (s *ConfigSearcher) performTitleSearch(
 	p *searchParams,
 	indcfg *config.IndexersConfig,
 	cats int,
+	forcetitle bool,
 	results *apiexternal.NzbSlice,
 ) error {
 	var err error
 
 	// Primary title search
-	if p.titlesearch || s.Quality.BackupSearchForTitle {
+	if p.titlesearch || forcetitle || s.Quality.BackupSearchForTitle {
 		// Use SearchFor if populated (includes artist/author for music/books/audiobooks),
 		// otherwise fall back to WantedTitle
 		searchQuery := p.e.WantedTitle
@@ -623,8 +686,17 @@ func (s *ConfigSearcher) performTitleSearch(
 	// Absolute episode search (for shows with absolute numbering like anime or long-running series)
 	// Only search by absolute episode if it's filled and different from regular episode
 	if mediatype.SupportsAbsoluteEpisode(s.Cfgp.IsType) && p.e.Info.AbsoluteEpisode > 0 {
+		absolute := p.e.Info.AbsoluteEpisode
+		// Releases follow the scene absolute numbering if the series has a mapping for it
+		season, _ := strconv.Atoi(p.e.NZB.Season)
+		episode, _ := strconv.Atoi(p.e.NZB.Episode)
+		if _, _, sceneAbsolute, ok := database.TvdbToScene(p.e.Dbid, season, episode); ok &&
+			sceneAbsolute > 0 {
+			absolute = sceneAbsolute
+		}
+
 		// Build search string with absolute episode number (e.g., "Series Name E643")
-		absoluteSearch := p.e.WantedTitle + " E" + strconv.Itoa(p.e.Info.AbsoluteEpisode)
+		absoluteSearch := p.e.WantedTitle + " E" + strconv.Itoa(absolute)
 		if err = s.executeQuerySearch(
 			p,
 			indcfg,
@@ -733,39 +805,48 @@ func (s *ConfigSearcher) Download() {
 				Msg("NZB found - starting download")
 		}
 
-		// Download based on media type using appropriate handler
-		switch s.Cfgp.IsType {
-		case config.MediaTypeMovie:
-			if entry.NzbmovieID != 0 {
-				s.downloadedMap[entry.NzbmovieID] = struct{}{} // O(1) duplicate tracking
-				downloader.DownloadMovie(s.Cfgp, entry)
-			}
+		// Errors are logged by the downloader
+		s.downloadEntry(entry)
+	}
+}
 
-		case config.MediaTypeSeries:
-			if entry.NzbepisodeID != 0 {
-				s.downloadedMap[entry.NzbepisodeID] = struct{}{} // O(1) duplicate tracking
-				downloader.DownloadSeriesEpisode(s.Cfgp, entry)
-			}
+// downloadEntry downloads the entry with the downloader of its media type.
+// Returns errNoMediaID if the entry has no ID of the media type and the error
+// of the downloader if nothing was grabbed.
+func (s *ConfigSearcher) downloadEntry(entry *apiexternal_v2.Nzbwithprio) error {
+	switch s.Cfgp.IsType {
+	case config.MediaTypeMovie:
+		if entry.NzbmovieID != 0 {
+			s.downloadedMap[entry.NzbmovieID] = struct{}{} // O(1) duplicate tracking
+			return downloader.DownloadMovie(s.Cfgp, entry)
+		}
 
-		case config.MediaTypeBook:
-			if entry.NzbbookID != 0 {
-				s.downloadedMap[entry.NzbbookID] = struct{}{}
-				downloader.DownloadBook(s.Cfgp, entry)
-			}
+	case config.MediaTypeSeries:
+		if entry.NzbepisodeID != 0 {
+			s.downloadedMap[entry.NzbepisodeID] = struct{}{} // O(1) duplicate tracking
+			return downloader.DownloadSeriesEpisode(s.Cfgp, entry)
+		}
 
-		case config.MediaTypeAudiobook:
-			if entry.NzbaudiobookID != 0 {
-				s.downloadedMap[entry.NzbaudiobookID] = struct{}{}
-				downloader.DownloadAudiobook(s.Cfgp, entry)
-			}
+	case config.MediaTypeBook:
+		if entry.NzbbookID != 0 {
+			s.downloadedMap[entry.NzbbookID] = struct{}{}
+			return downloader.DownloadBook(s.Cfgp, entry)
+		}
 
-		case config.MediaTypeMusic:
-			if entry.NzbalbumID != 0 {
-				s.downloadedMap[entry.NzbalbumID] = struct{}{}
-				downloader.DownloadAlbum(s.Cfgp, entry)
-			}
+	case config.MediaTypeAudiobook:
+		if entry.NzbaudiobookID != 0 {
+			s.downloadedMap[entry.NzbaudiobookID] = struct{}{}
+			return downloader.DownloadAudiobook(s.Cfgp, entry)
+		}
+
+	case config.MediaTypeMusic:
+		if entry.NzbalbumID != 0 {
+			s.downloadedMap[entry.NzbalbumID] = struct{}{}
+			return downloader.DownloadAlbum(s.Cfgp, entry)
 		}
 	}
+
+	return errNoMediaID
 }
 
 // Close closes the ConfigSearcher, including closing any open connections and clearing resources.
@@ -821,14 +902,22 @@ func (s *ConfigSearcher) searchparse(
 			continue
 		}
 
-		parser_v2.ParseFileP(
-			entry.NZB.Title,
-			false,
-			false,
-			s.Cfgp,
-			-1,
-			&entry.Info,
-		)
+		var indexer string
+		if entry.NZB.Indexer != nil {
+			indexer = entry.NZB.Indexer.Name
+		}
+
+		// The list scopes the parser rewrite rules - the searched media's list
+		// or the list of the RSS entry
+		listid := -1
+		switch {
+		case e != nil:
+			listid = e.Info.ListID
+		case entry.Listname != "":
+			listid = s.Cfgp.GetMediaListsEntryListID(entry.Listname)
+		}
+
+		parser_v2.ParseReleaseP(entry.NZB.Title, indexer, s.Cfgp, listid, &entry.Info)
 
 		// For music, fall back to category-inferred format when the release title
 		// has no explicit format indicator (e.g. "DENNISONN-Polyhedron EP-(XR593)-WEB-2026-PTC"
@@ -841,29 +930,42 @@ func (s *ConfigSearcher) searchparse(
 		// has no explicit resolution indicator.
 		if (s.Cfgp.IsType == config.MediaTypeMovie || s.Cfgp.IsType == config.MediaTypeSeries) &&
 			entry.Info.Resolution == "" && entry.NZB.Category != "" && entry.NZB.Indexer != nil {
-			provider := apiexternal.Getnewznabclient(entry.NZB.Indexer)
+			var supported []string
+			if provider := apiexternal.Getnewznabclient(entry.NZB.Indexer); provider != nil {
+				supported = provider.SupportedCategories
+			}
 
-			entry.Info.Resolution = resolutionFromCategory(
-				entry.NZB.Category,
-				provider.SupportedCategories,
-			)
+			entry.Info.Resolution = resolutionFromCategory(entry.NZB.Category, supported)
 		}
 
 		if handler != nil {
 			handler.ClearUntrustedID(entry)
 		}
 
-		if err := parser.GetDBIDs(&entry.Info, s.Cfgp, true, false); err != nil {
-			s.logdenied1Str(
-				err.Error(),
-				entry,
-				strCheckedFor,
-				entry.Info.Title,
-			)
+		ispack, skip := s.getseasonpack(entry)
+		if skip {
+			continue
+		}
 
+		if s.seasonPackOnly && !ispack {
+			s.logdenied("not a season pack", entry)
 			continue
 		}
 
+		// Season packs already carry the IDs of the searched season
+		if !ispack {
+			if err := parser.GetDBIDs(&entry.Info, s.Cfgp, true, false); err != nil {
+				s.logdenied1Str(
+					err.Error(),
+					entry,
+					strCheckedFor,
+					entry.Info.Title,
+				)
+
+				continue
+			}
+		}
+
 		var qual *config.QualityConfig
 		if isRSS {
 			skip, q := s.getmediadatarss(entry, -1, false)
@@ -1079,10 +1181,11 @@ func (s *ConfigSearcher) performIDSearch(
 	var err error
 
 	if h := mediatype.Get(s.Cfgp.IsType); h != nil {
-		err = h.PerformIDSearch(indcfg, s.Quality, &p.e, cats, results)
+		err = h.PerformIDSearch(indcfg, s.Quality, &p.e, p.searchids, cats, results)
 	}
 
-	if err != nil && !errors.Is(err, logger.ErrToWait) {
+	if err != nil && !errors.Is(err, logger.ErrToWait) &&
+		!errors.Is(err, apiexternal.ErrNoIDSearch) {
 		p.e.Info.TempID = p.mediaid
 		logsearcherror("Error Searching Media by ID", p.e.Info.TempID, s.Cfgp.IsType, "", err)
 
@@ -1186,11 +1289,18 @@ func getrssdata(isType uint, entry *apiexternal_v2.Nzbwithprio) {
 	)
 }
 
-// isIndexerBlocked checks if an indexer is temporarily blocked due to previous failures.
+// isIndexerBlocked checks if an indexer is temporarily blocked due to previous failures
+// or an exhausted quota.
 // It returns true if the indexer has failed within the configured block interval,
-// preventing repeated attempts to use a problematic indexer.
+// preventing repeated attempts to use a problematic indexer, or if the next request
+// would exceed the api limit of the indexer. The grab limit is checked when
+// downloading.
 // Returns false if blocking is disabled or no recent failures are found.
-func (*ConfigSearcher) isIndexerBlocked(url string) bool {
+func (*ConfigSearcher) isIndexerBlocked(ind *config.IndexersConfig) bool {
+	if apiexternal.IndexerAPIQuotaReached(ind) {
+		return true
+	}
+
 	if config.GetSettingsGeneral().FailedIndexerBlockTime == 0 {
 		return false
 	}
@@ -1201,7 +1311,7 @@ func (*ConfigSearcher) isIndexerBlocked(url string) bool {
 	return database.Getdatarow[uint](
 		false,
 		"select count() from indexer_fails where last_fail > ? and indexer = ?",
-		&intval, &url,
+		&intval, &ind.URL,
 	) >= 1
 }
 
@@ -1877,6 +1987,8 @@ func calculateFilePriority(
 		return 0
 	}
 
+	prio += parser.ReleaseGroupBonus(qualcfg, file.ReleaseGroup)
+
 	// Add bonuses for special attributes
 	if qualcfg.UseForPriorityOther || useall {
 		if file.Proper {
@@ -2066,6 +2178,7 @@ func (s *ConfigSearcher) processSearchResults(
 	}
 
 	s.searchparse(nil, nil)
+	s.recordRSSItems(quality)
 
 	if downloadentries && len(s.Accepted) > 0 {
 		s.Download()
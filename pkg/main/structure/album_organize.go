@@ -536,7 +536,7 @@ func (s *Organizer) organizeAlbumFolderViaAPI(
 	o.Filenames = s.generateTrackFilenames(o, &m, album, cfgp)
 
 	// Step 5: Tag files with proper metadata
-	if err := s.tagAlbumFiles(ctx, album); err != nil {
+	if err := s.tagAlbumFiles(ctx, album, false); err != nil {
 		logger.Logtype("error", 1).
 			Str("folder", folder).
 			Err(err).
@@ -564,7 +564,7 @@ func (s *Organizer) organizeAlbumFolderViaAPI(
 	// are different directories. When they're the same (in-place reorganization),
 	// the rename inside MoveFile handles cleanup of old filenames.
 	if filepath.Clean(folder) != filepath.Clean(o.TargetPath) {
-		if s.targetpathCfg.MoveReplaced && s.targetpathCfg.MoveReplacedTargetPath != "" {
+		if RecycleEnabled(s.targetpathCfg) {
 			s.moveReplacedAlbumFiles(o)
 		} else {
 			s.removePreexistingFilesAtTarget(o, album)
@@ -607,6 +607,14 @@ func (s *Organizer) organizeAlbumFolderViaAPI(
 	// Update the SourceFolder to the new location
 	album.SourceFolder = targetPath
 
+	// Step 7b: Tag the imported copies when the source was kept
+	if err := s.tagAlbumFiles(ctx, album, true); err != nil {
+		logger.Logtype("error", 1).
+			Str("folder", targetPath).
+			Err(err).
+			Msg("Failed to tag album files")
+	}
+
 	logger.Logtype("info", 1).
 		Str("folder", folder).
 		Str("newpath", newpath).
@@ -677,6 +685,10 @@ func (s *Organizer) organizeMultiEpisodeFolder(
 			return "cancelled", err
 		}
 
+		if s.keepSource() && database.IsImportedSource(files[i]) {
+			continue
+		}
+
 		// Match this single file as its own audiobook
 		album, matchReason := importfeed.MatchSingleAudiobookFile(
 			ctx,
@@ -762,7 +774,7 @@ func (s *Organizer) organizeMultiEpisodeFolder(
 		o.Filenames = s.generateTrackFilenames(o, &m, album, cfgp)
 
 		// Tag the file
-		if err := s.tagAlbumFiles(ctx, album); err != nil {
+		if err := s.tagAlbumFiles(ctx, album, false); err != nil {
 			logger.Logtype("error", 1).
 				Str("file", files[i]).
 				Err(err).
@@ -812,6 +824,13 @@ func (s *Organizer) organizeMultiEpisodeFolder(
 
 		album.SourceFolder = targetPath
 
+		if err := s.tagAlbumFiles(ctx, album, true); err != nil {
+			logger.Logtype("error", 1).
+				Str("file", newpath).
+				Err(err).
+				Msg("Multi-episode: failed to tag file")
+		}
+
 		logger.Logtype("info", 1).
 			Str("file", files[i]).
 			Str("newpath", newpath).
@@ -1189,7 +1208,8 @@ func (s *Organizer) removePreexistingFilesAtTarget(o *Organizerdata, _ *parser_v
 }
 
 // moveReplacedAlbumFiles moves all audio files currently at o.TargetPath to
-// targetpathCfg.MoveReplacedTargetPath before new files are written there.
+// the recycle bin in targetpathCfg.MoveReplacedTargetPath before new files are
+// written there.
 // This mirrors the MoveReplaced behaviour in the general performMove path.
 func (s *Organizer) moveReplacedAlbumFiles(o *Organizerdata) {
 	if o.TargetPath == "" || o.TargetPath == s.targetpathCfg.Path {
@@ -1209,7 +1229,8 @@ func (s *Organizer) moveReplacedAlbumFiles(o *Organizerdata) {
 			return nil
 		}
 
-		if moveErr := s.moveRemoveOldMediaFile(path, &path, nil, true); moveErr != nil {
+		moveErr := s.moveRemoveOldMediaFile(path, &path, nil, s.listname(o), true)
+		if moveErr != nil {
 			logger.Logtype("error", 1).
 				Str("file", path).
 				Err(moveErr).
@@ -1657,7 +1678,28 @@ func fetchCoverArt(coverURL string) ([]byte, string) {
 
 // tagAlbumFiles writes proper metadata tags to all tracks in an album.
 // It uses the tags package to write tags based on the album and track information.
-func (s *Organizer) tagAlbumFiles(ctx context.Context, album *parser_v2.AlbumInfo) error {
+// afterMove tells if the tracks already point to the target files. Sources kept
+// for the download client are never tagged: copies and reflinks are tagged after
+// the move, hardlinks and symlinks share the data of the source and stay untagged.
+func (s *Organizer) tagAlbumFiles(
+	ctx context.Context,
+	album *parser_v2.AlbumInfo,
+	afterMove bool,
+) error {
+	if afterMove != s.keepSource() {
+		return nil
+	}
+
+	if afterMove && s.sourcepathCfg.ImportMode != config.ImportModeCopy &&
+		s.sourcepathCfg.ImportMode != config.ImportModeReflink {
+		logger.Logtype("debug", 1).
+			Str("folder", album.SourceFolder).
+			Str("mode", s.sourcepathCfg.ImportMode).
+			Msg("Tagging skipped - files are shared with the download client")
+
+		return nil
+	}
+
 	embedArt := false
 
 	embedLyrics := false
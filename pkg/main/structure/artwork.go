@@ -0,0 +1,530 @@
+package structure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/providers"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/syncops"
+)
+
+const (
+	// tmdbImageURL is the base URL of TMDB image paths, followed by the size.
+	tmdbImageURL = "https://image.tmdb.org/t/p/"
+	// tvdbImageURL is the base URL of relative TheTVDB image paths.
+	tvdbImageURL = "https://artworks.thetvdb.com/banners/"
+)
+
+// Artwork types of the artwork_types setting.
+const (
+	artworkPoster    = "poster"
+	artworkFanart    = "fanart"
+	artworkBanner    = "banner"
+	artworkClearlogo = "clearlogo"
+	artworkSeason    = "season"
+)
+
+// serieArtworkRetry is the time until TMDB is asked again for the missing
+// artwork of a series folder.
+const serieArtworkRetry = 24 * time.Hour
+
+// serieArtworkFetched holds the series folders whose TMDB artwork was looked
+// up, so the episodes organized into them don't look it up again.
+var serieArtworkFetched = syncops.NewSyncMap[struct{}](10)
+
+// artworkURL returns the download URL of an image path of the database or a
+// provider. Paths starting with a slash are TMDB paths and get the size,
+// other relative paths are TheTVDB paths and full URLs are kept.
+func artworkURL(path, size string) string {
+	switch {
+	case path == "":
+		return ""
+	case strings.HasPrefix(path, "http://"), strings.HasPrefix(path, "https://"):
+		return path
+	case strings.HasPrefix(path, "/"):
+		if size == "" {
+			size = "original"
+		}
+
+		return tmdbImageURL + size + path
+	default:
+		return tvdbImageURL + path
+	}
+}
+
+// artworkWanted reports if the artwork type is enabled in the data config.
+// An empty artwork_types list enables all types.
+func artworkWanted(dataCfg *config.MediaDataConfig, kind string) bool {
+	return len(dataCfg.ArtworkTypes) == 0 || logger.SlicesContainsI(dataCfg.ArtworkTypes, kind)
+}
+
+// bestArtworkImage returns the path of the best rated image in English or
+// without language, or of the best rated image if there is none.
+func bestArtworkImage(images []apiexternal_v2.Image) string {
+	best, fallback := -1, -1
+	for idx := range images {
+		if images[idx].FilePath == "" {
+			continue
+		}
+
+		if fallback == -1 || images[idx].VoteAverage > images[fallback].VoteAverage {
+			fallback = idx
+		}
+
+		if images[idx].ISO639_1 != "" && images[idx].ISO639_1 != "en" {
+			continue
+		}
+
+		if best == -1 || images[idx].VoteAverage > images[best].VoteAverage {
+			best = idx
+		}
+	}
+
+	if best == -1 {
+		best = fallback
+	}
+
+	if best == -1 {
+		return ""
+	}
+
+	return images[best].FilePath
+}
+
+// artworkNeeded reports if the artwork type is enabled in the data config and
+// its file has to be written. Existing files are only replaced with overwrite.
+func artworkNeeded(dataCfg *config.MediaDataConfig, kind, path string, overwrite bool) bool {
+	if !artworkWanted(dataCfg, kind) {
+		return false
+	}
+
+	if overwrite {
+		return true
+	}
+
+	_, err := os.Stat(path)
+
+	return err != nil
+}
+
+// writeArtworkFile downloads the image into the file. The image is written
+// to a temporary file first, so an interrupted download never leaves a
+// truncated image which would be skipped as existing later on.
+func writeArtworkFile(path, url string) {
+	if url == "" {
+		return
+	}
+
+	data, _ := fetchCoverArt(url)
+	if len(data) == 0 {
+		return
+	}
+
+	tmppath := path + ".tmp"
+
+	err := os.WriteFile(tmppath, data, 0o644)
+	if err == nil {
+		err = os.Rename(tmppath, path)
+	}
+
+	if err != nil {
+		os.Remove(tmppath)
+		logger.Logtype("error", 1).
+			Str(logger.StrPath, path).
+			Err(err).
+			Msg("Failed to write artwork")
+
+		return
+	}
+
+	logger.Logtype("debug", 1).
+		Str(logger.StrPath, path).
+		Str("url", url).
+		Msg("Artwork written")
+}
+
+// movieArtworkPrefix returns the prefix of the artwork file names of a movie
+// video. Like the NFO, folders dedicated to the movie get poster.jpg etc.,
+// videos in shared folders get <video>-poster.jpg etc.
+func movieArtworkPrefix(videofile string, dbmovieID uint, pathcfg *config.PathsConfig) string {
+	if movieFolderShared(videofile, dbmovieID, pathcfg) {
+		return strings.TrimSuffix(videofile, filepath.Ext(videofile)) + "-"
+	}
+
+	return filepath.Dir(videofile) + string(os.PathSeparator)
+}
+
+// writeMovieArtwork downloads the poster, fanart and clearlogo of the
+// database movie. prefix is the artwork file name prefix of the video as
+// returned by movieArtworkPrefix.
+func writeMovieArtwork(
+	ctx context.Context,
+	dataCfg *config.MediaDataConfig,
+	dbmovieID *uint,
+	prefix string,
+) {
+	var (
+		posterPath = prefix + "poster.jpg"
+		fanartPath = prefix + "fanart.jpg"
+		logoPath   = prefix + "clearlogo.png"
+
+		needPoster = artworkNeeded(dataCfg, artworkPoster, posterPath, dataCfg.ArtworkOverwrite)
+		needFanart = artworkNeeded(dataCfg, artworkFanart, fanartPath, dataCfg.ArtworkOverwrite)
+		needLogo   = artworkNeeded(dataCfg, artworkClearlogo, logoPath, dataCfg.ArtworkOverwrite)
+	)
+	if !needPoster && !needFanart && !needLogo {
+		return
+	}
+
+	var movie database.Dbmovie
+	if err := movie.GetDbmovieByIDP(dbmovieID); err != nil {
+		return
+	}
+
+	if needPoster {
+		writeArtworkFile(posterPath, artworkURL(movie.Poster, dataCfg.ArtworkPosterSize))
+	}
+
+	if needFanart {
+		writeArtworkFile(fanartPath, artworkURL(movie.Backdrop, dataCfg.ArtworkFanartSize))
+	}
+
+	if !needLogo || movie.MoviedbID == 0 {
+		return
+	}
+
+	tmdb := providers.GetTMDB()
+	if tmdb == nil {
+		return
+	}
+
+	images, err := tmdb.GetMovieImages(ctx, movie.MoviedbID)
+	if err != nil {
+		logger.Logtype("warn", 1).
+			Int("tmdb", movie.MoviedbID).
+			Err(err).
+			Msg("Failed to get movie images")
+
+		return
+	}
+
+	writeArtworkFile(
+		logoPath,
+		artworkURL(bestArtworkImage(images.Logos), dataCfg.ArtworkPosterSize),
+	)
+}
+
+// seasonPostersMissing reports if the poster of a season of the database
+// series is missing in the series folder.
+func seasonPostersMissing(dbserieID *uint, folder string) bool {
+	for _, season := range database.GetrowsN[string](
+		false,
+		0,
+		"select distinct season from dbserie_episodes where dbserie_id = ? and season != ''",
+		dbserieID,
+	) {
+		num, err := strconv.Atoi(season)
+		if err != nil {
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(folder, seasonPosterName(num))); err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeSerieArtwork downloads the poster, fanart, banner, clearlogo and
+// season posters of the database series into the series folder. Posters and
+// fanart missing in the database are taken from TMDB. TMDB is only asked
+// when one of its images is missing and at most once a day per folder, as
+// seasons or logos TMDB has no image for stay missing.
+func writeSerieArtwork(
+	ctx context.Context,
+	dataCfg *config.MediaDataConfig,
+	dbserieID *uint,
+	folder string,
+	overwrite bool,
+) {
+	var (
+		posterPath = filepath.Join(folder, "poster.jpg")
+		fanartPath = filepath.Join(folder, "fanart.jpg")
+		bannerPath = filepath.Join(folder, "banner.jpg")
+		logoPath   = filepath.Join(folder, "clearlogo.png")
+
+		needPoster  = artworkNeeded(dataCfg, artworkPoster, posterPath, overwrite)
+		needFanart  = artworkNeeded(dataCfg, artworkFanart, fanartPath, overwrite)
+		needBanner  = artworkNeeded(dataCfg, artworkBanner, bannerPath, overwrite)
+		needLogo    = artworkNeeded(dataCfg, artworkClearlogo, logoPath, overwrite)
+		needSeasons = artworkWanted(dataCfg, artworkSeason) &&
+			(overwrite || seasonPostersMissing(dbserieID, folder))
+	)
+	if !needPoster && !needFanart && !needBanner && !needLogo && !needSeasons {
+		return
+	}
+
+	var serie database.Dbserie
+	if err := serie.GetDbserieByIDP(dbserieID); err != nil {
+		return
+	}
+
+	tmdb := providers.GetTMDB()
+	if serie.MoviedbID == 0 ||
+		(!overwrite && serieArtworkFetched.Check(folder) &&
+			!serieArtworkFetched.CheckExpires(folder, false, 0)) {
+		tmdb = nil
+	}
+
+	var images *apiexternal_v2.ImageCollection
+	if tmdb != nil && (needLogo || (needPoster && serie.Poster == "") ||
+		(needFanart && serie.Fanart == "")) {
+		var err error
+
+		images, err = tmdb.GetSeriesImages(ctx, serie.MoviedbID)
+		if err != nil {
+			logger.Logtype("warn", 1).
+				Int("tmdb", serie.MoviedbID).
+				Err(err).
+				Msg("Failed to get series images")
+		}
+	}
+
+	if images == nil {
+		images = &apiexternal_v2.ImageCollection{}
+	}
+
+	if needPoster {
+		poster := serie.Poster
+		if poster == "" {
+			poster = bestArtworkImage(images.Posters)
+		}
+
+		writeArtworkFile(posterPath, artworkURL(poster, dataCfg.ArtworkPosterSize))
+	}
+
+	if needFanart {
+		fanart := serie.Fanart
+		if fanart == "" {
+			fanart = bestArtworkImage(images.Backdrops)
+		}
+
+		writeArtworkFile(fanartPath, artworkURL(fanart, dataCfg.ArtworkFanartSize))
+	}
+
+	if needBanner {
+		writeArtworkFile(bannerPath, artworkURL(serie.Banner, dataCfg.ArtworkFanartSize))
+	}
+
+	if needLogo {
+		writeArtworkFile(
+			logoPath,
+			artworkURL(bestArtworkImage(images.Logos), dataCfg.ArtworkPosterSize),
+		)
+	}
+
+	if tmdb == nil {
+		return
+	}
+
+	serieArtworkFetched.Add(
+		folder, struct{}{}, time.Now().Add(serieArtworkRetry).UnixNano(), false, 0)
+
+	if !needSeasons {
+		return
+	}
+
+	details, err := tmdb.GetSeriesByID(ctx, serie.MoviedbID)
+	if err != nil {
+		logger.Logtype("warn", 1).
+			Int("tmdb", serie.MoviedbID).
+			Err(err).
+			Msg("Failed to get series seasons")
+
+		return
+	}
+
+	for idx := range details.Seasons {
+		path := filepath.Join(folder, seasonPosterName(details.Seasons[idx].SeasonNumber))
+		if !overwrite {
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+		}
+
+		writeArtworkFile(
+			path,
+			artworkURL(details.Seasons[idx].PosterPath, dataCfg.ArtworkPosterSize),
+		)
+	}
+}
+
+// seasonPosterName returns the Kodi file name of a season poster.
+func seasonPosterName(season int) string {
+	if season == 0 {
+		return "season-specials-poster.jpg"
+	}
+
+	return fmt.Sprintf("season%02d-poster.jpg", season)
+}
+
+// writeArtwork downloads the artwork of the organized movie or series if the
+// data config of the target path has download_artwork enabled.
+func (s *Organizer) writeArtwork(
+	ctx context.Context,
+	o *Organizerdata,
+	m *database.ParseInfo,
+	newpath string,
+) {
+	dataCfg := s.getDataConfig()
+	if dataCfg == nil || !dataCfg.DownloadArtwork {
+		return
+	}
+
+	switch s.Cfgp.IsType {
+	case config.MediaTypeMovie:
+		writeMovieArtwork(
+			ctx,
+			dataCfg,
+			&m.DbmovieID,
+			movieArtworkPrefix(newpath, m.DbmovieID, s.targetpathCfg),
+		)
+
+	case config.MediaTypeSeries:
+		rootpath := database.Getdatarow[string](
+			false,
+			"select rootpath from series where id = ?",
+			&m.SerieID,
+		)
+		if rootpath == "" {
+			rootpath = o.TargetPath
+		}
+
+		// The series folder is shared by all episodes, existing artwork is only
+		// replaced when the metadata is refreshed
+		writeSerieArtwork(ctx, dataCfg, &m.DbserieID, rootpath, false)
+	}
+}
+
+// artworkConfig returns the data config of the media config covering the
+// location if it has download_artwork enabled.
+func artworkConfig(cfgp *config.MediaTypeConfig, location string) *config.MediaDataConfig {
+	for idx := range cfgp.Data {
+		if cfgp.Data[idx].DownloadArtwork && inPath(location, cfgp.Data[idx].CfgPath) {
+			return &cfgp.Data[idx]
+		}
+	}
+
+	return nil
+}
+
+// WriteMovieArtwork downloads the artwork of the database movie next to its
+// files, e.g. after its metadata was refreshed. Only files of data paths with
+// download_artwork enabled get it.
+func WriteMovieArtwork(ctx context.Context, cfgp *config.MediaTypeConfig, dbmovieID uint) {
+	if cfgp == nil || dbmovieID == 0 {
+		return
+	}
+
+	prefixes := make(map[string]*config.MediaDataConfig)
+	for _, location := range database.GetrowsN[string](
+		false,
+		0,
+		"select location from movie_files where dbmovie_id = ?",
+		&dbmovieID,
+	) {
+		if dataCfg := artworkConfig(cfgp, location); dataCfg != nil {
+			prefixes[movieArtworkPrefix(location, dbmovieID, dataCfg.CfgPath)] = dataCfg
+		}
+	}
+
+	for prefix, dataCfg := range prefixes {
+		writeMovieArtwork(ctx, dataCfg, &dbmovieID, prefix)
+	}
+}
+
+// WriteSerieArtwork downloads the artwork of the database series into its
+// series folders, e.g. after its metadata was refreshed. Only data paths
+// with download_artwork enabled get it.
+func WriteSerieArtwork(ctx context.Context, cfgp *config.MediaTypeConfig, dbserieID uint) {
+	if cfgp == nil || dbserieID == 0 {
+		return
+	}
+
+	for _, rootpath := range database.GetrowsN[string](
+		false,
+		0,
+		"select distinct rootpath from series where dbserie_id = ? and rootpath != ''",
+		&dbserieID,
+	) {
+		if dataCfg := artworkConfig(cfgp, rootpath); dataCfg != nil {
+			writeSerieArtwork(ctx, dataCfg, &dbserieID, rootpath, dataCfg.ArtworkOverwrite)
+		}
+	}
+}
+
+// RefreshArtwork downloads the missing artwork of all movies or series of
+// the media config which have files in a data path with download_artwork
+// enabled.
+func RefreshArtwork(ctx context.Context, cfgp *config.MediaTypeConfig) error {
+	var (
+		query string
+		write func(context.Context, *config.MediaTypeConfig, uint)
+	)
+
+	switch cfgp.IsType {
+	case config.MediaTypeMovie:
+		query = logger.JoinStrings(
+			"select distinct movies.dbmovie_id from movie_files inner join movies on movies.id = movie_files.movie_id where movies.listname COLLATE NOCASE in (?",
+			cfgp.ListsQu,
+			")",
+		)
+		write = WriteMovieArtwork
+	case config.MediaTypeSeries:
+		query = logger.JoinStrings(
+			"select distinct dbserie_id from series where rootpath != '' and listname COLLATE NOCASE in (?",
+			cfgp.ListsQu,
+			")",
+		)
+		write = WriteSerieArtwork
+	default:
+		return nil
+	}
+
+	enabled := false
+	for idx := range cfgp.Data {
+		if cfgp.Data[idx].DownloadArtwork {
+			enabled = true
+			break
+		}
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	args := make([]any, 0, len(cfgp.ListsNames))
+	for idx := range cfgp.ListsNames {
+		args = append(args, &cfgp.ListsNames[idx])
+	}
+
+	for _, id := range database.GetrowsN[uint](false, 0, query, args...) {
+		if err := logger.CheckContextEnded(ctx); err != nil {
+			return err
+		}
+
+		write(ctx, cfgp, id)
+	}
+
+	return nil
+}
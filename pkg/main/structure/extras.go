@@ -0,0 +1,306 @@
+package structure
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype/mtstrings"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scanner"
+)
+
+// extraKind is the kind of a video which is not a main file.
+type extraKind uint8
+
+const (
+	extraNone extraKind = iota
+	extraSample
+	extraTrailer
+	extraFeaturette
+	extraDeletedScene
+	extraBehindTheScenes
+	extraInterview
+	extraOther
+)
+
+// extraPattern matches the file and folder names of an extra kind.
+// Folder names have to match completely, file names only need to contain
+// the words.
+type extraPattern struct {
+	file   *regexp.Regexp
+	folder *regexp.Regexp
+	kind   extraKind
+}
+
+// extraVideo is a video of the organized folder.
+type extraVideo struct {
+	path string
+	size int64
+}
+
+// extraPatterns are checked in order, the first match wins.
+var extraPatterns = []extraPattern{
+	newExtraPattern(extraSample, `samples?`, true),
+	newExtraPattern(extraTrailer, `trailers?|teasers?`, true),
+	newExtraPattern(extraFeaturette, `featurettes?`, true),
+	newExtraPattern(extraDeletedScene, `deleted(?:\W*scenes?)?`, true),
+	newExtraPattern(extraBehindTheScenes, `behind\W*the\W*scenes|making\W*of`, true),
+	newExtraPattern(extraInterview, `interviews?`, true),
+	newExtraPattern(extraOther, `extras?|bonus|other|shorts`, false),
+}
+
+// newExtraPattern compiles the words of an extra kind. Words with a
+// separator may be written with any separator.
+func newExtraPattern(kind extraKind, words string, matchFile bool) extraPattern {
+	words = strings.ReplaceAll(words, `\W*`, `[\W_]*`)
+
+	p := extraPattern{
+		kind:   kind,
+		folder: regexp.MustCompile(`(?i)^(?:` + words + `)$`),
+	}
+	if matchFile {
+		p.file = regexp.MustCompile(`(?i)(?:^|[\W_])(?:` + words + `)(?:[\W_]|$)`)
+	}
+
+	return p
+}
+
+// classifyExtraName returns the extra kind of a file name without extension
+// or of a folder name.
+func classifyExtraName(name string, folder bool) extraKind {
+	for idx := range extraPatterns {
+		if folder {
+			if extraPatterns[idx].folder.MatchString(strings.TrimSpace(name)) {
+				return extraPatterns[idx].kind
+			}
+
+			continue
+		}
+
+		if extraPatterns[idx].file != nil && extraPatterns[idx].file.MatchString(name) {
+			return extraPatterns[idx].kind
+		}
+	}
+
+	return extraNone
+}
+
+// classifyExtras collects the videos of the folder and classifies the extras
+// among them by their folder and file names. File names only count for videos
+// less than half the size of the largest one, so a main file whose title
+// contains one of the words is not taken for an extra.
+func (s *Organizer) classifyExtras(folder string) {
+	s.extras = nil
+	s.extraVideos = s.extraVideos[:0]
+	s.extraLargest = 0
+	s.extrasRuntimeChecked = false
+
+	if !s.sourcepathCfg.OrganizeExtras ||
+		(s.Cfgp.IsType != config.MediaTypeMovie && s.Cfgp.IsType != config.MediaTypeSeries) {
+		return
+	}
+
+	filepath.WalkDir(folder, func(fpath string, info fs.DirEntry, errw error) error {
+		if errw != nil {
+			return errw
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		if ok, _ := scanner.CheckExtensionsType(
+			s.Cfgp.IsType,
+			false,
+			s.sourcepathCfg,
+			filepath.Ext(info.Name()),
+		); !ok {
+			return nil
+		}
+
+		fsinfo, err := info.Info()
+		if err != nil {
+			return nil
+		}
+
+		s.extraVideos = append(s.extraVideos, extraVideo{path: fpath, size: fsinfo.Size()})
+		s.extraLargest = max(s.extraLargest, fsinfo.Size())
+
+		return nil
+	})
+
+	s.extras = make(map[string]extraKind)
+	for idx := range s.extraVideos {
+		video := &s.extraVideos[idx]
+
+		kind := extraNone
+		if rel, err := filepath.Rel(folder, filepath.Dir(video.path)); err == nil && rel != "." {
+			for part := range strings.SplitSeq(rel, string(filepath.Separator)) {
+				if kind = classifyExtraName(part, true); kind != extraNone {
+					break
+				}
+			}
+		}
+
+		if kind == extraNone && video.size*2 < s.extraLargest {
+			base := filepath.Base(video.path)
+			kind = classifyExtraName(strings.TrimSuffix(base, filepath.Ext(base)), false)
+		}
+
+		if kind == extraNone {
+			continue
+		}
+
+		s.extras[video.path] = kind
+
+		logger.Logtype("debug", 1).
+			Str(logger.StrFile, video.path).
+			Str("kind", s.extrasFolder(kind)).
+			Msg("Extra classified")
+	}
+}
+
+// classifyExtrasByRuntime marks the videos of a movie folder as extras when
+// their duration is below the configured percentage of the movie runtime.
+// The largest video is never an extra. It only runs once per folder.
+func (s *Organizer) classifyExtrasByRuntime(ctx context.Context, dbmovieID *uint) {
+	if s.extras == nil || s.extrasRuntimeChecked || s.Cfgp.IsType != config.MediaTypeMovie ||
+		s.sourcepathCfg.ExtrasMaxRuntimePercent <= 0 || len(s.extraVideos) < 2 {
+		return
+	}
+
+	s.extrasRuntimeChecked = true
+
+	var runtimestr string
+	database.Scanrowsdyn(
+		false,
+		mtstrings.GetStringsMap(s.Cfgp.IsType, "SelectRuntime"),
+		&runtimestr,
+		dbmovieID,
+	)
+
+	runtime, err := strconv.Atoi(runtimestr)
+	if err != nil || runtime <= 0 {
+		return
+	}
+
+	maxseconds := runtime * 60 * s.sourcepathCfg.ExtrasMaxRuntimePercent / 100
+	for idx := range s.extraVideos {
+		video := &s.extraVideos[idx]
+		if video.size == s.extraLargest {
+			continue
+		}
+
+		if _, ok := s.extras[video.path]; ok {
+			continue
+		}
+
+		duration := parser.ProbeDuration(ctx, video.path)
+		if duration <= 0 || duration >= maxseconds {
+			continue
+		}
+
+		s.extras[video.path] = extraOther
+
+		logger.Logtype("debug", 1).
+			Str(logger.StrFile, video.path).
+			Int("duration", duration).
+			Int("runtime", runtime*60).
+			Msg("Extra classified by duration")
+	}
+}
+
+// isExtra reports if the video was classified as a sample or an extra.
+func (s *Organizer) isExtra(fpath string) bool {
+	_, ok := s.extras[fpath]
+	return ok
+}
+
+// extrasFolder returns the configured subfolder of the extra kind.
+func (s *Organizer) extrasFolder(kind extraKind) string {
+	var name, def string
+	switch kind {
+	case extraSample:
+		return "Sample"
+	case extraTrailer:
+		name, def = s.sourcepathCfg.ExtrasTrailersFolder, "Trailers"
+	case extraFeaturette:
+		name, def = s.sourcepathCfg.ExtrasFeaturettesFolder, "Featurettes"
+	case extraDeletedScene:
+		name, def = s.sourcepathCfg.ExtrasDeletedScenesFolder, "Deleted Scenes"
+	case extraBehindTheScenes:
+		name, def = s.sourcepathCfg.ExtrasBehindTheScenesFolder, "Behind The Scenes"
+	case extraInterview:
+		name, def = s.sourcepathCfg.ExtrasInterviewsFolder, "Interviews"
+	default:
+		name, def = s.sourcepathCfg.ExtrasOtherFolder, "Other"
+	}
+
+	if name == "" {
+		return def
+	}
+
+	return name
+}
+
+// moveExtras moves the extras of the organized folder into their subfolders
+// next to the main file with the import mode and removes the samples unless
+// the source is kept.
+func (s *Organizer) moveExtras(o *Organizerdata) {
+	if len(s.extras) == 0 {
+		return
+	}
+
+	moveOpts := scanner.MoveFileOptions{
+		UseBufferCopy: config.GetSettingsGeneral().UseFileBufferCopy,
+		Chmod:         s.targetpathCfg.SetChmod,
+		ChmodFolder:   s.targetpathCfg.SetChmodFolder,
+		MediaType:     s.Cfgp.IsType,
+		Mode:          s.sourcepathCfg.ImportMode,
+	}
+
+	for fpath, kind := range s.extras {
+		if fpath == o.MediaFile {
+			continue
+		}
+
+		if kind == extraSample {
+			if !s.keepSource() {
+				scanner.RemoveFile(fpath)
+			}
+
+			continue
+		}
+
+		newpath, err := scanner.MoveFile(
+			fpath,
+			s.sourcepathCfg,
+			filepath.Join(o.TargetPath, s.extrasFolder(kind)),
+			"",
+			moveOpts,
+		)
+		if err != nil {
+			if !errors.Is(err, logger.ErrNotFound) {
+				logger.Logtype("error", 1).
+					Str(logger.StrFile, fpath).
+					Err(err).
+					Msg("extra move")
+			}
+
+			continue
+		}
+
+		o.RenamedFiles = append(o.RenamedFiles, RenameEntry{
+			OldName: filepath.Base(fpath),
+			NewName: filepath.Join(s.extrasFolder(kind), filepath.Base(newpath)),
+		})
+	}
+}
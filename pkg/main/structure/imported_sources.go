@@ -0,0 +1,94 @@
+package structure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scanner"
+)
+
+// unmatchedSourceRetryHours is the time after which kept sources which could
+// not be matched are parsed again.
+const unmatchedSourceRetryHours = 24
+
+// prunesSources reports if the kept sources of the path are removed once
+// keep_source_days passed. Symlink imports are never pruned, the library file
+// points at the source and would be lost with it.
+func prunesSources(pathcfg *config.PathsConfig) bool {
+	return pathcfg != nil && pathcfg.KeepsSource() &&
+		pathcfg.ImportMode != config.ImportModeSymlink && pathcfg.KeepSourceDays > 0 &&
+		pathcfg.Path != ""
+}
+
+// PruneImportedSources cleans up the sources kept by import modes which keep
+// the source. Sources imported more than keep_source_days ago are removed from
+// their source path unless they were symlinked, entries of sources removed by
+// the download client are dropped and unmatched sources are released to be
+// parsed again.
+func PruneImportedSources(ctx context.Context) error {
+	database.ReleaseUnmatchedSources(unmatchedSourceRetryHours)
+
+	var err error
+
+	config.RangeSettingsPath(func(_ string, pathcfg *config.PathsConfig) {
+		if err != nil || !prunesSources(pathcfg) {
+			return
+		}
+
+		root := filepath.Clean(pathcfg.Path)
+
+		items := database.GetExpiredImportedSources(
+			root+string(os.PathSeparator),
+			pathcfg.KeepSourceDays,
+		)
+		for idx := range items {
+			if err = logger.CheckContextEnded(ctx); err != nil {
+				return
+			}
+
+			if _, errd := scanner.RemoveFile(items[idx].Source); errd != nil {
+				logger.Logtype("error", 1).
+					Str(logger.StrFile, items[idx].Source).
+					Err(errd).
+					Msg("Failed to remove kept source")
+
+				continue
+			}
+
+			database.DeleteImportedSource(items[idx].ID)
+
+			// Only succeeds once the folder is empty
+			if folder := filepath.Dir(items[idx].Source); folder != root {
+				os.Remove(folder)
+			}
+		}
+
+		if len(items) > 0 {
+			logger.Logtype("info", 0).
+				Str("path", pathcfg.Name).
+				Int("count", len(items)).
+				Msg("Removed kept sources")
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	sources := database.GetImportedSources()
+	for idx := range sources {
+		if err = logger.CheckContextEnded(ctx); err != nil {
+			return err
+		}
+
+		if !scanner.CheckFileExist(sources[idx].Source) {
+			database.DeleteImportedSource(sources[idx].ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,472 @@
+package structure
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+// nfoHeader starts every NFO file written in the Kodi schema.
+const nfoHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes" ?>` + "\n"
+
+// nfoUniqueID is an ID of the media on a metadata site.
+type nfoUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+// nfoRating is a user rating of the media.
+type nfoRating struct {
+	Name    string  `xml:"name,attr"`
+	Max     int     `xml:"max,attr"`
+	Default bool    `xml:"default,attr,omitempty"`
+	Value   float64 `xml:"value"`
+	Votes   int     `xml:"votes,omitempty"`
+}
+
+// nfoRatings are the ratings of the media - only written when there is one.
+type nfoRatings struct {
+	Rating []nfoRating `xml:"rating"`
+}
+
+// nfoMovie is the movie.nfo of a movie folder.
+type nfoMovie struct {
+	XMLName       xml.Name      `xml:"movie"`
+	Title         string        `xml:"title"`
+	OriginalTitle string        `xml:"originaltitle,omitempty"`
+	Year          int           `xml:"year,omitempty"`
+	Plot          string        `xml:"plot,omitempty"`
+	Tagline       string        `xml:"tagline,omitempty"`
+	Runtime       int           `xml:"runtime,omitempty"`
+	Premiered     string        `xml:"premiered,omitempty"`
+	Status        string        `xml:"status,omitempty"`
+	Ratings       *nfoRatings   `xml:"ratings,omitempty"`
+	UniqueIDs     []nfoUniqueID `xml:"uniqueid"`
+	Genres        []string      `xml:"genre"`
+}
+
+// nfoTvshow is the tvshow.nfo of a series folder.
+type nfoTvshow struct {
+	XMLName   xml.Name      `xml:"tvshow"`
+	Title     string        `xml:"title"`
+	Year      int           `xml:"year,omitempty"`
+	Plot      string        `xml:"plot,omitempty"`
+	Runtime   int           `xml:"runtime,omitempty"`
+	Premiered string        `xml:"premiered,omitempty"`
+	Status    string        `xml:"status,omitempty"`
+	Studio    string        `xml:"studio,omitempty"`
+	Mpaa      string        `xml:"mpaa,omitempty"`
+	Ratings   *nfoRatings   `xml:"ratings,omitempty"`
+	UniqueIDs []nfoUniqueID `xml:"uniqueid"`
+	Genres    []string      `xml:"genre"`
+}
+
+// nfoEpisode is an episode in the NFO file of an episode video. Videos with
+// several episodes get one after the other.
+type nfoEpisode struct {
+	XMLName   xml.Name      `xml:"episodedetails"`
+	Title     string        `xml:"title"`
+	ShowTitle string        `xml:"showtitle,omitempty"`
+	Season    int           `xml:"season"`
+	Episode   int           `xml:"episode"`
+	Plot      string        `xml:"plot,omitempty"`
+	Runtime   int           `xml:"runtime,omitempty"`
+	Aired     string        `xml:"aired,omitempty"`
+	UniqueIDs []nfoUniqueID `xml:"uniqueid"`
+}
+
+// MovieNfo returns the movie.nfo content of the database movie.
+func MovieNfo(dbmovieID *uint) ([]byte, error) {
+	var movie database.Dbmovie
+	if err := movie.GetDbmovieByIDP(dbmovieID); err != nil {
+		return nil, err
+	}
+
+	nfo := nfoMovie{
+		Title:     movie.Title,
+		Year:      int(movie.Year),
+		Plot:      movie.Overview,
+		Tagline:   movie.Tagline,
+		Runtime:   movie.Runtime,
+		Status:    movie.Status,
+		Genres:    splitNfoList(movie.Genres),
+		UniqueIDs: make([]nfoUniqueID, 0, 3),
+	}
+	if movie.OriginalTitle != movie.Title {
+		nfo.OriginalTitle = movie.OriginalTitle
+	}
+
+	if movie.ReleaseDate.Valid {
+		nfo.Premiered = movie.ReleaseDate.Time.Format("2006-01-02")
+	}
+
+	if movie.VoteAverage > 0 {
+		nfo.Ratings = &nfoRatings{Rating: []nfoRating{{
+			Name:    "default",
+			Max:     10,
+			Default: true,
+			Value:   float64(movie.VoteAverage),
+			Votes:   int(movie.VoteCount),
+		}}}
+	}
+
+	if movie.ImdbID != "" {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:    "imdb",
+			Default: true,
+			Value:   logger.AddImdbPrefix(movie.ImdbID),
+		})
+	}
+
+	if movie.MoviedbID != 0 {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:    "tmdb",
+			Default: movie.ImdbID == "",
+			Value:   strconv.Itoa(movie.MoviedbID),
+		})
+	}
+
+	if movie.TraktID != 0 {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:  "trakt",
+			Value: strconv.Itoa(movie.TraktID),
+		})
+	}
+
+	return marshalNfo(nfo)
+}
+
+// SerieNfo returns the tvshow.nfo content of the database series.
+func SerieNfo(dbserieID *uint) ([]byte, error) {
+	var serie database.Dbserie
+	if err := serie.GetDbserieByIDP(dbserieID); err != nil {
+		return nil, err
+	}
+
+	nfo := nfoTvshow{
+		Title:     serie.Seriename,
+		Plot:      serie.Overview,
+		Premiered: serie.Firstaired,
+		Status:    serie.Status,
+		Studio:    serie.Network,
+		Mpaa:      serie.Rating,
+		Genres:    splitNfoList(serie.Genre),
+		UniqueIDs: make([]nfoUniqueID, 0, 4),
+	}
+	nfo.Runtime, _ = strconv.Atoi(serie.Runtime)
+	if len(serie.Firstaired) >= 4 {
+		nfo.Year, _ = strconv.Atoi(serie.Firstaired[:4])
+	}
+
+	if rating, err := strconv.ParseFloat(serie.Siterating, 64); err == nil && rating > 0 {
+		votes, _ := strconv.Atoi(serie.SiteratingCount)
+		nfo.Ratings = &nfoRatings{Rating: []nfoRating{{
+			Name:    "default",
+			Max:     10,
+			Default: true,
+			Value:   rating,
+			Votes:   votes,
+		}}}
+	}
+
+	if serie.ThetvdbID != 0 {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:    "tvdb",
+			Default: true,
+			Value:   strconv.Itoa(serie.ThetvdbID),
+		})
+	}
+
+	if serie.ImdbID != "" {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:    "imdb",
+			Default: serie.ThetvdbID == 0,
+			Value:   logger.AddImdbPrefix(serie.ImdbID),
+		})
+	}
+
+	if serie.MoviedbID != 0 {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:  "tmdb",
+			Value: strconv.Itoa(serie.MoviedbID),
+		})
+	}
+
+	if serie.TvmazeID != 0 {
+		nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+			Type:  "tvmaze",
+			Value: strconv.Itoa(serie.TvmazeID),
+		})
+	}
+
+	return marshalNfo(nfo)
+}
+
+// EpisodeNfo returns the NFO content of a video with the database episodes.
+func EpisodeNfo(dbepisodeIDs []uint) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString(nfoHeader)
+
+	var showtitle string
+	for idx := range dbepisodeIDs {
+		var episode database.DbserieEpisode
+		if err := episode.GetDbserieEpisodesByIDP(&dbepisodeIDs[idx]); err != nil {
+			return nil, err
+		}
+
+		if showtitle == "" {
+			showtitle = database.Getdatarow[string](
+				false,
+				"select seriename from dbseries where id = ?",
+				&episode.DbserieID,
+			)
+		}
+
+		nfo := nfoEpisode{
+			Title:     episode.Title,
+			ShowTitle: showtitle,
+			Plot:      episode.Overview,
+			Runtime:   episode.Runtime,
+		}
+		nfo.Season, _ = strconv.Atoi(episode.Season)
+		nfo.Episode, _ = strconv.Atoi(episode.Episode)
+		if episode.FirstAired.Valid {
+			nfo.Aired = episode.FirstAired.Time.Format("2006-01-02")
+		}
+
+		if episode.ThetvdbID != 0 {
+			nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+				Type:    "tvdb",
+				Default: true,
+				Value:   strconv.Itoa(episode.ThetvdbID),
+			})
+		}
+
+		if episode.ImdbID != "" {
+			nfo.UniqueIDs = append(nfo.UniqueIDs, nfoUniqueID{
+				Type:    "imdb",
+				Default: episode.ThetvdbID == 0,
+				Value:   logger.AddImdbPrefix(episode.ImdbID),
+			})
+		}
+
+		data, err := xml.MarshalIndent(nfo, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	return []byte(b.String()), nil
+}
+
+// marshalNfo encodes the NFO struct with the XML header.
+func marshalNfo(v any) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte(nfoHeader), data...), '\n'), nil
+}
+
+// splitNfoList splits a comma separated database list like the genres.
+func splitNfoList(s string) []string {
+	var list []string
+	for entry := range strings.SplitSeq(s, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// writeNfoFile writes the NFO content built by fn to the path. Errors are
+// logged - a missing NFO never fails the organize.
+func writeNfoFile(path string, fn func() ([]byte, error)) {
+	data, err := fn()
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+
+	if err != nil {
+		logger.Logtype("error", 1).
+			Str(logger.StrPath, path).
+			Err(err).
+			Msg("Failed to write NFO")
+		return
+	}
+
+	logger.Logtype("debug", 1).
+		Str(logger.StrPath, path).
+		Msg("NFO written")
+}
+
+// videoNfoPath returns the NFO path named after the video.
+func videoNfoPath(videofile string) string {
+	return strings.TrimSuffix(videofile, filepath.Ext(videofile)) + ".nfo"
+}
+
+// movieFolderShared reports if the folder of a movie video is not dedicated
+// to the movie - it is the root of the data path or holds files of other movies.
+func movieFolderShared(videofile string, dbmovieID uint, pathcfg *config.PathsConfig) bool {
+	folder := filepath.Dir(videofile)
+	if pathcfg != nil && folder == filepath.Clean(pathcfg.Path) {
+		return true
+	}
+
+	prefix := folder + string(os.PathSeparator)
+	for _, location := range database.GetrowsN[string](
+		false,
+		0,
+		"select location from movie_files where dbmovie_id != ? and instr(location, ?) = 1",
+		&dbmovieID,
+		&prefix,
+	) {
+		if filepath.Dir(location) == folder {
+			return true
+		}
+	}
+
+	return false
+}
+
+// movieNfoPath returns the NFO path of a movie video. A movie.nfo is only
+// read from folders dedicated to the movie - videos in the root of the data
+// path or next to files of other movies get an NFO named after the video.
+func movieNfoPath(videofile string, dbmovieID uint, pathcfg *config.PathsConfig) string {
+	if movieFolderShared(videofile, dbmovieID, pathcfg) {
+		return videoNfoPath(videofile)
+	}
+
+	return filepath.Join(filepath.Dir(videofile), "movie.nfo")
+}
+
+// writeNfos writes the NFO files of the organized movie or episode if the
+// data config of the target path has write_nfo enabled.
+func (s *Organizer) writeNfos(o *Organizerdata, m *database.ParseInfo, newpath string) {
+	dataCfg := s.getDataConfig()
+	if dataCfg == nil || !dataCfg.WriteNfo {
+		return
+	}
+
+	switch s.Cfgp.IsType {
+	case config.MediaTypeMovie:
+		nfopath := movieNfoPath(newpath, m.DbmovieID, s.targetpathCfg)
+		writeNfoFile(nfopath, func() ([]byte, error) {
+			return MovieNfo(&m.DbmovieID)
+		})
+
+	case config.MediaTypeSeries:
+		rootpath := database.Getdatarow[string](
+			false,
+			"select rootpath from series where id = ?",
+			&m.SerieID,
+		)
+		if rootpath == "" {
+			rootpath = o.TargetPath
+		}
+
+		writeNfoFile(filepath.Join(rootpath, "tvshow.nfo"), func() ([]byte, error) {
+			return SerieNfo(&m.DbserieID)
+		})
+
+		episodes := make([]uint, 0, len(m.Episodes))
+		for idx := range m.Episodes {
+			episodes = append(episodes, m.Episodes[idx].Num2)
+		}
+
+		writeNfoFile(videoNfoPath(newpath), func() ([]byte, error) {
+			return EpisodeNfo(episodes)
+		})
+	}
+}
+
+// nfoPath returns the data path of the media config containing the location
+// if its data config has write_nfo enabled, nil otherwise.
+func nfoPath(cfgp *config.MediaTypeConfig, location string) *config.PathsConfig {
+	for idx := range cfgp.Data {
+		if cfgp.Data[idx].WriteNfo && inPath(location, cfgp.Data[idx].CfgPath) {
+			return cfgp.Data[idx].CfgPath
+		}
+	}
+
+	return nil
+}
+
+// WriteMovieNfos regenerates the NFO files next to the files of the database
+// movie, e.g. after its metadata was refreshed. Only folders of data paths
+// with write_nfo enabled get one.
+func WriteMovieNfos(cfgp *config.MediaTypeConfig, dbmovieID uint) {
+	if cfgp == nil || dbmovieID == 0 {
+		return
+	}
+
+	nfopaths := make(map[string]struct{})
+	for _, location := range database.GetrowsN[string](
+		false,
+		0,
+		"select location from movie_files where dbmovie_id = ?",
+		&dbmovieID,
+	) {
+		if pathcfg := nfoPath(cfgp, location); pathcfg != nil {
+			nfopaths[movieNfoPath(location, dbmovieID, pathcfg)] = struct{}{}
+		}
+	}
+
+	for nfopath := range nfopaths {
+		writeNfoFile(nfopath, func() ([]byte, error) {
+			return MovieNfo(&dbmovieID)
+		})
+	}
+}
+
+// WriteSerieNfos regenerates the tvshow.nfo of the series folders and the
+// NFO files of the episode videos of the database series, e.g. after its
+// metadata was refreshed. Only data paths with write_nfo enabled get them.
+func WriteSerieNfos(cfgp *config.MediaTypeConfig, dbserieID uint) {
+	if cfgp == nil || dbserieID == 0 {
+		return
+	}
+
+	for _, rootpath := range database.GetrowsN[string](
+		false,
+		0,
+		"select distinct rootpath from series where dbserie_id = ? and rootpath != ''",
+		&dbserieID,
+	) {
+		if nfoPath(cfgp, rootpath) != nil {
+			writeNfoFile(filepath.Join(rootpath, "tvshow.nfo"), func() ([]byte, error) {
+				return SerieNfo(&dbserieID)
+			})
+		}
+	}
+
+	videos := make(map[string][]uint)
+	for _, row := range database.GetrowsN[database.DbstaticOneStringOneUInt](
+		false,
+		0,
+		"select location, dbserie_episode_id from serie_episode_files where dbserie_id = ? order by location, dbserie_episode_id",
+		&dbserieID,
+	) {
+		if nfoPath(cfgp, row.Str) != nil {
+			videos[row.Str] = append(videos[row.Str], row.Num)
+		}
+	}
+
+	for location, episodes := range videos {
+		writeNfoFile(videoNfoPath(location), func() ([]byte, error) {
+			return EpisodeNfo(episodes)
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package structure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scanner"
+)
+
+// recycleDateFormat names the dated folders of the recycle bin.
+const recycleDateFormat = "2006-01-02"
+
+var errRecycleRestoreExists = errors.New("a file already exists at the original path")
+
+// RecycleEnabled reports if replaced and deleted files of the paths config
+// are moved into its recycle bin instead of being deleted.
+func RecycleEnabled(pathcfg *config.PathsConfig) bool {
+	return pathcfg != nil && pathcfg.MoveReplaced && pathcfg.MoveReplacedTargetPath != ""
+}
+
+// RecycleFile moves the file into today's folder of the recycle bin of the
+// paths config and records it with its media item and the reason in the
+// recycle bin manifest. It returns the path of the recycled file.
+func RecycleFile(
+	pathcfg *config.PathsConfig,
+	cfgp *config.MediaTypeConfig,
+	file, listname, reason string,
+	mediaID uint,
+) (string, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", logger.ErrNotFound
+		}
+
+		return "", err
+	}
+
+	opts := scanner.MoveFileOptions{
+		UseBufferCopy: config.GetSettingsGeneral().UseFileBufferCopy,
+		Chmod:         pathcfg.SetChmod,
+		ChmodFolder:   pathcfg.SetChmodFolder,
+		UseNil:        true,
+	}
+
+	item := database.RecycleItem{
+		Listname:     listname,
+		PathTemplate: pathcfg.Name,
+		MediaID:      mediaID,
+		Reason:       reason,
+		OriginalPath: file,
+		Size:         info.Size(),
+	}
+	if cfgp != nil {
+		opts.MediaType = cfgp.IsType
+		item.Config = cfgp.NamePrefix
+	}
+
+	item.RecyclePath, err = scanner.MoveFile(
+		file,
+		nil,
+		recycleFolder(pathcfg.MoveReplacedTargetPath, file),
+		"",
+		opts,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	database.AddRecycleItem(&item)
+
+	logger.Logtype("info", 1).
+		Str(logger.StrFile, file).
+		Str("reason", reason).
+		Msg("File moved to recycle bin")
+
+	return item.RecyclePath, nil
+}
+
+// recycle moves the file into the recycle bin of the target path.
+func (s *Organizer) recycle(file, listname, reason string, id *uint) error {
+	var mediaID uint
+	if id != nil {
+		mediaID = *id
+	}
+
+	_, err := RecycleFile(s.targetpathCfg, s.Cfgp, file, listname, reason, mediaID)
+
+	return err
+}
+
+// listname returns the name of the list of the organized media or an empty
+// string if the list is unknown.
+func (s *Organizer) listname(o *Organizerdata) string {
+	if o == nil || o.Listid < 0 || o.Listid >= len(s.Cfgp.Lists) {
+		return ""
+	}
+
+	return s.Cfgp.Lists[o.Listid].Name
+}
+
+// discardFile removes the media file of a rejected import and cleans up its
+// folder. With the recycle bin of the target path enabled the file is
+// recycled with the reason instead of being deleted.
+func (s *Organizer) discardFile(o *Organizerdata, m *database.ParseInfo, reason string) error {
+	if s.keepSource() || o.MediaFile == "" || !RecycleEnabled(s.targetpathCfg) {
+		return s.fileCleanup(o.Folder, o.MediaFile, o.Rootpath)
+	}
+
+	var mediaID uint
+	if h := mediatype.Get(s.Cfgp.IsType); h != nil {
+		mediaID = h.GetMediaID(m)
+	}
+
+	_, err := RecycleFile(s.targetpathCfg, s.Cfgp, o.MediaFile, s.listname(o), reason, mediaID)
+	if err != nil {
+		return err
+	}
+
+	return s.cleanupAfterRemove(o.Folder, o.MediaFile, o.Rootpath)
+}
+
+// recycleFolder returns the recycle bin folder for the file: a folder named
+// after its parent folder in the dated folder of today. A numbered folder is
+// used if a file with the same name was already recycled today.
+func recycleFolder(bin, file string) string {
+	base := filepath.Join(
+		bin,
+		logger.TimeGetNow().Format(recycleDateFormat),
+		filepath.Base(filepath.Dir(file)),
+	)
+
+	folder := base
+	for idx := 2; scanner.CheckFileExist(filepath.Join(folder, filepath.Base(file))); idx++ {
+		folder = base + " (" + strconv.Itoa(idx) + ")"
+	}
+
+	return folder
+}
+
+// RemoveMediaFile deletes a file of a media data path. If the recycle bin of
+// the path is enabled the file is recycled with the reason instead.
+func RemoveMediaFile(file, reason string) error {
+	cfgp, pathcfg := mediaDataConfig(file)
+	if !RecycleEnabled(pathcfg) {
+		_, err := scanner.RemoveFile(file)
+		return err
+	}
+
+	_, err := RecycleFile(pathcfg, cfgp, file, "", reason, 0)
+
+	return err
+}
+
+// mediaDataConfig returns the media config and the paths config of the data
+// path which contains the file.
+func mediaDataConfig(file string) (*config.MediaTypeConfig, *config.PathsConfig) {
+	var (
+		cfgp    *config.MediaTypeConfig
+		pathcfg *config.PathsConfig
+	)
+
+	config.RangeSettingsMediaBreak(func(_ string, media *config.MediaTypeConfig) bool {
+		for idx := range media.Data {
+			if inPath(file, media.Data[idx].CfgPath) {
+				cfgp, pathcfg = media, media.Data[idx].CfgPath
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return cfgp, pathcfg
+}
+
+// inPath reports if the file is located below the path of the paths config.
+func inPath(file string, pathcfg *config.PathsConfig) bool {
+	if pathcfg == nil || pathcfg.Path == "" {
+		return false
+	}
+
+	return strings.HasPrefix(file, filepath.Clean(pathcfg.Path)+string(os.PathSeparator))
+}
+
+// RestoreRecycledFile moves the recycled file of the recycle bin entry back to
+// its original path and removes the entry. Files removed for a wrong language
+// or runtime are allowed from now on. It returns the media config of the
+// entry, or nil if the restored file is not located in one of its data paths
+// and so can not be linked to its media item again.
+func RestoreRecycledFile(item *database.RecycleItem) (*config.MediaTypeConfig, error) {
+	if scanner.CheckFileExist(item.OriginalPath) {
+		return nil, errRecycleRestoreExists
+	}
+
+	opts := scanner.MoveFileOptions{
+		UseBufferCopy: config.GetSettingsGeneral().UseFileBufferCopy,
+		UseNil:        true,
+		ForceRename:   true,
+	}
+	if pathcfg := config.GetSettingsPath(item.PathTemplate); pathcfg != nil {
+		opts.Chmod = pathcfg.SetChmod
+		opts.ChmodFolder = pathcfg.SetChmodFolder
+	}
+
+	_, err := scanner.MoveFile(
+		item.RecyclePath,
+		nil,
+		filepath.Dir(item.OriginalPath),
+		filepath.Base(item.OriginalPath),
+		opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	database.DeleteRecycleItem(item.ID)
+	removeEmptyRecycleFolders(item)
+
+	// The user overruled the check, the file must not be removed again
+	if item.Reason == database.RecycleReasonWrongLanguage ||
+		item.Reason == database.RecycleReasonWrongRuntime {
+		database.AddRestoredFile(item.OriginalPath)
+	}
+
+	logger.Logtype("info", 1).
+		Str(logger.StrFile, item.OriginalPath).
+		Msg("File restored from recycle bin")
+
+	cfgp := config.GetSettingsMedia(item.Config)
+	if cfgp == nil {
+		return nil, nil
+	}
+
+	for idx := range cfgp.Data {
+		if inPath(item.OriginalPath, cfgp.Data[idx].CfgPath) {
+			return cfgp, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DeleteRecycledFile deletes the recycled file of the recycle bin entry and
+// removes the entry.
+func DeleteRecycledFile(item *database.RecycleItem) error {
+	if _, err := scanner.RemoveFile(item.RecyclePath); err != nil {
+		return err
+	}
+
+	database.DeleteRecycleItem(item.ID)
+	removeEmptyRecycleFolders(item)
+
+	return nil
+}
+
+// removeEmptyRecycleFolders removes the folders of the recycled file of the
+// entry up to the recycle bin root once they are empty.
+func removeEmptyRecycleFolders(item *database.RecycleItem) {
+	pathcfg := config.GetSettingsPath(item.PathTemplate)
+	if pathcfg == nil || pathcfg.MoveReplacedTargetPath == "" {
+		return
+	}
+
+	root := filepath.Clean(pathcfg.MoveReplacedTargetPath) + string(os.PathSeparator)
+	for dir := filepath.Dir(item.RecyclePath); strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
+}
+
+// PurgeRecycleBin deletes the recycled files of all paths configs which were
+// recycled longer ago than the retention days of the path. The entries of
+// restored files which are gone are dropped too.
+func PurgeRecycleBin(ctx context.Context) error {
+	var err error
+
+	config.RangeSettingsPath(func(_ string, pathcfg *config.PathsConfig) {
+		if err != nil || !RecycleEnabled(pathcfg) || pathcfg.RecycleRetentionDays <= 0 {
+			return
+		}
+
+		items := database.GetExpiredRecycleItems(pathcfg.Name, pathcfg.RecycleRetentionDays)
+		for idx := range items {
+			if err = logger.CheckContextEnded(ctx); err != nil {
+				return
+			}
+
+			if errd := DeleteRecycledFile(&items[idx]); errd != nil {
+				logger.Logtype("error", 1).
+					Str(logger.StrFile, items[idx].RecyclePath).
+					Err(errd).
+					Msg("Failed to purge recycled file")
+			}
+		}
+
+		if len(items) > 0 {
+			logger.Logtype("info", 0).
+				Str("path", pathcfg.Name).
+				Int("count", len(items)).
+				Msg("Purged recycle bin")
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Restored files which were organized or deleted since need no entry
+	for _, location := range database.GetRestoredFiles() {
+		if !scanner.CheckFileExist(location) {
+			database.DeleteRestoredFile(location)
+		}
+	}
+
+	return nil
+}
@@ -48,6 +48,19 @@ type Organizer struct {
 	sourcepathCfg *config.PathsConfig
 	// TargetpathCfg is a pointer to the PathsConfig for the target path
 	targetpathCfg *config.PathsConfig
+	// extras are the samples and extras of the organized folder by path
+	extras map[string]extraKind
+	// extraVideos are the videos of the organized folder
+	extraVideos []extraVideo
+	// extraLargest is the size of the largest video of the organized folder
+	extraLargest int64
+	// extrasRuntimeChecked is set once the durations of the videos were checked
+	extrasRuntimeChecked bool
+	// subtitles are the external subtitles handled with the organized video
+	subtitles map[string]struct{}
+	// seasonPack is set while the episodes of a season pack folder are split,
+	// the folder is cleaned up once all of them were handled
+	seasonPack bool
 	// orgadata Organizerdata
 }
 
@@ -503,9 +516,42 @@ func (p *Organizerdata) Clear() {
 	}
 }
 
+// keepSource reports if the import mode of the source path keeps the organized
+// files and their folders for the download client. Nothing below the source
+// path may be removed or moved away then, kept sources are only removed by
+// PruneImportedSources once keep_source_days passed.
+func (s *Organizer) keepSource() bool {
+	return s.sourcepathCfg != nil && s.sourcepathCfg.KeepsSource()
+}
+
+// importedFolder reports if all audio files of the folder are kept sources
+// which were already imported.
+func (s *Organizer) importedFolder(folder string) bool {
+	if !s.keepSource() {
+		return false
+	}
+
+	files, err := parser_v2.CollectFilesOnly(folder, parser_v2.AudioExtensions)
+	if err != nil || len(files) == 0 {
+		return false
+	}
+
+	for idx := range files {
+		if !database.IsImportedSource(files[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // FileCleanup removes the video file and cleans up the folder for the given Organizerdata.
 // It handles both series and non-series files.
 func (s *Organizer) fileCleanup(folder, videofile, rootpath string) error {
+	if s.keepSource() {
+		return nil
+	}
+
 	if videofile == "" {
 		return s.cleanUpFolder(folder)
 	}
@@ -519,8 +565,14 @@ func (s *Organizer) fileCleanup(folder, videofile, rootpath string) error {
 		return nil
 	}
 
+	return s.cleanupAfterRemove(folder, videofile, rootpath)
+}
+
+// cleanupAfterRemove removes the other files of the removed video file and
+// cleans up its folder.
+func (s *Organizer) cleanupAfterRemove(folder, videofile, rootpath string) error {
 	if h := mediatype.Get(s.Cfgp.IsType); h != nil {
-		err = h.CleanupAfterRemove(
+		err := h.CleanupAfterRemove(
 			folder,
 			rootpath,
 			s.sourcepathCfg.Name,
@@ -538,8 +590,22 @@ func (s *Organizer) fileCleanup(folder, videofile, rootpath string) error {
 // cleanUpFolder walks the given folder path to calculate total size.
 // It then compares total size to given cleanup threshold in MB.
 // If folder size is less than threshold, folder is deleted.
+// While a season pack is split the cleanup waits for its last episode.
 // Returns any error encountered.
 func (s *Organizer) cleanUpFolder(folder string) error {
+	if s.keepSource() {
+		logger.Logtype("debug", 1).
+			Str(logger.StrPath, folder).
+			Str("mode", s.sourcepathCfg.ImportMode).
+			Msg("Folder kept for the download client")
+
+		return nil
+	}
+
+	if s.seasonPack {
+		return nil
+	}
+
 	if !scanner.CheckFileExist(folder) {
 		return errCleanupFolderNotFound
 	}
@@ -656,6 +722,12 @@ func (s *Organizer) ParseFileAdditional(
 			return err
 		}
 
+		// Files restored from the recycle bin after a wrong language or runtime
+		// were allowed by the user
+		if database.IsRestoredFile(o.MediaFile) {
+			return nil
+		}
+
 		// Runtime validation (only for video)
 		if err := s.validateRuntime(m, runtime, checkruntime, o); err != nil {
 			return err
@@ -719,7 +791,15 @@ func (s *Organizer) validateRuntime(
 	difference := abs(wantedruntime - targetruntime)
 	if difference > maxdifference {
 		if s.targetpathCfg.DeleteWrongRuntime {
-			s.fileCleanup(o.Folder, o.MediaFile, o.Rootpath)
+			if err := s.discardFile(o, m, database.RecycleReasonWrongRuntime); err != nil {
+				logger.Logtype("error", 2).
+					Int(logger.StrWanted, wantedruntime).
+					Int(logger.StrFound, targetruntime).
+					Err(err).
+					Msg("failed to cleanup wrong runtime file")
+
+				return err
+			}
 		}
 
 		logger.Logtype("warning", 2).
@@ -783,7 +863,7 @@ func (s *Organizer) validateLanguage(
 	}
 
 	// deletewronglanguage is already true at this point (checked above)
-	if err := s.fileCleanup(o.Folder, o.MediaFile, o.Rootpath); err != nil {
+	if err := s.discardFile(o, m, database.RecycleReasonWrongLanguage); err != nil {
 		logger.Logtype("error", 2).
 			Str(logger.StrWanted, wantedLang).
 			Str(logger.StrFound, foundLang).
@@ -1031,6 +1111,7 @@ func (s *Organizer) moveMediaFile(o *Organizerdata) (string, error) {
 		Chmod:         s.targetpathCfg.SetChmod,
 		ChmodFolder:   s.targetpathCfg.SetChmodFolder,
 		MediaType:     s.Cfgp.IsType,
+		Mode:          s.sourcepathCfg.ImportMode,
 	}
 
 	// Check if this is multi-file media (music albums, audiobooks)
@@ -1072,6 +1153,10 @@ func (s *Organizer) moveMediaFile(o *Organizerdata) (string, error) {
 				NewName: filepath.Base(movedPath),
 			})
 
+			if s.keepSource() {
+				database.AddImportedSource(mediaFile, movedPath)
+			}
+
 			if idx == 0 {
 				firstMovedPath = movedPath
 			}
@@ -1093,19 +1178,24 @@ func (s *Organizer) moveMediaFile(o *Organizerdata) (string, error) {
 			OldName: filepath.Base(o.MediaFile),
 			NewName: filepath.Base(newpath),
 		})
+
+		if s.keepSource() {
+			database.AddImportedSource(o.MediaFile, newpath)
+		}
 	}
 
 	return newpath, err
 }
 
-// moveRemoveOldMediaFile moves or deletes an old media file that is being
-// replaced. It handles moving/deleting additional files with different
-// extensions, and removing database references. This is an internal
-// implementation detail not meant to be called externally.
+// moveRemoveOldMediaFile moves an old media file that is being replaced into
+// the recycle bin or deletes it. It handles moving/deleting additional files
+// with different extensions, and removing database references. This is an
+// internal implementation detail not meant to be called externally.
 func (s *Organizer) moveRemoveOldMediaFile(
 	oldfile string,
 	oldfilep *string,
 	id *uint,
+	listname string,
 	move bool,
 ) error {
 	if oldfile == "" {
@@ -1115,26 +1205,8 @@ func (s *Organizer) moveRemoveOldMediaFile(
 	// Cache general settings once for this function
 	generalCfg := config.GetSettingsGeneral()
 
-	// Pre-compute target path for moves
-	var moveTargetPath string
-	if move {
-		moveTargetPath = filepath.Join(
-			s.targetpathCfg.MoveReplacedTargetPath,
-			filepath.Base(filepath.Dir(oldfile)),
-		)
-	}
-
-	// Create reusable move options
-	moveOpts := scanner.MoveFileOptions{
-		UseBufferCopy: generalCfg.UseFileBufferCopy,
-		Chmod:         s.targetpathCfg.SetChmod,
-		ChmodFolder:   s.targetpathCfg.SetChmodFolder,
-		UseNil:        true,
-		MediaType:     s.Cfgp.IsType,
-	}
-
 	if move {
-		_, err := scanner.MoveFile(oldfile, nil, moveTargetPath, "", moveOpts)
+		err := s.recycle(oldfile, listname, database.RecycleReasonUpgrade, id)
 		if err != nil {
 			if errors.Is(err, logger.ErrNotFound) {
 				return nil
@@ -1184,7 +1256,7 @@ func (s *Organizer) moveRemoveOldMediaFile(
 		}
 
 		if move {
-			_, err = scanner.MoveFile(additionalfile, nil, moveTargetPath, "", moveOpts)
+			err = s.recycle(additionalfile, listname, database.RecycleReasonUpgrade, id)
 			if err != nil {
 				if !errors.Is(err, logger.ErrNotFound) {
 					logger.Logtype("error", 1).
@@ -1407,9 +1479,8 @@ func (s *Organizer) Organize(
 		return errGeneratingFilename
 	}
 
-	// Move old files to replaced folder if configured
-	if s.targetpathCfg.MoveReplaced && s.targetpathCfg.MoveReplacedTargetPath != "" &&
-		len(oldfiles) >= 1 {
+	// Move old files to the recycle bin if configured
+	if RecycleEnabled(s.targetpathCfg) && len(oldfiles) >= 1 {
 		if err := s.moveremoveoldfiles(o, false, mediaID, true, oldfiles); err != nil {
 			return err
 		}
@@ -1457,6 +1528,12 @@ func (s *Organizer) Organize(
 		}
 	}
 
+	// Write the NFO files and download the artwork if configured (movies/series)
+	if s.Cfgp.IsType == config.MediaTypeMovie || s.Cfgp.IsType == config.MediaTypeSeries {
+		s.writeNfos(o, m, newpath)
+		s.writeArtwork(ctx, o, m, newpath)
+	}
+
 	// Calculate quality reached
 	var reached int
 	if m.Priority >= cfgquality.CutoffPriority {
@@ -1465,6 +1542,7 @@ func (s *Organizer) Organize(
 
 	fileext := filepath.Ext(newpath)
 	filebase := filepath.Base(newpath)
+	sublangs := logger.JoinStringsSep(m.SubtitleLanguages, ",")
 	insertQuery := mtstrings.GetStringsMap(s.Cfgp.IsType, "InsertFileOrganize")
 	updateQuery := mtstrings.GetStringsMap(s.Cfgp.IsType, "UpdateMissingReached")
 
@@ -1477,6 +1555,8 @@ func (s *Organizer) Organize(
 			&m.Proper, &m.Repack, &m.Extended,
 			&m.MovieID, &m.DbmovieID,
 			&m.Height, &m.Width,
+			&m.ReleaseName, &m.ReleaseGroup,
+			&m.HDR, &sublangs,
 		)
 		database.ExecN(updateQuery, &reached, &m.MovieID)
 
@@ -1488,6 +1568,8 @@ func (s *Organizer) Organize(
 				&m.Proper, &m.Repack, &m.Extended,
 				&m.SerieID, &m.Episodes[idx].Num1, &m.Episodes[idx].Num2, &m.DbserieID,
 				&m.Height, &m.Width,
+				&m.ReleaseName, &m.ReleaseGroup,
+				&m.HDR, &sublangs,
 			)
 			database.ExecN(updateQuery, reached, m.Episodes[idx].Num1)
 		}
@@ -1575,6 +1657,9 @@ func (s *Organizer) moveandcleanup(
 		return nil
 	}
 
+	s.moveExtras(o)
+	s.moveSubtitles(o, newfile)
+
 	// Cache general settings for the closure
 	generalCfg := config.GetSettingsGeneral()
 	moveOpts := scanner.MoveFileOptions{
@@ -1583,6 +1668,7 @@ func (s *Organizer) moveandcleanup(
 		ChmodFolder:   s.targetpathCfg.SetChmodFolder,
 		UseOther:      true,
 		MediaType:     s.Cfgp.IsType,
+		Mode:          s.sourcepathCfg.ImportMode,
 	}
 
 	return h.MoveOtherFilesAfterOrganize(&mediatype.MoveOtherFilesParams{
@@ -1595,6 +1681,10 @@ func (s *Organizer) moveandcleanup(
 		AllowedOtherExtensions: s.sourcepathCfg.AllowedOtherExtensions,
 		WalkCleanupFn:          func(rp, vt, fn string) { s.walkcleanup(rp, vt, fn, false, &o.RenamedFiles) },
 		MoveFileFn: func(source, target, filename string) error {
+			if s.isSubtitleHandled(source) {
+				return nil
+			}
+
 			newpath, err := scanner.MoveFile(source, s.sourcepathCfg, target, filename, moveOpts)
 			if err == nil {
 				o.RenamedFiles = append(o.RenamedFiles, RenameEntry{
@@ -1625,6 +1715,10 @@ func (s *Organizer) walkcleanup(
 		return nil
 	}
 
+	if useremove && s.keepSource() {
+		return nil
+	}
+
 	// Cache settings outside the walk function to avoid repeated lookups
 	var moveOpts scanner.MoveFileOptions
 	if !useremove {
@@ -1634,6 +1728,7 @@ func (s *Organizer) walkcleanup(
 			ChmodFolder:   s.targetpathCfg.SetChmodFolder,
 			UseOther:      true,
 			MediaType:     s.Cfgp.IsType,
+			Mode:          s.sourcepathCfg.ImportMode,
 		}
 	}
 
@@ -1665,7 +1760,7 @@ func (s *Organizer) walkcleanup(
 
 		if useremove {
 			scanner.RemoveFile(fpath)
-		} else {
+		} else if !s.isSubtitleHandled(fpath) {
 			newpath, err := scanner.MoveFile(
 				fpath,
 				s.sourcepathCfg,
@@ -1715,7 +1810,7 @@ func (s *Organizer) moveremoveoldfiles(
 			continue
 		}
 
-		err = s.moveRemoveOldMediaFile(oldfiles[idx], &oldfiles[idx], id, move)
+		err = s.moveRemoveOldMediaFile(oldfiles[idx], &oldfiles[idx], id, s.listname(o), move)
 		if err != nil {
 			// Continue if old cannot be moved
 			logger.Logtype("error", 1).
@@ -1963,7 +2058,7 @@ func (s *Organizer) GetSeriesEpisodes(
 					&m.Episodes[0].Num1,
 				) == 0 {
 				bl = true
-			} else if !skipdelete {
+			} else if !skipdelete && !s.keepSource() {
 				bl, err = scanner.RemoveFile(o.MediaFile)
 				if err == nil && bl {
 					logger.Logtype("info", 3).
@@ -2025,7 +2120,7 @@ func (s *Organizer) GetSeriesEpisodes(
 				&m.Episodes[idx].Num1,
 			) == 0 {
 				bl = true
-			} else if !skipdelete {
+			} else if !skipdelete && !s.keepSource() {
 				bl, err = scanner.RemoveFile(o.MediaFile)
 				if err == nil && bl {
 					logger.Logtype("info", 3).
@@ -2075,6 +2170,10 @@ func (s *Organizer) GetSeriesEpisodes(
 // the configured allowed extensions and calls RemoveFile on the
 // same filename with that extension.
 func (s *Organizer) removeotherfiles(videofile string) {
+	if s.keepSource() {
+		return
+	}
+
 	fileext := filepath.Ext(videofile)
 	for idx := range s.sourcepathCfg.AllowedOtherExtensions {
 		if fileext == s.sourcepathCfg.AllowedOtherExtensions[idx] {
@@ -2163,8 +2262,16 @@ func OrganizeSingleFolder(
 			return nil
 		}
 
+		// Skip kept sources which were already imported
+		if s.importedFolder(folder) {
+			logger.Logtype("debug", 1).
+				Str(logger.StrPath, folder).
+				Msg("skipped - already imported")
+			return nil
+		}
+
 		reason, matchReport, err := s.organizeAlbumFolderViaAPI(ctx, folder, cfgp, data)
-		if err != nil && reason != "" && data.MoveUnprocessed != "" &&
+		if err != nil && reason != "" && data.MoveUnprocessed != "" && !s.keepSource() &&
 			scanner.CheckFileExist(folder) {
 			moveUnprocessedFolder(
 				folder,
@@ -2191,11 +2298,26 @@ func OrganizeSingleFolder(
 	// For single-file media (movies, series, books), process each file individually
 	importAddFound := data != nil && data.AddFound
 
+	// Samples and extras are not organized as main files
+	s.classifyExtras(folder)
+
 	var (
 		anyOrganized, anySkippedTemporary bool
 		lastMoveReason                    string
+		samples                           []string
 	)
 
+	// Imported and skipped episodes per season pack folder
+	type packCount struct{ imported, skipped int }
+
+	packs := make(map[string]*packCount)
+
+	// A season pack is split into its episodes - each file is matched to its
+	// episode on its own and the folder is cleaned up after the last one
+	if cfgp.IsType == config.MediaTypeSeries {
+		_, _, s.seasonPack = parser_v2.ParseSeasonPack(filepath.Base(folder))
+	}
+
 	walkErr := filepath.WalkDir(folder, func(fpath string, info fs.DirEntry, errw error) error {
 		if errw != nil {
 			return errw
@@ -2262,6 +2384,22 @@ func OrganizeSingleFolder(
 			return nil
 		}
 
+		// Kept sources which were already imported or could not be matched stay
+		// for the download client
+		if s.keepSource() && database.IsImportedSource(fpath) {
+			return nil
+		}
+
+		// Extras are moved with their main file, samples are dropped once the
+		// folder was organized
+		if kind, ok := s.extras[fpath]; ok {
+			if kind == extraSample {
+				samples = append(samples, fpath)
+			}
+
+			return nil
+		}
+
 		organized, moveReason, result := s.walkorganizefolder(
 			ctx,
 			fpath,
@@ -2273,6 +2411,20 @@ func OrganizeSingleFolder(
 			anyOrganized = true
 		}
 
+		if pack := seasonPackFolder(fpath, folder, s.seasonPack); pack != "" {
+			counts, ok := packs[pack]
+			if !ok {
+				counts = &packCount{}
+				packs[pack] = counts
+			}
+
+			if organized {
+				counts.imported++
+			} else {
+				counts.skipped++
+			}
+		}
+
 		if moveReason != "" {
 			lastMoveReason = moveReason
 		}
@@ -2282,11 +2434,46 @@ func OrganizeSingleFolder(
 			return nil
 		}
 
+		// Kept sources which can't be matched aren't moved to the unprocessed
+		// folder, record them so they are not parsed again on every run
+		if !organized && moveReason != "" && s.keepSource() {
+			database.AddImportedSource(fpath, "")
+		}
+
 		return result
 	})
 
+	for pack, counts := range packs {
+		logger.Logtype("info", 1).
+			Str(logger.StrPath, pack).
+			Int("imported", counts.imported).
+			Int("skipped", counts.skipped).
+			Msg("Season pack split into episodes")
+	}
+
+	if s.seasonPack {
+		s.seasonPack = false
+
+		if anyOrganized {
+			if err := s.cleanUpFolder(folder); err != nil {
+				logger.Logtype("warn", 1).
+					Str(logger.StrPath, folder).
+					Err(err).
+					Msg("Failed to clean up the season pack folder")
+			}
+		}
+	}
+
+	if anyOrganized && walkErr == nil && !s.keepSource() {
+		for _, sample := range samples {
+			if scanner.CheckFileExist(sample) {
+				scanner.RemoveFile(sample)
+			}
+		}
+	}
+
 	if !anyOrganized && !anySkippedTemporary && data.MoveUnprocessed != "" &&
-		lastMoveReason != "" && scanner.CheckFileExist(folder) {
+		lastMoveReason != "" && !s.keepSource() && scanner.CheckFileExist(folder) {
 		moveUnprocessedFolder(
 			folder,
 			data.MoveUnprocessed,
@@ -2301,6 +2488,24 @@ func OrganizeSingleFolder(
 	return walkErr
 }
 
+// seasonPackFolder returns the season pack folder the file was unpacked from -
+// its own folder if that is named as a season pack, else the walked root if it
+// is one. Returns an empty string for files which are not part of a pack.
+func seasonPackFolder(fpath, root string, rootIsPack bool) string {
+	dir := filepath.Dir(fpath)
+	if dir != root {
+		if _, _, ok := parser_v2.ParseSeasonPack(filepath.Base(dir)); ok {
+			return dir
+		}
+	}
+
+	if rootIsPack {
+		return root
+	}
+
+	return ""
+}
+
 // walkorganizefolder is a method of the Organizer struct that processes a file path, parses the file, and organizes the media item based on the configuration settings.
 // It performs various checks and validations on the file, such as checking for disallowed subtitle files, minimum video size, and valid IDs. It then updates the media item's metadata and organizes the file accordingly.
 // If any errors occur during the process, it logs the errors and adds the file to the unmatched list.
@@ -2320,6 +2525,11 @@ func (s *Organizer) walkorganizefolder(
 
 	defer m.Close()
 
+	// Files unpacked from a season pack are often named by episode number only
+	if cfgp.IsType == config.MediaTypeSeries {
+		parser_v2.ApplySeasonPackFolder(fpath, m)
+	}
+
 	// The handler is constant for this media type; fetch it once and reuse.
 	handler := mediatype.Get(s.Cfgp.IsType)
 
@@ -2336,6 +2546,13 @@ func (s *Organizer) walkorganizefolder(
 		return false, "no_match", nil
 	}
 
+	// Short videos of a movie are extras moved with the main file
+	s.classifyExtrasByRuntime(ctx, &m.DbmovieID)
+
+	if s.isExtra(fpath) {
+		return false, "", nil
+	}
+
 	if s.sourcepathCfg.MinVideoSize > 0 {
 		info, err := os.Stat(fpath)
 		if err == nil {
@@ -2357,8 +2574,9 @@ func (s *Organizer) walkorganizefolder(
 					filepath.Ext(fpath),
 				)
 
-				shouldRemove := ok || oknorename ||
-					!mediatype.HasConfiguredExtensions(cfgp.IsType, s.sourcepathCfg)
+				shouldRemove := (ok || oknorename ||
+					!mediatype.HasConfiguredExtensions(cfgp.IsType, s.sourcepathCfg)) &&
+					!s.keepSource()
 
 				if shouldRemove {
 					scanner.SecureRemove(fpath)
@@ -2545,7 +2763,7 @@ func (s *Organizer) checksubfiles(folder, videofile, rootpath string) bool {
 			return nil
 		}
 
-		if strings.EqualFold(filepath.Ext(info.Name()), ext) {
+		if strings.EqualFold(filepath.Ext(info.Name()), ext) && !s.isExtra(fpath) {
 			if count < 2 {
 				count++
 			}
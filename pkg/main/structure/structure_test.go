@@ -1,9 +1,12 @@
 package structure
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 )
 
@@ -548,3 +551,377 @@ func TestTestParsertype(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyExtraName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		folder   bool
+		expected extraKind
+	}{
+		{name: "Sample suffix", input: "Movie.2024.1080p-sample", expected: extraSample},
+		{name: "Sample prefix", input: "sample-movie.2024.1080p", expected: extraSample},
+		{name: "Plex trailer suffix", input: "Movie (2024)-trailer", expected: extraTrailer},
+		{name: "Teaser", input: "Movie.2024.Teaser.2", expected: extraTrailer},
+		{name: "Featurette", input: "Movie.2024.Featurette.Visual.Effects", expected: extraFeaturette},
+		{name: "Deleted scenes", input: "Movie.2024.Deleted.Scenes", expected: extraDeletedScene},
+		{name: "Making of", input: "Movie 2024 - Making of", expected: extraBehindTheScenes},
+		{name: "Behind the scenes", input: "Movie.Behind_The_Scenes", expected: extraBehindTheScenes},
+		{name: "Interview", input: "Movie.2024.Interview.Director", expected: extraInterview},
+		{name: "Word inside another word", input: "Movie.Sampler.2024", expected: extraNone},
+		{name: "Main file", input: "Movie.2024.1080p.BluRay.x264-GROUP", expected: extraNone},
+		{name: "Extras only as folder", input: "Movie.Extras.2024", expected: extraNone},
+		{name: "Extras folder", input: "Extras", folder: true, expected: extraOther},
+		{name: "Sample folder", input: "Sample", folder: true, expected: extraSample},
+		{name: "Deleted scenes folder", input: "Deleted Scenes", folder: true, expected: extraDeletedScene},
+		{name: "Release folder", input: "Movie.2024.Trailer.Pack", folder: true, expected: extraNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyExtraName(tt.input, tt.folder); got != tt.expected {
+				t.Errorf("classifyExtraName(%q, %t) = %d, want %d", tt.input, tt.folder, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarshalNfo(t *testing.T) {
+	data, err := marshalNfo(nfoMovie{
+		Title:  "Movie & Co",
+		Year:   2024,
+		Genres: splitNfoList("Action, Drama,"),
+		UniqueIDs: []nfoUniqueID{
+			{Type: "imdb", Default: true, Value: "tt0000001"},
+			{Type: "tmdb", Value: "42"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := string(data)
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8" standalone="yes" ?>`,
+		"<movie>",
+		"<title>Movie &amp; Co</title>",
+		"<year>2024</year>",
+		`<uniqueid type="imdb" default="true">tt0000001</uniqueid>`,
+		`<uniqueid type="tmdb">42</uniqueid>`,
+		"<genre>Action</genre>",
+		"<genre>Drama</genre>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("marshalNfo() = %s, missing %s", got, want)
+		}
+	}
+
+	for _, unwanted := range []string{"<originaltitle>", "<ratings>", "<genre></genre>"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("marshalNfo() = %s, should not contain %s", got, unwanted)
+		}
+	}
+}
+
+func TestArtworkURL(t *testing.T) {
+	tests := []struct {
+		path string
+		size string
+		want string
+	}{
+		{"", "w500", ""},
+		{"/abc.jpg", "w500", "https://image.tmdb.org/t/p/w500/abc.jpg"},
+		{"/abc.jpg", "", "https://image.tmdb.org/t/p/original/abc.jpg"},
+		{"posters/123-1.jpg", "w500", "https://artworks.thetvdb.com/banners/posters/123-1.jpg"},
+		{"https://static.tvmaze.com/a.jpg", "w500", "https://static.tvmaze.com/a.jpg"},
+	}
+
+	for _, tt := range tests {
+		if got := artworkURL(tt.path, tt.size); got != tt.want {
+			t.Errorf("artworkURL(%q, %q) = %q, want %q", tt.path, tt.size, got, tt.want)
+		}
+	}
+
+	if got := seasonPosterName(3); got != "season03-poster.jpg" {
+		t.Errorf("seasonPosterName(3) = %q", got)
+	}
+
+	if got := seasonPosterName(0); got != "season-specials-poster.jpg" {
+		t.Errorf("seasonPosterName(0) = %q", got)
+	}
+}
+
+func TestParseSubtitleName(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		flag string
+	}{
+		{"Movie.2020.en", "en", ""},
+		{"Movie.2020.eng.forced", "en", "forced"},
+		{"Movie.2020.German.SDH", "de", "sdh"},
+		{"2_English", "en", ""},
+		{"Movie.2020.hi", "", "sdh"},
+		{"Movie.2020", "", ""},
+	}
+
+	for _, tt := range tests {
+		lang, flag := parseSubtitleName(tt.name)
+		if lang != tt.lang || flag != tt.flag {
+			t.Errorf("parseSubtitleName(%q) = %q, %q, want %q, %q", tt.name, lang, flag, tt.lang, tt.flag)
+		}
+	}
+
+	if got := subtitleName("Show S01E01", "en", "forced"); got != "Show S01E01.en.forced" {
+		t.Errorf("subtitleName() = %q", got)
+	}
+}
+
+func TestDetectTextLanguage(t *testing.T) {
+	text := "1\n00:00:01,000 --> 00:00:02,000\nIch weiß nicht, was das ist.\n\n2\n00:00:03,000 --> 00:00:04,000\nSie und wir sind nicht die Richtigen.\n"
+	if got := detectTextLanguage(text); got != "de" {
+		t.Errorf("detectTextLanguage() = %q, want de", got)
+	}
+
+	if got := detectTextLanguage("1\n00:00:01,000 --> 00:00:02,000\nHello.\n"); got != "" {
+		t.Errorf("detectTextLanguage() = %q, want empty", got)
+	}
+}
+
+func TestFindSubtitles(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "Show.S01")
+	for _, name := range []string{
+		"Show.S01E01.mkv",
+		"Show.S01E01.en.srt",
+		"Show.S01E02.en.srt",
+		"Subs/Show.S01E01/3_French.srt",
+		"Show.S01E01.forced.idx",
+		"Show.S01E01.forced.sub",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		content := ""
+		if strings.HasSuffix(name, ".idx") {
+			content = "# VobSub index file\nid: de, index: 0\n"
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Organizer{
+		Cfgp:          &config.MediaTypeConfig{IsType: config.MediaTypeSeries},
+		sourcepathCfg: &config.PathsConfig{Path: root},
+	}
+
+	got := make(map[string]string)
+	for _, sub := range s.findSubtitles(filepath.Join(dir, "Show.S01E01.mkv")) {
+		got[filepath.Base(sub.paths[0])] = subtitleName("x", sub.lang, sub.flag) + " " + strings.Repeat("+", len(sub.paths))
+	}
+
+	want := map[string]string{
+		"Show.S01E01.en.srt":     "x.en +",
+		"3_French.srt":           "x.fr +",
+		"Show.S01E01.forced.idx": "x.de.forced ++",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findSubtitles() = %v, want %v", got, want)
+	}
+
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("findSubtitles()[%q] = %q, want %q", name, got[name], value)
+		}
+	}
+}
+
+func TestSubtitleRelease(t *testing.T) {
+	tests := []struct {
+		name   string
+		group  string
+		source string
+	}{
+		{"Movie.2020.1080p.BluRay.x264-GROUP", "group", "bluray"},
+		{"Show.S01E01.720p.WEB-DL.DDP5.1.H.264-NTb[rarbg]", "ntb", "web"},
+		{"Movie 2020 HDTV", "", "hdtv"},
+	}
+
+	for _, tt := range tests {
+		group, source := subtitleRelease(tt.name)
+		if group != tt.group || source != tt.source {
+			t.Errorf("subtitleRelease(%q) = %q, %q, want %q, %q", tt.name, group, source, tt.group, tt.source)
+		}
+	}
+}
+
+func TestBestSubtitle(t *testing.T) {
+	results := []apiexternal_v2.SubtitleResult{
+		{FileID: "1", Language: "en", ReleaseName: "Movie.2020.720p.HDTV.x264-OTHER", Downloads: 900},
+		{FileID: "2", Language: "en", ReleaseName: "Movie.2020.1080p.BluRay.x264-GROUP", Downloads: 10},
+		{FileID: "3", Language: "en", ReleaseName: "Movie.2020.1080p.BluRay.x264-GROUP", HearingImpaired: true},
+		{FileID: "4", Language: "en", ReleaseName: "Movie.2020.1080p.WEB-DL-X", HashMatch: true, Forced: true},
+		{FileID: "5", Language: "pt-BR", ReleaseName: "Movie.2020.1080p.BluRay.x264-GROUP", HashMatch: true},
+	}
+
+	tests := []struct {
+		hearingImpaired string
+		lang            string
+		fileID          string
+		score           int
+	}{
+		{"", "en", "2", subtitleScoreReleaseGroup + subtitleScoreSource},
+		{config.SubtitleHearingImpairedPrefer, "en", "3", subtitleScoreReleaseGroup + subtitleScoreSource + subtitleScoreHearingImpaired},
+		{config.SubtitleHearingImpairedExclude, "en", "2", subtitleScoreReleaseGroup + subtitleScoreSource},
+		{"", "pt", "5", subtitleScoreHash + subtitleScoreReleaseGroup + subtitleScoreSource},
+	}
+
+	for _, tt := range tests {
+		best, score := bestSubtitle(results, tt.lang, "group", "bluray", tt.hearingImpaired)
+		if best == nil || best.FileID != tt.fileID || score != tt.score {
+			t.Errorf("bestSubtitle(%q, %q) = %v, %d, want %s, %d", tt.lang, tt.hearingImpaired, best, score, tt.fileID, tt.score)
+		}
+	}
+
+	if best, _ := bestSubtitle(results, "de", "group", "bluray", ""); best != nil {
+		t.Errorf("bestSubtitle(de) = %v, want nil", best)
+	}
+}
+
+func TestRecycleFolder(t *testing.T) {
+	bin := t.TempDir()
+	file := filepath.Join("media", "Movie (2020)", "movie.mkv")
+
+	first := recycleFolder(bin, file)
+	if filepath.Dir(filepath.Dir(first)) != bin || filepath.Base(first) != "Movie (2020)" {
+		t.Fatalf("recycleFolder() = %q, want <bin>/<date>/Movie (2020)", first)
+	}
+
+	if err := os.MkdirAll(first, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(first, "movie.mkv"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := recycleFolder(bin, file); got != first+" (2)" {
+		t.Errorf("recycleFolder() with existing file = %q, want %q", got, first+" (2)")
+	}
+}
+
+func TestInPath(t *testing.T) {
+	pathcfg := &config.PathsConfig{Path: filepath.Join("media", "movies") + string(os.PathSeparator)}
+
+	tests := []struct {
+		file string
+		want bool
+	}{
+		{filepath.Join("media", "movies", "Movie (2020)", "movie.mkv"), true},
+		{filepath.Join("media", "movies2", "movie.mkv"), false},
+		{filepath.Join("downloads", "movie.mkv"), false},
+	}
+
+	for _, tt := range tests {
+		if got := inPath(tt.file, pathcfg); got != tt.want {
+			t.Errorf("inPath(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+
+	if inPath(tests[0].file, nil) {
+		t.Error("inPath() with nil paths config = true, want false")
+	}
+}
+
+func TestPrunesSources(t *testing.T) {
+	tests := []struct {
+		name string
+		mode string
+		days int
+		want bool
+	}{
+		{name: "Move keeps no source", mode: config.ImportModeMove, days: 14},
+		{name: "Hardlink after days", mode: config.ImportModeHardlink, days: 14, want: true},
+		{name: "Copy after days", mode: config.ImportModeCopy, days: 14, want: true},
+		{name: "Hardlink without days", mode: config.ImportModeHardlink},
+		{name: "Symlink is never pruned", mode: config.ImportModeSymlink, days: 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pathcfg := &config.PathsConfig{
+				Path:           "downloads",
+				ImportMode:     tt.mode,
+				KeepSourceDays: tt.days,
+			}
+			if got := prunesSources(pathcfg); got != tt.want {
+				t.Errorf("prunesSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if prunesSources(nil) {
+		t.Error("prunesSources() with nil paths config = true, want false")
+	}
+}
+
+func TestMovieArtworkPrefix(t *testing.T) {
+	root := filepath.Join("media", "movies")
+	pathcfg := &config.PathsConfig{Path: root}
+
+	// Videos in the root of the data path share it with other movies
+	video := filepath.Join(root, "Movie (2020).mkv")
+	want := filepath.Join(root, "Movie (2020)") + "-"
+
+	if got := movieArtworkPrefix(video, 1, pathcfg); got != want {
+		t.Errorf("movieArtworkPrefix(%q) = %q, want %q", video, got, want)
+	}
+
+	if got := movieNfoPath(video, 1, pathcfg); got != filepath.Join(root, "Movie (2020).nfo") {
+		t.Errorf("movieNfoPath(%q) = %q, want the NFO named after the video", video, got)
+	}
+}
+
+func TestSeasonPackFolder(t *testing.T) {
+	root := filepath.Join("downloads", "Show.Complete.1080p")
+	season := filepath.Join(root, "Show.S02.1080p")
+
+	tests := []struct {
+		name       string
+		fpath      string
+		rootIsPack bool
+		want       string
+	}{
+		{
+			name:  "File of a season pack subfolder",
+			fpath: filepath.Join(season, "Show.S02E01.mkv"),
+			want:  season,
+		},
+		{
+			name:       "File of the pack root",
+			fpath:      filepath.Join(root, "Show.S02E01.mkv"),
+			rootIsPack: true,
+			want:       root,
+		},
+		{
+			name:  "File of another folder",
+			fpath: filepath.Join(root, "Extras", "Show.S02E01.mkv"),
+		},
+		{
+			name:  "Root which is no pack",
+			fpath: filepath.Join(root, "Show.S02E01.mkv"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := seasonPackFolder(tt.fpath, root, tt.rootIsPack); got != tt.want {
+				t.Errorf("seasonPackFolder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
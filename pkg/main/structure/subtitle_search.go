@@ -0,0 +1,543 @@
+package structure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/providers/opensubtitles"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/providers"
+)
+
+// subtitleRetryHours is how long a language without a provider match is not
+// searched again.
+const subtitleRetryHours = 24
+
+// Score parts of a downloaded subtitle. The file hash identifies the exact
+// release, the release group and the source make the timing likely to fit.
+const (
+	subtitleScoreHash            = 100
+	subtitleScoreReleaseGroup    = 50
+	subtitleScoreSource          = 25
+	subtitleScoreHearingImpaired = 10
+)
+
+// subtitleSources maps the source words of release names to the source they
+// are compared by.
+var subtitleSources = map[string]string{
+	"bluray":  "bluray",
+	"blu":     "bluray",
+	"bdrip":   "bluray",
+	"brrip":   "bluray",
+	"bdremux": "bluray",
+	"remux":   "bluray",
+	"web":     "web",
+	"webdl":   "web",
+	"webrip":  "web",
+	"hdtv":    "hdtv",
+	"pdtv":    "hdtv",
+	"dvdrip":  "dvd",
+	"dvd":     "dvd",
+	"dvdr":    "dvd",
+	"hdrip":   "hdrip",
+}
+
+// subtitleSearchVideo is a video file of the library with the IDs and the
+// release used for the subtitle search.
+type subtitleSearchVideo struct {
+	Location     string
+	ImdbID       string `db:"imdb_id"`
+	Season       string
+	Episode      string
+	ReleaseName  string `db:"release_name"`
+	ReleaseGroup string `db:"release_group"`
+	SubLanguages string `db:"subtitle_languages"`
+}
+
+// subtitleRelease returns the lower case release group and source of a
+// release name like Movie.2020.1080p.BluRay.x264-GROUP.
+func subtitleRelease(name string) (string, string) {
+	var group, source string
+	if idx := strings.LastIndexByte(name, '-'); idx >= 0 {
+		group = strings.ToLower(name[idx+1:])
+		if end := strings.IndexAny(group, " .[("); end >= 0 {
+			group = group[:end]
+		}
+	}
+
+	for _, word := range splitSubtitleName(name) {
+		if src, ok := subtitleSources[strings.ToLower(word)]; ok {
+			source = src
+			break
+		}
+	}
+
+	return group, source
+}
+
+// subtitleResultLanguage returns the ISO 639-1 code of a provider language
+// like en or pt-BR.
+func subtitleResultLanguage(lang string) string {
+	lang, _, _ = strings.Cut(lang, "-")
+	if code := normalizeSubtitleLanguage(lang); code != "" {
+		return code
+	}
+
+	return strings.ToLower(lang)
+}
+
+// scoreSubtitle returns the match score of the subtitle for a video of the
+// release group and source, or -1 if the subtitle is not allowed by the
+// hearing impaired setting.
+func scoreSubtitle(
+	sub *apiexternal_v2.SubtitleResult,
+	group, source, hearingImpaired string,
+) int {
+	if sub.HearingImpaired && hearingImpaired == config.SubtitleHearingImpairedExclude {
+		return -1
+	}
+
+	score := 0
+	if sub.HashMatch {
+		score += subtitleScoreHash
+	}
+
+	subgroup, subsource := subtitleRelease(sub.ReleaseName)
+	if group != "" && subgroup == group {
+		score += subtitleScoreReleaseGroup
+	}
+
+	if source != "" && subsource == source {
+		score += subtitleScoreSource
+	}
+
+	if sub.HearingImpaired && hearingImpaired == config.SubtitleHearingImpairedPrefer {
+		score += subtitleScoreHearingImpaired
+	}
+
+	return score
+}
+
+// bestSubtitle returns the subtitle of the language with the highest score
+// and its score. Forced subtitles only cover foreign parts and are skipped.
+// Equal scores are decided by the number of downloads.
+func bestSubtitle(
+	results []apiexternal_v2.SubtitleResult,
+	lang, group, source, hearingImpaired string,
+) (*apiexternal_v2.SubtitleResult, int) {
+	var best *apiexternal_v2.SubtitleResult
+
+	bestscore := -1
+	for idx := range results {
+		if results[idx].Forced || subtitleResultLanguage(results[idx].Language) != lang {
+			continue
+		}
+
+		score := scoreSubtitle(&results[idx], group, source, hearingImpaired)
+		if score < 0 {
+			continue
+		}
+
+		if best == nil || score > bestscore ||
+			(score == bestscore && results[idx].Downloads > best.Downloads) {
+			best, bestscore = &results[idx], score
+		}
+	}
+
+	return best, bestscore
+}
+
+// subtitlePathConfig returns the data path of the location if it has
+// download_subtitles enabled.
+func subtitlePathConfig(cfgp *config.MediaTypeConfig, location string) *config.PathsConfig {
+	for idx := range cfgp.Data {
+		if inPath(location, cfgp.Data[idx].CfgPath) && cfgp.Data[idx].CfgPath.DownloadSubtitles {
+			return cfgp.Data[idx].CfgPath
+		}
+	}
+
+	return nil
+}
+
+// wantedSubtitleLanguages returns the ISO 639-1 codes of the subtitle
+// languages of the path.
+func wantedSubtitleLanguages(pathCfg *config.PathsConfig) []string {
+	langs := make([]string, 0, len(pathCfg.SubtitleLanguages))
+	for _, wanted := range pathCfg.SubtitleLanguages {
+		lang := normalizeSubtitleLanguage(wanted)
+		if lang == "" {
+			lang = strings.ToLower(wanted)
+		}
+
+		if lang != "" && !slices.Contains(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+
+	return langs
+}
+
+// SearchMissingSubtitles searches the registered subtitle providers for the
+// video files of the media config which have no subtitle of a wanted
+// language. Only files in data paths with download_subtitles enabled are
+// searched. The best match is written next to the video and the status of
+// every wanted language is stored in the database.
+func SearchMissingSubtitles(ctx context.Context, cfgp *config.MediaTypeConfig) error {
+	if cfgp == nil || len(cfgp.ListsNames) == 0 {
+		return nil
+	}
+
+	var query string
+	switch cfgp.IsType {
+	case config.MediaTypeMovie:
+		query = logger.JoinStrings(
+			"select movie_files.location, movie_files.release_name, movie_files.release_group, movie_files.subtitle_languages, dbmovies.imdb_id from movie_files inner join movies on movies.id = movie_files.movie_id inner join dbmovies on dbmovies.id = movie_files.dbmovie_id where movies.listname in (?",
+			cfgp.ListsQu,
+			")",
+		)
+	case config.MediaTypeSeries:
+		query = logger.JoinStrings(
+			"select serie_episode_files.location, serie_episode_files.release_name, serie_episode_files.release_group, serie_episode_files.subtitle_languages, dbseries.imdb_id, dbserie_episodes.season, dbserie_episodes.episode from serie_episode_files inner join series on series.id = serie_episode_files.serie_id inner join dbseries on dbseries.id = serie_episode_files.dbserie_id inner join dbserie_episodes on dbserie_episodes.id = serie_episode_files.dbserie_episode_id where series.listname in (?",
+			cfgp.ListsQu,
+			")",
+		)
+	default:
+		return nil
+	}
+
+	enabled := false
+	for idx := range cfgp.Data {
+		if cfgp.Data[idx].CfgPath != nil && cfgp.Data[idx].CfgPath.DownloadSubtitles {
+			enabled = true
+			break
+		}
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	subproviders := providers.GetAllSubtitleProviders()
+	if len(subproviders) == 0 {
+		logger.Logtype("debug", 1).
+			Str(logger.StrConfig, cfgp.NamePrefix).
+			Msg("No subtitle provider configured")
+
+		return nil
+	}
+
+	args := make([]any, 0, len(cfgp.ListsNames))
+	for idx := range cfgp.ListsNames {
+		args = append(args, &cfgp.ListsNames[idx])
+	}
+
+	// Providers whose download quota is used up, the search stops once all are
+	exhausted := make(map[string]struct{}, len(subproviders))
+
+	for _, video := range database.StructscanT[subtitleSearchVideo](false, 0, query, args...) {
+		if err := logger.CheckContextEnded(ctx); err != nil {
+			return err
+		}
+
+		if len(exhausted) == len(subproviders) {
+			logger.Logtype("info", 1).
+				Str(logger.StrConfig, cfgp.NamePrefix).
+				Msg("Subtitle search stopped - download quota reached")
+
+			return nil
+		}
+
+		pathCfg := subtitlePathConfig(cfgp, video.Location)
+		if pathCfg == nil {
+			continue
+		}
+
+		searchVideoSubtitles(
+			ctx,
+			subproviders,
+			exhausted,
+			pathCfg,
+			&video,
+			cfgp.IsType == config.MediaTypeSeries,
+		)
+	}
+
+	return nil
+}
+
+// searchVideoSubtitles searches and downloads the missing subtitle languages
+// of one video file. Providers in exhausted are not used, providers whose
+// download quota runs out are added to it.
+func searchVideoSubtitles(
+	ctx context.Context,
+	subproviders map[string]apiexternal_v2.SubtitleProvider,
+	exhausted map[string]struct{},
+	pathCfg *config.PathsConfig,
+	video *subtitleSearchVideo,
+	series bool,
+) {
+	if _, err := os.Stat(video.Location); err != nil {
+		return
+	}
+
+	wanted := wantedSubtitleLanguages(pathCfg)
+	if len(wanted) == 0 {
+		return
+	}
+
+	present := make(map[string]string)
+	for _, sub := range findVideoSubtitles(video.Location, false) {
+		if sub.lang != "" && sub.flag != "forced" {
+			if _, ok := present[sub.lang]; !ok {
+				present[sub.lang] = sub.paths[0]
+			}
+		}
+	}
+
+	// Languages embedded in the video are present too, with the video as path
+	for embedded := range strings.SplitSeq(video.SubLanguages, ",") {
+		if lang := normalizeSubtitleLanguage(embedded); lang != "" {
+			if _, ok := present[lang]; !ok {
+				present[lang] = video.Location
+			}
+		}
+	}
+
+	// Present languages are only stored if their status changed
+	stored := make(map[string]string)
+	for _, sub := range database.GetSubtitles(video.Location) {
+		if sub.Status != database.SubtitleStatusMissing {
+			stored[sub.Language] = sub.Path
+		}
+	}
+
+	missing := make([]string, 0, len(wanted))
+	for _, lang := range wanted {
+		if path, ok := present[lang]; ok {
+			if storedpath, ok := stored[lang]; !ok || storedpath != path {
+				database.SetSubtitle(&database.Subtitle{
+					Location: video.Location,
+					Language: lang,
+					Status:   database.SubtitleStatusExisting,
+					Path:     path,
+				}, false)
+			}
+
+			continue
+		}
+
+		if database.SubtitleSearchedRecently(video.Location, lang, subtitleRetryHours) {
+			continue
+		}
+
+		missing = append(missing, lang)
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	videobase := strings.TrimSuffix(filepath.Base(video.Location), filepath.Ext(video.Location))
+
+	// The video was renamed on import, its release is taken from the stored
+	// release name and group. Files imported before they were stored fall
+	// back to the file name.
+	release := video.ReleaseName
+	if release == "" {
+		release = videobase
+	}
+
+	group, source := subtitleRelease(release)
+	if video.ReleaseGroup != "" {
+		group = strings.ToLower(video.ReleaseGroup)
+	}
+
+	request := apiexternal_v2.SubtitleSearchRequest{Languages: missing}
+	request.MovieHash, _ = opensubtitles.Hash(video.Location)
+	if series {
+		request.ParentImdbID = video.ImdbID
+		request.Season, _ = strconv.Atoi(video.Season)
+		request.Episode, _ = strconv.Atoi(video.Episode)
+	} else {
+		request.ImdbID = video.ImdbID
+	}
+
+	// The release name is only searched when the video has no IMDb ID,
+	// otherwise it is used for the score.
+	if video.ImdbID == "" {
+		request.Query = release
+	}
+
+	names := make([]string, 0, len(subproviders))
+	for name := range subproviders {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var results []apiexternal_v2.SubtitleResult
+	for _, name := range names {
+		if _, ok := exhausted[name]; ok {
+			continue
+		}
+
+		found, err := subproviders[name].SearchSubtitles(ctx, request)
+		if err != nil {
+			logger.Logtype("error", 1).
+				Str(logger.StrFile, video.Location).
+				Str("provider", name).
+				Err(err).
+				Msg("Subtitle search failed")
+
+			continue
+		}
+
+		for idx := range found {
+			found[idx].Provider = name
+		}
+
+		results = append(results, found...)
+	}
+
+	for _, lang := range missing {
+		status := database.Subtitle{
+			Location: video.Location,
+			Language: lang,
+			Status:   database.SubtitleStatusMissing,
+		}
+
+		for {
+			best, score := bestSubtitle(
+				results, lang, group, source, pathCfg.SubtitleHearingImpaired)
+			if best == nil {
+				break
+			}
+
+			path, err := downloadSubtitle(
+				ctx,
+				subproviders[best.Provider],
+				best,
+				video.Location,
+				videobase,
+				lang,
+			)
+			if errors.Is(err, apiexternal_v2.ErrSubtitleQuota) {
+				logger.Logtype("info", 1).
+					Str("provider", best.Provider).
+					Msg("Subtitle download quota reached")
+
+				// The subtitles of the provider can't be downloaded anymore,
+				// the next best one of another provider is tried instead
+				exhausted[best.Provider] = struct{}{}
+				provider := best.Provider
+				results = slices.DeleteFunc(results, func(sub apiexternal_v2.SubtitleResult) bool {
+					return sub.Provider == provider
+				})
+
+				continue
+			}
+
+			if path != "" {
+				status.Status = database.SubtitleStatusDownloaded
+				status.Path = path
+				status.Provider = best.Provider
+				status.ReleaseName = best.ReleaseName
+				status.HearingImpaired = best.HearingImpaired
+				status.Score = score
+			}
+
+			break
+		}
+
+		// Without downloads left the language is searched again next time
+		if status.Status == database.SubtitleStatusMissing && len(exhausted) > 0 {
+			continue
+		}
+
+		database.SetSubtitle(&status, true)
+	}
+}
+
+// downloadSubtitle downloads the subtitle and writes it next to the video as
+// <video>.<lang>[.sdh].<ext>. It returns the path of the written file, or an
+// empty path if it was not written. Existing subtitles are never replaced.
+// The error is only returned if the download quota of the provider is used up.
+func downloadSubtitle(
+	ctx context.Context,
+	provider apiexternal_v2.SubtitleProvider,
+	sub *apiexternal_v2.SubtitleResult,
+	location, videobase, lang string,
+) (string, error) {
+	if provider == nil {
+		return "", nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(sub.FileName))
+	if !isSubtitleFile(sub.FileName) || ext == ".idx" || ext == ".sub" {
+		ext = ".srt"
+	}
+
+	flag := ""
+	if sub.HearingImpaired {
+		flag = "sdh"
+	}
+
+	path := filepath.Join(filepath.Dir(location), subtitleName(videobase, lang, flag)+ext)
+	if _, err := os.Stat(path); err == nil {
+		logger.Logtype("warn", 1).
+			Str(logger.StrPath, path).
+			Msg("Subtitle not downloaded - file already exists")
+
+		return "", nil
+	}
+
+	data, err := provider.DownloadSubtitle(ctx, sub)
+	if errors.Is(err, apiexternal_v2.ErrSubtitleQuota) {
+		return "", err
+	}
+
+	if err != nil || len(data) == 0 {
+		logger.Logtype("error", 1).
+			Str(logger.StrFile, location).
+			Str("provider", sub.Provider).
+			Err(err).
+			Msg("Subtitle download failed")
+
+		return "", nil
+	}
+
+	// Written to a temporary file first, so an interrupted write never leaves
+	// a truncated subtitle which would be taken as present later on
+	tmppath := path + ".tmp"
+
+	err = os.WriteFile(tmppath, data, 0o644)
+	if err == nil {
+		err = os.Rename(tmppath, path)
+	}
+
+	if err != nil {
+		os.Remove(tmppath)
+		logger.Logtype("error", 1).
+			Str(logger.StrPath, path).
+			Err(err).
+			Msg("Failed to write subtitle")
+
+		return "", nil
+	}
+
+	logger.Logtype("info", 1).
+		Str(logger.StrPath, path).
+		Str("provider", sub.Provider).
+		Str("release", sub.ReleaseName).
+		Msg("Subtitle downloaded")
+
+	return path, nil
+}
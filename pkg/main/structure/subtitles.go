@@ -0,0 +1,456 @@
+package structure
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/scanner"
+)
+
+// subtitleFile is an external subtitle of the organized video. VobSub .sub
+// and .idx files of the same name form one subtitle with two files.
+type subtitleFile struct {
+	paths []string
+	lang  string
+	flag  string
+}
+
+// subtitleExtensions are the external subtitle formats moved with the video.
+var subtitleExtensions = []string{".srt", ".ass", ".ssa", ".sub", ".idx", ".sup"}
+
+// subtitleFolders are the folder names below the video containing subtitles.
+var subtitleFolders = []string{"subs", "subtitles", "subtitle", "sub"}
+
+// subtitleLanguages maps ISO 639-1 and ISO 639-2 codes and the English and
+// native names of languages to their ISO 639-1 code. Two letter codes which
+// are common words or flags (hi, he, is, no) are left out.
+var subtitleLanguages = map[string]string{
+	"en": "en", "eng": "en", "english": "en",
+	"de": "de", "ger": "de", "deu": "de", "german": "de", "deutsch": "de",
+	"fr": "fr", "fre": "fr", "fra": "fr", "french": "fr", "francais": "fr", "français": "fr",
+	"es": "es", "spa": "es", "spanish": "es", "espanol": "es", "español": "es", "castellano": "es",
+	"it": "it", "ita": "it", "italian": "it", "italiano": "it",
+	"nl": "nl", "dut": "nl", "nld": "nl", "dutch": "nl", "nederlands": "nl",
+	"pt": "pt", "por": "pt", "portuguese": "pt", "portugues": "pt", "português": "pt",
+	"sv": "sv", "swe": "sv", "swedish": "sv", "svenska": "sv",
+	"da": "da", "dan": "da", "danish": "da", "dansk": "da",
+	"nor": "no", "nob": "no", "nno": "no", "norwegian": "no", "norsk": "no",
+	"fi": "fi", "fin": "fi", "finnish": "fi", "suomi": "fi",
+	"pl": "pl", "pol": "pl", "polish": "pl", "polski": "pl",
+	"cs": "cs", "cze": "cs", "ces": "cs", "czech": "cs", "cesky": "cs",
+	"sk": "sk", "slo": "sk", "slk": "sk", "slovak": "sk",
+	"hu": "hu", "hun": "hu", "hungarian": "hu", "magyar": "hu",
+	"ro": "ro", "rum": "ro", "ron": "ro", "romanian": "ro",
+	"hr": "hr", "hrv": "hr", "croatian": "hr",
+	"sr": "sr", "srp": "sr", "serbian": "sr",
+	"sl": "sl", "slv": "sl", "slovenian": "sl",
+	"bg": "bg", "bul": "bg", "bulgarian": "bg",
+	"uk": "uk", "ukr": "uk", "ukrainian": "uk",
+	"ru": "ru", "rus": "ru", "russian": "ru",
+	"el": "el", "gre": "el", "ell": "el", "greek": "el",
+	"tr": "tr", "tur": "tr", "turkish": "tr",
+	"ar": "ar", "ara": "ar", "arabic": "ar",
+	"heb": "he", "hebrew": "he",
+	"fa": "fa", "per": "fa", "fas": "fa", "persian": "fa",
+	"hin": "hi", "hindi": "hi",
+	"th": "th", "tha": "th", "thai": "th",
+	"vi": "vi", "vie": "vi", "vietnamese": "vi",
+	"id": "id", "ind": "id", "indonesian": "id",
+	"ms": "ms", "may": "ms", "msa": "ms", "malay": "ms",
+	"ja": "ja", "jpn": "ja", "japanese": "ja",
+	"ko": "ko", "kor": "ko", "korean": "ko",
+	"zh": "zh", "chi": "zh", "zho": "zh", "chinese": "zh",
+	"et": "et", "est": "et", "estonian": "et",
+	"lv": "lv", "lav": "lv", "latvian": "lv",
+	"lt": "lt", "lit": "lt", "lithuanian": "lt",
+	"ice": "is", "isl": "is", "icelandic": "is",
+}
+
+// subtitleFlags maps the file name words of forced and SDH subtitles to the
+// flag written into the subtitle name.
+var subtitleFlags = map[string]string{
+	"forced":  "forced",
+	"foreign": "forced",
+	"sdh":     "sdh",
+	"cc":      "sdh",
+	"hi":      "sdh",
+	"hoh":     "sdh",
+}
+
+// subtitleStopwords are frequent words of a language used to detect the
+// language of .srt files without one in the file name.
+var subtitleStopwords = map[string][]string{
+	"en": {"the", "you", "and", "what", "this", "that", "with", "have", "are", "your"},
+	"de": {"und", "ich", "nicht", "das", "ist", "sie", "wir", "der", "die", "ein"},
+	"fr": {"je", "vous", "les", "est", "pas", "que", "une", "nous", "le", "et"},
+	"es": {"el", "los", "las", "por", "una", "esto", "está", "pero", "qué", "muy"},
+	"it": {"il", "che", "non", "sono", "questo", "perché", "della", "cosa", "gli", "ho"},
+	"nl": {"het", "een", "niet", "ik", "wat", "dat", "van", "zijn", "maar", "je"},
+	"pt": {"não", "você", "uma", "isso", "está", "com", "para", "os", "muito", "eu"},
+	"sv": {"och", "det", "jag", "inte", "är", "att", "har", "vi", "på", "du"},
+}
+
+// subtitleSniffBytes is how much of a .srt file is read to detect its language.
+const subtitleSniffBytes = 16 << 10
+
+// normalizeSubtitleLanguage returns the ISO 639-1 code of a language code or
+// name, or an empty string if it is unknown.
+func normalizeSubtitleLanguage(lang string) string {
+	return subtitleLanguages[strings.ToLower(strings.TrimSpace(lang))]
+}
+
+// splitSubtitleName splits a file name without extension into its words.
+func splitSubtitleName(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == ' ' || r == '[' || r == ']' ||
+			r == '(' || r == ')'
+	})
+}
+
+// parseSubtitleName returns the language and flag of a subtitle file name
+// without extension. The words are checked from the end, so the tags after
+// the title win over words of the title.
+func parseSubtitleName(name string) (string, string) {
+	var lang, flag string
+
+	words := splitSubtitleName(name)
+	for idx := len(words) - 1; idx >= 0; idx-- {
+		word := strings.ToLower(words[idx])
+		if f, ok := subtitleFlags[word]; ok {
+			if flag == "" {
+				flag = f
+			}
+
+			continue
+		}
+
+		if lang == "" {
+			lang = subtitleLanguages[word]
+		}
+	}
+
+	return lang, flag
+}
+
+// idxLanguage returns the language of the first stream of a VobSub index.
+func idxLanguage(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if !strings.HasPrefix(line, "id:") {
+			continue
+		}
+
+		lang, _, _ := strings.Cut(strings.TrimPrefix(line, "id:"), ",")
+		if lang = normalizeSubtitleLanguage(lang); lang != "" {
+			return lang
+		}
+	}
+
+	return ""
+}
+
+// sniffSrtLanguage detects the language of a .srt file by counting frequent
+// words of the languages in its first lines. It returns an empty string if
+// no language is clearly ahead.
+func sniffSrtLanguage(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, subtitleSniffBytes))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return ""
+	}
+
+	return detectTextLanguage(string(data))
+}
+
+// detectTextLanguage returns the language with the most frequent words in
+// the text if it has at least 5 of them and more than any other language.
+func detectTextLanguage(text string) string {
+	counts := make(map[string]int, len(subtitleStopwords))
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for lang, stopwords := range subtitleStopwords {
+			if slices.Contains(stopwords, word) {
+				counts[lang]++
+			}
+		}
+	}
+
+	var best, second int
+
+	lang := ""
+	for l, count := range counts {
+		switch {
+		case count > best:
+			second = best
+			best, lang = count, l
+		case count > second:
+			second = count
+		}
+	}
+
+	if best < 5 || best == second {
+		return ""
+	}
+
+	return lang
+}
+
+// isSubtitleFolder reports if the folder name is one of the subtitle folders.
+func isSubtitleFolder(name string) bool {
+	return logger.SlicesContainsI(subtitleFolders, name)
+}
+
+// isSubtitleFile reports if the file has one of the subtitle extensions.
+func isSubtitleFile(name string) bool {
+	return logger.SlicesContainsI(subtitleExtensions, filepath.Ext(name))
+}
+
+// findSubtitles returns the external subtitles of the video to organize.
+// For movies in their own folder all subtitles of the folder belong to the
+// movie.
+func (s *Organizer) findSubtitles(videofile string) []subtitleFile {
+	return findVideoSubtitles(
+		videofile,
+		s.Cfgp.IsType == config.MediaTypeMovie &&
+			filepath.Clean(filepath.Dir(videofile)) != filepath.Clean(s.sourcepathCfg.Path),
+	)
+}
+
+// findVideoSubtitles returns the external subtitles of the video next to it
+// and in the subtitle folders below it. Subtitles belong to the video if
+// their name starts with the name of the video or their folder is named like
+// the video. With single all subtitles of the folder belong to the video.
+func findVideoSubtitles(videofile string, single bool) []subtitleFile {
+	dir := filepath.Dir(videofile)
+	videobase := strings.TrimSuffix(filepath.Base(videofile), filepath.Ext(videofile))
+
+	belongs := func(fpath string) bool {
+		return single || logger.HasPrefixI(filepath.Base(fpath), videobase) ||
+			strings.EqualFold(filepath.Base(filepath.Dir(fpath)), videobase)
+	}
+
+	var paths []string
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		fpath := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			if isSubtitleFile(entry.Name()) && belongs(fpath) {
+				paths = append(paths, fpath)
+			}
+
+			continue
+		}
+
+		if !isSubtitleFolder(entry.Name()) {
+			continue
+		}
+
+		filepath.WalkDir(fpath, func(subpath string, info os.DirEntry, errw error) error {
+			if errw != nil {
+				return errw
+			}
+
+			if !info.IsDir() && isSubtitleFile(info.Name()) && belongs(subpath) {
+				paths = append(paths, subpath)
+			}
+
+			return nil
+		})
+	}
+
+	slices.Sort(paths)
+
+	// VobSub .sub and .idx files of the same name are one subtitle
+	subs := make([]subtitleFile, 0, len(paths))
+	groups := make(map[string]int, len(paths))
+	for _, fpath := range paths {
+		ext := strings.ToLower(filepath.Ext(fpath))
+		key := strings.TrimSuffix(fpath, filepath.Ext(fpath))
+		if ext == ".sub" || ext == ".idx" {
+			if idx, ok := groups[key]; ok {
+				subs[idx].paths = append(subs[idx].paths, fpath)
+				continue
+			}
+
+			groups[key] = len(subs)
+		}
+
+		subs = append(subs, subtitleFile{paths: []string{fpath}})
+	}
+
+	for idx := range subs {
+		subs[idx].detect(videobase)
+	}
+
+	return subs
+}
+
+// detect sets the language and flag of the subtitle from its file name, the
+// VobSub index or the text of a .srt file.
+func (sub *subtitleFile) detect(videobase string) {
+	name := filepath.Base(sub.paths[0])
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if logger.HasPrefixI(name, videobase) {
+		name = name[len(videobase):]
+	}
+
+	sub.lang, sub.flag = parseSubtitleName(name)
+	if sub.lang == "" {
+		// Subs/<video>/English.srt or Subs/English/1.srt
+		sub.lang = normalizeSubtitleLanguage(filepath.Base(filepath.Dir(sub.paths[0])))
+	}
+
+	for _, fpath := range sub.paths {
+		if sub.lang != "" {
+			return
+		}
+
+		switch strings.ToLower(filepath.Ext(fpath)) {
+		case ".idx":
+			sub.lang = idxLanguage(fpath)
+		case ".srt":
+			sub.lang = sniffSrtLanguage(fpath)
+		}
+	}
+}
+
+// subtitleWanted reports if subtitles of the language are kept by the
+// subtitle language filter of the source path.
+func (s *Organizer) subtitleWanted(lang string) bool {
+	if len(s.sourcepathCfg.SubtitleLanguages) == 0 {
+		return true
+	}
+
+	if lang == "" {
+		return s.sourcepathCfg.SubtitleKeepUnknown
+	}
+
+	for _, wanted := range s.sourcepathCfg.SubtitleLanguages {
+		if normalizeSubtitleLanguage(wanted) == lang || strings.EqualFold(wanted, lang) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subtitleName returns the new name of a subtitle without extension:
+// <video>.<lang>[.forced|.sdh].
+func subtitleName(videobase, lang, flag string) string {
+	name := videobase
+	if lang != "" {
+		name += "." + lang
+	}
+
+	if flag != "" {
+		name += "." + flag
+	}
+
+	return name
+}
+
+// moveSubtitles renames the external subtitles of the organized video to
+// the new name of the video and moves them next to it with the import mode.
+// Subtitles of unwanted languages stay in the source folder. All found
+// subtitles are remembered so they are not moved again as other files.
+func (s *Organizer) moveSubtitles(o *Organizerdata, newfile string) {
+	s.subtitles = nil
+	if !s.sourcepathCfg.OrganizeSubtitles || newfile == "" ||
+		(s.Cfgp.IsType != config.MediaTypeMovie && s.Cfgp.IsType != config.MediaTypeSeries) {
+		return
+	}
+
+	subs := s.findSubtitles(o.MediaFile)
+	if len(subs) == 0 {
+		return
+	}
+
+	moveOpts := scanner.MoveFileOptions{
+		UseBufferCopy: config.GetSettingsGeneral().UseFileBufferCopy,
+		Chmod:         s.targetpathCfg.SetChmod,
+		ChmodFolder:   s.targetpathCfg.SetChmodFolder,
+		UseNil:        true,
+		ForceRename:   true,
+		MediaType:     s.Cfgp.IsType,
+		Mode:          s.sourcepathCfg.ImportMode,
+	}
+
+	target := filepath.Dir(newfile)
+	videobase := strings.TrimSuffix(filepath.Base(newfile), filepath.Ext(newfile))
+
+	s.subtitles = make(map[string]struct{}, len(subs))
+	used := make(map[string]int, len(subs))
+	for idx := range subs {
+		for _, fpath := range subs[idx].paths {
+			s.subtitles[fpath] = struct{}{}
+		}
+
+		if !s.subtitleWanted(subs[idx].lang) {
+			logger.Logtype("debug", 1).
+				Str(logger.StrFile, subs[idx].paths[0]).
+				Str("language", subs[idx].lang).
+				Msg("Subtitle skipped - language")
+
+			continue
+		}
+
+		name := subtitleName(videobase, subs[idx].lang, subs[idx].flag)
+		used[name]++
+		if used[name] > 1 {
+			name += "." + strconv.Itoa(used[name])
+		}
+
+		for _, fpath := range subs[idx].paths {
+			newpath, err := scanner.MoveFile(fpath, s.sourcepathCfg, target, name, moveOpts)
+			if err != nil {
+				if !errors.Is(err, logger.ErrNotFound) {
+					logger.Logtype("error", 1).
+						Str(logger.StrFile, fpath).
+						Err(err).
+						Msg("subtitle move")
+				}
+
+				continue
+			}
+
+			o.RenamedFiles = append(o.RenamedFiles, RenameEntry{
+				OldName: filepath.Base(fpath),
+				NewName: filepath.Base(newpath),
+			})
+		}
+	}
+}
+
+// isSubtitleHandled reports if the file is a subtitle already handled with
+// the organized video.
+func (s *Organizer) isSubtitleHandled(fpath string) bool {
+	_, ok := s.subtitles[fpath]
+	return ok
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/importfeed"
@@ -1298,6 +1299,11 @@ func runMovieHTMLXPathScraper(
 		PageURLPattern:   cfglist.CfgList.MoviePageURLPattern,
 		DateFormat:       cfglist.CfgList.MovieDateFormat,
 		WaitSeconds:      cfglist.CfgList.MovieWaitSeconds,
+		Proxy: apiexternal.ProxyConfig(
+			cfglist.CfgList.ProxyURL,
+			cfglist.CfgList.ProxyUsername,
+			cfglist.CfgList.ProxyPassword,
+		),
 	}
 
 	scraper, err := htmlxpath.NewMovieScraper(cfg)
@@ -1334,10 +1340,10 @@ func extractChartText(node *html.Node, xpath, attr string) string {
 	return strings.TrimSpace(htmlquery.InnerText(found))
 }
 
-// fetchAndParseChartPage fetches url, parses the HTML and calls fn with the document root.
-// The request is bounded by a timeout so a slow or hung chart server cannot block
-// the feed job indefinitely.
-func fetchAndParseChartPage(url string, fn func(*html.Node)) error {
+// fetchAndParseChartPage fetches url through proxy, parses the HTML and calls fn with the
+// document root. The request is bounded by a timeout so a slow or hung chart server cannot
+// block the feed job indefinitely.
+func fetchAndParseChartPage(url string, proxy base.ProxyConfig, fn func(*html.Node)) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
@@ -1362,7 +1368,13 @@ func fetchAndParseChartPage(url string, fn func(*html.Node)) error {
 	req.Header.Set("Sec-Fetch-Site", "none")
 	req.Header.Set("Sec-Fetch-User", "?1")
 
-	resp, err := http.DefaultClient.Do(req)
+	transport, err := proxy.Transport()
+	if err != nil {
+		return fmt.Errorf("chart page proxy: %w", err)
+	}
+	defer transport.CloseIdleConnections()
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
 	if err != nil {
 		return fmt.Errorf("chart page fetch: %w", err)
 	}
@@ -1442,7 +1454,13 @@ func (d *feedResults) scrapeChart(
 		updateListnameQualityQuery = "UPDATE " + sc.table + " SET listname = ?, quality_profile = ? WHERE id = ?"
 	}
 
-	return fetchAndParseChartPage(cfglist.CfgList.URL, func(doc *html.Node) {
+	proxy := apiexternal.ProxyConfig(
+		cfglist.CfgList.ProxyURL,
+		cfglist.CfgList.ProxyUsername,
+		cfglist.CfgList.ProxyPassword,
+	)
+
+	return fetchAndParseChartPage(cfglist.CfgList.URL, proxy, func(doc *html.Node) {
 		nodes := htmlquery.Find(doc, cfglist.CfgList.ChartEntryNodeXPath)
 
 		if limit, err := strconv.Atoi(
@@ -1590,6 +1608,11 @@ func runMovieCSRFAPIScraper(
 		ReleaseDateField: cfglist.CfgList.MovieReleaseDateField,
 		DateFormat:       cfglist.CfgList.MovieDateFormat,
 		WaitSeconds:      cfglist.CfgList.MovieWaitSeconds,
+		Proxy: apiexternal.ProxyConfig(
+			cfglist.CfgList.ProxyURL,
+			cfglist.CfgList.ProxyUsername,
+			cfglist.CfgList.ProxyPassword,
+		),
 	}
 
 	scraper, err := csrfapi.NewMovieScraper(cfg)
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2/base"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 )
@@ -415,7 +416,13 @@ func (s *ircSession) remove() {
 // connectAndListen opens one connection, authenticates, joins the channels and
 // buffers matching announce lines until the connection drops or ctx is done.
 func (s *ircSession) connectAndListen(ctx context.Context, cl *config.ListsConfig) error {
-	conn, err := dialIRC(ctx, cl.IRCServer, cl.IRCUseTLS, time.Now().Add(ircDialTimeout))
+	conn, err := dialIRC(
+		ctx,
+		cl.IRCServer,
+		cl.IRCUseTLS,
+		apiexternal.ProxyConfig(cl.ProxyURL, cl.ProxyUsername, cl.ProxyPassword),
+		time.Now().Add(ircDialTimeout),
+	)
 	if err != nil {
 		return err
 	}
@@ -583,15 +590,17 @@ func (s *ircSession) waitDrain(ctx context.Context, window time.Duration) []stri
 }
 
 // dialIRC establishes a TCP or TLS connection honouring ctx and the deadline.
+// The connection goes through the SOCKS5 proxy of the list if one is set.
 func dialIRC(
 	ctx context.Context,
 	server string,
 	useTLS bool,
+	proxy base.ProxyConfig,
 	deadline time.Time,
 ) (net.Conn, error) {
 	dialer := &net.Dialer{Deadline: deadline}
 
-	conn, err := dialer.DialContext(ctx, "tcp", server)
+	conn, err := proxy.DialContext(ctx, dialer, "tcp", server)
 	if err != nil {
 		return nil, err
 	}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/apiexternal_v2"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
@@ -24,6 +25,7 @@ import (
 	"github.com/Kellerman81/go_media_downloader/pkg/main/providers"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/scanner"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/searcher"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/structure"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/syncops"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/worker"
 )
@@ -2238,6 +2240,12 @@ func runjoblistfunc(
 		err := structurefolders(rootctx, cfgp)
 		return err
 
+	case logger.StrArtwork:
+		return structure.RefreshArtwork(rootctx, cfgp)
+
+	case logger.StrSubtitles:
+		return structure.SearchMissingSubtitles(rootctx, cfgp)
+
 	case logger.StrRssSeasons:
 		return searcher.SearchSeriesRSSSeasons(rootctx, cfgp)
 	case logger.StrRssSeasonsAll:
@@ -2413,12 +2421,14 @@ func jobsearchmedia(
 	var (
 		scaninterval int
 		scandatepre  int
+		backoff      bool
 	)
 
 	if cfgp.DataLen >= 1 && cfgp.Data[0].CfgPath != nil {
 		if searchmissing {
 			scaninterval = cfgp.Data[0].CfgPath.MissingScanInterval
 			scandatepre = cfgp.Data[0].CfgPath.MissingScanReleaseDatePre
+			backoff = cfgp.Data[0].CfgPath.MissingSearchBackoffMaxDays > 0
 		} else {
 			scaninterval = cfgp.Data[0].CfgPath.UpgradeScanInterval
 		}
@@ -2454,7 +2464,14 @@ func jobsearchmedia(
 		bld.WriteByte(')')
 	}
 
-	if scaninterval != 0 {
+	// With the search backoff the planned next search replaces the fixed scan interval
+	if backoff {
+		bld.WriteStringMap(cfgp.IsType, logger.SearchGenNextSearch)
+
+		timenow := logger.TimeGetNow()
+
+		args.Arr = append(args.Arr, &timenow)
+	} else if scaninterval != 0 {
 		bld.WriteStringMap(cfgp.IsType, logger.SearchGenLastScan)
 
 		timeinterval := logger.TimeGetNow().AddDate(0, 0, 0-scaninterval)
@@ -2470,6 +2487,18 @@ func jobsearchmedia(
 		args.Arr = append(args.Arr, &timedatepre)
 	}
 
+	// Episodes with a known broadcast time wait until they aired plus the search delay
+	if searchmissing && cfgp.IsType == config.MediaTypeSeries {
+		bld.WriteStringMap(cfgp.IsType, logger.SearchGenAirTime)
+
+		args.Arr = append(args.Arr, &cfgp.SearchDelay)
+	}
+
+	// Movies wait until the minimum availability of their list is reached
+	if searchmissing && cfgp.IsType == config.MediaTypeMovie {
+		writeMinimumAvailability(bld, args, cfgp)
+	}
+
 	bld.WriteStringMap(cfgp.IsType, logger.SearchGenOrder)
 
 	if searchinterval != 0 {
@@ -2486,6 +2515,12 @@ func jobsearchmedia(
 	// album may exist with different track counts or editions
 	searchedQueries := make(map[string]struct{})
 
+	// Track season pack searches per series season so each season is queried once
+	var seasonPacks map[string]bool
+	if searchmissing && cfgp.IsType == config.MediaTypeSeries {
+		seasonPacks = make(map[string]bool)
+	}
+
 	arr := database.GetrowsNuncached[database.DbstaticOneStringOneUInt](
 		database.Getdatarow[uint](false, logger.JoinStrings("select count() ", str), args.Arr...),
 		logger.JoinStrings(mtstrings.GetStringsMap(cfgp.IsType, logger.SearchGenSelect), str),
@@ -2511,7 +2546,17 @@ func jobsearchmedia(
 			}
 		}
 
-		if errsub := searcher.NewSearcher(cfgp, cfgp.GetMediaQualityConfigStr(arr[i].Str), "", nil).
+		quality := cfgp.GetMediaQualityConfigStr(arr[i].Str)
+		if seasonPacks != nil &&
+			searcher.SearchSeasonPack(ctx, cfgp, quality, arr[i].Num, seasonPacks) {
+			logger.Logtype("debug", 2).
+				Uint("mediaid", arr[i].Num).
+				Msg("Skipping episode search - covered by season pack")
+
+			continue
+		}
+
+		if errsub := searcher.NewSearcher(cfgp, quality, "", nil).Scheduled().
 			MediaSearch(ctx, cfgp, arr[i].Num, searchtitle, true, true); errsub != nil {
 			err = errsub
 		}
@@ -2520,6 +2565,44 @@ func jobsearchmedia(
 	return err
 }
 
+// writeMinimumAvailability adds a filter per availability level to the
+// missing search query of movies. Movies of lists with a minimum availability
+// are skipped until the release date of that level has passed. Movies without
+// any known release date are searched.
+func writeMinimumAvailability(
+	bld *logger.AddBuffer,
+	args *logger.Arrany,
+	cfgp *config.MediaTypeConfig,
+) {
+	for _, level := range []string{
+		config.AvailabilityInCinemas,
+		config.AvailabilityDigital,
+		config.AvailabilityPhysical,
+	} {
+		var found bool
+		for i := range cfgp.Lists {
+			if cfgp.Lists[i].GetMinimumAvailability() != level {
+				continue
+			}
+
+			if found {
+				bld.WriteString(",?")
+			} else {
+				bld.WriteString(" and (movies.listname COLLATE NOCASE not in (?")
+				found = true
+			}
+
+			args.Arr = append(args.Arr, &cfgp.Lists[i].Name)
+		}
+
+		if found {
+			bld.WriteString(") or coalesce(date(")
+			bld.WriteString(database.MovieAvailabilityDateExpr("dbmovies", level))
+			bld.WriteString("), date('now')) <= date('now'))")
+		}
+	}
+}
+
 // checkmissing checks for missing files for the given media list.
 // It queries for file locations, checks if they exist, and updates
 // the database to set missing flags on media items with no files.
@@ -2710,6 +2793,22 @@ func LoadGlobalSchedulerConfig() {
 
 			return nil
 		},
+		"RefreshIndexerCaps": func(key uint32, _ context.Context) error {
+			apiexternal.RefreshAllIndexerCaps()
+			worker.RemoveQueueEntry(key)
+
+			return nil
+		},
+		"PurgeRecycleBin": func(key uint32, ctx context.Context) error {
+			defer worker.RemoveQueueEntry(key)
+
+			return structure.PurgeRecycleBin(ctx)
+		},
+		"PruneImportedSources": func(key uint32, ctx context.Context) error {
+			defer worker.RemoveQueueEntry(key)
+
+			return structure.PruneImportedSources(ctx)
+		},
 	}
 }
 
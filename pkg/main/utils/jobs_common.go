@@ -376,6 +376,7 @@ func jobImportParseCommon(
 
 	basefile := filepath.Base(pathv)
 	extfile := filepath.Ext(pathv)
+	sublangs := logger.JoinStringsSep(m.SubtitleLanguages, ",")
 
 	// Get queries from StringsMap
 	insertQuery := mtstrings.GetStringsMap(cfgp.IsType, "InsertFile")
@@ -406,6 +407,8 @@ func jobImportParseCommon(
 				&m.Proper, &m.Repack, &m.Extended,
 				&mediaID, &dbMediaID,
 				&m.Height, &m.Width,
+				&m.ReleaseName, &m.ReleaseGroup,
+				&m.HDR, &sublangs,
 			)
 			database.ExecN(updateMissing, &mediaID)
 			database.ExecN(updateReached, &reached, &mediaID)
@@ -430,6 +433,8 @@ func jobImportParseCommon(
 					&m.Proper, &m.Repack, &m.Extended,
 					&m.SerieID, &m.Episodes[idx].Num1, &m.Episodes[idx].Num2, &m.DbserieID,
 					&m.Height, &m.Width,
+					&m.ReleaseName, &m.ReleaseGroup,
+					&m.HDR, &sublangs,
 				)
 
 				database.ExecN(updateMissing, &m.Episodes[idx].Num1)
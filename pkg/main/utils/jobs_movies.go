@@ -8,6 +8,7 @@ import (
 	"github.com/Kellerman81/go_media_downloader/pkg/main/importfeed"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
 	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype/movies"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/structure"
 )
 
 // SQL query constant for movie lookup.
@@ -291,7 +292,12 @@ func refreshmovies(
 		)
 		if errsub != nil {
 			err = errsub
+			continue
 		}
+
+		dbmovieID := importfeed.MovieFindDBIDByImdb(&arr[idx])
+		structure.WriteMovieNfos(cfgp, dbmovieID)
+		structure.WriteMovieArtwork(ctx, cfgp, dbmovieID)
 	}
 
 	return err
@@ -100,7 +100,17 @@ func refreshseries(
 				Msg("Import series failed")
 
 			err = errsub
+
+			continue
 		}
+
+		dbserieID := database.Getdatarow[uint](
+			false,
+			"select id from dbseries where thetvdb_id = ?",
+			&tbl[idx].Num,
+		)
+		structure.WriteSerieNfos(cfgp, dbserieID)
+		structure.WriteSerieArtwork(ctx, cfgp, dbserieID)
 	}
 
 	return err
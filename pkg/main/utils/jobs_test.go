@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+)
+
+func TestWriteMinimumAvailability(t *testing.T) {
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		create table dbmovies (id integer primary key, release_date date, cinema_release date,
+			digital_release date, physical_release date);
+		create table movies (id integer primary key, dbmovie_id integer, listname text);
+		insert into dbmovies (id, release_date) values (1, date('now', '+30 days'));
+		insert into dbmovies (id, release_date) values (2, date('now', '-200 days'));
+		insert into dbmovies (id) values (3);
+		insert into movies (id, dbmovie_id, listname) values (1, 1, 'upcoming');
+		insert into movies (id, dbmovie_id, listname) values (2, 2, 'Upcoming');
+		insert into movies (id, dbmovie_id, listname) values (3, 3, 'UPCOMING');
+		insert into movies (id, dbmovie_id, listname) values (4, 1, 'Other');
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfgp := &config.MediaTypeConfig{
+		Lists: []config.MediaListsConfig{
+			{Name: "Upcoming", MinimumAvailability: config.AvailabilityDigital},
+			{Name: "Other"},
+		},
+	}
+
+	var (
+		bld  logger.AddBuffer
+		args logger.Arrany
+	)
+
+	bld.WriteString("select movies.id from movies")
+	bld.WriteString(" join dbmovies on dbmovies.id = movies.dbmovie_id where 1 = 1")
+	writeMinimumAvailability(&bld, &args, cfgp)
+	bld.WriteString(" order by movies.id")
+
+	rows, err := db.Query(bld.String(), args.Arr...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	// The listname matches case-insensitively, so the movie released in 30
+	// days waits even though its listname is written differently. Movies
+	// without a release date and of other lists are searched.
+	want := []int{2, 3, 4}
+	if len(ids) != len(want) {
+		t.Fatalf("searched movies = %v, want %v", ids, want)
+	}
+
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("searched movies = %v, want %v", ids, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Kellerman81/go_media_downloader/pkg/main/config"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/database"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/logger"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/mediatype"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/parser_v2"
+	"github.com/Kellerman81/go_media_downloader/pkg/main/structure"
+)
+
+var (
+	errRestoreListNotFound  = errors.New("list of the restored file not found")
+	errRestoreMediaNotFound = errors.New("media of the restored file not found")
+)
+
+// RestoreRecycleItem moves the file of the recycle bin entry back to its
+// original path and links it to the media item and list it was recycled
+// from. Files restored outside of the data paths (rejected imports) are
+// picked up by the next structure run without checking their language and
+// runtime again, albums and audiobooks by the next scan for new files.
+// Files recycled without media (cleanup orphans and duplicates) or whose
+// media was removed since are restored without being linked.
+func RestoreRecycleItem(ctx context.Context, id uint) error {
+	item, err := database.GetRecycleItem(id)
+	if err != nil {
+		return err
+	}
+
+	cfgp, err := structure.RestoreRecycledFile(item)
+	if err != nil || cfgp == nil || mediatype.UsesGroupedFileProcessing(cfgp.IsType) {
+		return err
+	}
+
+	// Cleanup orphans and duplicates are recycled without media - they are
+	// restored without being linked
+	if item.MediaID == 0 || item.Listname == "" {
+		logger.Logtype("info", 1).
+			Str(logger.StrFile, item.OriginalPath).
+			Msg("Restored file without media to link")
+
+		return nil
+	}
+
+	listid := cfgp.GetMediaListsEntryListID(item.Listname)
+	if listid == -1 {
+		logRestoredUnlinked(item, errRestoreListNotFound)
+
+		return nil
+	}
+
+	// The file is linked to the media item it was recycled from, the parsed
+	// file only provides the quality and the episodes
+	var query string
+	switch cfgp.IsType {
+	case config.MediaTypeMovie:
+		query = "select dbmovie_id from movies where id = ?"
+	case config.MediaTypeSeries:
+		query = "select dbserie_id from series where id = ?"
+	case config.MediaTypeBook:
+		query = "select dbbook_id from books where id = ?"
+	}
+
+	h := mediatype.Get(cfgp.IsType)
+	if h == nil || query == "" {
+		logRestoredUnlinked(item, errRestoreMediaNotFound)
+
+		return nil
+	}
+
+	dbid := database.Getdatarow[uint](false, query, &item.MediaID)
+	if dbid == 0 {
+		logRestoredUnlinked(item, errRestoreMediaNotFound)
+
+		return nil
+	}
+
+	m := parser_v2.ParseFile(item.OriginalPath, true, true, cfgp, -1)
+	if m == nil {
+		return logger.ErrNotFound
+	}
+
+	defer m.Close()
+
+	h.SetMediaID(m, item.MediaID)
+	h.SetDBID(m, dbid)
+
+	if cfgp.IsType == config.MediaTypeBook {
+		err = jobImportParseBook(m, item.OriginalPath, cfgp, &cfgp.Lists[listid], false)
+	} else {
+		err = jobImportParseCommon(ctx, m, item.OriginalPath, cfgp, &cfgp.Lists[listid], false)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger.Logtype("info", 1).
+		Str(logger.StrFile, item.OriginalPath).
+		Str(logger.StrListname, cfgp.Lists[listid].Name).
+		Msg("Restored file linked to its media")
+
+	return nil
+}
+
+// logRestoredUnlinked logs that the restored file could not be linked to its
+// media, e.g. as the media was removed since. The file itself was restored,
+// so the restore counts as done.
+func logRestoredUnlinked(item *database.RecycleItem, err error) {
+	logger.Logtype("warn", 1).
+		Str(logger.StrFile, item.OriginalPath).
+		Str(logger.StrListname, item.Listname).
+		Err(err).
+		Msg("Restored file not linked to its media")
+}
@@ -0,0 +1,6 @@
+-- Remove the scene numbering mapping table.
+DROP TRIGGER IF EXISTS tg_dbserie_scene_mappings_updated_at;
+DROP INDEX IF EXISTS idx_dbserie_scene_mappings_tvdb;
+DROP INDEX IF EXISTS idx_dbserie_scene_mappings_absolute;
+DROP INDEX IF EXISTS idx_dbserie_scene_mappings_scene;
+DROP TABLE IF EXISTS dbserie_scene_mappings;
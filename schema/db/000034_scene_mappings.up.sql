@@ -0,0 +1,22 @@
+-- Add a per-series mapping between scene (indexer) numbering and TVDB
+-- numbering. Many anime and some western shows are released with scene
+-- season/episode or absolute numbers that differ from TVDB; each row maps one
+-- scene numbered episode to the TVDB season/episode of the same dbserie.
+CREATE TABLE IF NOT EXISTS `dbserie_scene_mappings` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `dbserie_id` integer NOT NULL DEFAULT 0,
+    `scene_season` integer NOT NULL DEFAULT 0,
+    `scene_episode` integer NOT NULL DEFAULT 0,
+    `scene_absolute` integer NOT NULL DEFAULT 0,
+    `tvdb_season` integer NOT NULL DEFAULT 0,
+    `tvdb_episode` integer NOT NULL DEFAULT 0,
+    CONSTRAINT `fk_dbserie_scene_mappings_dbserie` FOREIGN KEY (`dbserie_id`) REFERENCES `dbseries`(`id`) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS `idx_dbserie_scene_mappings_scene` ON `dbserie_scene_mappings`(`dbserie_id`, `scene_season`, `scene_episode`);
+CREATE INDEX IF NOT EXISTS `idx_dbserie_scene_mappings_absolute` ON `dbserie_scene_mappings`(`dbserie_id`, `scene_absolute`);
+CREATE INDEX IF NOT EXISTS `idx_dbserie_scene_mappings_tvdb` ON `dbserie_scene_mappings`(`dbserie_id`, `tvdb_season`, `tvdb_episode`);
+
+CREATE TRIGGER tg_dbserie_scene_mappings_updated_at AFTER UPDATE ON dbserie_scene_mappings FOR EACH ROW BEGIN UPDATE dbserie_scene_mappings SET updated_at = current_timestamp WHERE id = old.id; END;
@@ -0,0 +1,4 @@
+-- Remove the indexer capabilities cache.
+DROP TRIGGER IF EXISTS tg_indexer_caps_updated_at;
+DROP INDEX IF EXISTS idx_indexer_caps_indexer;
+DROP TABLE IF EXISTS indexer_caps;
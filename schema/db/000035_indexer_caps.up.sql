@@ -0,0 +1,22 @@
+-- Cache the capabilities (t=caps) reported by each newznab/torznab indexer:
+-- available search modes, the parameters each mode accepts (imdbid, tvdbid,
+-- tmdbid, tvmazeid, rid, ...), the category ids and the result limits. The
+-- searcher uses them to choose between ID and title searches per indexer.
+CREATE TABLE IF NOT EXISTS `indexer_caps` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `indexer` text NOT NULL DEFAULT '',
+    `server_title` text NOT NULL DEFAULT '',
+    `search_modes` text NOT NULL DEFAULT '',
+    `search_params` text NOT NULL DEFAULT '',
+    `tv_search_params` text NOT NULL DEFAULT '',
+    `movie_search_params` text NOT NULL DEFAULT '',
+    `categories` text NOT NULL DEFAULT '',
+    `max_results` integer NOT NULL DEFAULT 0,
+    `default_limit` integer NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_indexer_caps_indexer` ON `indexer_caps`(`indexer` COLLATE NOCASE);
+
+CREATE TRIGGER tg_indexer_caps_updated_at AFTER UPDATE ON indexer_caps FOR EACH ROW BEGIN UPDATE indexer_caps SET updated_at = current_timestamp WHERE id = old.id; END;
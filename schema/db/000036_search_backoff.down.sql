@@ -0,0 +1,11 @@
+-- Remove the search backoff columns from the wanted item tables.
+ALTER TABLE `movies` DROP COLUMN `search_empty_count`;
+ALTER TABLE `movies` DROP COLUMN `next_search`;
+ALTER TABLE `serie_episodes` DROP COLUMN `search_empty_count`;
+ALTER TABLE `serie_episodes` DROP COLUMN `next_search`;
+ALTER TABLE `albums` DROP COLUMN `search_empty_count`;
+ALTER TABLE `albums` DROP COLUMN `next_search`;
+ALTER TABLE `books` DROP COLUMN `search_empty_count`;
+ALTER TABLE `books` DROP COLUMN `next_search`;
+ALTER TABLE `audiobooks` DROP COLUMN `search_empty_count`;
+ALTER TABLE `audiobooks` DROP COLUMN `next_search`;
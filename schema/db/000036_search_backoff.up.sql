@@ -0,0 +1,12 @@
+-- Track consecutive empty missing searches and the next planned search per
+-- wanted item so long-missing items are searched with an exponential backoff.
+ALTER TABLE `movies` ADD COLUMN `search_empty_count` integer NOT NULL DEFAULT 0;
+ALTER TABLE `movies` ADD COLUMN `next_search` datetime;
+ALTER TABLE `serie_episodes` ADD COLUMN `search_empty_count` integer NOT NULL DEFAULT 0;
+ALTER TABLE `serie_episodes` ADD COLUMN `next_search` datetime;
+ALTER TABLE `albums` ADD COLUMN `search_empty_count` integer NOT NULL DEFAULT 0;
+ALTER TABLE `albums` ADD COLUMN `next_search` datetime;
+ALTER TABLE `books` ADD COLUMN `search_empty_count` integer NOT NULL DEFAULT 0;
+ALTER TABLE `books` ADD COLUMN `next_search` datetime;
+ALTER TABLE `audiobooks` ADD COLUMN `search_empty_count` integer NOT NULL DEFAULT 0;
+ALTER TABLE `audiobooks` ADD COLUMN `next_search` datetime;
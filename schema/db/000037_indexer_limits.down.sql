@@ -0,0 +1,4 @@
+-- Remove the indexer api and grab counters.
+DROP TRIGGER IF EXISTS tg_indexer_limits_updated_at;
+DROP INDEX IF EXISTS idx_indexer_limits_indexer;
+DROP TABLE IF EXISTS indexer_limits;
@@ -0,0 +1,19 @@
+-- Persist the api and grab usage of each indexer. Counters are increased per
+-- query and grab and replaced by the usage the indexer reports (apilimits,
+-- X-RateLimit headers). The reset columns hold the end of the current window.
+CREATE TABLE IF NOT EXISTS `indexer_limits` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `indexer` text NOT NULL DEFAULT '',
+    `api_current` integer NOT NULL DEFAULT 0,
+    `api_max` integer NOT NULL DEFAULT 0,
+    `api_reset` datetime,
+    `grab_current` integer NOT NULL DEFAULT 0,
+    `grab_max` integer NOT NULL DEFAULT 0,
+    `grab_reset` datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_indexer_limits_indexer` ON `indexer_limits`(`indexer` COLLATE NOCASE);
+
+CREATE TRIGGER tg_indexer_limits_updated_at AFTER UPDATE ON indexer_limits FOR EACH ROW BEGIN UPDATE indexer_limits SET updated_at = current_timestamp WHERE id = old.id; END;
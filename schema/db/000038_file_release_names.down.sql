@@ -0,0 +1,5 @@
+-- Remove the release name and group columns from the video file tables.
+ALTER TABLE `movie_files` DROP COLUMN `release_name`;
+ALTER TABLE `movie_files` DROP COLUMN `release_group`;
+ALTER TABLE `serie_episode_files` DROP COLUMN `release_name`;
+ALTER TABLE `serie_episode_files` DROP COLUMN `release_group`;
@@ -0,0 +1,6 @@
+-- Store the original scene release name and release group of imported video
+-- files. The group decides whether a PROPER/REPACK is an automatic upgrade.
+ALTER TABLE `movie_files` ADD COLUMN `release_name` text NOT NULL DEFAULT '';
+ALTER TABLE `movie_files` ADD COLUMN `release_group` text NOT NULL DEFAULT '';
+ALTER TABLE `serie_episode_files` ADD COLUMN `release_name` text NOT NULL DEFAULT '';
+ALTER TABLE `serie_episode_files` ADD COLUMN `release_group` text NOT NULL DEFAULT '';
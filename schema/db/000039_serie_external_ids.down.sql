@@ -0,0 +1,3 @@
+-- Remove the TMDB and TVmaze id columns of series.
+ALTER TABLE `dbseries` DROP COLUMN `moviedb_id`;
+ALTER TABLE `dbseries` DROP COLUMN `tvmaze_id`;
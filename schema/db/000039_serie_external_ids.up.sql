@@ -0,0 +1,4 @@
+-- Store the TMDB and TVmaze ids of series for indexer id searches. Series
+-- missing on TVDB can still be searched by tmdbid, tvmazeid or traktid.
+ALTER TABLE `dbseries` ADD COLUMN `moviedb_id` integer NOT NULL DEFAULT 0;
+ALTER TABLE `dbseries` ADD COLUMN `tvmaze_id` integer NOT NULL DEFAULT 0;
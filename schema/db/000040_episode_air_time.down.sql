@@ -0,0 +1,3 @@
+-- Remove the episode air time and the series search delay.
+ALTER TABLE `dbserie_episodes` DROP COLUMN `air_time`;
+ALTER TABLE `series` DROP COLUMN `search_delay`;
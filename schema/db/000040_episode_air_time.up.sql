@@ -0,0 +1,4 @@
+-- Store the full UTC air timestamp of episodes and a per-series search delay
+-- so missing and RSS searches wait until an episode has actually aired.
+ALTER TABLE `dbserie_episodes` ADD COLUMN `air_time` datetime;
+ALTER TABLE `series` ADD COLUMN `search_delay` integer NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- Remove the regional release dates of movies.
+ALTER TABLE `dbmovies` DROP COLUMN `cinema_release`;
+ALTER TABLE `dbmovies` DROP COLUMN `digital_release`;
+ALTER TABLE `dbmovies` DROP COLUMN `physical_release`;
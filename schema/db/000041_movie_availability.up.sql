@@ -0,0 +1,5 @@
+-- Store the regional cinema, digital and physical release dates of movies
+-- for the minimum availability of missing searches.
+ALTER TABLE `dbmovies` ADD COLUMN `cinema_release` datetime;
+ALTER TABLE `dbmovies` ADD COLUMN `digital_release` datetime;
+ALTER TABLE `dbmovies` ADD COLUMN `physical_release` datetime;
@@ -0,0 +1,4 @@
+-- Remove the store of recent RSS candidates.
+DROP TRIGGER IF EXISTS tg_rss_items_updated_at;
+DROP INDEX IF EXISTS idx_rss_items_config;
+DROP TABLE IF EXISTS rss_items;
@@ -0,0 +1,38 @@
+-- Keep the recent candidates of the RSS searches with the decision the
+-- searcher made, so they can be browsed, re-evaluated against the current
+-- configuration or grabbed manually. The store is trimmed to the newest
+-- rss_history_items rows of the general config.
+CREATE TABLE IF NOT EXISTS `rss_items` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `config` text NOT NULL DEFAULT '',
+    `quality_profile` text NOT NULL DEFAULT '',
+    `indexer` text NOT NULL DEFAULT '',
+    `title` text NOT NULL DEFAULT '',
+    `download_url` text NOT NULL DEFAULT '',
+    `size` integer NOT NULL DEFAULT 0,
+    `pub_date` datetime,
+    `is_torrent` integer NOT NULL DEFAULT 0,
+    `seeders` integer NOT NULL DEFAULT 0,
+    `category` text NOT NULL DEFAULT '',
+    `imdb` text NOT NULL DEFAULT '',
+    `tvdb` integer NOT NULL DEFAULT 0,
+    `season` text NOT NULL DEFAULT '',
+    `episode` text NOT NULL DEFAULT '',
+    `resolution` text NOT NULL DEFAULT '',
+    `quality` text NOT NULL DEFAULT '',
+    `codec` text NOT NULL DEFAULT '',
+    `audio` text NOT NULL DEFAULT '',
+    `release_group` text NOT NULL DEFAULT '',
+    `identifier` text NOT NULL DEFAULT '',
+    `listname` text NOT NULL DEFAULT '',
+    `media_id` integer NOT NULL DEFAULT 0,
+    `priority` integer NOT NULL DEFAULT 0,
+    `decision` text NOT NULL DEFAULT '',
+    `reason` text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS `idx_rss_items_config` ON `rss_items`(`config`, `decision`);
+
+CREATE TRIGGER tg_rss_items_updated_at AFTER UPDATE ON rss_items FOR EACH ROW BEGIN UPDATE rss_items SET updated_at = current_timestamp WHERE id = old.id; END;
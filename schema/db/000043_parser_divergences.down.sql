@@ -0,0 +1,5 @@
+-- Remove the parser shadow mode divergences.
+DROP TRIGGER IF EXISTS tg_parser_divergences_updated_at;
+DROP INDEX IF EXISTS idx_parser_divergences_field;
+DROP INDEX IF EXISTS idx_parser_divergences_input;
+DROP TABLE IF EXISTS parser_divergences;
@@ -0,0 +1,19 @@
+-- Fields on which the legacy and the v2 file parser disagree while running
+-- in shadow mode. Each input and field is stored once; hits counts how often
+-- the divergence was seen.
+CREATE TABLE IF NOT EXISTS `parser_divergences` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `media_type` text NOT NULL DEFAULT '',
+    `input` text NOT NULL DEFAULT '',
+    `field` text NOT NULL DEFAULT '',
+    `legacy_value` text NOT NULL DEFAULT '',
+    `v2_value` text NOT NULL DEFAULT '',
+    `hits` integer NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_parser_divergences_input` ON `parser_divergences`(`input`, `field`);
+CREATE INDEX IF NOT EXISTS `idx_parser_divergences_field` ON `parser_divergences`(`field`);
+
+CREATE TRIGGER tg_parser_divergences_updated_at AFTER UPDATE ON parser_divergences FOR EACH ROW BEGIN UPDATE parser_divergences SET updated_at = current_timestamp WHERE id = old.id; END;
@@ -0,0 +1,4 @@
+-- Remove the imported sources of the keep-source import modes.
+DROP TRIGGER IF EXISTS tg_imported_sources_updated_at;
+DROP INDEX IF EXISTS idx_imported_sources_source;
+DROP TABLE IF EXISTS imported_sources;
@@ -0,0 +1,14 @@
+-- Source files which were organized with an import mode keeping the source
+-- (copy, hardlink, reflink, symlink). They stay in the download folder for
+-- seeding and must not be organized again.
+CREATE TABLE IF NOT EXISTS `imported_sources` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `source` text NOT NULL DEFAULT '',
+    `target` text NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_imported_sources_source` ON `imported_sources`(`source`);
+
+CREATE TRIGGER tg_imported_sources_updated_at AFTER UPDATE ON imported_sources FOR EACH ROW BEGIN UPDATE imported_sources SET updated_at = current_timestamp WHERE id = old.id; END;
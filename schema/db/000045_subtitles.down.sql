@@ -0,0 +1,4 @@
+-- Remove the subtitle status of the subtitle download.
+DROP TRIGGER IF EXISTS tg_subtitles_updated_at;
+DROP INDEX IF EXISTS idx_subtitles_location_language;
+DROP TABLE IF EXISTS subtitles;
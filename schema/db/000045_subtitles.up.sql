@@ -0,0 +1,23 @@
+-- Subtitle status per video file and wanted language. Rows are written by
+-- the subtitle search: 'existing' for subtitles found next to the video,
+-- 'downloaded' for subtitles fetched from a provider and 'missing' when no
+-- provider returned a match (retried after last_search).
+CREATE TABLE IF NOT EXISTS `subtitles` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `location` text NOT NULL DEFAULT '',
+    `language` text NOT NULL DEFAULT '',
+    `status` text NOT NULL DEFAULT '',
+    `path` text NOT NULL DEFAULT '',
+    `provider` text NOT NULL DEFAULT '',
+    `release_name` text NOT NULL DEFAULT '',
+    `hearing_impaired` numeric NOT NULL DEFAULT 0,
+    `forced` numeric NOT NULL DEFAULT 0,
+    `score` integer NOT NULL DEFAULT 0,
+    `last_search` datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_subtitles_location_language` ON `subtitles`(`location`, `language`);
+
+CREATE TRIGGER tg_subtitles_updated_at AFTER UPDATE ON subtitles FOR EACH ROW BEGIN UPDATE subtitles SET updated_at = current_timestamp WHERE id = old.id; END;
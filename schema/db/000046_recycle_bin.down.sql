@@ -0,0 +1,4 @@
+-- Remove the recycle bin manifest.
+DROP TRIGGER IF EXISTS tg_recycle_bin_updated_at;
+DROP INDEX IF EXISTS idx_recycle_bin_path_template_created_at;
+DROP TABLE IF EXISTS recycle_bin;
@@ -0,0 +1,20 @@
+-- Manifest of the recycle bin. Every row is a file which was moved into a
+-- dated folder of move_replaced_target_path instead of being deleted, with
+-- the media item it belonged to and the reason it was removed.
+CREATE TABLE IF NOT EXISTS `recycle_bin` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `updated_at` datetime NOT NULL DEFAULT current_timestamp,
+    `config` text NOT NULL DEFAULT '',
+    `listname` text NOT NULL DEFAULT '',
+    `path_template` text NOT NULL DEFAULT '',
+    `media_id` integer NOT NULL DEFAULT 0,
+    `reason` text NOT NULL DEFAULT '',
+    `original_path` text NOT NULL DEFAULT '',
+    `recycle_path` text NOT NULL DEFAULT '',
+    `size` integer NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS `idx_recycle_bin_path_template_created_at` ON `recycle_bin`(`path_template`, `created_at`);
+
+CREATE TRIGGER tg_recycle_bin_updated_at AFTER UPDATE ON recycle_bin FOR EACH ROW BEGIN UPDATE recycle_bin SET updated_at = current_timestamp WHERE id = old.id; END;
@@ -0,0 +1,5 @@
+-- Remove the HDR and subtitle language columns from the video file tables.
+ALTER TABLE `movie_files` DROP COLUMN `hdr`;
+ALTER TABLE `movie_files` DROP COLUMN `subtitle_languages`;
+ALTER TABLE `serie_episode_files` DROP COLUMN `hdr`;
+ALTER TABLE `serie_episode_files` DROP COLUMN `subtitle_languages`;
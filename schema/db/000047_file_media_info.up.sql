@@ -0,0 +1,6 @@
+-- Store the HDR format and the embedded subtitle languages of imported video
+-- files. The subtitle search skips languages already embedded in the video.
+ALTER TABLE `movie_files` ADD COLUMN `hdr` text NOT NULL DEFAULT '';
+ALTER TABLE `movie_files` ADD COLUMN `subtitle_languages` text NOT NULL DEFAULT '';
+ALTER TABLE `serie_episode_files` ADD COLUMN `hdr` text NOT NULL DEFAULT '';
+ALTER TABLE `serie_episode_files` ADD COLUMN `subtitle_languages` text NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Remove the TheTVDB and IMDb ID columns from the episode table.
+ALTER TABLE `dbserie_episodes` DROP COLUMN `thetvdb_id`;
+ALTER TABLE `dbserie_episodes` DROP COLUMN `imdb_id`;
@@ -0,0 +1,4 @@
+-- Store the TheTVDB and IMDb IDs of episodes for the uniqueid elements of the
+-- episode NFO files.
+ALTER TABLE `dbserie_episodes` ADD COLUMN `thetvdb_id` integer NOT NULL DEFAULT 0;
+ALTER TABLE `dbserie_episodes` ADD COLUMN `imdb_id` text NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Remove the table of files restored from the recycle bin.
+DROP INDEX IF EXISTS idx_restored_files_location;
+DROP TABLE IF EXISTS restored_files;
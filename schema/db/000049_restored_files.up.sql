@@ -0,0 +1,10 @@
+-- Files restored from the recycle bin after they were removed for a wrong
+-- language or runtime. The user allowed them, so the structure run does not
+-- check them again.
+CREATE TABLE IF NOT EXISTS `restored_files` (
+    `id` integer PRIMARY KEY,
+    `created_at` datetime NOT NULL DEFAULT current_timestamp,
+    `location` text NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_restored_files_location` ON `restored_files`(`location`);